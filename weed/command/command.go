@@ -16,6 +16,7 @@ var Commands = []*Command{
 	cmdExport,
 	cmdFiler,
 	cmdFilerReplicate,
+	cmdFilerMigrate,
 	cmdFix,
 	cmdMaster,
 	cmdMount,
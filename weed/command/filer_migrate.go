@@ -0,0 +1,428 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func init() {
+	cmdFilerMigrate.Run = runFilerMigrate // break init cycle
+}
+
+var cmdFilerMigrate = &Command{
+	UsageLine: "filer.migrate -filer=localhost:8888 -config=/path/to/target/filer.toml",
+	Short:     "copy the filer metadata to another filer store while the filer is running",
+	Long: `copy the filer metadata to another filer store while the filer is running
+
+	filer.migrate copies all entries of the running filer to the store enabled in the -config file,
+	and then follows the metadata changes since the copy started, until no change is seen for -idle.
+
+	The cut-over:
+	1. run filer.migrate while the filer is serving as usual, and wait until it is catching up.
+	2. stop the writes to the filer. The catch-up finishes after -idle, and the meta log files,
+	   the chunk references, the hard link records and the bucket usage are synced again,
+	   since their changes are saved to the store without metadata events.
+	3. the entry counts and checksums of every directory are compared.
+	4. switch the filer.toml of the filer to the new store, and restart the filer.
+
+  `,
+}
+
+var (
+	migrateFiler  = cmdFilerMigrate.Flag.String("filer", "localhost:8888", "the running filer, hostname:port")
+	migrateConfig = cmdFilerMigrate.Flag.String("config", "", "a filer.toml file with the target store enabled")
+	migrateIdle   = cmdFilerMigrate.Flag.Duration("idle", 10*time.Second, "finish the catch-up after no metadata change for this long")
+	migrateVerify = cmdFilerMigrate.Flag.Bool("verify", true, "compare the entry counts and checksums of each directory after the catch-up")
+)
+
+func runFilerMigrate(cmd *Command, args []string) bool {
+
+	util.LoadConfiguration("security", false)
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	if *migrateConfig == "" {
+		return false
+	}
+
+	filerGrpcAddress, err := pb.ParseFilerGrpcAddress(*migrateFiler)
+	if err != nil {
+		fmt.Printf("filer address %s: %v\n", *migrateFiler, err)
+		return true
+	}
+
+	store, err := loadTargetStore(*migrateConfig)
+	if err != nil {
+		fmt.Printf("target store: %v\n", err)
+		return true
+	}
+	defer store.Shutdown()
+
+	m := &filerMigration{
+		filerClient: &migrateFilerClient{filerGrpcAddress: filerGrpcAddress, grpcDialOption: grpcDialOption},
+		store:       filer2.NewFilerStoreWrapper(store),
+	}
+
+	if err = m.migrate(*migrateIdle, *migrateVerify); err != nil {
+		fmt.Printf("migrate %s to %s: %v\n", *migrateFiler, store.GetName(), err)
+	}
+
+	return true
+}
+
+// loadTargetStore initializes the only enabled store in the filer.toml file
+func loadTargetStore(configFile string) (filer2.FilerStore, error) {
+	config := viper.New()
+	config.SetConfigFile(configFile)
+	if err := config.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read %s: %v", configFile, err)
+	}
+
+	var enabledStore filer2.FilerStore
+	for _, store := range filer2.Stores {
+		if config.GetBool(store.GetName() + ".enabled") {
+			if enabledStore != nil {
+				return nil, fmt.Errorf("both %s and %s are enabled in %s", enabledStore.GetName(), store.GetName(), configFile)
+			}
+			enabledStore = store
+		}
+	}
+	if enabledStore == nil {
+		return nil, fmt.Errorf("no filer store is enabled in %s", configFile)
+	}
+
+	if err := enabledStore.Initialize(config, enabledStore.GetName()+"."); err != nil {
+		return nil, fmt.Errorf("initialize %s: %v", enabledStore.GetName(), err)
+	}
+	return enabledStore, nil
+}
+
+type migrateFilerClient struct {
+	filerGrpcAddress string
+	grpcDialOption   grpc.DialOption
+}
+
+func (c *migrateFilerClient) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithGrpcFilerClient(c.filerGrpcAddress, c.grpcDialOption, fn)
+}
+func (c *migrateFilerClient) AdjustedUrl(hostAndPort string) string {
+	return hostAndPort
+}
+
+type filerMigration struct {
+	filerClient filer_pb.FilerClient
+	store       filer2.FilerStore
+}
+
+func (m *filerMigration) migrate(idle time.Duration, verify bool) error {
+
+	startTsNs := time.Now().UnixNano()
+
+	fmt.Printf("copying entries...\n")
+	count, err := m.syncDirectory("/", true)
+	if err != nil {
+		return fmt.Errorf("copy: %v", err)
+	}
+	fmt.Printf("copied %d entries\n", count)
+
+	fmt.Printf("catching up the changes since %v, stop the writes to the filer to finish...\n", time.Unix(0, startTsNs))
+	count, err = m.catchUp(startTsNs, idle)
+	if err != nil {
+		return fmt.Errorf("catch up: %v", err)
+	}
+	fmt.Printf("applied %d changes, no change in %v\n", count, idle)
+
+	// the changes saved to the store directly are not in the metadata events
+	for _, dir := range []util.FullPath{filer2.SystemLogDir, filer2.ChunkReferencesDir, filer2.HardLinksDir} {
+		if _, err = m.syncDirectory(dir, true); err != nil {
+			return fmt.Errorf("sync %s: %v", dir, err)
+		}
+	}
+	bucketsDir, err := m.bucketsDir()
+	if err != nil {
+		return fmt.Errorf("read filer configuration: %v", err)
+	}
+	// only the bucket entries keep the usage
+	if _, err = m.syncDirectory(bucketsDir, false); err != nil {
+		return fmt.Errorf("sync %s: %v", bucketsDir, err)
+	}
+
+	if !verify {
+		return nil
+	}
+	fmt.Printf("verifying...\n")
+	mismatches, err := m.verifyDirectory("/")
+	if err != nil {
+		return fmt.Errorf("verify: %v", err)
+	}
+	if mismatches > 0 {
+		return fmt.Errorf("%d directories are different", mismatches)
+	}
+	fmt.Printf("all directories are the same, the filer can be switched to %s\n", m.store.GetName())
+
+	return nil
+}
+
+// syncDirectory copies the entries under the directory, and deletes the ones no longer in the filer.
+// Only the entries of the directory itself are synced if not recursive.
+func (m *filerMigration) syncDirectory(dir util.FullPath, isRecursive bool) (count int64, err error) {
+
+	ctx := context.Background()
+	names := make(map[string]bool)
+	var subDirs []util.FullPath
+	err = filer_pb.ReadDirAllEntries(m.filerClient, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		if err := m.saveEntry(ctx, filer2.FromPbEntry(string(dir), entry)); err != nil {
+			return err
+		}
+		names[entry.Name] = true
+		count++
+		if entry.IsDirectory && isRecursive {
+			subDirs = append(subDirs, dir.Child(entry.Name))
+		}
+		return nil
+	})
+	if err != nil {
+		return count, fmt.Errorf("list %s: %v", dir, err)
+	}
+
+	var deletedEntries []*filer2.Entry
+	lastFileName := ""
+	for {
+		entries, err := m.store.ListDirectoryEntries(ctx, dir, lastFileName, false, filer2.PaginationSize)
+		if err != nil {
+			return count, fmt.Errorf("list %s in %s: %v", dir, m.store.GetName(), err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			if !names[lastFileName] {
+				deletedEntries = append(deletedEntries, entry)
+			}
+		}
+		if len(entries) < filer2.PaginationSize {
+			break
+		}
+	}
+	for _, entry := range deletedEntries {
+		if err := m.store.DeleteEntry(ctx, entry.FullPath); err != nil {
+			return count, fmt.Errorf("delete %s: %v", entry.FullPath, err)
+		}
+		if entry.IsDirectory() {
+			if err := m.store.DeleteFolderChildren(ctx, entry.FullPath); err != nil {
+				return count, fmt.Errorf("delete %s children: %v", entry.FullPath, err)
+			}
+		}
+	}
+
+	for _, subDir := range subDirs {
+		subCount, err := m.syncDirectory(subDir, isRecursive)
+		count += subCount
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
+
+func (m *filerMigration) bucketsDir() (dir util.FullPath, err error) {
+	err = m.filerClient.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
+		if err != nil {
+			return err
+		}
+		dir = util.FullPath(resp.DirBuckets)
+		return nil
+	})
+	return
+}
+
+// catchUp applies the metadata changes since the time, until no change arrives for the idle duration
+func (m *filerMigration) catchUp(sinceNs int64, idle time.Duration) (count int64, err error) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	idleTimer := time.AfterFunc(idle, cancel)
+	defer idleTimer.Stop()
+
+	err = m.filerClient.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		stream, err := client.SubscribeMetadata(ctx, &filer_pb.SubscribeMetadataRequest{
+			ClientName: "migrate",
+			PathPrefix: "/",
+			SinceNs:    sinceNs,
+		})
+		if err != nil {
+			return fmt.Errorf("subscribe: %v", err)
+		}
+
+		for {
+			resp, listenErr := stream.Recv()
+			if listenErr == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			if listenErr != nil {
+				return listenErr
+			}
+			idleTimer.Reset(idle)
+			if err := m.applyEvent(resp); err != nil {
+				return fmt.Errorf("apply %v: %v", resp, err)
+			}
+			count++
+		}
+	})
+
+	return count, err
+}
+
+func (m *filerMigration) applyEvent(resp *filer_pb.SubscribeMetadataResponse) error {
+	ctx := context.Background()
+	message := resp.EventNotification
+
+	if message.OldEntry != nil {
+		oldPath := util.NewFullPath(resp.Directory, message.OldEntry.Name)
+		if err := m.store.DeleteEntry(ctx, oldPath); err != nil {
+			return err
+		}
+		// the files of a deleted directory have no events of their own
+		if message.OldEntry.IsDirectory && message.NewEntry == nil {
+			if err := m.store.DeleteFolderChildren(ctx, oldPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if message.NewEntry != nil {
+		dir := resp.Directory
+		if message.NewParentPath != "" {
+			dir = message.NewParentPath
+		}
+		if err := m.saveEntry(ctx, filer2.FromPbEntry(dir, message.NewEntry)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveEntry inserts or updates the entry. The metadata changes replayed after the copy may repeat the copied entries,
+// and the stores with unique keys, like the sql stores, refuse to insert them again.
+func (m *filerMigration) saveEntry(ctx context.Context, entry *filer2.Entry) error {
+	if _, err := m.store.FindEntry(ctx, entry.FullPath); err == nil {
+		return m.store.UpdateEntry(ctx, entry)
+	}
+	return m.store.InsertEntry(ctx, entry)
+}
+
+// verifyDirectory compares the entry counts and checksums of the directory and its sub directories
+func (m *filerMigration) verifyDirectory(dir util.FullPath) (mismatches int, err error) {
+
+	var subDirs []util.FullPath
+	sourceChecksums := make(map[string][]byte)
+	err = filer_pb.ReadDirAllEntries(m.filerClient, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		sourceChecksums[entry.Name] = entryChecksum(filer2.FromPbEntry(string(dir), entry))
+		if entry.IsDirectory {
+			subDirs = append(subDirs, dir.Child(entry.Name))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list %s: %v", dir, err)
+	}
+
+	targetChecksums := make(map[string][]byte)
+	lastFileName := ""
+	for {
+		entries, err := m.store.ListDirectoryEntries(context.Background(), dir, lastFileName, false, filer2.PaginationSize)
+		if err != nil {
+			return 0, fmt.Errorf("list %s in %s: %v", dir, m.store.GetName(), err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			targetChecksums[lastFileName] = entryChecksum(entry)
+		}
+		if len(entries) < filer2.PaginationSize {
+			break
+		}
+	}
+
+	if len(sourceChecksums) != len(targetChecksums) {
+		fmt.Printf("%s: %d entries, but %d in %s\n", dir, len(sourceChecksums), len(targetChecksums), m.store.GetName())
+		mismatches++
+	} else if !bytes.Equal(directoryChecksum(sourceChecksums), directoryChecksum(targetChecksums)) {
+		fmt.Printf("%s: the entries are different in %s\n", dir, m.store.GetName())
+		mismatches++
+	}
+
+	for _, subDir := range subDirs {
+		subMismatches, err := m.verifyDirectory(subDir)
+		mismatches += subMismatches
+		if err != nil {
+			return mismatches, err
+		}
+	}
+
+	return mismatches, nil
+}
+
+// directoryChecksum combines the checksums of the entries, in the order of their names
+func directoryChecksum(checksums map[string][]byte) []byte {
+	var names []string
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := md5.New()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write(checksums[name])
+	}
+	return h.Sum(nil)
+}
+
+// entryChecksum covers the attributes, chunks and extended attributes of the entry,
+// ignoring whether the chunk file ids are kept as strings or parsed
+func entryChecksum(entry *filer2.Entry) []byte {
+	h := md5.New()
+
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(filer2.EntryAttributeToPb(entry)); err == nil {
+		h.Write(buf.Bytes())
+	}
+
+	filer_pb.AfterEntryDeserialization(entry.Chunks)
+	for _, chunk := range entry.Chunks {
+		fmt.Fprintf(h, "%s %d %d %d %s %s %x %v\n", chunk.FileId, chunk.Offset, chunk.Size, chunk.Mtime,
+			chunk.ETag, chunk.SourceFileId, chunk.CipherKey, chunk.IsGzipped)
+	}
+
+	writeExtendedChecksum(h, entry.Extended)
+
+	return h.Sum(nil)
+}
+
+func writeExtendedChecksum(h hash.Hash, extended map[string][]byte) {
+	var keys []string
+	for key := range extended {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(h, "%s=%x\n", key, extended[key])
+	}
+}
@@ -0,0 +1,180 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/filer2/abstract_sql"
+	"github.com/chrislusf/seaweedfs/weed/filer2/mysql"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// memorySqlDriver runs the statements of the mysql store on a map keyed by the primary key,
+// and refuses duplicated keys like the databases do
+type memorySqlDriver struct {
+	sync.Mutex
+	rows map[string][]byte // directory + "\x00" + name => meta
+}
+
+type memorySqlConn struct{ d *memorySqlDriver }
+type memorySqlStmt struct {
+	d     *memorySqlDriver
+	query string
+}
+type memorySqlRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (d *memorySqlDriver) Open(name string) (driver.Conn, error) { return &memorySqlConn{d}, nil }
+func (c *memorySqlConn) Prepare(query string) (driver.Stmt, error) {
+	return &memorySqlStmt{c.d, query}, nil
+}
+func (c *memorySqlConn) Close() error              { return nil }
+func (c *memorySqlConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *memorySqlConn) Commit() error             { return nil }
+func (c *memorySqlConn) Rollback() error           { return nil }
+func (s *memorySqlStmt) Close() error              { return nil }
+func (s *memorySqlStmt) NumInput() int             { return strings.Count(s.query, "?") }
+
+func (s *memorySqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.Lock()
+	defer s.d.Unlock()
+	switch {
+	case strings.HasPrefix(s.query, "INSERT"):
+		key := fmt.Sprintf("%s\x00%s", args[2], args[1])
+		if _, found := s.d.rows[key]; found {
+			return nil, fmt.Errorf("Duplicate entry '%s' for key 'PRIMARY'", key)
+		}
+		s.d.rows[key] = args[3].([]byte)
+	case strings.HasPrefix(s.query, "UPDATE"):
+		key := fmt.Sprintf("%s\x00%s", args[3], args[2])
+		if _, found := s.d.rows[key]; found {
+			s.d.rows[key] = args[0].([]byte)
+		}
+	case strings.Contains(s.query, "name=?"):
+		delete(s.d.rows, fmt.Sprintf("%s\x00%s", args[2], args[1]))
+	default:
+		for key := range s.d.rows {
+			if strings.HasPrefix(key, fmt.Sprintf("%s\x00", args[1])) {
+				delete(s.d.rows, key)
+			}
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *memorySqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.Lock()
+	defer s.d.Unlock()
+	if strings.HasPrefix(s.query, "SELECT meta") {
+		rows := &memorySqlRows{columns: []string{"meta"}}
+		if meta, found := s.d.rows[fmt.Sprintf("%s\x00%s", args[2], args[1])]; found {
+			rows.values = append(rows.values, []driver.Value{meta})
+		}
+		return rows, nil
+	}
+	rows := &memorySqlRows{columns: []string{"name", "meta"}}
+	var names []string
+	for key := range s.d.rows {
+		parts := strings.SplitN(key, "\x00", 2)
+		if parts[0] == args[2] && (parts[1] > args[1].(string) || strings.Contains(s.query, ">=") && parts[1] == args[1]) {
+			names = append(names, parts[1])
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if int64(len(rows.values)) < args[3].(int64) {
+			rows.values = append(rows.values, []driver.Value{name, s.d.rows[fmt.Sprintf("%s\x00%s", args[2], name)]})
+		}
+	}
+	return rows, nil
+}
+
+func (r *memorySqlRows) Columns() []string { return r.columns }
+func (r *memorySqlRows) Close() error      { return nil }
+func (r *memorySqlRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	for i, value := range r.values[0] {
+		dest[i] = value
+	}
+	r.values = r.values[1:]
+	return nil
+}
+
+// newMemorySqlStore is the mysql store on the memory sql driver
+func newMemorySqlStore(t *testing.T) *mysql.MysqlStore {
+	driverName := "memory_sql_" + t.Name()
+	sql.Register(driverName, &memorySqlDriver{rows: make(map[string][]byte)})
+	db, err := sql.Open(driverName, "")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	return &mysql.MysqlStore{AbstractSqlStore: abstract_sql.AbstractSqlStore{
+		DB:                      db,
+		SqlInsert:               "INSERT INTO filemeta (dirhash,name,directory,meta) VALUES(?,?,?,?)",
+		SqlUpdate:               "UPDATE filemeta SET meta=? WHERE dirhash=? AND name=? AND directory=?",
+		SqlFind:                 "SELECT meta FROM filemeta WHERE dirhash=? AND name=? AND directory=?",
+		SqlDelete:               "DELETE FROM filemeta WHERE dirhash=? AND name=? AND directory=?",
+		SqlDeleteFolderChildren: "DELETE FROM filemeta WHERE dirhash=? AND directory=?",
+		SqlListExclusive:        "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>? AND directory=? ORDER BY NAME ASC LIMIT ?",
+		SqlListInclusive:        "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>=? AND directory=? ORDER BY NAME ASC LIMIT ?",
+	}}
+}
+
+func TestFilerMigrationReplayOnSqlStore(t *testing.T) {
+
+	store := newMemorySqlStore(t)
+	defer store.Shutdown()
+	m := &filerMigration{store: filer2.NewFilerStoreWrapper(store)}
+	ctx := context.Background()
+
+	// the entry is copied, and then its creation is replayed with a later change
+	file := &filer_pb.Entry{
+		Name:       "a.txt",
+		Attributes: &filer_pb.FuseAttributes{FileMode: 0644, Mtime: 1590000000},
+		Chunks:     []*filer_pb.FileChunk{{FileId: "3,01637037d6", Size: 10}},
+	}
+	if err := m.saveEntry(ctx, filer2.FromPbEntry("/d1", file)); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if err := store.InsertEntry(ctx, filer2.FromPbEntry("/d1", file)); err == nil {
+		t.Fatalf("the sql store should refuse to insert the same entry twice")
+	}
+
+	changed := &filer_pb.Entry{
+		Name:       "a.txt",
+		Attributes: &filer_pb.FuseAttributes{FileMode: 0644, Mtime: 1590000001},
+		Chunks:     []*filer_pb.FileChunk{{FileId: "3,01637037d7", Size: 20}},
+	}
+	for _, event := range []*filer_pb.SubscribeMetadataResponse{
+		{Directory: "/d1", EventNotification: &filer_pb.EventNotification{NewEntry: file}},
+		{Directory: "/d1", EventNotification: &filer_pb.EventNotification{NewEntry: changed}},
+	} {
+		if err := m.applyEvent(event); err != nil {
+			t.Fatalf("replay %v: %v", event, err)
+		}
+	}
+
+	entry, err := store.FindEntry(ctx, "/d1/a.txt")
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if len(entry.Chunks) != 1 || entry.Chunks[0].GetFileIdString() != "3,01637037d7" {
+		t.Errorf("the replayed change is not saved: %+v", entry)
+	}
+	if entries, err := store.ListDirectoryEntries(ctx, "/d1", "", false, 100); err != nil || len(entries) != 1 {
+		t.Errorf("unexpected entries %+v: %v", entries, err)
+	}
+
+}
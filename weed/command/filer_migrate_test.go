@@ -0,0 +1,83 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/filer2/leveldb2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestFilerMigrationApplyEvent(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "seaweedfs_migrate_test")
+	defer os.RemoveAll(dir)
+	config := viper.New()
+	config.Set("leveldb2.dir", dir)
+	store := &leveldb.LevelDB2Store{}
+	if err := store.Initialize(config, "leveldb2."); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	defer store.Shutdown()
+
+	m := &filerMigration{store: filer2.NewFilerStoreWrapper(store)}
+	ctx := context.Background()
+
+	file := &filer_pb.Entry{
+		Name:       "a.txt",
+		Attributes: &filer_pb.FuseAttributes{FileMode: 0644, Mtime: 1590000000},
+		Chunks:     []*filer_pb.FileChunk{{FileId: "3,01637037d6", Size: 10}},
+		Extended:   map[string][]byte{"k": []byte("v")},
+	}
+	events := []*filer_pb.SubscribeMetadataResponse{
+		{Directory: "/d1", EventNotification: &filer_pb.EventNotification{
+			NewEntry: &filer_pb.Entry{Name: "d2", IsDirectory: true, Attributes: &filer_pb.FuseAttributes{FileMode: uint32(os.ModeDir | 0755)}},
+		}},
+		{Directory: "/d1/d2", EventNotification: &filer_pb.EventNotification{NewEntry: file}},
+		// rename
+		{Directory: "/d1/d2", EventNotification: &filer_pb.EventNotification{
+			OldEntry: file, NewEntry: file, NewParentPath: "/d1",
+		}},
+	}
+	for _, event := range events {
+		if err := m.applyEvent(event); err != nil {
+			t.Fatalf("apply %v: %v", event, err)
+		}
+	}
+
+	if _, err := store.FindEntry(ctx, "/d1/d2/a.txt"); err != filer_pb.ErrNotFound {
+		t.Errorf("renamed entry is still found: %v", err)
+	}
+	entry, err := m.store.FindEntry(ctx, "/d1/a.txt")
+	if err != nil {
+		t.Fatalf("find renamed entry: %v", err)
+	}
+
+	// the same checksum after going through the store
+	if !bytes.Equal(entryChecksum(filer2.FromPbEntry("/d1", file)), entryChecksum(entry)) {
+		t.Errorf("checksum changed after stored: %+v", entry)
+	}
+	entry.Extended["k"] = []byte("changed")
+	if bytes.Equal(entryChecksum(filer2.FromPbEntry("/d1", file)), entryChecksum(entry)) {
+		t.Errorf("checksum ignores the extended attributes")
+	}
+
+	// deleting a directory deletes its files, which have no events
+	m.store.InsertEntry(ctx, &filer2.Entry{FullPath: "/d1/d2/b.txt"})
+	err = m.applyEvent(&filer_pb.SubscribeMetadataResponse{Directory: "/d1", EventNotification: &filer_pb.EventNotification{
+		OldEntry: &filer_pb.Entry{Name: "d2", IsDirectory: true},
+	}})
+	if err != nil {
+		t.Fatalf("apply directory deletion: %v", err)
+	}
+	if entries, _ := store.ListDirectoryEntries(ctx, "/d1/d2", "", false, 100); len(entries) != 0 {
+		t.Errorf("files of the deleted directory: %v", entries)
+	}
+
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/notification"
 	"github.com/chrislusf/seaweedfs/weed/s3api"
 	"github.com/chrislusf/seaweedfs/weed/util"
 )
@@ -101,10 +102,49 @@ var cmdS3 = &Command{
         "Read:bucket1",
         "Write:bucket1"
       ]
+    },
+    {
+      "name": "user_with_policy",
+      "credentials": [
+        {
+          "accessKey": "some_access_key5",
+          "secretKey": "some_secret_key5"
+        }
+      ],
+      "policy": {
+        "statements": [
+          {
+            "effect": "Allow",
+            "actions": ["s3:Get*", "s3:List*"],
+            "resources": ["arn:aws:s3:::bucket2", "arn:aws:s3:::bucket2/*"]
+          },
+          {
+            "effect": "Deny",
+            "actions": ["s3:*"],
+            "resources": ["arn:aws:s3:::bucket2/private/*"]
+          }
+        ]
+      }
     }
   ]
 }
 
+	Policy statements allow or deny S3 actions, e.g. "s3:GetObject" or "s3:Put*", on resources
+	like "arn:aws:s3:::bucket/prefix*". An explicit Deny wins over any Allow.
+	Bucket policies set by PutBucketPolicy are evaluated the same way.
+
+	Identities and access keys can also be managed by the SeaweedIdentityAccessManagement gRPC service
+	of the filer. They are stored under /etc/iam/identities, and all S3 gateways pick up changes right away.
+	The secret keys are stored encrypted by the [iam] key in security.toml, which the filer and all gateways need.
+	Once the folder exists, requests are authenticated even if all its identities are deleted.
+
+	Temporary credentials are issued by the STS AssumeRole and GetSessionToken actions, sent to the gateway
+	with the access key to derive them from. An optional session policy narrows their permissions.
+	All gateways verifying them need the same [s3.sts] key in security.toml.
+
+	Object lock also needs the [iam] key, which signs the retention and legal hold changes for the filer.
+	Without it, creating buckets with object lock, setting the object lock configuration, and locking objects are refused.
+
 `,
 }
 
@@ -147,15 +187,28 @@ func (s3opt *S3Options) startS3Server() bool {
 		}
 	}
 
+	// the bucket notifications are sent to their own message queue, not to the one of the filer metadata changes
+	util.LoadConfiguration("notification", false)
+	v := util.GetViper()
+	notificationQueue, err := notification.NewMessageQueue(v, "s3_notification.")
+	if err != nil {
+		glog.Fatalf("S3 bucket notifications: %v", err)
+	}
+
 	router := mux.NewRouter().SkipClean(true)
 
 	_, s3ApiServer_err := s3api.NewS3ApiServer(router, &s3api.S3ApiServerOption{
-		Filer:            *s3opt.filer,
-		FilerGrpcAddress: filerGrpcAddress,
-		Config:           *s3opt.config,
-		DomainName:       *s3opt.domainName,
-		BucketsPath:      filerBucketsPath,
-		GrpcDialOption:   grpcDialOption,
+		Filer:                *s3opt.filer,
+		FilerGrpcAddress:     filerGrpcAddress,
+		Config:               *s3opt.config,
+		DomainName:           *s3opt.domainName,
+		BucketsPath:          filerBucketsPath,
+		GrpcDialOption:       grpcDialOption,
+		StsSigningKey:        v.GetString("s3.sts.key"),
+		StsMaxDuration:       time.Duration(v.GetInt("s3.sts.max_duration_seconds")) * time.Second,
+		IamKey:               v.GetString("iam.key"),
+		NotificationQueue:    notificationQueue,
+		NotificationWebhooks: v.GetStringMapString("s3_notification.webhooks"),
 	})
 	if s3ApiServer_err != nil {
 		glog.Fatalf("S3 API Server startup error: %v", s3ApiServer_err)
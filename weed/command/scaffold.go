@@ -80,10 +80,21 @@ buckets_fsync = [          # a list of buckets with all write requests fsync=tru
 	"important_bucket",
 	"should_always_fsync",
 ]
+# how often to apply the s3 bucket lifecycle rules, 0 to disable
+# one filer at a time applies them, holding a lifecycle lock of the master
+lifecycle_interval_minutes = 60
+# deleted entries are moved to /.trash/buckets/<bucket> or /.trash/users/<uid>, and their chunks
+# are deleted after this many days. See fs.trash.list, fs.trash.restore and fs.trash.purge in weed shell.
+# 0 to delete right away.
+trash_retention_days = 0
 
 ####################################################
 # The following are filer store options
 ####################################################
+# One enabled store keeps all the entries by default. Other stores can be enabled
+# with "locations", a list of path prefixes, to keep only the entries under them, e.g.
+#   locations = ["/buckets/sessions"]
+# The longest matching location decides the store of an entry.
 
 [leveldb2]
 # local on disk, mostly for simple single-machine setup, fairly scalable
@@ -91,6 +102,14 @@ buckets_fsync = [          # a list of buckets with all write requests fsync=tru
 enabled = true
 dir = "."					# directory to store level db files
 
+[bbolt]
+# local on disk, for single-machine setup, with transactions to make renames crash-safe
+enabled = false
+dir = "."					# directory to store the filer.db file
+backup_dir = ""				# online backups of filer.db, empty to disable
+backup_interval_minutes = 60
+backup_count = 3			# keep the last few backups
+
 [mysql]  # or tidb
 # CREATE TABLE IF NOT EXISTS filemeta (
 #   dirhash     BIGINT         COMMENT 'first 64 bits of MD5 hash value of directory field',
@@ -229,6 +248,24 @@ enabled = false
 # the RabbitMQ management plugin.
 topic_url = "rabbit://myexchange"
 sub_url = "rabbit://myqueue"
+
+####################################################
+# s3 bucket notifications
+# the S3 gateway sends the bucket events as json records to their own message queue,
+# with the target ARN "arn:seaweedfs:sqs:::queue", apart from the filer updates above.
+# any of the message queues above can be enabled here with the same options, e.g.
+####################################################
+[s3_notification.kafka]
+enabled = false
+hosts = [
+  "localhost:9092"
+]
+topic = "seaweedfs_s3_events"
+
+[s3_notification.webhooks]
+# The S3 bucket notifications can also be posted to the webhooks named here,
+# with the target ARN "arn:seaweedfs:sqs:::webhook:<name>".
+# example = "http://localhost:8080/s3/events"
 `
 
 	REPLICATION_TOML_EXAMPLE = `
@@ -311,6 +348,20 @@ expires_after_seconds = 10           # seconds
 key = ""
 expires_after_seconds = 10           # seconds
 
+# the sts signing key is read by the s3 gateways, to issue and verify temporary credentials.
+# all s3 gateways serving the same clients need the same key.
+# if empty, a random key is used, and the temporary credentials only work with the issuing gateway.
+[s3.sts]
+key = ""
+max_duration_seconds = 43200         # 12 hours
+
+# the iam key encrypts the secret keys of the s3 identities managed through the filer,
+# and signs the object retention and legal hold changes of the s3 gateways.
+# it is read by the filer and the s3 gateways, which need the same key.
+# without it, the s3 gateways refuse to enable object lock, and to lock objects.
+[iam]
+key = ""
+
 # all grpc tls authentications are mutual
 # the values for the following ca, cert, and key are paths to the PERM files.
 # the host name is not checked, so the PERM files can be shared.
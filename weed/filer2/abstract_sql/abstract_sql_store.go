@@ -37,23 +37,24 @@ func (store *AbstractSqlStore) BeginTransaction(ctx context.Context) (context.Co
 		return ctx, err
 	}
 
-	return context.WithValue(ctx, "tx", tx), nil
+	// keyed by the store, since several sql stores can serve different paths
+	return context.WithValue(ctx, store, tx), nil
 }
 func (store *AbstractSqlStore) CommitTransaction(ctx context.Context) error {
-	if tx, ok := ctx.Value("tx").(*sql.Tx); ok {
+	if tx, ok := ctx.Value(store).(*sql.Tx); ok {
 		return tx.Commit()
 	}
 	return nil
 }
 func (store *AbstractSqlStore) RollbackTransaction(ctx context.Context) error {
-	if tx, ok := ctx.Value("tx").(*sql.Tx); ok {
+	if tx, ok := ctx.Value(store).(*sql.Tx); ok {
 		return tx.Rollback()
 	}
 	return nil
 }
 
 func (store *AbstractSqlStore) getTxOrDB(ctx context.Context) TxOrDB {
-	if tx, ok := ctx.Value("tx").(*sql.Tx); ok {
+	if tx, ok := ctx.Value(store).(*sql.Tx); ok {
 		return tx
 	}
 	return store.DB
@@ -0,0 +1,328 @@
+package bbolt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	weed_util "github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	dbFileName = "filer.db"
+)
+
+var (
+	entriesBucket = []byte("entries")
+)
+
+// txKey keeps the transaction of a store in the context
+type txKey struct {
+	store *BboltStore
+}
+
+func init() {
+	filer2.Stores = append(filer2.Stores, &BboltStore{})
+}
+
+// BboltStore keeps the entries in one bolt file, keyed by "<directory>\x00<name>",
+// so the entries of a directory are adjacent and listed by a prefix scan.
+// Transactions are real bolt transactions, only one of them writing at a time.
+type BboltStore struct {
+	db *bolt.DB
+
+	backupDir      string
+	backupInterval time.Duration
+	backupCount    int
+	stopBackup     chan struct{}
+}
+
+func (store *BboltStore) GetName() string {
+	return "bbolt"
+}
+
+func (store *BboltStore) Initialize(configuration weed_util.Configuration, prefix string) (err error) {
+	configuration.SetDefault(prefix+"backup_interval_minutes", 60)
+	configuration.SetDefault(prefix+"backup_count", 3)
+	dir := configuration.GetString(prefix + "dir")
+	store.backupDir = configuration.GetString(prefix + "backup_dir")
+	store.backupInterval = time.Duration(configuration.GetInt(prefix+"backup_interval_minutes")) * time.Minute
+	store.backupCount = configuration.GetInt(prefix + "backup_count")
+	return store.initialize(dir)
+}
+
+func (store *BboltStore) initialize(dir string) (err error) {
+	glog.Infof("filer store bbolt dir: %s", dir)
+	if err := weed_util.TestFolderWritable(dir); err != nil {
+		return fmt.Errorf("Check Bolt Folder %s Writable: %s", dir, err)
+	}
+
+	dbFile := filepath.Join(dir, dbFileName)
+	if store.db, err = bolt.Open(dbFile, 0644, &bolt.Options{Timeout: 10 * time.Second}); err != nil {
+		return fmt.Errorf("open %s: %v", dbFile, err)
+	}
+	if err = store.db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(entriesBucket)
+		return err
+	}); err != nil {
+		store.db.Close()
+		return fmt.Errorf("create bucket in %s: %v", dbFile, err)
+	}
+
+	store.stopBackup = make(chan struct{})
+	if store.backupDir != "" && store.backupInterval > 0 {
+		if err := os.MkdirAll(store.backupDir, 0755); err != nil {
+			store.db.Close()
+			return fmt.Errorf("create backup dir %s: %v", store.backupDir, err)
+		}
+		go store.loopBackup()
+	}
+
+	return nil
+}
+
+// BeginTransaction starts a write transaction, which blocks the other writes until it is committed or rolled back.
+// The writes in the transaction must use the returned context: a write with a context without the transaction
+// waits for the transaction to end, and deadlocks if it is on the goroutine holding the transaction.
+func (store *BboltStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	tx, err := store.db.Begin(true)
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, txKey{store}, tx), nil
+}
+func (store *BboltStore) CommitTransaction(ctx context.Context) error {
+	if tx, ok := ctx.Value(txKey{store}).(*bolt.Tx); ok {
+		return tx.Commit()
+	}
+	return nil
+}
+func (store *BboltStore) RollbackTransaction(ctx context.Context) error {
+	if tx, ok := ctx.Value(txKey{store}).(*bolt.Tx); ok {
+		return tx.Rollback()
+	}
+	return nil
+}
+
+// update runs fn in the transaction of the context, or else in its own one
+func (store *BboltStore) update(ctx context.Context, fn func(bucket *bolt.Bucket) error) error {
+	if tx, ok := ctx.Value(txKey{store}).(*bolt.Tx); ok {
+		return fn(tx.Bucket(entriesBucket))
+	}
+	return store.db.Update(func(tx *bolt.Tx) error {
+		return fn(tx.Bucket(entriesBucket))
+	})
+}
+
+// view runs fn in the transaction of the context, which sees its own changes, or else in a read only one
+func (store *BboltStore) view(ctx context.Context, fn func(bucket *bolt.Bucket) error) error {
+	if tx, ok := ctx.Value(txKey{store}).(*bolt.Tx); ok {
+		return fn(tx.Bucket(entriesBucket))
+	}
+	return store.db.View(func(tx *bolt.Tx) error {
+		return fn(tx.Bucket(entriesBucket))
+	})
+}
+
+func (store *BboltStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+	dir, name := entry.DirAndName()
+
+	value, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		return fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
+	}
+
+	err = store.update(ctx, func(bucket *bolt.Bucket) error {
+		return bucket.Put(genKey(dir, name), value)
+	})
+	if err != nil {
+		return fmt.Errorf("persisting %s : %v", entry.FullPath, err)
+	}
+
+	return nil
+}
+
+func (store *BboltStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+
+	return store.InsertEntry(ctx, entry)
+}
+
+func (store *BboltStore) FindEntry(ctx context.Context, fullpath weed_util.FullPath) (entry *filer2.Entry, err error) {
+	dir, name := fullpath.DirAndName()
+
+	var data []byte
+	err = store.view(ctx, func(bucket *bolt.Bucket) error {
+		// the value is only valid during the transaction
+		if value := bucket.Get(genKey(dir, name)); value != nil {
+			data = append([]byte{}, value...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get %s : %v", fullpath, err)
+	}
+	if data == nil {
+		return nil, filer_pb.ErrNotFound
+	}
+
+	entry = &filer2.Entry{
+		FullPath: fullpath,
+	}
+	err = entry.DecodeAttributesAndChunks(data)
+	if err != nil {
+		return entry, fmt.Errorf("decode %s : %v", entry.FullPath, err)
+	}
+
+	return entry, nil
+}
+
+func (store *BboltStore) DeleteEntry(ctx context.Context, fullpath weed_util.FullPath) (err error) {
+	dir, name := fullpath.DirAndName()
+
+	err = store.update(ctx, func(bucket *bolt.Bucket) error {
+		return bucket.Delete(genKey(dir, name))
+	})
+	if err != nil {
+		return fmt.Errorf("delete %s : %v", fullpath, err)
+	}
+
+	return nil
+}
+
+func (store *BboltStore) DeleteFolderChildren(ctx context.Context, fullpath weed_util.FullPath) (err error) {
+	directoryPrefix := genDirectoryKeyPrefix(fullpath, "")
+
+	err = store.update(ctx, func(bucket *bolt.Bucket) error {
+		// deleting while iterating may skip keys
+		var keys [][]byte
+		c := bucket.Cursor()
+		for key, _ := c.Seek(directoryPrefix); key != nil && bytes.HasPrefix(key, directoryPrefix); key, _ = c.Next() {
+			keys = append(keys, append([]byte{}, key...))
+		}
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("delete %s : %v", fullpath, err)
+	}
+
+	return nil
+}
+
+func (store *BboltStore) ListDirectoryEntries(ctx context.Context, fullpath weed_util.FullPath, startFileName string, inclusive bool,
+	limit int) (entries []*filer2.Entry, err error) {
+
+	directoryPrefix := genDirectoryKeyPrefix(fullpath, "")
+	lastFileStart := genDirectoryKeyPrefix(fullpath, startFileName)
+
+	err = store.view(ctx, func(bucket *bolt.Bucket) error {
+		c := bucket.Cursor()
+		for key, value := c.Seek(lastFileStart); key != nil && bytes.HasPrefix(key, directoryPrefix); key, value = c.Next() {
+			fileName := string(key[len(directoryPrefix):])
+			if fileName == "" {
+				continue
+			}
+			if fileName == startFileName && !inclusive {
+				continue
+			}
+			limit--
+			if limit < 0 {
+				break
+			}
+			entry := &filer2.Entry{
+				FullPath: weed_util.NewFullPath(string(fullpath), fileName),
+			}
+			if decodeErr := entry.DecodeAttributesAndChunks(append([]byte{}, value...)); decodeErr != nil {
+				glog.V(0).Infof("list %s : %v", entry.FullPath, decodeErr)
+				return decodeErr
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+
+	return entries, err
+}
+
+// Backup writes a consistent copy of the store to the file, while the store is still in use
+func (store *BboltStore) Backup(path string) error {
+	tmpPath := path + ".tmp"
+	err := store.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(tmpPath, 0644)
+	})
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("backup to %s: %v", tmpPath, err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// loopBackup backs up the store periodically, keeping the last few backups
+func (store *BboltStore) loopBackup() {
+	ticker := time.NewTicker(store.backupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-store.stopBackup:
+			return
+		case now := <-ticker.C:
+			path := filepath.Join(store.backupDir, fmt.Sprintf("filer-%s.db", now.Format("20060102-150405")))
+			if err := store.Backup(path); err != nil {
+				glog.Errorf("filer store bbolt: %v", err)
+				continue
+			}
+			glog.V(1).Infof("filer store bbolt backup: %s", path)
+			store.removeOldBackups()
+		}
+	}
+}
+
+func (store *BboltStore) removeOldBackups() {
+	backups, err := filepath.Glob(filepath.Join(store.backupDir, "filer-*.db"))
+	if err != nil {
+		return
+	}
+	// the names sort by time
+	sort.Strings(backups)
+	for len(backups) > store.backupCount && store.backupCount > 0 {
+		if err := os.Remove(backups[0]); err != nil {
+			glog.V(0).Infof("remove backup %s: %v", backups[0], err)
+		}
+		backups = backups[1:]
+	}
+}
+
+func genKey(dirPath, fileName string) (key []byte) {
+	return []byte(dirPath + "\x00" + fileName)
+}
+
+func genDirectoryKeyPrefix(fullpath weed_util.FullPath, startFileName string) (keyPrefix []byte) {
+	return genKey(strings.TrimSuffix(string(fullpath), "/")+slashIfRoot(fullpath), startFileName)
+}
+
+// the entries under the root have the directory "/"
+func slashIfRoot(fullpath weed_util.FullPath) string {
+	if fullpath == "/" {
+		return "/"
+	}
+	return ""
+}
+
+func (store *BboltStore) Shutdown() {
+	close(store.stopBackup)
+	store.db.Close()
+}
@@ -0,0 +1,197 @@
+package bbolt
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestCreateAndFind(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil, "", 0, "", "", nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BboltStore{}
+	store.initialize(dir)
+	defer store.Shutdown()
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	fullpath := util.FullPath("/home/chris/this/is/one/file1.jpg")
+
+	ctx := context.Background()
+
+	entry1 := &filer2.Entry{
+		FullPath: fullpath,
+		Attr: filer2.Attr{
+			Mode: 0440,
+			Uid:  1234,
+			Gid:  5678,
+		},
+	}
+
+	if err := filer.CreateEntry(ctx, entry1, false); err != nil {
+		t.Errorf("create entry %v: %v", entry1.FullPath, err)
+		return
+	}
+
+	entry, err := filer.FindEntry(ctx, fullpath)
+
+	if err != nil {
+		t.Errorf("find entry: %v", err)
+		return
+	}
+
+	if entry.FullPath != entry1.FullPath || entry.Attr.Uid != 1234 {
+		t.Errorf("find wrong entry: %v", entry.FullPath)
+		return
+	}
+
+	// checking one upper directory
+	entries, _ := filer.ListDirectoryEntries(ctx, util.FullPath("/home/chris/this/is/one"), "", false, 100)
+	if len(entries) != 1 {
+		t.Errorf("list entries count: %v", len(entries))
+		return
+	}
+
+	// checking root directory
+	entries, _ = filer.ListDirectoryEntries(ctx, util.FullPath("/"), "", false, 100)
+	if len(entries) != 1 || entries[0].FullPath != "/home" {
+		t.Errorf("list root entries: %v", entries)
+		return
+	}
+
+}
+
+func TestListDirectoryEntries(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BboltStore{}
+	store.initialize(dir)
+	defer store.Shutdown()
+
+	ctx := context.Background()
+	for _, p := range []string{"/a/1", "/a/2", "/a/3", "/a/1/x", "/ab/4"} {
+		store.InsertEntry(ctx, &filer2.Entry{FullPath: util.FullPath(p)})
+	}
+
+	entries, _ := store.ListDirectoryEntries(ctx, "/a", "", false, 100)
+	if len(entries) != 3 {
+		t.Errorf("list /a entries: %v", entries)
+	}
+	entries, _ = store.ListDirectoryEntries(ctx, "/a", "1", false, 1)
+	if len(entries) != 1 || entries[0].FullPath != "/a/2" {
+		t.Errorf("list /a after 1: %v", entries)
+	}
+	entries, _ = store.ListDirectoryEntries(ctx, "/a", "2", true, 100)
+	if len(entries) != 2 || entries[0].FullPath != "/a/2" {
+		t.Errorf("list /a from 2: %v", entries)
+	}
+
+	if err := store.DeleteFolderChildren(ctx, "/a"); err != nil {
+		t.Errorf("delete /a children: %v", err)
+	}
+	entries, _ = store.ListDirectoryEntries(ctx, "/a", "", false, 100)
+	if len(entries) != 0 {
+		t.Errorf("list deleted /a entries: %v", entries)
+	}
+	if _, err := store.FindEntry(ctx, "/ab/4"); err != nil {
+		t.Errorf("find /ab/4: %v", err)
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BboltStore{}
+	store.initialize(dir)
+	defer store.Shutdown()
+
+	ctx := context.Background()
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/dir/old"})
+
+	// a rolled back rename leaves the old entry
+	txCtx, err := store.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatalf("begin transaction: %v", err)
+	}
+	store.InsertEntry(txCtx, &filer2.Entry{FullPath: "/dir/new"})
+	store.DeleteEntry(txCtx, "/dir/old")
+	if _, err := store.FindEntry(txCtx, "/dir/new"); err != nil {
+		t.Errorf("find new entry in the transaction: %v", err)
+	}
+	store.RollbackTransaction(txCtx)
+
+	if _, err := store.FindEntry(ctx, "/dir/new"); err != filer_pb.ErrNotFound {
+		t.Errorf("find rolled back entry: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/dir/old"); err != nil {
+		t.Errorf("find old entry: %v", err)
+	}
+
+	// a committed rename moves it
+	txCtx, _ = store.BeginTransaction(ctx)
+	store.InsertEntry(txCtx, &filer2.Entry{FullPath: "/dir/new"})
+	store.DeleteEntry(txCtx, "/dir/old")
+	if err := store.CommitTransaction(txCtx); err != nil {
+		t.Errorf("commit: %v", err)
+	}
+
+	if _, err := store.FindEntry(ctx, "/dir/new"); err != nil {
+		t.Errorf("find committed entry: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/dir/old"); err != filer_pb.ErrNotFound {
+		t.Errorf("find moved entry: %v", err)
+	}
+}
+
+func TestBackup(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BboltStore{}
+	store.initialize(dir)
+	defer store.Shutdown()
+
+	ctx := context.Background()
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/dir/file"})
+
+	backupDir := filepath.Join(dir, "backup")
+	os.MkdirAll(backupDir, 0755)
+	if err := store.Backup(filepath.Join(backupDir, dbFileName)); err != nil {
+		t.Fatalf("backup: %v", err)
+	}
+
+	restored := &BboltStore{}
+	if err := restored.initialize(backupDir); err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	defer restored.Shutdown()
+	if _, err := restored.FindEntry(ctx, "/dir/file"); err != nil {
+		t.Errorf("find entry in the backup: %v", err)
+	}
+}
+
+func TestInitializeWithBadBackupDir(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	ioutil.WriteFile(file, nil, 0644)
+
+	store := &BboltStore{backupDir: filepath.Join(file, "backup"), backupInterval: time.Minute}
+	if err := store.initialize(dir); err == nil {
+		t.Fatalf("expecting error creating the backup dir under a file")
+	}
+
+	// the db is closed, and can be opened again
+	reopened := &BboltStore{}
+	if err := reopened.initialize(dir); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	reopened.Shutdown()
+}
@@ -2,6 +2,7 @@ package filer2
 
 import (
 	"os"
+	"strings"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/spf13/viper"
@@ -11,40 +12,83 @@ var (
 	Stores []FilerStore
 )
 
+// LoadConfiguration initializes the enabled stores. The one without "locations" is the default store,
+// and each of the others only keeps the entries under its locations, the path prefixes.
 func (f *Filer) LoadConfiguration(config *viper.Viper) {
 
-	validateOneEnabledStore(config)
+	defaultStore, pathStores := validateEnabledStores(config)
 
-	for _, store := range Stores {
-		if config.GetBool(store.GetName() + ".enabled") {
-			if err := store.Initialize(config, store.GetName()+"."); err != nil {
-				glog.Fatalf("Failed to initialize store for %s: %+v",
-					store.GetName(), err)
-			}
-			f.SetStore(store)
-			glog.V(0).Infof("Configure filer for %s", store.GetName())
-			return
+	if defaultStore == nil {
+		if len(pathStores) > 0 {
+			glog.Fatalf("Filer store without locations is needed for the paths not covered by any locations")
 		}
+
+		println()
+		println("Supported filer stores are:")
+		for _, store := range Stores {
+			println("    " + store.GetName())
+		}
+
+		os.Exit(-1)
 	}
 
-	println()
-	println("Supported filer stores are:")
-	for _, store := range Stores {
-		println("    " + store.GetName())
+	initializeStore(config, defaultStore)
+	f.SetStore(defaultStore)
+	glog.V(0).Infof("Configure filer for %s", defaultStore.GetName())
+
+	for _, store := range pathStores {
+		initializeStore(config, store)
+		for _, location := range storeLocations(config, store) {
+			f.store.AddPathSpecificStore(location, store)
+			glog.V(0).Infof("Configure filer for %s under %s", store.GetName(), location)
+		}
 	}
 
-	os.Exit(-1)
 }
 
-func validateOneEnabledStore(config *viper.Viper) {
-	enabledStore := ""
+func initializeStore(config *viper.Viper, store FilerStore) {
+	if err := store.Initialize(config, store.GetName()+"."); err != nil {
+		glog.Fatalf("Failed to initialize store for %s: %+v",
+			store.GetName(), err)
+	}
+}
+
+// storeLocations reads the path prefixes of the store, without the trailing "/"
+func storeLocations(config *viper.Viper, store FilerStore) (locations []string) {
+	for _, location := range config.GetStringSlice(store.GetName() + ".locations") {
+		if location != "/" {
+			location = strings.TrimSuffix(location, "/")
+		}
+		locations = append(locations, location)
+	}
+	return
+}
+
+// validateEnabledStores allows only one enabled store without locations, and each location for only one store
+func validateEnabledStores(config *viper.Viper) (defaultStore FilerStore, pathStores []FilerStore) {
+	storeOfLocation := make(map[string]string)
 	for _, store := range Stores {
-		if config.GetBool(store.GetName() + ".enabled") {
-			if enabledStore == "" {
-				enabledStore = store.GetName()
-			} else {
-				glog.Fatalf("Filer store is enabled for both %s and %s", enabledStore, store.GetName())
+		if !config.GetBool(store.GetName() + ".enabled") {
+			continue
+		}
+		locations := storeLocations(config, store)
+		if len(locations) == 0 {
+			if defaultStore != nil {
+				glog.Fatalf("Filer store is enabled for both %s and %s", defaultStore.GetName(), store.GetName())
+			}
+			defaultStore = store
+			continue
+		}
+		for _, location := range locations {
+			if !strings.HasPrefix(location, "/") || location == "/" {
+				glog.Fatalf("Filer store %s location %s should be an absolute path other than /", store.GetName(), location)
+			}
+			if other, found := storeOfLocation[location]; found {
+				glog.Fatalf("Filer store location %s is configured for both %s and %s", location, other, store.GetName())
 			}
+			storeOfLocation[location] = store.GetName()
 		}
+		pathStores = append(pathStores, store)
 	}
+	return
 }
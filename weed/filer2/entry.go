@@ -78,6 +78,21 @@ func FromPbEntry(dir string, entry *filer_pb.Entry) *Entry {
 	return &Entry{
 		FullPath: util.NewFullPath(dir, entry.Name),
 		Attr:     PbToEntryAttribute(entry.Attributes),
+		Extended: entry.Extended,
 		Chunks:   entry.Chunks,
 	}
 }
+
+// copyEntryTo copies the entry to another path, sharing the chunks
+func copyEntryTo(entry *Entry, p util.FullPath) *Entry {
+	newEntry := &Entry{
+		FullPath: p,
+		Attr:     entry.Attr,
+		Chunks:   entry.Chunks,
+		Extended: make(map[string][]byte),
+	}
+	for k, v := range entry.Extended {
+		newEntry.Extended[k] = v
+	}
+	return newEntry
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -38,6 +39,9 @@ type Filer struct {
 	MetaLogBuffer       *log_buffer.LogBuffer
 	metaLogCollection   string
 	metaLogReplication  string
+	chunkReferencesLock sync.Mutex
+	entryLocks          entryLocks
+	TrashRetention      time.Duration
 }
 
 func NewFiler(masters []string, grpcDialOption grpc.DialOption, filerHost string, filerGrpcPort uint32, collection string, replication string, notifyFn func()) *Filer {
@@ -84,6 +88,11 @@ func (f *Filer) RollbackTransaction(ctx context.Context) error {
 	return f.store.RollbackTransaction(ctx)
 }
 
+// IsInOneStore tells whether the entries at the paths, and all the entries under them, can be changed in one transaction
+func (f *Filer) IsInOneStore(paths ...util.FullPath) bool {
+	return f.store.isInOneStore(paths...)
+}
+
 func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool) error {
 
 	if string(entry.FullPath) == "/" {
@@ -172,20 +181,54 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool) erro
 		}
 	*/
 
+	// hold the path from reading the old entry until the new entry is stored,
+	// so of the concurrent exclusive creations only one can succeed
+	unlock := f.entryLocks.lock(entry.FullPath)
+	defer unlock()
+
 	oldEntry, _ := f.FindEntry(ctx, entry.FullPath)
 
 	glog.V(4).Infof("CreateEntry %s: old entry: %v exclusive:%v", entry.FullPath, oldEntry, o_excl)
+	if oldEntry != nil && o_excl {
+		glog.V(3).Infof("EEXIST: entry %s already exists", entry.FullPath)
+		return fmt.Errorf("EEXIST: entry %s already exists", entry.FullPath)
+	}
+
+	// the filer counts the hard links, not the clients
+	delete(entry.Extended, HardLinkCountKey)
+
+	sharedFileIds := takeChunkReferences(oldEntry, entry)
+	if err := f.addChunkReferences(ctx, sharedFileIds); err != nil {
+		glog.Errorf("reference chunks of %s: %v", entry.FullPath, err)
+		return fmt.Errorf("reference chunks of %s: %v", entry.FullPath, err)
+	}
+
+	// a versioned bucket keeps the replaced version
+	archived, err := f.archiveVersion(ctx, oldEntry, entry)
+	if err != nil {
+		f.releaseChunkReferences(ctx, sharedFileIds)
+		glog.Errorf("create entry %s: %v", entry.FullPath, err)
+		return err
+	}
+
 	if oldEntry == nil {
+		if err := f.checkBucketQuota(nil, entry); err != nil {
+			f.releaseChunkReferences(ctx, sharedFileIds)
+			return err
+		}
 		if err := f.store.InsertEntry(ctx, entry); err != nil {
+			f.releaseChunkReferences(ctx, sharedFileIds)
 			glog.Errorf("insert entry %s: %v", entry.FullPath, err)
 			return fmt.Errorf("insert entry %s: %v", entry.FullPath, err)
 		}
-	} else {
-		if o_excl {
-			glog.V(3).Infof("EEXIST: entry %s already exists", entry.FullPath)
-			return fmt.Errorf("EEXIST: entry %s already exists", entry.FullPath)
+		if err := f.updateHardLinks(ctx, nil, entry); err != nil {
+			glog.Errorf("link entry %s: %v", entry.FullPath, err)
+			return fmt.Errorf("link entry %s: %v", entry.FullPath, err)
 		}
+	} else {
 		if err := f.UpdateEntry(ctx, oldEntry, entry); err != nil {
+			f.releaseChunkReferences(ctx, sharedFileIds)
+			f.unarchiveVersion(ctx, archived)
 			glog.Errorf("update entry %s: %v", entry.FullPath, err)
 			return fmt.Errorf("update entry %s: %v", entry.FullPath, err)
 		}
@@ -194,7 +237,7 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool) erro
 	f.maybeAddBucket(entry)
 	f.NotifyUpdateEvent(oldEntry, entry, true)
 
-	f.deleteChunksIfNotNew(oldEntry, entry)
+	f.deleteChunksIfNotNew(ctx, oldEntry, entry)
 
 	glog.V(4).Infof("CreateEntry %s: created", entry.FullPath)
 
@@ -212,7 +255,20 @@ func (f *Filer) UpdateEntry(ctx context.Context, oldEntry, entry *Entry) (err er
 			return fmt.Errorf("existing %s is a file", entry.FullPath)
 		}
 	}
-	return f.store.UpdateEntry(ctx, entry)
+	if err = checkObjectLockUpdate(oldEntry, entry, time.Now()); err != nil {
+		return err
+	}
+	if err = f.checkBucketQuota(oldEntry, entry); err != nil {
+		return err
+	}
+	if f.isBucket(entry) {
+		f.updateBucketQuota(oldEntry, entry)
+	}
+	delete(entry.Extended, HardLinkCountKey)
+	if err = f.store.UpdateEntry(ctx, entry); err != nil {
+		return err
+	}
+	return f.updateHardLinks(ctx, oldEntry, entry)
 }
 
 func (f *Filer) FindEntry(ctx context.Context, p util.FullPath) (entry *Entry, err error) {
@@ -235,6 +291,7 @@ func (f *Filer) FindEntry(ctx context.Context, p util.FullPath) (entry *Entry, e
 	if entry != nil && entry.TtlSec > 0 {
 		if entry.Crtime.Add(time.Duration(entry.TtlSec) * time.Second).Before(time.Now()) {
 			f.store.DeleteEntry(ctx, p.Child(entry.Name()))
+			f.updateBucketUsage(entry, nil)
 			return nil, filer_pb.ErrNotFound
 		}
 	}
@@ -269,6 +326,7 @@ func (f *Filer) doListDirectoryEntries(ctx context.Context, p util.FullPath, sta
 		if entry.TtlSec > 0 {
 			if entry.Crtime.Add(time.Duration(entry.TtlSec) * time.Second).Before(time.Now()) {
 				f.store.DeleteEntry(ctx, p.Child(entry.Name()))
+				f.updateBucketUsage(entry, nil)
 				expiredCount++
 				continue
 			}
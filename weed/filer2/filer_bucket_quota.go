@@ -0,0 +1,295 @@
+package filer2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	// BucketQuotaKey is the bucket entry's extended attribute for the quota
+	BucketQuotaKey = "s3-quota"
+	// BucketUsageKey is the bucket entry's extended attribute for the usage, saved by the filers
+	BucketUsageKey = "s3-usage"
+	// BucketUsageSeedKey resets the usage when set by a bucket entry update, e.g. after walking the bucket
+	BucketUsageSeedKey = "s3-usage-seed"
+
+	bucketUsageSaveInterval = 10 * time.Second
+)
+
+var ErrBucketQuotaExceeded = errors.New("bucket quota exceeded")
+
+// BucketQuota limits the total size and the number of objects in a bucket, where 0 means unlimited
+type BucketQuota struct {
+	MaxBytes   int64 `json:"maxBytes,omitempty"`
+	MaxObjects int64 `json:"maxObjects,omitempty"`
+}
+
+// BucketUsage counts the files in a bucket, except the multipart uploads in progress
+type BucketUsage struct {
+	Bytes   int64 `json:"bytes"`
+	Objects int64 `json:"objects"`
+}
+
+func ParseBucketQuota(data []byte) (quota BucketQuota, err error) {
+	if len(data) == 0 {
+		return
+	}
+	err = json.Unmarshal(data, &quota)
+	return
+}
+
+func ParseBucketUsage(data []byte) (usage BucketUsage, err error) {
+	if len(data) == 0 {
+		return
+	}
+	err = json.Unmarshal(data, &usage)
+	return
+}
+
+func EncodeBucketQuota(quota BucketQuota) ([]byte, error) {
+	return json.Marshal(quota)
+}
+
+func EncodeBucketUsage(usage BucketUsage) ([]byte, error) {
+	return json.Marshal(usage)
+}
+
+// IsBucketQuotaExceeded checks an error message passed along by the filer
+func IsBucketQuotaExceeded(errorMessage string) bool {
+	return strings.Contains(errorMessage, ErrBucketQuotaExceeded.Error())
+}
+
+func (usage BucketUsage) exceeds(quota BucketQuota) bool {
+	return quota.MaxBytes > 0 && usage.Bytes > quota.MaxBytes || quota.MaxObjects > 0 && usage.Objects > quota.MaxObjects
+}
+
+// bucketOf finds the bucket of a file counted in the usage
+func (f *Filer) bucketOf(entry *Entry) (bucket BucketName, counted bool) {
+	if entry == nil || entry.IsDirectory() || f.DirBucketsPath == "" {
+		return "", false
+	}
+	prefix := f.DirBucketsPath + "/"
+	if !strings.HasPrefix(string(entry.FullPath), prefix) {
+		return "", false
+	}
+	parts := strings.SplitN(string(entry.FullPath)[len(prefix):], "/", 3)
+	if len(parts) < 2 || parts[1] == MultipartUploadsFolder {
+		return "", false
+	}
+	return BucketName(parts[0]), true
+}
+
+func usageDelta(entry *Entry) BucketUsage {
+	return BucketUsage{
+		Bytes:   int64(TotalSize(entry.Chunks)),
+		Objects: 1,
+	}
+}
+
+// checkBucketQuota rejects the change if it grows a bucket beyond its quota.
+// The usage changed by the other filers is only reloaded every bucketUsageSaveInterval,
+// so the writes through several filers may exceed the quota by what they write within the interval.
+func (f *Filer) checkBucketQuota(oldEntry, newEntry *Entry) error {
+
+	bucket, counted := f.bucketOf(newEntry)
+	if !counted {
+		return nil
+	}
+	delta := usageDelta(newEntry)
+	if oldBucket, oldCounted := f.bucketOf(oldEntry); oldCounted && oldBucket == bucket {
+		oldDelta := usageDelta(oldEntry)
+		delta.Bytes -= oldDelta.Bytes
+		delta.Objects -= oldDelta.Objects
+	}
+
+	return f.checkBucketUsage(bucket, delta)
+}
+
+// CheckBucketQuota checks whether a file of the size can be written to the path before receiving its content
+func (f *Filer) CheckBucketQuota(ctx context.Context, p util.FullPath, size int64) error {
+
+	newEntry := &Entry{
+		FullPath: p,
+		Chunks:   []*filer_pb.FileChunk{{Size: uint64(size)}},
+	}
+	bucket, counted := f.bucketOf(newEntry)
+	if !counted {
+		return nil
+	}
+	delta := BucketUsage{Bytes: size, Objects: 1}
+	if !strings.HasSuffix(string(p), "/") {
+		if oldEntry, err := f.FindEntry(ctx, p); err == nil && !oldEntry.IsDirectory() {
+			delta.Bytes -= int64(TotalSize(oldEntry.Chunks))
+			delta.Objects = 0
+		}
+	}
+
+	return f.checkBucketUsage(bucket, delta)
+}
+
+func (f *Filer) checkBucketUsage(bucket BucketName, delta BucketUsage) error {
+
+	if delta.Bytes <= 0 && delta.Objects <= 0 {
+		return nil
+	}
+
+	f.buckets.RLock()
+	defer f.buckets.RUnlock()
+
+	option, found := f.buckets.buckets[bucket]
+	if !found {
+		return nil
+	}
+	usage := BucketUsage{
+		Bytes:   option.usage.Bytes + delta.Bytes,
+		Objects: option.usage.Objects + delta.Objects,
+	}
+	if usage.exceeds(option.quota) {
+		glog.V(1).Infof("bucket %s usage %+v quota %+v", bucket, usage, option.quota)
+		return fmt.Errorf("bucket %s: %v", bucket, ErrBucketQuotaExceeded)
+	}
+	return nil
+}
+
+// updateBucketUsage counts the change of a file in the bucket usage
+func (f *Filer) updateBucketUsage(oldEntry, newEntry *Entry) {
+	if bucket, counted := f.bucketOf(oldEntry); counted {
+		delta := usageDelta(oldEntry)
+		f.addBucketUsage(bucket, -delta.Bytes, -delta.Objects)
+	}
+	if bucket, counted := f.bucketOf(newEntry); counted {
+		delta := usageDelta(newEntry)
+		f.addBucketUsage(bucket, delta.Bytes, delta.Objects)
+	}
+}
+
+func (f *Filer) addBucketUsage(bucket BucketName, bytes, objects int64) {
+
+	f.buckets.Lock()
+	defer f.buckets.Unlock()
+
+	option, found := f.buckets.buckets[bucket]
+	if !found {
+		return
+	}
+	option.usage.Bytes += bytes
+	option.usage.Objects += objects
+	option.unsavedUsage.Bytes += bytes
+	option.unsavedUsage.Objects += objects
+}
+
+// updateBucketQuota applies the quota of an updated bucket entry, and keeps the stored usage
+// unless a new one is seeded
+func (f *Filer) updateBucketQuota(oldEntry, entry *Entry) {
+
+	_, dirName := entry.FullPath.DirAndName()
+	quota, err := ParseBucketQuota(entry.Extended[BucketQuotaKey])
+	if err != nil {
+		glog.Errorf("bucket %s quota: %v", dirName, err)
+	}
+
+	seed, isSeeded := entry.Extended[BucketUsageSeedKey]
+	if isSeeded {
+		delete(entry.Extended, BucketUsageSeedKey)
+		entry.Extended[BucketUsageKey] = seed
+	} else if oldEntry != nil {
+		if usage, found := oldEntry.Extended[BucketUsageKey]; found {
+			if entry.Extended == nil {
+				entry.Extended = make(map[string][]byte)
+			}
+			entry.Extended[BucketUsageKey] = usage
+		}
+	}
+
+	f.buckets.Lock()
+	defer f.buckets.Unlock()
+
+	option, found := f.buckets.buckets[BucketName(dirName)]
+	if !found {
+		return
+	}
+	option.quota = quota
+	if isSeeded {
+		usage, err := ParseBucketUsage(seed)
+		if err != nil {
+			glog.Errorf("bucket %s usage: %v", dirName, err)
+			return
+		}
+		option.usage = usage
+		option.unsavedUsage = BucketUsage{}
+	}
+}
+
+func (f *Filer) loopSavingBucketUsage() {
+	for {
+		time.Sleep(bucketUsageSaveInterval)
+		f.saveBucketUsage()
+	}
+}
+
+// saveBucketUsage adds the unsaved usage to the stored usage, which may also be updated by other filers,
+// and reloads the stored usage of the buckets with a quota
+func (f *Filer) saveBucketUsage() {
+
+	unsaved := make(map[BucketName]BucketUsage)
+	f.buckets.Lock()
+	for name, option := range f.buckets.buckets {
+		if option.unsavedUsage != (BucketUsage{}) || option.quota != (BucketQuota{}) {
+			unsaved[name] = option.unsavedUsage
+			option.unsavedUsage = BucketUsage{}
+		}
+	}
+	f.buckets.Unlock()
+
+	for name, delta := range unsaved {
+		usage, err := f.saveOneBucketUsage(name, delta)
+		f.buckets.Lock()
+		if option, found := f.buckets.buckets[name]; found {
+			if err != nil {
+				glog.V(0).Infof("save bucket %s usage: %v", name, err)
+				option.unsavedUsage.Bytes += delta.Bytes
+				option.unsavedUsage.Objects += delta.Objects
+			} else {
+				option.usage.Bytes = usage.Bytes + option.unsavedUsage.Bytes
+				option.usage.Objects = usage.Objects + option.unsavedUsage.Objects
+			}
+		}
+		f.buckets.Unlock()
+	}
+}
+
+func (f *Filer) saveOneBucketUsage(name BucketName, delta BucketUsage) (usage BucketUsage, err error) {
+
+	ctx := context.Background()
+	entry, err := f.store.FindEntry(ctx, util.NewFullPath(f.DirBucketsPath, string(name)))
+	if err != nil {
+		return
+	}
+	if usage, err = ParseBucketUsage(entry.Extended[BucketUsageKey]); err != nil {
+		return
+	}
+	if delta == (BucketUsage{}) {
+		return
+	}
+	usage.Bytes += delta.Bytes
+	usage.Objects += delta.Objects
+
+	data, err := EncodeBucketUsage(usage)
+	if err != nil {
+		return
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	entry.Extended[BucketUsageKey] = data
+	err = f.store.UpdateEntry(ctx, entry)
+	return
+}
@@ -0,0 +1,136 @@
+package filer2
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func newQuotaTestFiler(quota BucketQuota) *Filer {
+	return &Filer{
+		DirBucketsPath: "/buckets",
+		buckets: &FilerBuckets{
+			buckets: map[BucketName]*BucketOption{
+				"b1": {Name: "b1", quota: quota},
+			},
+		},
+	}
+}
+
+func newQuotaTestEntry(path string, size uint64) *Entry {
+	return &Entry{
+		FullPath: util.FullPath(path),
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,2", Size: size}},
+	}
+}
+
+func TestBucketUsage(t *testing.T) {
+
+	f := newQuotaTestFiler(BucketQuota{})
+
+	f.updateBucketUsage(nil, newQuotaTestEntry("/buckets/b1/a/x.txt", 10))
+	f.updateBucketUsage(nil, newQuotaTestEntry("/buckets/b1/y.txt", 5))
+	// multipart uploads in progress and other paths are not counted
+	f.updateBucketUsage(nil, newQuotaTestEntry("/buckets/b1/"+MultipartUploadsFolder+"/id/0001.part", 100))
+	f.updateBucketUsage(nil, newQuotaTestEntry("/other/b1/z.txt", 100))
+	// overwrite and delete
+	f.updateBucketUsage(newQuotaTestEntry("/buckets/b1/y.txt", 5), newQuotaTestEntry("/buckets/b1/y.txt", 7))
+	f.updateBucketUsage(newQuotaTestEntry("/buckets/b1/a/x.txt", 10), nil)
+
+	option := f.buckets.buckets["b1"]
+	if option.usage != (BucketUsage{Bytes: 7, Objects: 1}) {
+		t.Errorf("unexpected usage %+v", option.usage)
+	}
+	if option.unsavedUsage != option.usage {
+		t.Errorf("unexpected unsaved usage %+v", option.unsavedUsage)
+	}
+
+}
+
+func TestCheckBucketQuota(t *testing.T) {
+
+	f := newQuotaTestFiler(BucketQuota{MaxBytes: 100, MaxObjects: 2})
+	f.updateBucketUsage(nil, newQuotaTestEntry("/buckets/b1/x", 60))
+
+	if err := f.checkBucketQuota(nil, newQuotaTestEntry("/buckets/b1/y", 40)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := f.checkBucketQuota(nil, newQuotaTestEntry("/buckets/b1/y", 41)); err == nil || !IsBucketQuotaExceeded(err.Error()) {
+		t.Errorf("expecting quota exceeded error, got %v", err)
+	}
+	// overwriting only counts the difference
+	if err := f.checkBucketQuota(newQuotaTestEntry("/buckets/b1/x", 60), newQuotaTestEntry("/buckets/b1/x", 100)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// shrinking is always allowed
+	f.updateBucketUsage(nil, newQuotaTestEntry("/buckets/b1/y", 60))
+	if err := f.checkBucketQuota(newQuotaTestEntry("/buckets/b1/y", 60), newQuotaTestEntry("/buckets/b1/y", 50)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := f.checkBucketQuota(nil, newQuotaTestEntry("/buckets/b1/z", 0)); err == nil {
+		t.Errorf("expecting object count exceeded")
+	}
+	if err := f.checkBucketQuota(nil, newQuotaTestEntry("/buckets/b1/"+MultipartUploadsFolder+"/id/0001.part", 1000)); err != nil {
+		t.Errorf("unexpected error for multipart upload part: %v", err)
+	}
+
+}
+
+func TestUpdateBucketQuota(t *testing.T) {
+
+	f := newQuotaTestFiler(BucketQuota{})
+	f.updateBucketUsage(nil, newQuotaTestEntry("/buckets/b1/x", 60))
+
+	quota, _ := EncodeBucketQuota(BucketQuota{MaxObjects: 5})
+	seed, _ := EncodeBucketUsage(BucketUsage{Bytes: 1000, Objects: 3})
+	oldEntry := &Entry{FullPath: "/buckets/b1", Extended: map[string][]byte{BucketUsageKey: []byte(`{"bytes":1,"objects":1}`)}}
+	entry := &Entry{FullPath: "/buckets/b1", Extended: map[string][]byte{BucketQuotaKey: quota}}
+
+	f.updateBucketQuota(oldEntry, entry)
+	option := f.buckets.buckets["b1"]
+	if option.quota.MaxObjects != 5 {
+		t.Errorf("unexpected quota %+v", option.quota)
+	}
+	if string(entry.Extended[BucketUsageKey]) != `{"bytes":1,"objects":1}` {
+		t.Errorf("stored usage should be kept, got %s", entry.Extended[BucketUsageKey])
+	}
+
+	entry.Extended[BucketUsageSeedKey] = seed
+	f.updateBucketQuota(oldEntry, entry)
+	if option.usage != (BucketUsage{Bytes: 1000, Objects: 3}) || option.unsavedUsage != (BucketUsage{}) {
+		t.Errorf("unexpected seeded usage %+v unsaved %+v", option.usage, option.unsavedUsage)
+	}
+	if _, found := entry.Extended[BucketUsageSeedKey]; found {
+		t.Errorf("seed should be removed")
+	}
+	if string(entry.Extended[BucketUsageKey]) != string(seed) {
+		t.Errorf("unexpected stored usage %s", entry.Extended[BucketUsageKey])
+	}
+
+}
+
+func TestReloadBucketUsage(t *testing.T) {
+
+	f := newTestFiler()
+	f.DirBucketsPath = "/buckets"
+	f.buckets = newQuotaTestFiler(BucketQuota{MaxBytes: 100}).buckets
+	ctx := context.Background()
+
+	// the other filers wrote to the bucket
+	usage, _ := EncodeBucketUsage(BucketUsage{Bytes: 90, Objects: 3})
+	if err := f.store.InsertEntry(ctx, &Entry{FullPath: "/buckets/b1", Attr: Attr{Mode: os.ModeDir}, Extended: map[string][]byte{BucketUsageKey: usage}}); err != nil {
+		t.Fatalf("insert bucket: %v", err)
+	}
+	if err := f.checkBucketQuota(nil, newQuotaTestEntry("/buckets/b1/x", 20)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	f.saveBucketUsage()
+	if err := f.checkBucketQuota(nil, newQuotaTestEntry("/buckets/b1/x", 20)); err == nil || !IsBucketQuotaExceeded(err.Error()) {
+		t.Errorf("expecting quota exceeded error after reloading the usage, got %v", err)
+	}
+
+}
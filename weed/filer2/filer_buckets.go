@@ -11,9 +11,12 @@ import (
 
 type BucketName string
 type BucketOption struct {
-	Name        BucketName
-	Replication string
-	fsync       bool
+	Name         BucketName
+	Replication  string
+	fsync        bool
+	quota        BucketQuota
+	usage        BucketUsage
+	unsavedUsage BucketUsage
 }
 type FilerBuckets struct {
 	dirBucketsPath string
@@ -46,14 +49,18 @@ func (f *Filer) LoadBuckets() {
 	f.buckets.Lock()
 	for _, entry := range entries {
 		_, shouldFsnyc := shouldFsyncMap[entry.Name()]
-		f.buckets.buckets[BucketName(entry.Name())] = &BucketOption{
+		option := &BucketOption{
 			Name:        BucketName(entry.Name()),
 			Replication: entry.Replication,
 			fsync:       shouldFsnyc,
 		}
+		option.loadQuotaAndUsage(entry)
+		f.buckets.buckets[BucketName(entry.Name())] = option
 	}
 	f.buckets.Unlock()
 
+	go f.loopSavingBucketUsage()
+
 }
 
 func (f *Filer) ReadBucketOption(buketName string) (replication string, fsync bool) {
@@ -96,10 +103,33 @@ func (f *Filer) maybeAddBucket(entry *Entry) {
 	if parent != f.DirBucketsPath {
 		return
 	}
-	f.addBucket(dirName, &BucketOption{
-		Name:        BucketName(dirName),
-		Replication: entry.Replication,
-	})
+
+	f.buckets.Lock()
+	defer f.buckets.Unlock()
+
+	// keep the usage and other options of an existing bucket
+	option, found := f.buckets.buckets[BucketName(dirName)]
+	if !found {
+		option = &BucketOption{
+			Name: BucketName(dirName),
+		}
+		option.loadQuotaAndUsage(entry)
+		f.buckets.buckets[BucketName(dirName)] = option
+	}
+	option.Replication = entry.Replication
+	if quota, err := ParseBucketQuota(entry.Extended[BucketQuotaKey]); err == nil {
+		option.quota = quota
+	}
+}
+
+func (option *BucketOption) loadQuotaAndUsage(entry *Entry) {
+	var err error
+	if option.quota, err = ParseBucketQuota(entry.Extended[BucketQuotaKey]); err != nil {
+		glog.Errorf("bucket %s quota: %v", entry.FullPath, err)
+	}
+	if option.usage, err = ParseBucketUsage(entry.Extended[BucketUsageKey]); err != nil {
+		glog.Errorf("bucket %s usage: %v", entry.FullPath, err)
+	}
 }
 
 func (f *Filer) addBucket(buketName string, bucketOption *BucketOption) {
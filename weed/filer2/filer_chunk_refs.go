@@ -0,0 +1,154 @@
+package filer2
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	// ChunkReferencesKey lists the file ids, separated by spaces, of the chunks a new entry shares with other entries.
+	// The filer counts the extra references when creating the entry, and removes the key.
+	ChunkReferencesKey = "seaweed-chunk-references"
+
+	// each shared chunk has one entry, named by the file id, with the count of extra references
+	ChunkReferencesDir     = "/etc/chunk_refs"
+	chunkReferenceCountKey = "count"
+)
+
+// SetChunkReferences asks the filer to keep the shared chunks until the last entry using them is deleted
+func SetChunkReferences(extended map[string][]byte, chunks []*filer_pb.FileChunk) {
+	var fileIds []string
+	for _, chunk := range chunks {
+		fileIds = append(fileIds, chunk.GetFileIdString())
+	}
+	if len(fileIds) > 0 {
+		extended[ChunkReferencesKey] = []byte(strings.Join(fileIds, " "))
+	}
+}
+
+// takeChunkReferences removes the ChunkReferencesKey from the new entry, and returns the shared file ids
+// which are new to the entry. The chunks kept from the old entry are already counted.
+func takeChunkReferences(oldEntry, entry *Entry) (fileIds []string) {
+	if entry.Extended == nil {
+		return nil
+	}
+	value, found := entry.Extended[ChunkReferencesKey]
+	if !found {
+		return nil
+	}
+	delete(entry.Extended, ChunkReferencesKey)
+
+	chunkIds := make(map[string]bool)
+	for _, chunk := range entry.Chunks {
+		chunkIds[chunk.GetFileIdString()] = true
+	}
+	if oldEntry != nil {
+		for _, chunk := range oldEntry.Chunks {
+			delete(chunkIds, chunk.GetFileIdString())
+		}
+	}
+	for _, fileId := range strings.Fields(string(value)) {
+		if chunkIds[fileId] {
+			fileIds = append(fileIds, fileId)
+		}
+	}
+	return fileIds
+}
+
+// addChunkReferences counts one more reference for each file id, or none if failed
+func (f *Filer) addChunkReferences(ctx context.Context, fileIds []string) error {
+
+	f.chunkReferencesLock.Lock()
+	defer f.chunkReferencesLock.Unlock()
+
+	for i, fileId := range fileIds {
+		count, err := f.findChunkReferenceCount(ctx, fileId)
+		if err == nil {
+			err = f.saveChunkReferenceCount(ctx, fileId, count, count+1)
+		}
+		if err != nil {
+			for _, added := range fileIds[:i] {
+				f.releaseChunkReferenceLocked(ctx, added)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseChunkReferences undoes addChunkReferences, without deleting any chunk
+func (f *Filer) releaseChunkReferences(ctx context.Context, fileIds []string) {
+
+	f.chunkReferencesLock.Lock()
+	defer f.chunkReferencesLock.Unlock()
+
+	for _, fileId := range fileIds {
+		f.releaseChunkReferenceLocked(ctx, fileId)
+	}
+}
+
+// releaseChunkReference drops one extra reference of a chunk, returning whether the chunk is still used by other entries.
+// A chunk with unknown references is kept, since leaking it is safer than deleting the data of another entry.
+func (f *Filer) releaseChunkReference(ctx context.Context, fileId string) (isShared bool) {
+
+	f.chunkReferencesLock.Lock()
+	defer f.chunkReferencesLock.Unlock()
+
+	return f.releaseChunkReferenceLocked(ctx, fileId)
+}
+
+func (f *Filer) releaseChunkReferenceLocked(ctx context.Context, fileId string) (isShared bool) {
+	count, err := f.findChunkReferenceCount(ctx, fileId)
+	if err != nil {
+		glog.Errorf("find chunk %s references: %v", fileId, err)
+		return true
+	}
+	if count == 0 {
+		return false
+	}
+	if err = f.saveChunkReferenceCount(ctx, fileId, count, count-1); err != nil {
+		glog.Errorf("release chunk %s reference: %v", fileId, err)
+	}
+	return true
+}
+
+func (f *Filer) findChunkReferenceCount(ctx context.Context, fileId string) (count int64, err error) {
+	entry, err := f.store.FindEntry(ctx, util.NewFullPath(ChunkReferencesDir, fileId))
+	if err == filer_pb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(entry.Extended[chunkReferenceCountKey]), 10, 64)
+}
+
+func (f *Filer) saveChunkReferenceCount(ctx context.Context, fileId string, oldCount, count int64) error {
+	p := util.NewFullPath(ChunkReferencesDir, fileId)
+	if count == 0 {
+		return f.store.DeleteEntry(ctx, p)
+	}
+	now := time.Now()
+	entry := &Entry{
+		FullPath: p,
+		Attr: Attr{
+			Mtime:  now,
+			Crtime: now,
+			Mode:   0600,
+		},
+		Extended: map[string][]byte{
+			chunkReferenceCountKey: []byte(strconv.FormatInt(count, 10)),
+		},
+	}
+	if oldCount == 0 {
+		return f.store.InsertEntry(ctx, entry)
+	}
+	return f.store.UpdateEntry(ctx, entry)
+}
@@ -0,0 +1,85 @@
+package filer2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestSharedChunks(t *testing.T) {
+
+	f := newTestFiler()
+	ctx := context.Background()
+
+	source := &Entry{
+		FullPath: "/buckets/b1/source",
+		Chunks: []*filer_pb.FileChunk{
+			{FileId: "1,01", Offset: 0, Size: 10},
+			{FileId: "1,02", Offset: 10, Size: 10},
+		},
+	}
+	if err := f.CreateEntry(ctx, source, false); err != nil {
+		t.Fatalf("create source: %v", err)
+	}
+
+	// the copy shares the second chunk, and has one of its own
+	copied := &Entry{
+		FullPath: "/buckets/b1/copy",
+		Chunks: []*filer_pb.FileChunk{
+			{FileId: "1,02", Offset: 0, Size: 10},
+			{FileId: "1,03", Offset: 10, Size: 5},
+		},
+		Extended: make(map[string][]byte),
+	}
+	SetChunkReferences(copied.Extended, copied.Chunks[:1])
+	if err := f.CreateEntry(ctx, copied, false); err != nil {
+		t.Fatalf("create copy: %v", err)
+	}
+	if _, found := copied.Extended[ChunkReferencesKey]; found {
+		t.Errorf("the chunk references should not be saved with the entry")
+	}
+
+	// recreating the copy with the same chunks does not count them again
+	recopied := &Entry{
+		FullPath: copied.FullPath,
+		Chunks:   copied.Chunks,
+		Extended: make(map[string][]byte),
+	}
+	SetChunkReferences(recopied.Extended, recopied.Chunks[:1])
+	if err := f.CreateEntry(ctx, recopied, false); err != nil {
+		t.Fatalf("recreate copy: %v", err)
+	}
+	if count, _ := f.findChunkReferenceCount(ctx, "1,02"); count != 1 {
+		t.Errorf("expected 1 extra reference, got %d", count)
+	}
+
+	// deleting the source keeps the shared chunk
+	f.DeleteChunks(source.Chunks)
+	if fileIds := deletedFileIds(f); len(fileIds) != 1 || fileIds[0] != "1,01" {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+
+	// the last entry using the chunk deletes it
+	f.DeleteChunks(copied.Chunks)
+	if fileIds := deletedFileIds(f); len(fileIds) != 2 || fileIds[0] != "1,02" || fileIds[1] != "1,03" {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+
+	// a chunk not in the entry can not be referenced
+	other := &Entry{
+		FullPath: "/buckets/b1/other",
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,04", Size: 1}},
+		Extended: map[string][]byte{ChunkReferencesKey: []byte("1,01 1,04")},
+	}
+	if err := f.CreateEntry(ctx, other, false); err != nil {
+		t.Fatalf("create other: %v", err)
+	}
+	if count, _ := f.findChunkReferenceCount(ctx, "1,01"); count != 0 {
+		t.Errorf("expected no references, got %d", count)
+	}
+	if count, _ := f.findChunkReferenceCount(ctx, "1,04"); count != 1 {
+		t.Errorf("expected 1 extra reference, got %d", count)
+	}
+
+}
@@ -0,0 +1,59 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// CopyEntry creates the file dst sharing the chunks of the source file, without copying any data.
+// The chunks must be in the collection of dst, since deleting a collection drops its chunks.
+// The extended attributes are not copied, except the customer key to read the chunks.
+// The versionId, if not empty, is the version id of dst in a versioned bucket.
+func (f *Filer) CopyEntry(ctx context.Context, srcEntry *Entry, dst util.FullPath, o_excl bool, versionId string) (*Entry, error) {
+
+	if srcEntry.IsDirectory() {
+		return nil, fmt.Errorf("copy %s: is a directory", srcEntry.FullPath)
+	}
+
+	chunks, _ := CompactFileChunks(srcEntry.Chunks)
+	var sharedChunks []*filer_pb.FileChunk
+	for _, chunk := range chunks {
+		sharedChunks = append(sharedChunks, proto.Clone(chunk).(*filer_pb.FileChunk))
+	}
+
+	now := time.Now()
+	entry := &Entry{
+		FullPath: dst,
+		Attr:     srcEntry.Attr,
+		Extended: make(map[string][]byte),
+		Chunks:   sharedChunks,
+	}
+	entry.Mtime, entry.Crtime = now, now
+	if customerKeyMd5, found := srcEntry.Extended[CustomerKeyMd5Key]; found {
+		entry.Extended[CustomerKeyMd5Key] = customerKeyMd5
+	}
+	SetVersionId(entry.Extended, versionId)
+	SetChunkReferences(entry.Extended, sharedChunks)
+
+	if err := f.CreateEntry(ctx, entry, o_excl); err != nil {
+		return nil, err
+	}
+
+	// the source chunks may have been deleted before being referenced
+	if current, err := f.FindEntry(ctx, srcEntry.FullPath); err != nil || len(MinusChunks(sharedChunks, current.Chunks)) > 0 {
+		glog.V(0).Infof("copy %s to %s: source changed", srcEntry.FullPath, dst)
+		if deleteErr := f.DeleteEntryMetaAndData(ctx, dst, false, false, true); deleteErr != nil {
+			glog.Errorf("remove copy %s: %v", dst, deleteErr)
+		}
+		return nil, fmt.Errorf("copy %s to %s: source changed during copy", srcEntry.FullPath, dst)
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,61 @@
+package filer2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestCopyEntry(t *testing.T) {
+
+	f := newTestFiler()
+	ctx := context.Background()
+
+	source := &Entry{
+		FullPath: "/buckets/b1/source",
+		Attr:     Attr{Mode: 0644, Mime: "text/plain"},
+		Chunks: []*filer_pb.FileChunk{
+			{FileId: "1,01", Offset: 0, Size: 10, Mtime: 1},
+			{FileId: "1,02", Offset: 10, Size: 10, Mtime: 1},
+			// overwrites the first chunk
+			{FileId: "1,03", Offset: 0, Size: 10, Mtime: 2},
+		},
+		Extended: map[string][]byte{"key": []byte("value")},
+	}
+	if err := f.CreateEntry(ctx, source, false); err != nil {
+		t.Fatalf("create source: %v", err)
+	}
+
+	copied, err := f.CopyEntry(ctx, source, "/buckets/b1/copy", false, "")
+	if err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if copied.Mime != "text/plain" || len(copied.Chunks) != 2 || len(copied.Extended) != 0 {
+		t.Errorf("unexpected copy %+v", copied)
+	}
+	for _, fileId := range []string{"1,02", "1,03"} {
+		if count, _ := f.findChunkReferenceCount(ctx, fileId); count != 1 {
+			t.Errorf("expected 1 extra reference of %s, got %d", fileId, count)
+		}
+	}
+
+	// overwriting the source keeps the shared chunks
+	f.deleteChunksIfNotNew(context.Background(), source, &Entry{FullPath: source.FullPath})
+	if fileIds := deletedFileIds(f); len(fileIds) != 1 || fileIds[0] != "1,01" {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+
+	// a source deleted before the copy is referenced
+	stale := &Entry{
+		FullPath: "/buckets/b1/deleted",
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,04", Size: 10}},
+	}
+	if _, err = f.CopyEntry(ctx, stale, "/buckets/b1/copy2", false, ""); err == nil {
+		t.Errorf("expected the copy of a deleted source to fail")
+	}
+	if _, err = f.FindEntry(ctx, "/buckets/b1/copy2"); err != filer_pb.ErrNotFound {
+		t.Errorf("expected the failed copy to be removed, got %v", err)
+	}
+
+}
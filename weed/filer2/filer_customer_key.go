@@ -0,0 +1,85 @@
+package filer2
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	// CustomerKeyHeader carries a base64 encoded 256-bit key given by the client,
+	// which encrypts the chunk keys of the file being written or decrypts them for reading
+	CustomerKeyHeader = "X-Seaweedfs-Customer-Key"
+	// CustomerKeyMd5Key is the extended attribute for the base64 encoded MD5 of the customer key
+	CustomerKeyMd5Key = "customer-key-md5"
+)
+
+var (
+	ErrCustomerKeyRequired = errors.New("customer key required")
+	ErrCustomerKeyMismatch = errors.New("customer key mismatch")
+)
+
+// ParseCustomerKey decodes the customer key header, returning nil if it is empty
+func ParseCustomerKey(encoded string) (util.CipherKey, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode customer key: %v", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("customer key has %d bytes, expecting 32", len(key))
+	}
+	return util.CipherKey(key), nil
+}
+
+func CustomerKeyMd5(key []byte) string {
+	sum := md5.Sum(key)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// WrapCipherKeys encrypts the chunk keys with the customer key, so that the file can only be read with the same key.
+// All chunks should already be encrypted.
+func WrapCipherKeys(entry *Entry, customerKey util.CipherKey) error {
+	for _, chunk := range entry.Chunks {
+		if len(chunk.CipherKey) == 0 {
+			return fmt.Errorf("chunk %s is not encrypted", chunk.GetFileIdString())
+		}
+		wrapped, err := util.Encrypt(chunk.CipherKey, customerKey)
+		if err != nil {
+			return err
+		}
+		chunk.CipherKey = wrapped
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	entry.Extended[CustomerKeyMd5Key] = []byte(CustomerKeyMd5(customerKey))
+	return nil
+}
+
+// UnwrapCipherKeys decrypts the chunk keys of a file written with a customer key, and does nothing for other files
+func UnwrapCipherKeys(entry *Entry, customerKey util.CipherKey) error {
+	keyMd5, found := entry.Extended[CustomerKeyMd5Key]
+	if !found {
+		return nil
+	}
+	if customerKey == nil {
+		return ErrCustomerKeyRequired
+	}
+	if string(keyMd5) != CustomerKeyMd5(customerKey) {
+		return ErrCustomerKeyMismatch
+	}
+	for _, chunk := range entry.Chunks {
+		key, err := util.Decrypt(chunk.CipherKey, customerKey)
+		if err != nil {
+			return fmt.Errorf("decrypt chunk %s key: %v", chunk.GetFileIdString(), err)
+		}
+		chunk.CipherKey = key
+	}
+	return nil
+}
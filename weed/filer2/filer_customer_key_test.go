@@ -0,0 +1,68 @@
+package filer2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestWrapCipherKeys(t *testing.T) {
+
+	chunkKey := util.GenCipherKey()
+	customerKey := util.GenCipherKey()
+
+	entry := &Entry{
+		FullPath: "/buckets/b1/x",
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,2", Size: 10, CipherKey: chunkKey}},
+	}
+	if err := WrapCipherKeys(entry, customerKey); err != nil {
+		t.Fatalf("wrap: %v", err)
+	}
+	if bytes.Equal(entry.Chunks[0].CipherKey, chunkKey) {
+		t.Errorf("chunk key is not wrapped")
+	}
+
+	if err := UnwrapCipherKeys(entry, nil); err != ErrCustomerKeyRequired {
+		t.Errorf("expecting %v, got %v", ErrCustomerKeyRequired, err)
+	}
+	if err := UnwrapCipherKeys(entry, util.GenCipherKey()); err != ErrCustomerKeyMismatch {
+		t.Errorf("expecting %v, got %v", ErrCustomerKeyMismatch, err)
+	}
+	if err := UnwrapCipherKeys(entry, customerKey); err != nil {
+		t.Fatalf("unwrap: %v", err)
+	}
+	if !bytes.Equal(entry.Chunks[0].CipherKey, chunkKey) {
+		t.Errorf("unexpected chunk key after unwrapping")
+	}
+
+	plain := &Entry{FullPath: "/buckets/b1/y", Chunks: []*filer_pb.FileChunk{{FileId: "1,3", Size: 10}}}
+	if err := UnwrapCipherKeys(plain, nil); err != nil {
+		t.Errorf("unexpected error for a file without customer key: %v", err)
+	}
+	if err := WrapCipherKeys(plain, customerKey); err == nil {
+		t.Errorf("expecting error wrapping unencrypted chunks")
+	}
+
+}
+
+func TestParseCustomerKey(t *testing.T) {
+
+	if key, err := ParseCustomerKey(""); key != nil || err != nil {
+		t.Errorf("unexpected key %v error %v for empty header", key, err)
+	}
+	if _, err := ParseCustomerKey(base64.StdEncoding.EncodeToString(make([]byte, 16))); err == nil {
+		t.Errorf("expecting error for a short key")
+	}
+	if _, err := ParseCustomerKey("not base64!"); err == nil {
+		t.Errorf("expecting error for a malformed key")
+	}
+	key := util.GenCipherKey()
+	parsed, err := ParseCustomerKey(base64.StdEncoding.EncodeToString(key))
+	if err != nil || !bytes.Equal(parsed, key) {
+		t.Errorf("unexpected key %v error %v", parsed, err)
+	}
+
+}
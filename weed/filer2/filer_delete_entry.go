@@ -3,6 +3,7 @@ package filer2
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
@@ -11,6 +12,15 @@ import (
 )
 
 func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p util.FullPath, isRecursive bool, ignoreRecursiveError, shouldDeleteChunks bool) (err error) {
+	return f.deleteEntryMetaAndData(ctx, p, isRecursive, ignoreRecursiveError, shouldDeleteChunks, true)
+}
+
+// DeleteMovedEntry removes the old entry of a moved file or folder, whose chunks and object lock are kept by the new entry
+func (f *Filer) DeleteMovedEntry(ctx context.Context, p util.FullPath) (err error) {
+	return f.deleteEntryMetaAndData(ctx, p, false, false, false, false)
+}
+
+func (f *Filer) deleteEntryMetaAndData(ctx context.Context, p util.FullPath, isRecursive bool, ignoreRecursiveError, shouldDeleteChunks, checkObjectLock bool) (err error) {
 	if p == "/" {
 		return nil
 	}
@@ -20,10 +30,26 @@ func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p util.FullPath, isR
 		return findErr
 	}
 
+	if checkObjectLock {
+		if entry.IsDirectory() && isRecursive {
+			err = f.checkObjectLockDeleteTree(ctx, entry, time.Now())
+		} else {
+			err = checkObjectLockDelete(entry, time.Now())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	isCollection := f.isBucket(entry)
 
+	if shouldDeleteChunks {
+		if trashDir, ok := f.trashDirOf(entry); ok {
+			return f.moveToTrash(ctx, entry, trashDir, isRecursive, time.Now())
+		}
+	}
+
 	var chunks []*filer_pb.FileChunk
-	chunks = append(chunks, entry.Chunks...)
 	if entry.IsDirectory() {
 		// delete the folder children, not including the folder itself
 		var dirChunks []*filer_pb.FileChunk
@@ -41,11 +67,24 @@ func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p util.FullPath, isR
 		return fmt.Errorf("delete file %s: %v", p, err)
 	}
 
+	// the chunks of a hard link are deleted with the last link
+	isSharedLink, err := f.releaseHardLink(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("unlink file %s: %v", p, err)
+	}
+	if !isSharedLink {
+		chunks = append(chunks, entry.Chunks...)
+	}
+
 	if shouldDeleteChunks && !isCollection {
 		go f.DeleteChunks(chunks)
 	}
 	if isCollection {
 		collectionName := entry.Name()
+		// the trashed objects would outlive their chunks in the dropped collection
+		if err := f.DeleteEntryMetaAndData(ctx, BucketTrashDir(collectionName), true, true, true); err != nil && err != filer_pb.ErrNotFound {
+			glog.V(0).Infof("purge trash of bucket %s: %v", collectionName, err)
+		}
 		f.doDeleteCollection(collectionName)
 		f.deleteBucket(collectionName)
 	}
@@ -77,9 +116,20 @@ func (f *Filer) doBatchDeleteFolderMetaAndData(ctx context.Context, entry *Entry
 				f.NotifyUpdateEvent(sub, nil, shouldDeleteChunks)
 				chunks = append(chunks, dirChunks...)
 			} else {
-				chunks = append(chunks, sub.Chunks...)
+				if err = checkObjectLockDelete(sub, time.Now()); err != nil {
+					return nil, err
+				}
+				var isSharedLink bool
+				if isSharedLink, err = f.releaseHardLink(ctx, sub); err != nil {
+					return nil, err
+				}
+				if !isSharedLink {
+					chunks = append(chunks, sub.Chunks...)
+				}
+				f.updateBucketUsage(sub, nil)
 			}
-			if err != nil && !ignoreRecursiveError {
+			// the locked files are never deleted together with the folder
+			if err != nil && (!ignoreRecursiveError || IsObjectLocked(err.Error())) {
 				return nil, err
 			}
 		}
@@ -1,6 +1,7 @@
 package filer2
 
 import (
+	"context"
 	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
@@ -56,8 +57,17 @@ func (f *Filer) loopProcessingDeletion() {
 	}
 }
 
+// DeleteChunks deletes the chunks, except the ones still shared by other entries
 func (f *Filer) DeleteChunks(chunks []*filer_pb.FileChunk) {
+	f.deleteChunks(context.Background(), chunks)
+}
+
+// deleteChunks releases the chunk references in the transaction of the context, if any
+func (f *Filer) deleteChunks(ctx context.Context, chunks []*filer_pb.FileChunk) {
 	for _, chunk := range chunks {
+		if f.releaseChunkReference(ctx, chunk.GetFileIdString()) {
+			continue
+		}
 		f.fileIdDeletionQueue.EnQueue(chunk.GetFileIdString())
 	}
 }
@@ -68,13 +78,18 @@ func (f *Filer) DeleteFileByFileId(fileId string) {
 	f.fileIdDeletionQueue.EnQueue(fileId)
 }
 
-func (f *Filer) deleteChunksIfNotNew(oldEntry, newEntry *Entry) {
+func (f *Filer) deleteChunksIfNotNew(ctx context.Context, oldEntry, newEntry *Entry) {
 
 	if oldEntry == nil {
 		return
 	}
+	// the chunks of a hard link replaced by another entry are still used by the other links
+	if isSharedHardLink(oldEntry) && (newEntry == nil || HardLinkId(oldEntry.Extended) != HardLinkId(newEntry.Extended)) {
+		return
+	}
 	if newEntry == nil {
-		f.DeleteChunks(oldEntry.Chunks)
+		f.deleteChunks(ctx, oldEntry.Chunks)
+		return
 	}
 
 	var toDelete []*filer_pb.FileChunk
@@ -88,5 +103,5 @@ func (f *Filer) deleteChunksIfNotNew(oldEntry, newEntry *Entry) {
 			toDelete = append(toDelete, oldChunk)
 		}
 	}
-	f.DeleteChunks(toDelete)
+	f.deleteChunks(ctx, toDelete)
 }
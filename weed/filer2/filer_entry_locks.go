@@ -0,0 +1,46 @@
+package filer2
+
+import (
+	"sync"
+
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// entryLocks serializes the changes to the same path on this filer
+type entryLocks struct {
+	sync.Mutex
+	locks map[util.FullPath]*entryLock
+}
+
+type entryLock struct {
+	sync.Mutex
+	holders int
+}
+
+// lock waits for the path, and returns the function to release it
+func (l *entryLocks) lock(p util.FullPath) (unlock func()) {
+
+	l.Lock()
+	if l.locks == nil {
+		l.locks = make(map[util.FullPath]*entryLock)
+	}
+	el, found := l.locks[p]
+	if !found {
+		el = &entryLock{}
+		l.locks[p] = el
+	}
+	el.holders++
+	l.Unlock()
+
+	el.Lock()
+
+	return func() {
+		el.Unlock()
+		l.Lock()
+		el.holders--
+		if el.holders == 0 {
+			delete(l.locks, p)
+		}
+		l.Unlock()
+	}
+}
@@ -0,0 +1,42 @@
+package filer2
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestConcurrentExclusiveCreateEntry(t *testing.T) {
+
+	f := newTestFiler()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	var created sync.Map
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := f.CreateEntry(ctx, &Entry{
+				FullPath: "/buckets/b1/.uploads/u1",
+				Attr:     Attr{Mode: 0644},
+				Chunks:   []*filer_pb.FileChunk{{FileId: "1,01", Size: 10}},
+			}, true); err == nil {
+				created.Store(i, true)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var count int
+	created.Range(func(key, value interface{}) bool {
+		count++
+		return true
+	})
+	if count != 1 {
+		t.Errorf("expected only one exclusive creation to succeed, but %d did", count)
+	}
+
+}
@@ -0,0 +1,45 @@
+package filer2
+
+import (
+	"context"
+)
+
+// updateHardLinks counts the link of the new entry, and drops the link of the old entry it replaces
+func (f *Filer) updateHardLinks(ctx context.Context, oldEntry, newEntry *Entry) error {
+	oldId, newId := "", HardLinkId(newEntry.Extended)
+	if oldEntry != nil {
+		oldId = HardLinkId(oldEntry.Extended)
+	}
+	if oldId == newId {
+		return nil
+	}
+	if newId != "" {
+		if _, err := f.store.addHardLinkCount(ctx, newId, 1); err != nil {
+			return err
+		}
+	}
+	if oldId != "" {
+		if _, err := f.store.addHardLinkCount(ctx, oldId, -1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseHardLink drops the link of a deleted entry, returning whether its chunks are still used by other links
+func (f *Filer) releaseHardLink(ctx context.Context, entry *Entry) (isShared bool, err error) {
+	id := HardLinkId(entry.Extended)
+	if id == "" {
+		return false, nil
+	}
+	count, err := f.store.addHardLinkCount(ctx, id, -1)
+	if err != nil {
+		return true, err
+	}
+	return count > 0, nil
+}
+
+// isSharedHardLink tells whether other links use the chunks of the entry, as read before it changes
+func isSharedHardLink(entry *Entry) bool {
+	return HardLinkId(entry.Extended) != "" && HardLinkCount(entry.Extended) > 1
+}
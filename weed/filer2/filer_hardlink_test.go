@@ -0,0 +1,84 @@
+package filer2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestHardLinks(t *testing.T) {
+
+	f := newTestFiler()
+	ctx := context.Background()
+
+	if err := f.CreateEntry(ctx, &Entry{
+		FullPath: "/home/a",
+		Attr:     Attr{Mode: 0644},
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,01", Size: 10}},
+	}, false); err != nil {
+		t.Fatalf("create a: %v", err)
+	}
+
+	// the first link turns the file into a hard link, and the second one shares it
+	a, _ := f.FindEntry(ctx, "/home/a")
+	a.Extended = map[string][]byte{HardLinkIdKey: NewHardLinkId()}
+	if err := f.CreateEntry(ctx, a, false); err != nil {
+		t.Fatalf("link a: %v", err)
+	}
+	b := &Entry{FullPath: "/home/b", Attr: a.Attr, Chunks: a.Chunks, Extended: map[string][]byte{HardLinkIdKey: a.Extended[HardLinkIdKey]}}
+	if err := f.CreateEntry(ctx, b, false); err != nil {
+		t.Fatalf("link b: %v", err)
+	}
+	if b, _ = f.FindEntry(ctx, "/home/b"); HardLinkCount(b.Extended) != 2 || len(b.Chunks) != 1 {
+		t.Fatalf("unexpected link %+v", b)
+	}
+
+	// writing one link changes the others
+	b.Chunks = []*filer_pb.FileChunk{{FileId: "1,02", Size: 20}}
+	if err := f.CreateEntry(ctx, b, false); err != nil {
+		t.Fatalf("write b: %v", err)
+	}
+	if fileIds := deletedFileIds(f); len(fileIds) != 1 || fileIds[0] != "1,01" {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+	if a, _ = f.FindEntry(ctx, "/home/a"); len(a.Chunks) != 1 || a.Chunks[0].GetFileIdString() != "1,02" || HardLinkCount(a.Extended) != 2 {
+		t.Errorf("unexpected link %+v", a)
+	}
+
+	// removing one link keeps the chunks
+	if err := f.DeleteEntryMetaAndData(ctx, "/home/a", false, false, true); err != nil {
+		t.Fatalf("delete a: %v", err)
+	}
+	if b, _ = f.FindEntry(ctx, "/home/b"); HardLinkCount(b.Extended) != 1 || len(b.Chunks) != 1 {
+		t.Errorf("unexpected link %+v", b)
+	}
+
+	// moving the link keeps the count
+	b.FullPath = "/home/c"
+	if err := f.CreateEntry(ctx, b, false); err != nil {
+		t.Fatalf("move b: %v", err)
+	}
+	if err := f.DeleteMovedEntry(ctx, "/home/b"); err != nil {
+		t.Fatalf("delete moved b: %v", err)
+	}
+	c, err := f.FindEntry(ctx, "/home/c")
+	if err != nil || HardLinkCount(c.Extended) != 1 {
+		t.Fatalf("unexpected moved link %+v: %v", c, err)
+	}
+	if fileIds := deletedFileIds(f); len(fileIds) != 0 {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+
+	// the last link deletes the chunks and the record
+	if err := f.DeleteEntryMetaAndData(ctx, "/home/c", false, false, true); err != nil {
+		t.Fatalf("delete c: %v", err)
+	}
+	if fileIds := waitDeletedFileIds(f); len(fileIds) != 1 || fileIds[0] != "1,02" {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+	if _, err := f.store.FindEntry(ctx, hardLinkPath(HardLinkId(c.Extended))); err != filer_pb.ErrNotFound {
+		t.Errorf("the record is not deleted: %v", err)
+	}
+
+}
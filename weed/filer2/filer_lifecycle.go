@@ -0,0 +1,191 @@
+package filer2
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/wdclient"
+)
+
+const (
+	// BucketLifecycleKey is the bucket entry extended attribute holding the json encoded lifecycle rules
+	BucketLifecycleKey = "s3-lifecycle"
+	// MultipartUploadsFolder holds the unfinished multipart uploads of a bucket
+	MultipartUploadsFolder = ".uploads"
+	// LifecycleLockName is the master lock held by the filer applying the lifecycle rules
+	LifecycleLockName = "filer.lifecycle"
+)
+
+type LifecycleRule struct {
+	Id                                 string `json:"id,omitempty"`
+	Prefix                             string `json:"prefix,omitempty"`
+	Enabled                            bool   `json:"enabled"`
+	ExpirationDays                     int    `json:"expirationDays,omitempty"`
+	AbortIncompleteMultipartUploadDays int    `json:"abortIncompleteMultipartUploadDays,omitempty"`
+}
+
+func ParseLifecycleRules(data []byte) (rules []*LifecycleRule, err error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	err = json.Unmarshal(data, &rules)
+	return
+}
+
+func EncodeLifecycleRules(rules []*LifecycleRule) ([]byte, error) {
+	return json.Marshal(rules)
+}
+
+// LoopProcessingLifecycle periodically applies the bucket lifecycle rules.
+// Only the filer holding the lifecycle lock of the master applies them, so the filers do not expire the same objects.
+func (f *Filer) LoopProcessingLifecycle(interval time.Duration) {
+	locker := wdclient.NewExclusiveLocker(f.MasterClient, LifecycleLockName)
+	for {
+		time.Sleep(interval)
+		if !locker.TryLock() {
+			glog.V(1).Infof("lifecycle skipped, the lifecycle lock is held by others")
+			continue
+		}
+		f.processLifecycle(time.Now())
+		locker.ReleaseLock()
+	}
+}
+
+func (f *Filer) processLifecycle(now time.Time) {
+
+	ctx := context.Background()
+
+	lastFileName := ""
+	for {
+		buckets, err := f.ListDirectoryEntries(ctx, util.FullPath(f.DirBucketsPath), lastFileName, false, PaginationSize)
+		if err != nil {
+			glog.V(0).Infof("lifecycle list buckets %s: %v", f.DirBucketsPath, err)
+			return
+		}
+		for _, bucket := range buckets {
+			lastFileName = bucket.Name()
+			if !bucket.IsDirectory() || bucket.Extended == nil {
+				continue
+			}
+			rules, err := ParseLifecycleRules(bucket.Extended[BucketLifecycleKey])
+			if err != nil {
+				glog.V(0).Infof("lifecycle rules of bucket %s: %v", bucket.FullPath, err)
+				continue
+			}
+			for _, rule := range rules {
+				if !rule.Enabled {
+					continue
+				}
+				if rule.ExpirationDays > 0 {
+					f.expireObjects(ctx, bucket.FullPath, string(bucket.Extended[BucketVersioningKey]), rule, now.Add(-time.Duration(rule.ExpirationDays)*24*time.Hour))
+				}
+				if rule.AbortIncompleteMultipartUploadDays > 0 {
+					f.abortIncompleteMultipartUploads(ctx, bucket.FullPath, rule, now.Add(-time.Duration(rule.AbortIncompleteMultipartUploadDays)*24*time.Hour))
+				}
+			}
+		}
+		if len(buckets) < PaginationSize {
+			break
+		}
+	}
+
+}
+
+// expireObjects deletes the objects under the rule prefix last modified before the cutoff time.
+// For versioned buckets the current versions are replaced by delete markers, and the prior versions are kept.
+func (f *Filer) expireObjects(ctx context.Context, bucketPath util.FullPath, versioningStatus string, rule *LifecycleRule, cutoff time.Time) {
+
+	now := time.Now()
+
+	dir := string(bucketPath)
+	if i := strings.LastIndex(rule.Prefix, "/"); i >= 0 {
+		dir = util.Join(dir, rule.Prefix[:i])
+	}
+
+	f.walkEntries(ctx, util.FullPath(dir), func(entry *Entry) bool {
+		key := strings.TrimPrefix(string(entry.FullPath), string(bucketPath)+"/")
+		if entry.IsDirectory() {
+			if strings.HasPrefix(key, ".") && !strings.Contains(key, "/") {
+				// skip .uploads, .versions, etc
+				return false
+			}
+			return true
+		}
+		if !strings.HasPrefix(key, rule.Prefix) || !entry.Mtime.Before(cutoff) {
+			return false
+		}
+		if versioningStatus != "" {
+			glog.V(2).Infof("lifecycle rule %s expires current version of %s", rule.Id, entry.FullPath)
+			if err := f.expireCurrentVersion(ctx, entry, versioningStatus, now); err != nil {
+				glog.V(0).Infof("lifecycle expire %s: %v", entry.FullPath, err)
+			}
+			return false
+		}
+		if GetObjectRetention(entry.Extended).IsLocked(now) {
+			glog.V(3).Infof("lifecycle rule %s skips locked %s", rule.Id, entry.FullPath)
+			return false
+		}
+		glog.V(2).Infof("lifecycle rule %s expires %s", rule.Id, entry.FullPath)
+		if err := f.DeleteEntryMetaAndData(ctx, entry.FullPath, false, false, true); err != nil {
+			glog.V(0).Infof("lifecycle expire %s: %v", entry.FullPath, err)
+		}
+		return false
+	})
+
+}
+
+// abortIncompleteMultipartUploads removes the multipart uploads under the rule prefix initiated before the cutoff time.
+func (f *Filer) abortIncompleteMultipartUploads(ctx context.Context, bucketPath util.FullPath, rule *LifecycleRule, cutoff time.Time) {
+
+	uploadsPath := bucketPath.Child(MultipartUploadsFolder)
+
+	var expiredUploads []util.FullPath
+	f.walkEntries(ctx, uploadsPath, func(entry *Entry) bool {
+		if !entry.IsDirectory() || !entry.Crtime.Before(cutoff) {
+			return false
+		}
+		key := ""
+		if entry.Extended != nil {
+			key = strings.TrimPrefix(string(entry.Extended["key"]), "/")
+		}
+		if strings.HasPrefix(key, rule.Prefix) {
+			expiredUploads = append(expiredUploads, entry.FullPath)
+		}
+		return false
+	})
+
+	for _, uploadPath := range expiredUploads {
+		glog.V(2).Infof("lifecycle rule %s aborts multipart upload %s", rule.Id, uploadPath)
+		if err := f.DeleteEntryMetaAndData(ctx, uploadPath, true, true, true); err != nil {
+			glog.V(0).Infof("lifecycle abort multipart upload %s: %v", uploadPath, err)
+		}
+	}
+
+}
+
+// walkEntries visits the entries under the directory, and descends into directories if fn returns true.
+func (f *Filer) walkEntries(ctx context.Context, dir util.FullPath, fn func(entry *Entry) bool) {
+
+	lastFileName := ""
+	for {
+		entries, err := f.ListDirectoryEntries(ctx, dir, lastFileName, false, PaginationSize)
+		if err != nil {
+			glog.V(0).Infof("list %s: %v", dir, err)
+			return
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			if fn(entry) && entry.IsDirectory() {
+				f.walkEntries(ctx, entry.FullPath, fn)
+			}
+		}
+		if len(entries) < PaginationSize {
+			return
+		}
+	}
+
+}
@@ -0,0 +1,63 @@
+package filer2
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestExpireVersionedObjects(t *testing.T) {
+
+	f := newTestFiler()
+	f.DirBucketsPath = "/buckets"
+	f.buckets = &FilerBuckets{buckets: make(map[BucketName]*BucketOption)}
+	ctx := context.Background()
+	now := time.Now()
+
+	if err := f.CreateEntry(ctx, &Entry{
+		FullPath: "/buckets/b1",
+		Attr:     Attr{Mode: os.ModeDir | 0755},
+		Extended: map[string][]byte{BucketVersioningKey: []byte(VersioningEnabled)},
+	}, false); err != nil {
+		t.Fatalf("create bucket: %v", err)
+	}
+
+	entry := &Entry{
+		FullPath: "/buckets/b1/logs/a.log",
+		Attr:     Attr{Mode: 0644, Mtime: now.Add(-48 * time.Hour)},
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,01", Size: 10}},
+		Extended: make(map[string][]byte),
+	}
+	SetVersionId(entry.Extended, "v1")
+	if err := f.CreateEntry(ctx, entry, false); err != nil {
+		t.Fatalf("create object: %v", err)
+	}
+
+	f.expireObjects(ctx, "/buckets/b1", VersioningEnabled, &LifecycleRule{Prefix: "logs/", Enabled: true, ExpirationDays: 1}, now.Add(-24*time.Hour))
+
+	if _, err := f.FindEntry(ctx, "/buckets/b1/logs/a.log"); err != filer_pb.ErrNotFound {
+		t.Errorf("the expired current version is still current: %v", err)
+	}
+	versions, err := f.ListDirectoryEntries(ctx, "/buckets/b1/.versions/logs/a.log", "", false, 100)
+	if err != nil || len(versions) != 2 {
+		t.Fatalf("unexpected versions %+v: %v", versions, err)
+	}
+	var markers int
+	for _, version := range versions {
+		if string(version.Extended[DeleteMarkerKey]) == DeleteMarkerTrueValue {
+			markers++
+		} else if version.Name() != "v1" || len(version.Chunks) != 1 {
+			t.Errorf("unexpected prior version %+v", version)
+		}
+	}
+	if markers != 1 {
+		t.Errorf("expected one delete marker, but got %d", markers)
+	}
+	if fileIds := deletedFileIds(f); len(fileIds) != 0 {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+
+}
@@ -0,0 +1,247 @@
+package filer2
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	// RetentionModeKey and RetainUntilKey are the file entry's extended attributes for the object retention
+	RetentionModeKey = "s3-retention-mode"
+	RetainUntilKey   = "s3-retain-until"
+	// LegalHoldKey is the file entry's extended attribute for the legal hold
+	LegalHoldKey = "s3-legal-hold"
+	// BypassGovernanceKey lets an entry update shorten or remove a governance retention. The update removes it.
+	BypassGovernanceKey = "s3-bypass-governance"
+	// ObjectLockSignatureKey carries the S3 gateway's signature of an object lock change. The filer removes it.
+	ObjectLockSignatureKey = "s3-object-lock-signature"
+
+	RetentionGovernance = "GOVERNANCE"
+	RetentionCompliance = "COMPLIANCE"
+	LegalHoldOn         = "ON"
+)
+
+var (
+	ErrObjectLocked         = errors.New("object is locked")
+	ErrObjectLockNotSigned  = errors.New("object lock change is not signed")
+	objectLockSignatureSkew = 5 * time.Minute
+)
+
+// IsObjectLocked checks an error message passed along by the filer
+func IsObjectLocked(errorMessage string) bool {
+	return strings.Contains(errorMessage, ErrObjectLocked.Error())
+}
+
+// ObjectRetention is the write once read many protection of a file.
+// A file can not be deleted or modified while it is under legal hold, or before its retention expires.
+type ObjectRetention struct {
+	Mode        string
+	RetainUntil time.Time
+	LegalHold   bool
+}
+
+func GetObjectRetention(extended map[string][]byte) (retention ObjectRetention) {
+	retention.Mode = string(extended[RetentionModeKey])
+	if retainUntil, err := time.Parse(time.RFC3339, string(extended[RetainUntilKey])); err == nil {
+		retention.RetainUntil = retainUntil
+	}
+	retention.LegalHold = string(extended[LegalHoldKey]) == LegalHoldOn
+	return
+}
+
+// SetRetention saves the retention mode and date, or removes them if the mode is empty
+func (retention ObjectRetention) SetRetention(extended map[string][]byte) {
+	if retention.Mode == "" {
+		delete(extended, RetentionModeKey)
+		delete(extended, RetainUntilKey)
+		return
+	}
+	extended[RetentionModeKey] = []byte(retention.Mode)
+	extended[RetainUntilKey] = []byte(retention.RetainUntil.UTC().Format(time.RFC3339))
+}
+
+func (retention ObjectRetention) SetLegalHold(extended map[string][]byte) {
+	if retention.LegalHold {
+		extended[LegalHoldKey] = []byte(LegalHoldOn)
+	} else {
+		delete(extended, LegalHoldKey)
+	}
+}
+
+// IsObjectLockKey tells the extended attributes which only the S3 gateway can change
+func IsObjectLockKey(name string) bool {
+	switch name {
+	case RetentionModeKey, RetainUntilKey, LegalHoldKey, BypassGovernanceKey, ObjectLockSignatureKey:
+		return true
+	}
+	return false
+}
+
+// SignObjectLock signs the retention, legal hold and governance bypass of the entry at the path
+// with the [iam] key shared by the S3 gateway and the filer, so the filer accepts the change.
+func SignObjectLock(fullPath util.FullPath, extended map[string][]byte, key string) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	extended[ObjectLockSignatureKey] = []byte(timestamp + ":" + hex.EncodeToString(objectLockMac(fullPath, extended, timestamp, key)))
+}
+
+// CheckObjectLockChange refuses a filer client to change the retention or legal hold of an entry,
+// or to bypass the governance retention, without the signature of the S3 gateway.
+// Copying an expired retention along, e.g. when restoring from the trash, is not a change.
+func CheckObjectLockChange(oldEntry, entry *Entry, key string, now time.Time) error {
+
+	signature, signed := entry.Extended[ObjectLockSignatureKey]
+	delete(entry.Extended, ObjectLockSignatureKey)
+
+	var oldExtended map[string][]byte
+	if oldEntry != nil {
+		oldExtended = oldEntry.Extended
+	}
+	_, bypassGovernance := entry.Extended[BypassGovernanceKey]
+	if !bypassGovernance && sameObjectLock(oldExtended, entry.Extended) {
+		return nil
+	}
+	if !bypassGovernance && !GetObjectRetention(oldExtended).IsLocked(now) && !GetObjectRetention(entry.Extended).IsLocked(now) {
+		return nil
+	}
+
+	if !signed || key == "" {
+		return fmt.Errorf("change %s: %v", entry.FullPath, ErrObjectLockNotSigned)
+	}
+	parts := strings.SplitN(string(signature), ":", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("change %s: %v", entry.FullPath, ErrObjectLockNotSigned)
+	}
+	signedAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || now.Sub(time.Unix(signedAt, 0)) > objectLockSignatureSkew || time.Unix(signedAt, 0).Sub(now) > objectLockSignatureSkew {
+		return fmt.Errorf("change %s: %v", entry.FullPath, ErrObjectLockNotSigned)
+	}
+	mac, err := hex.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(mac, objectLockMac(entry.FullPath, entry.Extended, parts[0], key)) {
+		return fmt.Errorf("change %s: %v", entry.FullPath, ErrObjectLockNotSigned)
+	}
+
+	return nil
+}
+
+func sameObjectLock(a, b map[string][]byte) bool {
+	for _, name := range []string{RetentionModeKey, RetainUntilKey, LegalHoldKey} {
+		if !bytes.Equal(a[name], b[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func objectLockMac(fullPath util.FullPath, extended map[string][]byte, timestamp string, key string) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	fmt.Fprintf(mac, "%s\n%s\n", fullPath, timestamp)
+	for _, name := range []string{RetentionModeKey, RetainUntilKey, LegalHoldKey, BypassGovernanceKey} {
+		if value, found := extended[name]; found {
+			fmt.Fprintf(mac, "%s=%s\n", name, value)
+		}
+	}
+	return mac.Sum(nil)
+}
+
+func (retention ObjectRetention) isRetained(now time.Time) bool {
+	return retention.Mode != "" && now.Before(retention.RetainUntil)
+}
+
+func (retention ObjectRetention) IsLocked(now time.Time) bool {
+	return retention.LegalHold || retention.isRetained(now)
+}
+
+// checkObjectLockDelete refuses to delete a locked file
+func checkObjectLockDelete(entry *Entry, now time.Time) error {
+	if entry.IsDirectory() {
+		return nil
+	}
+	if GetObjectRetention(entry.Extended).IsLocked(now) {
+		return fmt.Errorf("%s: %v", entry.FullPath, ErrObjectLocked)
+	}
+	return nil
+}
+
+// checkObjectLockDeleteTree refuses to delete a folder with any locked file under it,
+// before anything is deleted or moved to the trash
+func (f *Filer) checkObjectLockDeleteTree(ctx context.Context, entry *Entry, now time.Time) error {
+	if !entry.IsDirectory() {
+		return checkObjectLockDelete(entry, now)
+	}
+	lastFileName := ""
+	for {
+		entries, err := f.ListDirectoryEntries(ctx, entry.FullPath, lastFileName, false, PaginationSize)
+		if err != nil {
+			return fmt.Errorf("list folder %s: %v", entry.FullPath, err)
+		}
+		for _, sub := range entries {
+			lastFileName = sub.Name()
+			if err := f.checkObjectLockDeleteTree(ctx, sub, now); err != nil {
+				return err
+			}
+		}
+		if len(entries) < PaginationSize {
+			return nil
+		}
+	}
+}
+
+// checkObjectLockUpdate refuses to change the content of a locked file.
+// The legal hold can be changed, and the retention can only be extended,
+// except that a governance retention can be shortened or removed with BypassGovernanceKey.
+func checkObjectLockUpdate(oldEntry, entry *Entry, now time.Time) error {
+
+	_, bypassGovernance := entry.Extended[BypassGovernanceKey]
+	delete(entry.Extended, BypassGovernanceKey)
+
+	if oldEntry == nil || oldEntry.IsDirectory() {
+		return nil
+	}
+	old := GetObjectRetention(oldEntry.Extended)
+	if !old.IsLocked(now) {
+		return nil
+	}
+
+	if !sameChunks(oldEntry.Chunks, entry.Chunks) {
+		return fmt.Errorf("modify %s: %v", entry.FullPath, ErrObjectLocked)
+	}
+
+	if !old.isRetained(now) {
+		return nil
+	}
+	retention := GetObjectRetention(entry.Extended)
+	if old.Mode == RetentionGovernance && bypassGovernance {
+		return nil
+	}
+	if retention.Mode == "" || retention.RetainUntil.Before(old.RetainUntil) {
+		return fmt.Errorf("shorten %s retention: %v", entry.FullPath, ErrObjectLocked)
+	}
+	if old.Mode == RetentionCompliance && retention.Mode != RetentionCompliance {
+		return fmt.Errorf("change %s retention mode: %v", entry.FullPath, ErrObjectLocked)
+	}
+
+	return nil
+}
+
+func sameChunks(a, b []*filer_pb.FileChunk) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].GetFileIdString() != b[i].GetFileIdString() || a[i].Offset != b[i].Offset || a[i].Size != b[i].Size {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+package filer2
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func newLockTestEntry(fileId string, retention ObjectRetention) *Entry {
+	entry := &Entry{
+		FullPath: "/buckets/b1/x",
+		Chunks:   []*filer_pb.FileChunk{{FileId: fileId, Size: 10}},
+		Extended: make(map[string][]byte),
+	}
+	retention.SetRetention(entry.Extended)
+	retention.SetLegalHold(entry.Extended)
+	return entry
+}
+
+func TestObjectLockDelete(t *testing.T) {
+
+	now := time.Now()
+
+	tests := []struct {
+		retention ObjectRetention
+		locked    bool
+	}{
+		{ObjectRetention{}, false},
+		{ObjectRetention{Mode: RetentionGovernance, RetainUntil: now.Add(time.Hour)}, true},
+		{ObjectRetention{Mode: RetentionCompliance, RetainUntil: now.Add(time.Hour)}, true},
+		{ObjectRetention{Mode: RetentionCompliance, RetainUntil: now.Add(-time.Hour)}, false},
+		{ObjectRetention{LegalHold: true}, true},
+		{ObjectRetention{Mode: RetentionGovernance, RetainUntil: now.Add(-time.Hour), LegalHold: true}, true},
+	}
+
+	for i, test := range tests {
+		err := checkObjectLockDelete(newLockTestEntry("1,01", test.retention), now)
+		if test.locked && (err == nil || !IsObjectLocked(err.Error())) {
+			t.Errorf("%d: expecting object locked error, got %v", i, err)
+		}
+		if !test.locked && err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+	}
+
+	dir := &Entry{FullPath: "/buckets/b1", Attr: Attr{Mode: os.ModeDir | 0755}}
+	if err := checkObjectLockDelete(dir, now); err != nil {
+		t.Errorf("unexpected error for folder: %v", err)
+	}
+}
+
+func TestObjectLockUpdate(t *testing.T) {
+
+	now := time.Now()
+	governance := ObjectRetention{Mode: RetentionGovernance, RetainUntil: now.Add(time.Hour)}
+	compliance := ObjectRetention{Mode: RetentionCompliance, RetainUntil: now.Add(time.Hour)}
+	longer := now.Add(2 * time.Hour)
+	shorter := now.Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		old      *Entry
+		entry    *Entry
+		bypass   bool
+		expected bool
+	}{
+		{"new file", nil, newLockTestEntry("1,02", ObjectRetention{}), false, true},
+		{"unlocked overwrite", newLockTestEntry("1,01", ObjectRetention{}), newLockTestEntry("1,02", ObjectRetention{}), false, true},
+		{"locked overwrite", newLockTestEntry("1,01", governance), newLockTestEntry("1,02", governance), false, false},
+		{"legal hold overwrite", newLockTestEntry("1,01", ObjectRetention{LegalHold: true}), newLockTestEntry("1,02", ObjectRetention{}), false, false},
+		{"release legal hold", newLockTestEntry("1,01", ObjectRetention{LegalHold: true}), newLockTestEntry("1,01", ObjectRetention{}), false, true},
+		{"extend retention", newLockTestEntry("1,01", compliance), newLockTestEntry("1,01", ObjectRetention{Mode: RetentionCompliance, RetainUntil: longer}), false, true},
+		{"shorten compliance", newLockTestEntry("1,01", compliance), newLockTestEntry("1,01", ObjectRetention{Mode: RetentionCompliance, RetainUntil: shorter}), true, false},
+		{"remove compliance", newLockTestEntry("1,01", compliance), newLockTestEntry("1,01", ObjectRetention{}), true, false},
+		{"compliance to governance", newLockTestEntry("1,01", compliance), newLockTestEntry("1,01", ObjectRetention{Mode: RetentionGovernance, RetainUntil: longer}), false, false},
+		{"governance to compliance", newLockTestEntry("1,01", governance), newLockTestEntry("1,01", ObjectRetention{Mode: RetentionCompliance, RetainUntil: longer}), false, true},
+		{"shorten governance", newLockTestEntry("1,01", governance), newLockTestEntry("1,01", ObjectRetention{Mode: RetentionGovernance, RetainUntil: shorter}), false, false},
+		{"shorten governance with bypass", newLockTestEntry("1,01", governance), newLockTestEntry("1,01", ObjectRetention{Mode: RetentionGovernance, RetainUntil: shorter}), true, true},
+		{"remove governance with bypass", newLockTestEntry("1,01", governance), newLockTestEntry("1,01", ObjectRetention{}), true, true},
+		{"overwrite governance with bypass", newLockTestEntry("1,01", governance), newLockTestEntry("1,02", ObjectRetention{}), true, false},
+	}
+
+	for _, test := range tests {
+		if test.bypass {
+			test.entry.Extended[BypassGovernanceKey] = []byte("true")
+		}
+		err := checkObjectLockUpdate(test.old, test.entry, now)
+		if test.expected && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !test.expected && (err == nil || !IsObjectLocked(err.Error())) {
+			t.Errorf("%s: expecting object locked error, got %v", test.name, err)
+		}
+		if _, found := test.entry.Extended[BypassGovernanceKey]; found {
+			t.Errorf("%s: bypass governance attribute is saved", test.name)
+		}
+	}
+}
+
+func TestObjectLockChangeSignature(t *testing.T) {
+
+	now := time.Now()
+	unlocked := newLockTestEntry("1,01", ObjectRetention{})
+	held := newLockTestEntry("1,01", ObjectRetention{LegalHold: true})
+	expired := newLockTestEntry("1,01", ObjectRetention{Mode: RetentionGovernance, RetainUntil: now.Add(-time.Hour)})
+
+	// the metadata changes keeping the object lock do not need the signature
+	if err := CheckObjectLockChange(held, newLockTestEntry("1,01", ObjectRetention{LegalHold: true}), "", now); err != nil {
+		t.Errorf("unchanged legal hold: %v", err)
+	}
+	if err := CheckObjectLockChange(nil, newLockTestEntry("1,01", ObjectRetention{Mode: RetentionGovernance, RetainUntil: now.Add(-time.Hour)}), "", now); err != nil {
+		t.Errorf("copy expired retention: %v", err)
+	}
+
+	// the unsigned changes are refused
+	if err := CheckObjectLockChange(held, newLockTestEntry("1,01", ObjectRetention{}), "k", now); err == nil {
+		t.Errorf("removed legal hold without signature")
+	}
+	if err := CheckObjectLockChange(unlocked, newLockTestEntry("1,01", ObjectRetention{LegalHold: true}), "k", now); err == nil {
+		t.Errorf("added legal hold without signature")
+	}
+	bypass := newLockTestEntry("1,01", ObjectRetention{})
+	bypass.Extended[BypassGovernanceKey] = []byte("true")
+	if err := CheckObjectLockChange(expired, bypass, "k", now); err == nil {
+		t.Errorf("bypassed governance without signature")
+	}
+
+	// the signed changes are accepted, with the signature removed
+	entry := newLockTestEntry("1,01", ObjectRetention{})
+	SignObjectLock(entry.FullPath, entry.Extended, "k")
+	if err := CheckObjectLockChange(held, entry, "k", now); err != nil {
+		t.Errorf("signed change: %v", err)
+	}
+	if _, found := entry.Extended[ObjectLockSignatureKey]; found {
+		t.Errorf("signature is kept")
+	}
+
+	// the signature does not cover other paths, other values, or other keys
+	entry = newLockTestEntry("1,01", ObjectRetention{})
+	SignObjectLock("/buckets/b1/y", entry.Extended, "k")
+	if err := CheckObjectLockChange(held, entry, "k", now); err == nil {
+		t.Errorf("accepted the signature of another path")
+	}
+	entry = newLockTestEntry("1,01", ObjectRetention{LegalHold: true})
+	SignObjectLock(entry.FullPath, entry.Extended, "k")
+	delete(entry.Extended, LegalHoldKey)
+	if err := CheckObjectLockChange(held, entry, "k", now); err == nil {
+		t.Errorf("accepted the signature of other values")
+	}
+	entry = newLockTestEntry("1,01", ObjectRetention{})
+	SignObjectLock(entry.FullPath, entry.Extended, "other")
+	if err := CheckObjectLockChange(held, entry, "k", now); err == nil {
+		t.Errorf("accepted the signature of another key")
+	}
+	entry = newLockTestEntry("1,01", ObjectRetention{})
+	SignObjectLock(entry.FullPath, entry.Extended, "k")
+	if err := CheckObjectLockChange(held, entry, "k", now.Add(time.Hour)); err == nil {
+		t.Errorf("accepted an old signature")
+	}
+
+}
+
+func TestObjectLockDeleteFolder(t *testing.T) {
+
+	for _, trashRetention := range []time.Duration{0, time.Hour} {
+
+		f := newTestFiler()
+		f.DirBucketsPath = "/buckets"
+		f.buckets = &FilerBuckets{buckets: make(map[BucketName]*BucketOption)}
+		f.TrashRetention = trashRetention
+		ctx := context.Background()
+
+		// the locked file is listed after the sub folder, which would be deleted first
+		locked := newLockTestEntry("1,03", ObjectRetention{LegalHold: true})
+		locked.FullPath = "/buckets/b1/d/z"
+		for _, entry := range []*Entry{
+			{FullPath: "/buckets/b1/d/a", Attr: Attr{Mode: 0644}, Chunks: []*filer_pb.FileChunk{{FileId: "1,01", Size: 10}}},
+			{FullPath: "/buckets/b1/d/sub/b", Attr: Attr{Mode: 0644}, Chunks: []*filer_pb.FileChunk{{FileId: "1,02", Size: 10}}},
+			locked,
+		} {
+			if err := f.CreateEntry(ctx, entry, false); err != nil {
+				t.Fatalf("create %s: %v", entry.FullPath, err)
+			}
+		}
+
+		err := f.DeleteEntryMetaAndData(ctx, "/buckets/b1/d", true, true, true)
+		if err == nil || !IsObjectLocked(err.Error()) {
+			t.Errorf("trash retention %v: deleted a folder with a locked file: %v", trashRetention, err)
+		}
+		for _, p := range []util.FullPath{"/buckets/b1/d/a", "/buckets/b1/d/sub/b", "/buckets/b1/d/z"} {
+			if _, err := f.FindEntry(ctx, p); err != nil {
+				t.Errorf("trash retention %v: %s is gone: %v", trashRetention, p, err)
+			}
+		}
+		if fileIds := deletedFileIds(f); len(fileIds) != 0 {
+			t.Errorf("trash retention %v: unexpected deleted chunks %v", trashRetention, fileIds)
+		}
+
+	}
+
+}
@@ -0,0 +1,189 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/wdclient"
+)
+
+const (
+	// TrashDir keeps the deleted entries until the retention passes, one folder per bucket and per user
+	TrashDir        = "/.trash"
+	TrashBucketsDir = TrashDir + "/buckets"
+	TrashUsersDir   = TrashDir + "/users"
+
+	// TrashPathKey is the original full path of a deleted entry in the trash
+	TrashPathKey = "seaweed-trash-path"
+	// TrashTimeKey is the deletion time of an entry in the trash, in unix seconds
+	TrashTimeKey = "seaweed-trash-time"
+
+	// TrashPurgeLockName is the master lock held while purging the trash
+	TrashPurgeLockName = "filer.trash.purge"
+)
+
+// BucketTrashDir keeps the deleted objects of a bucket
+func BucketTrashDir(bucket string) util.FullPath {
+	return util.NewFullPath(TrashBucketsDir, bucket)
+}
+
+// UserTrashDir keeps the deleted entries owned by the user, outside of the buckets
+func UserTrashDir(uid uint32) util.FullPath {
+	return util.NewFullPath(TrashUsersDir, strconv.FormatUint(uint64(uid), 10))
+}
+
+// TrashTime returns the deletion time of an entry in the trash
+func TrashTime(extended map[string][]byte) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(string(extended[TrashTimeKey]), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
+
+// trashDirOf returns where the deleted entry should go, or false to delete it right away
+func (f *Filer) trashDirOf(entry *Entry) (util.FullPath, bool) {
+	if f.TrashRetention <= 0 {
+		return "", false
+	}
+
+	p := string(entry.FullPath)
+	// the trash itself, and the system entries, are deleted for good
+	for _, dir := range []string{TrashDir, TopicsDir, "/etc"} {
+		if p == dir || strings.HasPrefix(p, dir+"/") {
+			return "", false
+		}
+	}
+
+	if f.DirBucketsPath != "" && strings.HasPrefix(p, f.DirBucketsPath+"/") {
+		parts := strings.SplitN(p[len(f.DirBucketsPath)+1:], "/", 3)
+		// a bucket drops its collection, and the unfinished multipart uploads are not worth keeping
+		if len(parts) < 2 || parts[1] == MultipartUploadsFolder {
+			return "", false
+		}
+		return BucketTrashDir(parts[0]), true
+	}
+
+	return UserTrashDir(entry.Uid), true
+}
+
+// moveToTrash moves the deleted entry, and the children of a folder, into the trash dir.
+// The chunks are kept until the entry is purged from the trash.
+func (f *Filer) moveToTrash(ctx context.Context, entry *Entry, trashDir util.FullPath, isRecursive bool, now time.Time) error {
+
+	if entry.IsDirectory() && !isRecursive {
+		entries, err := f.ListDirectoryEntries(ctx, entry.FullPath, "", false, 1)
+		if err != nil {
+			return fmt.Errorf("list folder %s: %v", entry.FullPath, err)
+		}
+		if len(entries) > 0 {
+			return fmt.Errorf("fail to delete non-empty folder: %s", entry.FullPath)
+		}
+	}
+
+	trashEntry := copyEntryTo(entry, trashDir.Child(fmt.Sprintf("%d-%s", now.UnixNano(), entry.Name())))
+	trashEntry.Extended[TrashPathKey] = []byte(entry.FullPath)
+	trashEntry.Extended[TrashTimeKey] = []byte(strconv.FormatInt(now.Unix(), 10))
+
+	glog.V(3).Infof("move %s to trash %s", entry.FullPath, trashEntry.FullPath)
+
+	return f.moveEntryTree(ctx, entry, trashEntry, now)
+}
+
+func (f *Filer) moveEntryTree(ctx context.Context, entry, newEntry *Entry, now time.Time) error {
+
+	if !entry.IsDirectory() {
+		if err := checkObjectLockDelete(entry, now); err != nil {
+			return err
+		}
+	}
+
+	if err := f.CreateEntry(ctx, newEntry, false); err != nil {
+		return fmt.Errorf("create %s: %v", newEntry.FullPath, err)
+	}
+
+	if entry.IsDirectory() {
+		lastFileName := ""
+		for {
+			entries, err := f.ListDirectoryEntries(ctx, entry.FullPath, lastFileName, false, PaginationSize)
+			if err != nil {
+				return fmt.Errorf("list folder %s: %v", entry.FullPath, err)
+			}
+			for _, sub := range entries {
+				lastFileName = sub.Name()
+				if err := f.moveEntryTree(ctx, sub, copyEntryTo(sub, newEntry.FullPath.Child(sub.Name())), now); err != nil {
+					return err
+				}
+			}
+			if len(entries) < PaginationSize {
+				break
+			}
+		}
+	}
+
+	return f.DeleteMovedEntry(ctx, entry.FullPath)
+}
+
+// LoopPurgingTrash periodically deletes the entries kept in the trash longer than the retention.
+// Only the filer holding the purge lock of the master purges them, so the chunk references are not released twice.
+func (f *Filer) LoopPurgingTrash(interval time.Duration) {
+	locker := wdclient.NewExclusiveLocker(f.MasterClient, TrashPurgeLockName)
+	for {
+		time.Sleep(interval)
+		if !locker.TryLock() {
+			glog.V(1).Infof("trash purge skipped, the purge lock is held by others")
+			continue
+		}
+		f.purgeTrash(time.Now())
+		locker.ReleaseLock()
+	}
+}
+
+func (f *Filer) purgeTrash(now time.Time) {
+	ctx := context.Background()
+	for _, dir := range []util.FullPath{TrashBucketsDir, TrashUsersDir} {
+		f.eachTrashEntry(ctx, dir, func(trashDir util.FullPath) {
+			f.eachTrashEntry(ctx, trashDir, func(p util.FullPath) {
+				entry, err := f.FindEntry(ctx, p)
+				if err != nil {
+					return
+				}
+				deletedAt, found := TrashTime(entry.Extended)
+				if !found || now.Sub(deletedAt) < f.TrashRetention {
+					return
+				}
+				if err := f.DeleteEntryMetaAndData(ctx, p, true, false, true); err != nil {
+					glog.V(0).Infof("purge trash %s: %v", p, err)
+				}
+			})
+		})
+	}
+}
+
+// eachTrashEntry lists the folder before calling fn, which may delete the entries
+func (f *Filer) eachTrashEntry(ctx context.Context, dir util.FullPath, fn func(p util.FullPath)) {
+	var paths []util.FullPath
+	lastFileName := ""
+	for {
+		entries, err := f.ListDirectoryEntries(ctx, dir, lastFileName, false, PaginationSize)
+		if err != nil {
+			glog.V(0).Infof("list trash %s: %v", dir, err)
+			return
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			paths = append(paths, entry.FullPath)
+		}
+		if len(entries) < PaginationSize {
+			break
+		}
+	}
+	for _, p := range paths {
+		fn(p)
+	}
+}
@@ -0,0 +1,71 @@
+package filer2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestTrash(t *testing.T) {
+
+	f := newTestFiler()
+	f.TrashRetention = time.Hour
+	ctx := context.Background()
+
+	for _, entry := range []*Entry{
+		{FullPath: "/home/d/a", Attr: Attr{Mode: 0644, Uid: 1000}, Chunks: []*filer_pb.FileChunk{{FileId: "1,01", Size: 10}}},
+		{FullPath: "/home/d/e/b", Attr: Attr{Mode: 0644, Uid: 1000}, Chunks: []*filer_pb.FileChunk{{FileId: "1,02", Size: 20}}},
+	} {
+		if err := f.CreateEntry(ctx, entry, false); err != nil {
+			t.Fatalf("create %s: %v", entry.FullPath, err)
+		}
+	}
+
+	if err := f.DeleteEntryMetaAndData(ctx, "/home/d", false, false, true); err == nil {
+		t.Errorf("non-empty folder is moved to the trash without recursive")
+	}
+
+	// the folder and its files are moved to the trash of the owner, keeping the chunks
+	if err := f.DeleteEntryMetaAndData(ctx, "/home/d", true, false, true); err != nil {
+		t.Fatalf("delete d: %v", err)
+	}
+	if _, err := f.FindEntry(ctx, "/home/d/e/b"); err != filer_pb.ErrNotFound {
+		t.Errorf("deleted file is still found: %v", err)
+	}
+	if fileIds := deletedFileIds(f); len(fileIds) != 0 {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+	trashed, _ := f.ListDirectoryEntries(ctx, UserTrashDir(1000), "", false, 100)
+	if len(trashed) != 1 || string(trashed[0].Extended[TrashPathKey]) != "/home/d" {
+		t.Fatalf("unexpected trash %+v", trashed)
+	}
+	if b, err := f.FindEntry(ctx, trashed[0].FullPath.Child("e").Child("b")); err != nil || len(b.Chunks) != 1 {
+		t.Errorf("unexpected file in the trash %+v: %v", b, err)
+	}
+
+	// the entries are purged after the retention
+	f.purgeTrash(time.Now())
+	if fileIds := deletedFileIds(f); len(fileIds) != 0 {
+		t.Errorf("purged before the retention %v", fileIds)
+	}
+	f.purgeTrash(time.Now().Add(2 * time.Hour))
+	if fileIds := waitDeletedFileIds(f); len(fileIds) != 2 {
+		t.Errorf("unexpected purged chunks %v", fileIds)
+	}
+	if trashed, _ = f.ListDirectoryEntries(ctx, UserTrashDir(1000), "", false, 100); len(trashed) != 0 {
+		t.Errorf("unexpected trash after purging %+v", trashed)
+	}
+
+	// the system entries are deleted right away
+	f.CreateEntry(ctx, &Entry{FullPath: util.FullPath(SystemLogDir + "/f"), Chunks: []*filer_pb.FileChunk{{FileId: "1,03", Size: 30}}}, false)
+	if err := f.DeleteEntryMetaAndData(ctx, util.FullPath(SystemLogDir+"/f"), false, false, true); err != nil {
+		t.Fatalf("delete log file: %v", err)
+	}
+	if fileIds := waitDeletedFileIds(f); len(fileIds) != 1 || fileIds[0] != "1,03" {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+
+}
@@ -0,0 +1,173 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// The current version of an object stays at its normal path, and the prior versions and delete markers
+// are kept under <bucket>/.versions/<object key>/<version id>.
+
+const (
+	// BucketVersioningKey is the bucket entry extended attribute with the versioning status, empty if never configured
+	BucketVersioningKey = "s3-versioning"
+	// VersionIdKey is the version id of an object, which is the "null" version if absent
+	VersionIdKey = "s3-version-id"
+	// DeleteMarkerKey marks a delete marker among the prior versions
+	DeleteMarkerKey       = "s3-delete-marker"
+	DeleteMarkerTrueValue = "true"
+	VersionsFolder        = ".versions"
+	NullVersionId         = "null"
+
+	VersioningEnabled   = "Enabled"
+	VersioningSuspended = "Suspended"
+
+	// VersionIdHeader sets the version id of the file written through the filer http api
+	VersionIdHeader = "X-Seaweedfs-Version-Id"
+)
+
+// NewVersionId generates ids that sort from the newest to the oldest.
+func NewVersionId() string {
+	inverted := uint64(math.MaxInt64 - time.Now().UnixNano())
+	return fmt.Sprintf("%016x%s", inverted, strings.Replace(uuid.New().String(), "-", "", -1)[:16])
+}
+
+// VersionId returns the version id of an object
+func VersionId(extended map[string][]byte) string {
+	if versionId := extended[VersionIdKey]; len(versionId) > 0 {
+		return string(versionId)
+	}
+	return NullVersionId
+}
+
+// SetVersionId sets the version id of an object, removing it for the "null" version
+func SetVersionId(extended map[string][]byte, versionId string) {
+	if versionId == "" || versionId == NullVersionId {
+		delete(extended, VersionIdKey)
+		return
+	}
+	extended[VersionIdKey] = []byte(versionId)
+}
+
+// versionsDirOf returns the folder of the prior versions of a file in a bucket with versioning configured
+func (f *Filer) versionsDirOf(ctx context.Context, p util.FullPath) (util.FullPath, bool) {
+	if f.DirBucketsPath == "" || !strings.HasPrefix(string(p), f.DirBucketsPath+"/") {
+		return "", false
+	}
+	parts := strings.SplitN(string(p)[len(f.DirBucketsPath)+1:], "/", 2)
+	if len(parts) < 2 || strings.HasPrefix(parts[1], VersionsFolder+"/") || strings.HasPrefix(parts[1], MultipartUploadsFolder+"/") {
+		return "", false
+	}
+	bucket, err := f.FindEntry(ctx, util.NewFullPath(f.DirBucketsPath, parts[0]))
+	if err != nil || len(bucket.Extended[BucketVersioningKey]) == 0 {
+		return "", false
+	}
+	return util.FullPath(fmt.Sprintf("%s/%s/%s/%s", f.DirBucketsPath, parts[0], VersionsFolder, parts[1])), true
+}
+
+// archiveVersion keeps the current version of an object, which is replaced by a new version in a versioned bucket.
+// The archived entry references the chunks, so they are not deleted when the current version is overwritten.
+func (f *Filer) archiveVersion(ctx context.Context, oldEntry, entry *Entry) (archived *Entry, err error) {
+
+	if oldEntry == nil || oldEntry.IsDirectory() || entry.IsDirectory() {
+		return nil, nil
+	}
+	oldVersionId := VersionId(oldEntry.Extended)
+	if oldVersionId == VersionId(entry.Extended) {
+		// the same version is overwritten in place, e.g. the null version of a suspended bucket
+		return nil, nil
+	}
+	versionsDir, found := f.versionsDirOf(ctx, entry.FullPath)
+	if !found {
+		return nil, nil
+	}
+
+	archivedPath := versionsDir.Child(oldVersionId)
+	if _, err := f.FindEntry(ctx, archivedPath); err == nil {
+		return nil, fmt.Errorf("version %s is already archived", archivedPath)
+	}
+
+	archived = copyEntryTo(oldEntry, archivedPath)
+	SetChunkReferences(archived.Extended, archived.Chunks)
+	if err = f.CreateEntry(ctx, archived, false); err != nil {
+		return nil, fmt.Errorf("archive %s: %v", archivedPath, err)
+	}
+
+	return archived, nil
+}
+
+// unarchiveVersion removes the archived version again if the new version fails to be written
+func (f *Filer) unarchiveVersion(ctx context.Context, archived *Entry) {
+
+	if archived == nil {
+		return
+	}
+	if err := f.DeleteMovedEntry(ctx, archived.FullPath); err != nil {
+		glog.Errorf("remove archived version %s: %v", archived.FullPath, err)
+		return
+	}
+	var fileIds []string
+	for _, chunk := range archived.Chunks {
+		fileIds = append(fileIds, chunk.GetFileIdString())
+	}
+	f.releaseChunkReferences(ctx, fileIds)
+}
+
+// expireCurrentVersion replaces the current version of an object with a delete marker, as deleting it through S3 does.
+// The expired version is kept among the prior versions, except the null version of a suspended bucket.
+func (f *Filer) expireCurrentVersion(ctx context.Context, entry *Entry, versioningStatus string, now time.Time) (err error) {
+
+	versionsDir, found := f.versionsDirOf(ctx, entry.FullPath)
+	if !found {
+		return fmt.Errorf("%s is not versioned", entry.FullPath)
+	}
+
+	markerId := NewVersionId()
+	if versioningStatus == VersioningSuspended {
+		// a suspended bucket only keeps one null version
+		markerId = NullVersionId
+		if _, err = f.FindEntry(ctx, versionsDir.Child(NullVersionId)); err == nil {
+			if err = f.DeleteEntryMetaAndData(ctx, versionsDir.Child(NullVersionId), false, false, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	versionId := VersionId(entry.Extended)
+	if versionId == NullVersionId && versioningStatus == VersioningSuspended {
+		if err = f.DeleteEntryMetaAndData(ctx, entry.FullPath, false, false, true); err != nil {
+			return err
+		}
+	} else {
+		// move the current version, with its chunks, among the prior versions
+		if err = f.CreateEntry(ctx, copyEntryTo(entry, versionsDir.Child(versionId)), true); err != nil {
+			return err
+		}
+		if err = f.DeleteMovedEntry(ctx, entry.FullPath); err != nil {
+			return err
+		}
+	}
+
+	return f.CreateEntry(ctx, &Entry{
+		FullPath: versionsDir.Child(markerId),
+		Attr: Attr{
+			Mtime:  now,
+			Crtime: now,
+			Mode:   0770,
+			Uid:    OS_UID,
+			Gid:    OS_GID,
+		},
+		Extended: map[string][]byte{
+			VersionIdKey:    []byte(markerId),
+			DeleteMarkerKey: []byte(DeleteMarkerTrueValue),
+		},
+	}, false)
+}
@@ -0,0 +1,96 @@
+package filer2
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestNewVersionIdOrder(t *testing.T) {
+
+	older := NewVersionId()
+	time.Sleep(time.Millisecond)
+	newer := NewVersionId()
+
+	if len(older) != 32 {
+		t.Errorf("unexpected version id length %d: %s", len(older), older)
+	}
+	if newer >= older {
+		t.Errorf("newer version id %s should sort before older %s", newer, older)
+	}
+
+}
+
+func TestArchiveVersion(t *testing.T) {
+
+	f := newTestFiler()
+	f.DirBucketsPath = "/buckets"
+	f.buckets = &FilerBuckets{buckets: make(map[BucketName]*BucketOption)}
+	ctx := context.Background()
+
+	if err := f.CreateEntry(ctx, &Entry{
+		FullPath: "/buckets/b1",
+		Attr:     Attr{Mode: os.ModeDir | 0755},
+		Extended: map[string][]byte{BucketVersioningKey: []byte("Enabled")},
+	}, false); err != nil {
+		t.Fatalf("create bucket: %v", err)
+	}
+
+	writeVersion := func(versionId, fileId string, o_excl bool) error {
+		entry := &Entry{
+			FullPath: "/buckets/b1/dir/key",
+			Attr:     Attr{Mode: 0644},
+			Chunks:   []*filer_pb.FileChunk{{FileId: fileId, Size: 10}},
+			Extended: make(map[string][]byte),
+		}
+		SetVersionId(entry.Extended, versionId)
+		return f.CreateEntry(ctx, entry, o_excl)
+	}
+
+	if err := writeVersion("v1", "1,01", false); err != nil {
+		t.Fatalf("write v1: %v", err)
+	}
+	if err := writeVersion("v2", "1,02", false); err != nil {
+		t.Fatalf("write v2: %v", err)
+	}
+
+	// the replaced version is archived with its chunks
+	archived, err := f.FindEntry(ctx, "/buckets/b1/.versions/dir/key/v1")
+	if err != nil || len(archived.Chunks) != 1 || archived.Chunks[0].GetFileIdString() != "1,01" || VersionId(archived.Extended) != "v1" {
+		t.Fatalf("unexpected archived version %+v: %v", archived, err)
+	}
+	if fileIds := deletedFileIds(f); len(fileIds) != 0 {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+
+	// a failed write keeps the current version, and archives nothing
+	if err := writeVersion("v3", "1,03", true); err == nil {
+		t.Errorf("create-only write over an existing object")
+	}
+	if _, err := f.FindEntry(ctx, "/buckets/b1/.versions/dir/key/v2"); err != filer_pb.ErrNotFound {
+		t.Errorf("archived the current version of a failed write: %v", err)
+	}
+	if current, _ := f.FindEntry(ctx, "/buckets/b1/dir/key"); VersionId(current.Extended) != "v2" {
+		t.Errorf("unexpected current version %+v", current)
+	}
+
+	// the same version is overwritten in place
+	if err := writeVersion("v2", "1,02", false); err != nil {
+		t.Fatalf("rewrite v2: %v", err)
+	}
+	if _, err := f.FindEntry(ctx, "/buckets/b1/.versions/dir/key/v2"); err != filer_pb.ErrNotFound {
+		t.Errorf("archived the rewritten version: %v", err)
+	}
+
+	// the archived version owns its chunks
+	if err := f.DeleteEntryMetaAndData(ctx, archived.FullPath, false, false, true); err != nil {
+		t.Fatalf("delete v1: %v", err)
+	}
+	if fileIds := waitDeletedFileIds(f); len(fileIds) != 1 || fileIds[0] != "1,01" {
+		t.Errorf("unexpected deleted chunks %v", fileIds)
+	}
+
+}
@@ -2,6 +2,10 @@ package filer2
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
@@ -29,8 +33,18 @@ type FilerStore interface {
 	Shutdown()
 }
 
+// FilerStoreWrapper routes each entry to the store of the longest path prefix covering its directory,
+// or else to the default store. An entry stays in the store of its parent directory,
+// so the directory of a path prefix itself is listed together with its siblings.
 type FilerStoreWrapper struct {
-	actualStore FilerStore
+	actualStore   FilerStore
+	pathStores    []*pathStore
+	hardLinksLock sync.Mutex
+}
+
+type pathStore struct {
+	prefix string
+	store  FilerStore
 }
 
 func NewFilerStoreWrapper(store FilerStore) *FilerStoreWrapper {
@@ -42,6 +56,67 @@ func NewFilerStoreWrapper(store FilerStore) *FilerStoreWrapper {
 	}
 }
 
+// AddPathSpecificStore routes the entries under the path prefix to the store
+func (fsw *FilerStoreWrapper) AddPathSpecificStore(prefix string, store FilerStore) {
+	fsw.pathStores = append(fsw.pathStores, &pathStore{
+		prefix: strings.TrimSuffix(prefix, "/"),
+		store:  store,
+	})
+	// longer prefixes first
+	sort.Slice(fsw.pathStores, func(i, j int) bool {
+		return len(fsw.pathStores[i].prefix) > len(fsw.pathStores[j].prefix)
+	})
+}
+
+// getActualStore finds the store of the entries in the directory
+func (fsw *FilerStoreWrapper) getActualStore(dir util.FullPath) FilerStore {
+	for _, ps := range fsw.pathStores {
+		if string(dir) == ps.prefix || strings.HasPrefix(string(dir), ps.prefix+"/") {
+			return ps.store
+		}
+	}
+	return fsw.actualStore
+}
+
+func (fsw *FilerStoreWrapper) getEntryStore(fp util.FullPath) FilerStore {
+	dir, _ := fp.DirAndName()
+	return fsw.getActualStore(util.FullPath(dir))
+}
+
+// isInOneStore tells whether the entries at the paths, and all the entries under them, are kept in the same store
+func (fsw *FilerStoreWrapper) isInOneStore(paths ...util.FullPath) bool {
+	store := fsw.getEntryStore(paths[0])
+	for _, p := range paths {
+		if fsw.getEntryStore(p) != store {
+			return false
+		}
+		for _, ps := range fsw.pathStores {
+			if (ps.prefix == string(p) || strings.HasPrefix(ps.prefix, string(p)+"/")) && ps.store != store {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// allStores lists the default store, and then each path specific store once
+func (fsw *FilerStoreWrapper) allStores() (stores []FilerStore) {
+	stores = append(stores, fsw.actualStore)
+	for _, ps := range fsw.pathStores {
+		found := false
+		for _, store := range stores {
+			if store == ps.store {
+				found = true
+				break
+			}
+		}
+		if !found {
+			stores = append(stores, ps.store)
+		}
+	}
+	return
+}
+
 func (fsw *FilerStoreWrapper) GetName() string {
 	return fsw.actualStore.GetName()
 }
@@ -51,91 +126,149 @@ func (fsw *FilerStoreWrapper) Initialize(configuration util.Configuration, prefi
 }
 
 func (fsw *FilerStoreWrapper) InsertEntry(ctx context.Context, entry *Entry) error {
-	stats.FilerStoreCounter.WithLabelValues(fsw.actualStore.GetName(), "insert").Inc()
+	actualStore := fsw.getEntryStore(entry.FullPath)
+	stats.FilerStoreCounter.WithLabelValues(actualStore.GetName(), "insert").Inc()
 	start := time.Now()
 	defer func() {
-		stats.FilerStoreHistogram.WithLabelValues(fsw.actualStore.GetName(), "insert").Observe(time.Since(start).Seconds())
+		stats.FilerStoreHistogram.WithLabelValues(actualStore.GetName(), "insert").Observe(time.Since(start).Seconds())
 	}()
 
+	if HardLinkId(entry.Extended) != "" {
+		link, err := fsw.saveHardLink(ctx, entry)
+		if err != nil {
+			return err
+		}
+		entry = link
+	}
+
 	filer_pb.BeforeEntrySerialization(entry.Chunks)
-	return fsw.actualStore.InsertEntry(ctx, entry)
+	return actualStore.InsertEntry(ctx, entry)
 }
 
 func (fsw *FilerStoreWrapper) UpdateEntry(ctx context.Context, entry *Entry) error {
-	stats.FilerStoreCounter.WithLabelValues(fsw.actualStore.GetName(), "update").Inc()
+	actualStore := fsw.getEntryStore(entry.FullPath)
+	stats.FilerStoreCounter.WithLabelValues(actualStore.GetName(), "update").Inc()
 	start := time.Now()
 	defer func() {
-		stats.FilerStoreHistogram.WithLabelValues(fsw.actualStore.GetName(), "update").Observe(time.Since(start).Seconds())
+		stats.FilerStoreHistogram.WithLabelValues(actualStore.GetName(), "update").Observe(time.Since(start).Seconds())
 	}()
 
+	if HardLinkId(entry.Extended) != "" {
+		link, err := fsw.saveHardLink(ctx, entry)
+		if err != nil {
+			return err
+		}
+		entry = link
+	}
+
 	filer_pb.BeforeEntrySerialization(entry.Chunks)
-	return fsw.actualStore.UpdateEntry(ctx, entry)
+	return actualStore.UpdateEntry(ctx, entry)
 }
 
 func (fsw *FilerStoreWrapper) FindEntry(ctx context.Context, fp util.FullPath) (entry *Entry, err error) {
-	stats.FilerStoreCounter.WithLabelValues(fsw.actualStore.GetName(), "find").Inc()
+	actualStore := fsw.getEntryStore(fp)
+	stats.FilerStoreCounter.WithLabelValues(actualStore.GetName(), "find").Inc()
 	start := time.Now()
 	defer func() {
-		stats.FilerStoreHistogram.WithLabelValues(fsw.actualStore.GetName(), "find").Observe(time.Since(start).Seconds())
+		stats.FilerStoreHistogram.WithLabelValues(actualStore.GetName(), "find").Observe(time.Since(start).Seconds())
 	}()
 
-	entry, err = fsw.actualStore.FindEntry(ctx, fp)
+	entry, err = actualStore.FindEntry(ctx, fp)
 	if err != nil {
 		return nil, err
 	}
 	filer_pb.AfterEntryDeserialization(entry.Chunks)
+	if err = fsw.maybeReadHardLink(ctx, entry); err != nil {
+		return nil, err
+	}
 	return
 }
 
 func (fsw *FilerStoreWrapper) DeleteEntry(ctx context.Context, fp util.FullPath) (err error) {
-	stats.FilerStoreCounter.WithLabelValues(fsw.actualStore.GetName(), "delete").Inc()
+	actualStore := fsw.getEntryStore(fp)
+	stats.FilerStoreCounter.WithLabelValues(actualStore.GetName(), "delete").Inc()
 	start := time.Now()
 	defer func() {
-		stats.FilerStoreHistogram.WithLabelValues(fsw.actualStore.GetName(), "delete").Observe(time.Since(start).Seconds())
+		stats.FilerStoreHistogram.WithLabelValues(actualStore.GetName(), "delete").Observe(time.Since(start).Seconds())
 	}()
 
-	return fsw.actualStore.DeleteEntry(ctx, fp)
+	return actualStore.DeleteEntry(ctx, fp)
 }
 
 func (fsw *FilerStoreWrapper) DeleteFolderChildren(ctx context.Context, fp util.FullPath) (err error) {
-	stats.FilerStoreCounter.WithLabelValues(fsw.actualStore.GetName(), "deleteFolderChildren").Inc()
+	actualStore := fsw.getActualStore(fp)
+	stats.FilerStoreCounter.WithLabelValues(actualStore.GetName(), "deleteFolderChildren").Inc()
 	start := time.Now()
 	defer func() {
-		stats.FilerStoreHistogram.WithLabelValues(fsw.actualStore.GetName(), "deleteFolderChildren").Observe(time.Since(start).Seconds())
+		stats.FilerStoreHistogram.WithLabelValues(actualStore.GetName(), "deleteFolderChildren").Observe(time.Since(start).Seconds())
 	}()
 
-	return fsw.actualStore.DeleteFolderChildren(ctx, fp)
+	return actualStore.DeleteFolderChildren(ctx, fp)
 }
 
 func (fsw *FilerStoreWrapper) ListDirectoryEntries(ctx context.Context, dirPath util.FullPath, startFileName string, includeStartFile bool, limit int) ([]*Entry, error) {
-	stats.FilerStoreCounter.WithLabelValues(fsw.actualStore.GetName(), "list").Inc()
+	actualStore := fsw.getActualStore(dirPath)
+	stats.FilerStoreCounter.WithLabelValues(actualStore.GetName(), "list").Inc()
 	start := time.Now()
 	defer func() {
-		stats.FilerStoreHistogram.WithLabelValues(fsw.actualStore.GetName(), "list").Observe(time.Since(start).Seconds())
+		stats.FilerStoreHistogram.WithLabelValues(actualStore.GetName(), "list").Observe(time.Since(start).Seconds())
 	}()
 
-	entries, err := fsw.actualStore.ListDirectoryEntries(ctx, dirPath, startFileName, includeStartFile, limit)
+	entries, err := actualStore.ListDirectoryEntries(ctx, dirPath, startFileName, includeStartFile, limit)
 	if err != nil {
 		return nil, err
 	}
 	for _, entry := range entries {
 		filer_pb.AfterEntryDeserialization(entry.Chunks)
+		if err = fsw.maybeReadHardLink(ctx, entry); err != nil {
+			return nil, err
+		}
 	}
 	return entries, err
 }
 
+// BeginTransaction starts a transaction in every store.
+// They are committed one by one, so a change across stores, like moving an entry, is not atomic.
 func (fsw *FilerStoreWrapper) BeginTransaction(ctx context.Context) (context.Context, error) {
-	return fsw.actualStore.BeginTransaction(ctx)
+	stores := fsw.allStores()
+	for i, store := range stores {
+		txCtx, err := store.BeginTransaction(ctx)
+		if err != nil {
+			for _, started := range stores[:i] {
+				started.RollbackTransaction(ctx)
+			}
+			return ctx, fmt.Errorf("begin %s transaction: %v", store.GetName(), err)
+		}
+		ctx = txCtx
+	}
+	return ctx, nil
 }
 
+// CommitTransaction commits the stores one by one, and rolls back the rest after a failure
 func (fsw *FilerStoreWrapper) CommitTransaction(ctx context.Context) error {
-	return fsw.actualStore.CommitTransaction(ctx)
+	stores := fsw.allStores()
+	for i, store := range stores {
+		if err := store.CommitTransaction(ctx); err != nil {
+			for _, rest := range stores[i+1:] {
+				rest.RollbackTransaction(ctx)
+			}
+			return fmt.Errorf("commit %s transaction: %v", store.GetName(), err)
+		}
+	}
+	return nil
 }
 
-func (fsw *FilerStoreWrapper) RollbackTransaction(ctx context.Context) error {
-	return fsw.actualStore.RollbackTransaction(ctx)
+func (fsw *FilerStoreWrapper) RollbackTransaction(ctx context.Context) (err error) {
+	for _, store := range fsw.allStores() {
+		if rollbackErr := store.RollbackTransaction(ctx); rollbackErr != nil && err == nil {
+			err = fmt.Errorf("rollback %s transaction: %v", store.GetName(), rollbackErr)
+		}
+	}
+	return err
 }
 
 func (fsw *FilerStoreWrapper) Shutdown() {
-	fsw.actualStore.Shutdown()
+	for _, store := range fsw.allStores() {
+		store.Shutdown()
+	}
 }
@@ -0,0 +1,157 @@
+package filer2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	// HardLinkIdKey names the shared record of a hard linked file. The entry of each link only keeps the id,
+	// and reads the attributes, chunks and extended attributes from the record.
+	HardLinkIdKey = "seaweed-hardlink-id"
+	// HardLinkCountKey is the number of links, in the record and in the entries read from it
+	HardLinkCountKey = "seaweed-hardlink-count"
+
+	// each hard linked file has one record, named by the hard link id
+	HardLinksDir = "/etc/hard_links"
+)
+
+// NewHardLinkId generates the id to link the file for the first time
+func NewHardLinkId() []byte {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return []byte(hex.EncodeToString(b))
+}
+
+// HardLinkId returns the hard link id of the entry, or empty if not hard linked
+func HardLinkId(extended map[string][]byte) string {
+	return string(extended[HardLinkIdKey])
+}
+
+// HardLinkCount returns the number of links of the entry, which is 1 if not hard linked
+func HardLinkCount(extended map[string][]byte) int64 {
+	if HardLinkId(extended) == "" {
+		return 1
+	}
+	count, err := strconv.ParseInt(string(extended[HardLinkCountKey]), 10, 64)
+	if err != nil || count < 1 {
+		return 1
+	}
+	return count
+}
+
+func hardLinkPath(id string) util.FullPath {
+	return util.NewFullPath(HardLinksDir, id)
+}
+
+// saveHardLink writes the shared part of the linked entry to its record, and returns the entry to keep for the link.
+// The link count is kept, which is only changed by addHardLinkCount, or taken from the entry for a new record.
+func (fsw *FilerStoreWrapper) saveHardLink(ctx context.Context, entry *Entry) (link *Entry, err error) {
+
+	id := HardLinkId(entry.Extended)
+	recordPath := hardLinkPath(id)
+	store := fsw.getEntryStore(recordPath)
+
+	fsw.hardLinksLock.Lock()
+	defer fsw.hardLinksLock.Unlock()
+
+	count := int64(0)
+	if value, found := entry.Extended[HardLinkCountKey]; found {
+		count, _ = strconv.ParseInt(string(value), 10, 64)
+	}
+	existing, findErr := store.FindEntry(ctx, recordPath)
+	if findErr == nil {
+		count, _ = strconv.ParseInt(string(existing.Extended[HardLinkCountKey]), 10, 64)
+	} else if findErr != filer_pb.ErrNotFound {
+		return nil, fmt.Errorf("read hard link %s: %v", id, findErr)
+	}
+
+	record := &Entry{
+		FullPath: recordPath,
+		Attr:     entry.Attr,
+		Chunks:   entry.Chunks,
+		Extended: make(map[string][]byte),
+	}
+	for k, v := range entry.Extended {
+		if k != HardLinkIdKey && k != HardLinkCountKey {
+			record.Extended[k] = v
+		}
+	}
+	record.Extended[HardLinkCountKey] = []byte(strconv.FormatInt(count, 10))
+
+	filer_pb.BeforeEntrySerialization(record.Chunks)
+	if err = store.InsertEntry(ctx, record); err != nil {
+		return nil, fmt.Errorf("save hard link %s: %v", id, err)
+	}
+
+	return &Entry{
+		FullPath: entry.FullPath,
+		Attr:     entry.Attr,
+		Extended: map[string][]byte{HardLinkIdKey: []byte(id)},
+	}, nil
+}
+
+// maybeReadHardLink fills the entry of a link with the attributes, chunks and extended attributes of its record
+func (fsw *FilerStoreWrapper) maybeReadHardLink(ctx context.Context, entry *Entry) error {
+
+	id := HardLinkId(entry.Extended)
+	if id == "" {
+		return nil
+	}
+
+	recordPath := hardLinkPath(id)
+	record, err := fsw.getEntryStore(recordPath).FindEntry(ctx, recordPath)
+	if err != nil {
+		return fmt.Errorf("read hard link %s of %s: %v", id, entry.FullPath, err)
+	}
+	filer_pb.AfterEntryDeserialization(record.Chunks)
+
+	entry.Attr = record.Attr
+	entry.Chunks = record.Chunks
+	entry.Extended = make(map[string][]byte)
+	for k, v := range record.Extended {
+		entry.Extended[k] = v
+	}
+	entry.Extended[HardLinkIdKey] = []byte(id)
+
+	return nil
+}
+
+// addHardLinkCount changes the number of links, and removes the record without any link
+func (fsw *FilerStoreWrapper) addHardLinkCount(ctx context.Context, id string, delta int64) (count int64, err error) {
+
+	recordPath := hardLinkPath(id)
+	store := fsw.getEntryStore(recordPath)
+
+	fsw.hardLinksLock.Lock()
+	defer fsw.hardLinksLock.Unlock()
+
+	record, err := store.FindEntry(ctx, recordPath)
+	if err != nil {
+		return 0, fmt.Errorf("read hard link %s: %v", id, err)
+	}
+	count, _ = strconv.ParseInt(string(record.Extended[HardLinkCountKey]), 10, 64)
+	count += delta
+
+	if count <= 0 {
+		if err = store.DeleteEntry(ctx, recordPath); err != nil {
+			return 0, fmt.Errorf("delete hard link %s: %v", id, err)
+		}
+		return 0, nil
+	}
+
+	if record.Extended == nil {
+		record.Extended = make(map[string][]byte)
+	}
+	record.Extended[HardLinkCountKey] = []byte(strconv.FormatInt(count, 10))
+	if err = store.UpdateEntry(ctx, record); err != nil {
+		return 0, fmt.Errorf("update hard link %s: %v", id, err)
+	}
+	return count, nil
+}
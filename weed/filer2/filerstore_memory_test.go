@@ -0,0 +1,116 @@
+package filer2
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/util/log_buffer"
+)
+
+// memoryStore keeps the entries in a map, for the filer tests
+type memoryStore struct {
+	sync.Mutex
+	entries map[util.FullPath]*Entry
+}
+
+// copyEntry encodes and decodes the entry, so the stored one does not share anything with the caller, like real stores
+func copyEntry(entry *Entry) *Entry {
+	data, _ := entry.EncodeAttributesAndChunks()
+	copied := &Entry{FullPath: entry.FullPath}
+	copied.DecodeAttributesAndChunks(data)
+	return copied
+}
+
+func (store *memoryStore) GetName() string { return "memory" }
+func (store *memoryStore) Initialize(configuration util.Configuration, prefix string) error {
+	return nil
+}
+func (store *memoryStore) InsertEntry(ctx context.Context, entry *Entry) error {
+	store.Lock()
+	defer store.Unlock()
+	store.entries[entry.FullPath] = copyEntry(entry)
+	return nil
+}
+func (store *memoryStore) UpdateEntry(ctx context.Context, entry *Entry) error {
+	store.Lock()
+	defer store.Unlock()
+	store.entries[entry.FullPath] = copyEntry(entry)
+	return nil
+}
+func (store *memoryStore) FindEntry(ctx context.Context, p util.FullPath) (*Entry, error) {
+	store.Lock()
+	defer store.Unlock()
+	if entry, found := store.entries[p]; found {
+		return copyEntry(entry), nil
+	}
+	return nil, filer_pb.ErrNotFound
+}
+func (store *memoryStore) DeleteEntry(ctx context.Context, p util.FullPath) error {
+	store.Lock()
+	defer store.Unlock()
+	delete(store.entries, p)
+	return nil
+}
+func (store *memoryStore) DeleteFolderChildren(ctx context.Context, p util.FullPath) error {
+	store.Lock()
+	defer store.Unlock()
+	for fullPath := range store.entries {
+		if strings.HasPrefix(string(fullPath), string(p)+"/") {
+			delete(store.entries, fullPath)
+		}
+	}
+	return nil
+}
+func (store *memoryStore) ListDirectoryEntries(ctx context.Context, dirPath util.FullPath, startFileName string, includeStartFile bool, limit int) (entries []*Entry, err error) {
+	store.Lock()
+	defer store.Unlock()
+	for fullPath, entry := range store.entries {
+		dir, name := fullPath.DirAndName()
+		if dir == string(dirPath) && (name > startFileName || includeStartFile && name == startFileName) {
+			entries = append(entries, copyEntry(entry))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+func (store *memoryStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return ctx, nil
+}
+func (store *memoryStore) CommitTransaction(ctx context.Context) error   { return nil }
+func (store *memoryStore) RollbackTransaction(ctx context.Context) error { return nil }
+func (store *memoryStore) Shutdown()                                     {}
+
+// newTestFiler keeps the entries in a memoryStore, and the deleted chunks in its deletion queue
+func newTestFiler() *Filer {
+	f := &Filer{
+		fileIdDeletionQueue: util.NewUnboundedQueue(),
+		MetaLogBuffer:       log_buffer.NewLogBuffer(time.Minute, func(startTime, stopTime time.Time, buf []byte) {}, nil),
+	}
+	f.SetStore(&memoryStore{entries: make(map[util.FullPath]*Entry)})
+	f.DisableDirectoryCache()
+	return f
+}
+
+func deletedFileIds(f *Filer) (fileIds []string) {
+	f.fileIdDeletionQueue.Consume(func(ids []string) {
+		fileIds = append(fileIds, ids...)
+	})
+	sort.Strings(fileIds)
+	return
+}
+
+func waitDeletedFileIds(f *Filer) (fileIds []string) {
+	for i := 0; i < 100 && len(fileIds) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		fileIds = deletedFileIds(f)
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package filer2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestPathSpecificStores(t *testing.T) {
+
+	defaultStore := &memoryStore{entries: make(map[util.FullPath]*Entry)}
+	sessionStore := &memoryStore{entries: make(map[util.FullPath]*Entry)}
+	tmpStore := &memoryStore{entries: make(map[util.FullPath]*Entry)}
+
+	fsw := NewFilerStoreWrapper(defaultStore)
+	fsw.AddPathSpecificStore("/buckets/sessions/", sessionStore)
+	fsw.AddPathSpecificStore("/buckets/sessions/tmp", tmpStore)
+
+	ctx := context.Background()
+	for _, p := range []util.FullPath{"/buckets/sessions", "/buckets/sessions/a", "/buckets/sessions/a/b",
+		"/buckets/sessions/tmp", "/buckets/sessions/tmp/c", "/buckets/sessions2/d", "/e"} {
+		if err := fsw.InsertEntry(ctx, &Entry{FullPath: p}); err != nil {
+			t.Fatalf("insert %s: %v", p, err)
+		}
+	}
+
+	expected := map[util.FullPath]*memoryStore{
+		"/buckets/sessions":       defaultStore,
+		"/buckets/sessions/a":     sessionStore,
+		"/buckets/sessions/a/b":   sessionStore,
+		"/buckets/sessions/tmp":   sessionStore,
+		"/buckets/sessions/tmp/c": tmpStore,
+		"/buckets/sessions2/d":    defaultStore,
+		"/e":                      defaultStore,
+	}
+	for p, store := range expected {
+		if _, found := store.entries[p]; !found {
+			t.Errorf("%s is not in the expected store", p)
+		}
+		if _, err := fsw.FindEntry(ctx, p); err != nil {
+			t.Errorf("find %s: %v", p, err)
+		}
+	}
+	if len(defaultStore.entries)+len(sessionStore.entries)+len(tmpStore.entries) != len(expected) {
+		t.Errorf("entries are stored more than once")
+	}
+
+	if fsw.getActualStore("/buckets/sessions") != sessionStore || fsw.getActualStore("/buckets") != defaultStore {
+		t.Errorf("wrong store to list the directory")
+	}
+
+	if err := fsw.DeleteEntry(ctx, "/buckets/sessions/tmp/c"); err != nil {
+		t.Errorf("delete: %v", err)
+	}
+	if len(tmpStore.entries) != 0 {
+		t.Errorf("entry is not deleted from its store")
+	}
+
+	if stores := fsw.allStores(); len(stores) != 3 || stores[0] != defaultStore {
+		t.Errorf("all stores: %v", stores)
+	}
+
+	if !fsw.isInOneStore("/buckets/sessions/a", "/buckets/sessions/b") || !fsw.isInOneStore("/buckets/x", "/e") {
+		t.Errorf("entries in one store")
+	}
+	if fsw.isInOneStore("/buckets/sessions/a", "/buckets/x") || fsw.isInOneStore("/buckets/sessions", "/buckets/x") || fsw.isInOneStore("/buckets/sessions/a", "/buckets/sessions/tmp") {
+		t.Errorf("entries in more than one store")
+	}
+
+}
@@ -0,0 +1,66 @@
+package filer2
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/iam_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	// each identity is kept as one entry, with the protobuf encoded iam_pb.Identity in its extended attributes
+	IamIdentitiesDir  = "/etc/iam/identities"
+	IamIdentityExtKey = "iam-identity"
+
+	// IamSealedSecretPrefix marks the secret keys encrypted by the [iam] key of security.toml
+	IamSealedSecretPrefix = "sealed:"
+)
+
+// SealIdentitySecrets encrypts the secret keys of an identity before it is stored,
+// so the filer entries do not reveal them to anyone reading the /etc/iam folder.
+func SealIdentitySecrets(identity *iam_pb.Identity, key string) error {
+	for _, cred := range identity.Credentials {
+		if strings.HasPrefix(cred.SecretKey, IamSealedSecretPrefix) {
+			continue
+		}
+		if key == "" {
+			return fmt.Errorf("the [iam] key in security.toml is required to store secret keys")
+		}
+		sealed, err := util.Encrypt([]byte(cred.SecretKey), iamCipherKey(key))
+		if err != nil {
+			return fmt.Errorf("seal secret key of %s: %v", cred.AccessKey, err)
+		}
+		cred.SecretKey = IamSealedSecretPrefix + base64.StdEncoding.EncodeToString(sealed)
+	}
+	return nil
+}
+
+// OpenIdentitySecrets decrypts the secret keys of an identity read from the filer
+func OpenIdentitySecrets(identity *iam_pb.Identity, key string) error {
+	for _, cred := range identity.Credentials {
+		if !strings.HasPrefix(cred.SecretKey, IamSealedSecretPrefix) {
+			return fmt.Errorf("secret key of %s is not sealed", cred.AccessKey)
+		}
+		if key == "" {
+			return fmt.Errorf("the [iam] key in security.toml is required to read secret keys")
+		}
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(cred.SecretKey, IamSealedSecretPrefix))
+		if err != nil {
+			return fmt.Errorf("decode secret key of %s: %v", cred.AccessKey, err)
+		}
+		secretKey, err := util.Decrypt(sealed, iamCipherKey(key))
+		if err != nil {
+			return fmt.Errorf("open secret key of %s: %v", cred.AccessKey, err)
+		}
+		cred.SecretKey = string(secretKey)
+	}
+	return nil
+}
+
+func iamCipherKey(key string) util.CipherKey {
+	sum := sha256.Sum256([]byte(key))
+	return util.CipherKey(sum[:])
+}
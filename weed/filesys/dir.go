@@ -243,13 +243,16 @@ func (dir *Dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.
 		}
 
 		// resp.EntryValid = time.Second
-		resp.Attr.Inode = fullFilePath.AsInode()
+		resp.Attr.Inode = entryInode(fullFilePath, entry)
 		resp.Attr.Valid = time.Second
 		resp.Attr.Mtime = time.Unix(entry.Attributes.Mtime, 0)
 		resp.Attr.Crtime = time.Unix(entry.Attributes.Crtime, 0)
 		resp.Attr.Mode = os.FileMode(entry.Attributes.FileMode)
 		resp.Attr.Gid = entry.Attributes.Gid
 		resp.Attr.Uid = entry.Attributes.Uid
+		if !entry.IsDirectory {
+			resp.Attr.Nlink = uint32(filer2.HardLinkCount(entry.Extended))
+		}
 
 		return node, nil
 	}
@@ -265,7 +268,7 @@ func (dir *Dir) ReadDirAll(ctx context.Context) (ret []fuse.Dirent, err error) {
 	cacheTtl := 5 * time.Minute
 	processEachEntryFn := func(entry *filer_pb.Entry, isLast bool) error {
 		fullpath := util.NewFullPath(dir.FullPath(), entry.Name)
-		inode := fullpath.AsInode()
+		inode := entryInode(fullpath, entry)
 		if entry.IsDirectory {
 			dirent := fuse.Dirent{Inode: inode, Name: entry.Name, Type: fuse.DT_Dir}
 			ret = append(ret, dirent)
@@ -319,7 +322,17 @@ func (dir *Dir) removeOneFile(req *fuse.RemoveRequest) error {
 		return nil
 	}
 
-	dir.wfs.deleteFileChunks(entry.Chunks)
+	// the filer deletes the chunks, after checking the object lock, the hard links, and the trash
+	glog.V(3).Infof("remove file: %v", req)
+	err = filer_pb.Remove(dir.wfs, dir.FullPath(), req.Name, true, false, false)
+	if err != nil {
+		if filer2.IsObjectLocked(err.Error()) {
+			glog.V(1).Infof("remove locked file %s/%s: %v", dir.FullPath(), req.Name, err)
+			return fuse.EPERM
+		}
+		glog.V(3).Infof("not found remove file %s/%s: %v", dir.FullPath(), req.Name, err)
+		return fuse.ENOENT
+	}
 
 	dir.wfs.cacheDelete(filePath)
 	dir.wfs.fsNodeCache.DeleteFsNode(filePath)
@@ -328,13 +341,6 @@ func (dir *Dir) removeOneFile(req *fuse.RemoveRequest) error {
 		dir.wfs.metaCache.DeleteEntry(context.Background(), filePath)
 	}
 
-	glog.V(3).Infof("remove file: %v", req)
-	err = filer_pb.Remove(dir.wfs, dir.FullPath(), req.Name, false, false, false)
-	if err != nil {
-		glog.V(3).Infof("not found remove file %s/%s: %v", dir.FullPath(), req.Name, err)
-		return fuse.ENOENT
-	}
-
 	return nil
 
 }
@@ -9,13 +9,81 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
 	"github.com/seaweedfs/fuse"
 	"github.com/seaweedfs/fuse/fs"
 )
 
+var _ = fs.NodeLinker(&Dir{})
 var _ = fs.NodeSymlinker(&Dir{})
 var _ = fs.NodeReadlinker(&File{})
 
+// Link creates a hard link to the file. The first link moves the file content to a record shared by the links.
+func (dir *Dir) Link(ctx context.Context, req *fuse.LinkRequest, old fs.Node) (fs.Node, error) {
+
+	oldFile, ok := old.(*File)
+	if !ok {
+		return nil, fuse.Errno(syscall.EPERM)
+	}
+	if err := oldFile.maybeLoadEntry(ctx); err != nil {
+		return nil, err
+	}
+
+	newPath := util.NewFullPath(dir.FullPath(), req.NewName)
+	glog.V(3).Infof("Link: %v to %v", oldFile.fullpath(), newPath)
+
+	oldEntry := oldFile.entry
+	if filer2.HardLinkId(oldEntry.Extended) == "" {
+		if oldEntry.Extended == nil {
+			oldEntry.Extended = make(map[string][]byte)
+		}
+		oldEntry.Extended[filer2.HardLinkIdKey] = filer2.NewHardLinkId()
+		if err := oldFile.saveEntry(); err != nil {
+			delete(oldEntry.Extended, filer2.HardLinkIdKey)
+			return nil, err
+		}
+	}
+
+	request := &filer_pb.CreateEntryRequest{
+		Directory: dir.FullPath(),
+		Entry: &filer_pb.Entry{
+			Name:        req.NewName,
+			IsDirectory: false,
+			Attributes:  oldEntry.Attributes,
+			Chunks:      oldEntry.Chunks,
+			Extended:    oldEntry.Extended,
+		},
+	}
+
+	var newEntry *filer_pb.Entry
+	err := dir.wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		if err := filer_pb.CreateEntry(client, request); err != nil {
+			glog.V(0).Infof("link %s => %s: %v", oldFile.fullpath(), newPath, err)
+			return fuse.EIO
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// read back the link count
+	newEntry, err = filer_pb.GetEntry(dir.wfs, newPath)
+	if err != nil || newEntry == nil {
+		glog.V(0).Infof("read link %s: %v", newPath, err)
+		return nil, fuse.EIO
+	}
+	oldFile.entry.Extended = newEntry.Extended
+	dir.wfs.cacheDelete(oldFile.fullpath())
+
+	if dir.wfs.option.AsyncMetaDataCaching {
+		dir.wfs.metaCache.InsertEntry(context.Background(), filer2.FromPbEntry(request.Directory, newEntry))
+	}
+
+	return dir.newFile(req.NewName, newEntry), nil
+
+}
+
 func (dir *Dir) Symlink(ctx context.Context, req *fuse.SymlinkRequest) (fs.Node, error) {
 
 	glog.V(3).Infof("Symlink: %v/%v to %v", dir.FullPath(), req.NewName, req.Target)
@@ -70,3 +138,11 @@ func (file *File) Readlink(ctx context.Context, req *fuse.ReadlinkRequest) (stri
 	return file.entry.Attributes.SymlinkTarget, nil
 
 }
+
+// entryInode is shared by the hard links of a file, so tools can tell the links apart from copies
+func entryInode(fullpath util.FullPath, entry *filer_pb.Entry) uint64 {
+	if id := filer2.HardLinkId(entry.Extended); id != "" {
+		return util.NewFullPath(filer2.HardLinksDir, id).AsInode()
+	}
+	return fullpath.AsInode()
+}
@@ -51,7 +51,7 @@ func (file *File) Attr(ctx context.Context, attr *fuse.Attr) error {
 		}
 	}
 
-	attr.Inode = file.fullpath().AsInode()
+	attr.Inode = entryInode(file.fullpath(), file.entry)
 	attr.Valid = time.Second
 	attr.Mode = os.FileMode(file.entry.Attributes.FileMode)
 	attr.Size = filer2.TotalSize(file.entry.Chunks)
@@ -63,6 +63,7 @@ func (file *File) Attr(ctx context.Context, attr *fuse.Attr) error {
 	attr.Mtime = time.Unix(file.entry.Attributes.Mtime, 0)
 	attr.Gid = file.entry.Attributes.Gid
 	attr.Uid = file.entry.Attributes.Uid
+	attr.Nlink = uint32(filer2.HardLinkCount(file.entry.Extended))
 	attr.Blocks = attr.Size/blockSize + 1
 	attr.BlockSize = uint32(file.wfs.option.ChunkSizeLimit)
 
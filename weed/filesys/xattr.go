@@ -3,6 +3,7 @@ package filesys
 import (
 	"context"
 
+	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
 	"github.com/chrislusf/seaweedfs/weed/util"
@@ -43,6 +44,11 @@ func setxattr(entry *filer_pb.Entry, req *fuse.SetxattrRequest) error {
 		return fuse.EIO
 	}
 
+	// the object lock is only changed through the S3 gateway
+	if filer2.IsObjectLockKey(req.Name) {
+		return fuse.EPERM
+	}
+
 	if entry.Extended == nil {
 		entry.Extended = make(map[string][]byte)
 	}
@@ -70,6 +76,10 @@ func removexattr(entry *filer_pb.Entry, req *fuse.RemovexattrRequest) error {
 		return fuse.ErrNoXattr
 	}
 
+	if filer2.IsObjectLockKey(req.Name) {
+		return fuse.EPERM
+	}
+
 	_, found := entry.Extended[req.Name]
 
 	if !found {
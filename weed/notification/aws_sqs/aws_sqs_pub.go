@@ -72,6 +72,11 @@ func (k *AwsSqsPub) SendMessage(key string, message proto.Message) (err error) {
 
 	text := proto.MarshalTextString(message)
 
+	return k.SendRawMessage(key, []byte(text))
+}
+
+func (k *AwsSqsPub) SendRawMessage(key string, data []byte) (err error) {
+
 	_, err = k.svc.SendMessage(&sqs.SendMessageInput{
 		DelaySeconds: aws.Int64(10),
 		MessageAttributes: map[string]*sqs.MessageAttributeValue{
@@ -80,7 +85,7 @@ func (k *AwsSqsPub) SendMessage(key string, message proto.Message) (err error) {
 				StringValue: aws.String(key),
 			},
 		},
-		MessageBody: aws.String(text),
+		MessageBody: aws.String(string(data)),
 		QueueUrl:    &k.queueUrl,
 	})
 
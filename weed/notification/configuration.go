@@ -1,6 +1,9 @@
 package notification
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/util"
 	"github.com/golang/protobuf/proto"
@@ -13,6 +16,8 @@ type MessageQueue interface {
 	// Initialize initializes the file store
 	Initialize(configuration util.Configuration, prefix string) error
 	SendMessage(key string, message proto.Message) error
+	// SendRawMessage sends already encoded messages, e.g. the json records of the S3 bucket notifications
+	SendRawMessage(key string, data []byte) error
 }
 
 var (
@@ -43,6 +48,33 @@ func LoadConfiguration(config *viper.Viper, prefix string) {
 
 }
 
+// NewMessageQueue creates a message queue apart from the filer's Queue, e.g. for the S3 bucket notifications,
+// from the only one enabled under the prefix. It returns nil if none is enabled.
+func NewMessageQueue(config *viper.Viper, prefix string) (MessageQueue, error) {
+
+	var enabled MessageQueue
+	for _, queue := range MessageQueues {
+		if config.GetBool(prefix + queue.GetName() + ".enabled") {
+			if enabled != nil {
+				return nil, fmt.Errorf("message queue is enabled for both %s%s and %s%s", prefix, enabled.GetName(), prefix, queue.GetName())
+			}
+			enabled = queue
+		}
+	}
+	if enabled == nil {
+		return nil, nil
+	}
+
+	// the registered queues are shared with the filer, so a new one of the same type is initialized
+	queue := reflect.New(reflect.TypeOf(enabled).Elem()).Interface().(MessageQueue)
+	if err := queue.Initialize(config, prefix+queue.GetName()+"."); err != nil {
+		return nil, fmt.Errorf("initialize message queue %s%s: %v", prefix, queue.GetName(), err)
+	}
+	glog.V(0).Infof("Configure message queue for %s%s", prefix, queue.GetName())
+
+	return queue, nil
+}
+
 func validateOneEnabledQueue(config *viper.Viper) {
 	enabledQueue := ""
 	for _, queue := range MessageQueues {
@@ -52,10 +52,15 @@ func (k *KafkaQueue) SendMessage(key string, message proto.Message) (err error)
 		return
 	}
 
+	return k.SendRawMessage(key, bytes)
+}
+
+func (k *KafkaQueue) SendRawMessage(key string, data []byte) (err error) {
+
 	msg := &sarama.ProducerMessage{
 		Topic: k.topic,
 		Key:   sarama.StringEncoder(key),
-		Value: sarama.ByteEncoder(bytes),
+		Value: sarama.ByteEncoder(data),
 	}
 
 	k.producer.Input() <- msg
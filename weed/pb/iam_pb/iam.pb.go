@@ -12,6 +12,18 @@ It has these top-level messages:
 	S3ApiConfiguration
 	Identity
 	Credential
+	Policy
+	Statement
+	CreateIdentityRequest
+	CreateIdentityResponse
+	ListIdentitiesRequest
+	ListIdentitiesResponse
+	DeleteIdentityRequest
+	DeleteIdentityResponse
+	CreateAccessKeyRequest
+	CreateAccessKeyResponse
+	DeleteAccessKeyRequest
+	DeleteAccessKeyResponse
 */
 package iam_pb
 
@@ -55,6 +67,7 @@ type Identity struct {
 	Name        string        `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Credentials []*Credential `protobuf:"bytes,2,rep,name=credentials" json:"credentials,omitempty"`
 	Actions     []string      `protobuf:"bytes,3,rep,name=actions" json:"actions,omitempty"`
+	Policy      *Policy       `protobuf:"bytes,4,opt,name=policy" json:"policy,omitempty"`
 }
 
 func (m *Identity) Reset()                    { *m = Identity{} }
@@ -83,6 +96,13 @@ func (m *Identity) GetActions() []string {
 	return nil
 }
 
+func (m *Identity) GetPolicy() *Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
 type Credential struct {
 	AccessKey string `protobuf:"bytes,1,opt,name=access_key,json=accessKey" json:"access_key,omitempty"`
 	SecretKey string `protobuf:"bytes,2,opt,name=secret_key,json=secretKey" json:"secret_key,omitempty"`
@@ -107,10 +127,230 @@ func (m *Credential) GetSecretKey() string {
 	return ""
 }
 
+type Policy struct {
+	Version    string       `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	Statements []*Statement `protobuf:"bytes,2,rep,name=statements" json:"statements,omitempty"`
+}
+
+func (m *Policy) Reset()                    { *m = Policy{} }
+func (m *Policy) String() string            { return proto.CompactTextString(m) }
+func (*Policy) ProtoMessage()               {}
+func (*Policy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Policy) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Policy) GetStatements() []*Statement {
+	if m != nil {
+		return m.Statements
+	}
+	return nil
+}
+
+type Statement struct {
+	Sid       string   `protobuf:"bytes,1,opt,name=sid" json:"sid,omitempty"`
+	Effect    string   `protobuf:"bytes,2,opt,name=effect" json:"effect,omitempty"`
+	Actions   []string `protobuf:"bytes,3,rep,name=actions" json:"actions,omitempty"`
+	Resources []string `protobuf:"bytes,4,rep,name=resources" json:"resources,omitempty"`
+}
+
+func (m *Statement) Reset()                    { *m = Statement{} }
+func (m *Statement) String() string            { return proto.CompactTextString(m) }
+func (*Statement) ProtoMessage()               {}
+func (*Statement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Statement) GetSid() string {
+	if m != nil {
+		return m.Sid
+	}
+	return ""
+}
+
+func (m *Statement) GetEffect() string {
+	if m != nil {
+		return m.Effect
+	}
+	return ""
+}
+
+func (m *Statement) GetActions() []string {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *Statement) GetResources() []string {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type CreateIdentityRequest struct {
+	Identity *Identity `protobuf:"bytes,1,opt,name=identity" json:"identity,omitempty"`
+}
+
+func (m *CreateIdentityRequest) Reset()                    { *m = CreateIdentityRequest{} }
+func (m *CreateIdentityRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateIdentityRequest) ProtoMessage()               {}
+func (*CreateIdentityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *CreateIdentityRequest) GetIdentity() *Identity {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+type CreateIdentityResponse struct {
+}
+
+func (m *CreateIdentityResponse) Reset()                    { *m = CreateIdentityResponse{} }
+func (m *CreateIdentityResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateIdentityResponse) ProtoMessage()               {}
+func (*CreateIdentityResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+type ListIdentitiesRequest struct {
+}
+
+func (m *ListIdentitiesRequest) Reset()                    { *m = ListIdentitiesRequest{} }
+func (m *ListIdentitiesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListIdentitiesRequest) ProtoMessage()               {}
+func (*ListIdentitiesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type ListIdentitiesResponse struct {
+	Identities []*Identity `protobuf:"bytes,1,rep,name=identities" json:"identities,omitempty"`
+}
+
+func (m *ListIdentitiesResponse) Reset()                    { *m = ListIdentitiesResponse{} }
+func (m *ListIdentitiesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListIdentitiesResponse) ProtoMessage()               {}
+func (*ListIdentitiesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *ListIdentitiesResponse) GetIdentities() []*Identity {
+	if m != nil {
+		return m.Identities
+	}
+	return nil
+}
+
+type DeleteIdentityRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteIdentityRequest) Reset()                    { *m = DeleteIdentityRequest{} }
+func (m *DeleteIdentityRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteIdentityRequest) ProtoMessage()               {}
+func (*DeleteIdentityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *DeleteIdentityRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteIdentityResponse struct {
+}
+
+func (m *DeleteIdentityResponse) Reset()                    { *m = DeleteIdentityResponse{} }
+func (m *DeleteIdentityResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteIdentityResponse) ProtoMessage()               {}
+func (*DeleteIdentityResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+type CreateAccessKeyRequest struct {
+	IdentityName string      `protobuf:"bytes,1,opt,name=identity_name,json=identityName" json:"identity_name,omitempty"`
+	Credential   *Credential `protobuf:"bytes,2,opt,name=credential" json:"credential,omitempty"`
+}
+
+func (m *CreateAccessKeyRequest) Reset()                    { *m = CreateAccessKeyRequest{} }
+func (m *CreateAccessKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateAccessKeyRequest) ProtoMessage()               {}
+func (*CreateAccessKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *CreateAccessKeyRequest) GetIdentityName() string {
+	if m != nil {
+		return m.IdentityName
+	}
+	return ""
+}
+
+func (m *CreateAccessKeyRequest) GetCredential() *Credential {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+type CreateAccessKeyResponse struct {
+	Credential *Credential `protobuf:"bytes,1,opt,name=credential" json:"credential,omitempty"`
+}
+
+func (m *CreateAccessKeyResponse) Reset()                    { *m = CreateAccessKeyResponse{} }
+func (m *CreateAccessKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateAccessKeyResponse) ProtoMessage()               {}
+func (*CreateAccessKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *CreateAccessKeyResponse) GetCredential() *Credential {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+type DeleteAccessKeyRequest struct {
+	IdentityName string `protobuf:"bytes,1,opt,name=identity_name,json=identityName" json:"identity_name,omitempty"`
+	AccessKey    string `protobuf:"bytes,2,opt,name=access_key,json=accessKey" json:"access_key,omitempty"`
+}
+
+func (m *DeleteAccessKeyRequest) Reset()                    { *m = DeleteAccessKeyRequest{} }
+func (m *DeleteAccessKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAccessKeyRequest) ProtoMessage()               {}
+func (*DeleteAccessKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *DeleteAccessKeyRequest) GetIdentityName() string {
+	if m != nil {
+		return m.IdentityName
+	}
+	return ""
+}
+
+func (m *DeleteAccessKeyRequest) GetAccessKey() string {
+	if m != nil {
+		return m.AccessKey
+	}
+	return ""
+}
+
+type DeleteAccessKeyResponse struct {
+}
+
+func (m *DeleteAccessKeyResponse) Reset()                    { *m = DeleteAccessKeyResponse{} }
+func (m *DeleteAccessKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAccessKeyResponse) ProtoMessage()               {}
+func (*DeleteAccessKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
 func init() {
 	proto.RegisterType((*S3ApiConfiguration)(nil), "iam_pb.S3ApiConfiguration")
 	proto.RegisterType((*Identity)(nil), "iam_pb.Identity")
 	proto.RegisterType((*Credential)(nil), "iam_pb.Credential")
+	proto.RegisterType((*Policy)(nil), "iam_pb.Policy")
+	proto.RegisterType((*Statement)(nil), "iam_pb.Statement")
+	proto.RegisterType((*CreateIdentityRequest)(nil), "iam_pb.CreateIdentityRequest")
+	proto.RegisterType((*CreateIdentityResponse)(nil), "iam_pb.CreateIdentityResponse")
+	proto.RegisterType((*ListIdentitiesRequest)(nil), "iam_pb.ListIdentitiesRequest")
+	proto.RegisterType((*ListIdentitiesResponse)(nil), "iam_pb.ListIdentitiesResponse")
+	proto.RegisterType((*DeleteIdentityRequest)(nil), "iam_pb.DeleteIdentityRequest")
+	proto.RegisterType((*DeleteIdentityResponse)(nil), "iam_pb.DeleteIdentityResponse")
+	proto.RegisterType((*CreateAccessKeyRequest)(nil), "iam_pb.CreateAccessKeyRequest")
+	proto.RegisterType((*CreateAccessKeyResponse)(nil), "iam_pb.CreateAccessKeyResponse")
+	proto.RegisterType((*DeleteAccessKeyRequest)(nil), "iam_pb.DeleteAccessKeyRequest")
+	proto.RegisterType((*DeleteAccessKeyResponse)(nil), "iam_pb.DeleteAccessKeyResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -124,6 +364,11 @@ const _ = grpc.SupportPackageIsVersion4
 // Client API for SeaweedIdentityAccessManagement service
 
 type SeaweedIdentityAccessManagementClient interface {
+	CreateIdentity(ctx context.Context, in *CreateIdentityRequest, opts ...grpc.CallOption) (*CreateIdentityResponse, error)
+	ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesResponse, error)
+	DeleteIdentity(ctx context.Context, in *DeleteIdentityRequest, opts ...grpc.CallOption) (*DeleteIdentityResponse, error)
+	CreateAccessKey(ctx context.Context, in *CreateAccessKeyRequest, opts ...grpc.CallOption) (*CreateAccessKeyResponse, error)
+	DeleteAccessKey(ctx context.Context, in *DeleteAccessKeyRequest, opts ...grpc.CallOption) (*DeleteAccessKeyResponse, error)
 }
 
 type seaweedIdentityAccessManagementClient struct {
@@ -134,41 +379,222 @@ func NewSeaweedIdentityAccessManagementClient(cc *grpc.ClientConn) SeaweedIdenti
 	return &seaweedIdentityAccessManagementClient{cc}
 }
 
+func (c *seaweedIdentityAccessManagementClient) CreateIdentity(ctx context.Context, in *CreateIdentityRequest, opts ...grpc.CallOption) (*CreateIdentityResponse, error) {
+	out := new(CreateIdentityResponse)
+	err := grpc.Invoke(ctx, "/iam_pb.SeaweedIdentityAccessManagement/CreateIdentity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedIdentityAccessManagementClient) ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesResponse, error) {
+	out := new(ListIdentitiesResponse)
+	err := grpc.Invoke(ctx, "/iam_pb.SeaweedIdentityAccessManagement/ListIdentities", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedIdentityAccessManagementClient) DeleteIdentity(ctx context.Context, in *DeleteIdentityRequest, opts ...grpc.CallOption) (*DeleteIdentityResponse, error) {
+	out := new(DeleteIdentityResponse)
+	err := grpc.Invoke(ctx, "/iam_pb.SeaweedIdentityAccessManagement/DeleteIdentity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedIdentityAccessManagementClient) CreateAccessKey(ctx context.Context, in *CreateAccessKeyRequest, opts ...grpc.CallOption) (*CreateAccessKeyResponse, error) {
+	out := new(CreateAccessKeyResponse)
+	err := grpc.Invoke(ctx, "/iam_pb.SeaweedIdentityAccessManagement/CreateAccessKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedIdentityAccessManagementClient) DeleteAccessKey(ctx context.Context, in *DeleteAccessKeyRequest, opts ...grpc.CallOption) (*DeleteAccessKeyResponse, error) {
+	out := new(DeleteAccessKeyResponse)
+	err := grpc.Invoke(ctx, "/iam_pb.SeaweedIdentityAccessManagement/DeleteAccessKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for SeaweedIdentityAccessManagement service
 
 type SeaweedIdentityAccessManagementServer interface {
+	CreateIdentity(context.Context, *CreateIdentityRequest) (*CreateIdentityResponse, error)
+	ListIdentities(context.Context, *ListIdentitiesRequest) (*ListIdentitiesResponse, error)
+	DeleteIdentity(context.Context, *DeleteIdentityRequest) (*DeleteIdentityResponse, error)
+	CreateAccessKey(context.Context, *CreateAccessKeyRequest) (*CreateAccessKeyResponse, error)
+	DeleteAccessKey(context.Context, *DeleteAccessKeyRequest) (*DeleteAccessKeyResponse, error)
 }
 
 func RegisterSeaweedIdentityAccessManagementServer(s *grpc.Server, srv SeaweedIdentityAccessManagementServer) {
 	s.RegisterService(&_SeaweedIdentityAccessManagement_serviceDesc, srv)
 }
 
+func _SeaweedIdentityAccessManagement_CreateIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedIdentityAccessManagementServer).CreateIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam_pb.SeaweedIdentityAccessManagement/CreateIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedIdentityAccessManagementServer).CreateIdentity(ctx, req.(*CreateIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedIdentityAccessManagement_ListIdentities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIdentitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedIdentityAccessManagementServer).ListIdentities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam_pb.SeaweedIdentityAccessManagement/ListIdentities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedIdentityAccessManagementServer).ListIdentities(ctx, req.(*ListIdentitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedIdentityAccessManagement_DeleteIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedIdentityAccessManagementServer).DeleteIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam_pb.SeaweedIdentityAccessManagement/DeleteIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedIdentityAccessManagementServer).DeleteIdentity(ctx, req.(*DeleteIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedIdentityAccessManagement_CreateAccessKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedIdentityAccessManagementServer).CreateAccessKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam_pb.SeaweedIdentityAccessManagement/CreateAccessKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedIdentityAccessManagementServer).CreateAccessKey(ctx, req.(*CreateAccessKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedIdentityAccessManagement_DeleteAccessKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccessKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedIdentityAccessManagementServer).DeleteAccessKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam_pb.SeaweedIdentityAccessManagement/DeleteAccessKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedIdentityAccessManagementServer).DeleteAccessKey(ctx, req.(*DeleteAccessKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SeaweedIdentityAccessManagement_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "iam_pb.SeaweedIdentityAccessManagement",
 	HandlerType: (*SeaweedIdentityAccessManagementServer)(nil),
-	Methods:     []grpc.MethodDesc{},
-	Streams:     []grpc.StreamDesc{},
-	Metadata:    "iam.proto",
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateIdentity",
+			Handler:    _SeaweedIdentityAccessManagement_CreateIdentity_Handler,
+		},
+		{
+			MethodName: "ListIdentities",
+			Handler:    _SeaweedIdentityAccessManagement_ListIdentities_Handler,
+		},
+		{
+			MethodName: "DeleteIdentity",
+			Handler:    _SeaweedIdentityAccessManagement_DeleteIdentity_Handler,
+		},
+		{
+			MethodName: "CreateAccessKey",
+			Handler:    _SeaweedIdentityAccessManagement_CreateAccessKey_Handler,
+		},
+		{
+			MethodName: "DeleteAccessKey",
+			Handler:    _SeaweedIdentityAccessManagement_DeleteAccessKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "iam.proto",
 }
 
 func init() { proto.RegisterFile("iam.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 250 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x4c, 0x90, 0x41, 0x4b, 0xc3, 0x40,
-	0x10, 0x85, 0x69, 0x23, 0xb5, 0x99, 0x5e, 0xca, 0x9c, 0xf6, 0xa0, 0x18, 0x73, 0xca, 0x29, 0x48,
-	0xeb, 0x1f, 0xa8, 0x05, 0xa1, 0x16, 0x41, 0xd2, 0x1f, 0x50, 0xa6, 0xdb, 0x69, 0x19, 0xec, 0x6e,
-	0x42, 0x76, 0x45, 0xf2, 0xef, 0x25, 0xbb, 0x46, 0x7b, 0xdb, 0x7d, 0xdf, 0x7b, 0xb3, 0x3b, 0x0f,
-	0x52, 0x21, 0x53, 0x36, 0x6d, 0xed, 0x6b, 0x9c, 0x08, 0x99, 0x7d, 0x73, 0xc8, 0x5f, 0x01, 0x77,
-	0xcb, 0x55, 0x23, 0xeb, 0xda, 0x9e, 0xe4, 0xfc, 0xd5, 0x92, 0x97, 0xda, 0xe2, 0x13, 0x80, 0x1c,
-	0xd9, 0x7a, 0xf1, 0xc2, 0x4e, 0x8d, 0xb2, 0xa4, 0x98, 0x2d, 0xe6, 0x65, 0x8c, 0x94, 0x9b, 0x48,
-	0xba, 0xea, 0xca, 0x93, 0x5b, 0x98, 0x0e, 0x3a, 0x22, 0xdc, 0x58, 0x32, 0xac, 0x46, 0xd9, 0xa8,
-	0x48, 0xab, 0x70, 0xc6, 0x67, 0x98, 0xe9, 0x96, 0x83, 0x83, 0x2e, 0x4e, 0x8d, 0xc3, 0x48, 0x1c,
-	0x46, 0xae, 0xff, 0x50, 0x75, 0x6d, 0x43, 0x05, 0xb7, 0xa4, 0xfb, 0x1f, 0x39, 0x95, 0x64, 0x49,
-	0x91, 0x56, 0xc3, 0x35, 0x7f, 0x03, 0xf8, 0x0f, 0xe1, 0x3d, 0x00, 0x69, 0xcd, 0xce, 0xed, 0x3f,
-	0xb9, 0xfb, 0x7d, 0x37, 0x8d, 0xca, 0x96, 0xbb, 0x1e, 0x3b, 0xd6, 0x2d, 0xfb, 0x80, 0xc7, 0x11,
-	0x47, 0x65, 0xcb, 0xdd, 0xe2, 0x11, 0x1e, 0x76, 0x4c, 0xdf, 0xcc, 0xc7, 0x61, 0x85, 0x55, 0x88,
-	0xbe, 0x93, 0xa5, 0x33, 0x1b, 0xb6, 0xfe, 0xe5, 0x0e, 0xe6, 0x2e, 0x5a, 0x4e, 0xae, 0xd4, 0x17,
-	0xe9, 0xb5, 0xe9, 0x86, 0xcc, 0x47, 0x5f, 0xe6, 0x61, 0x12, 0x3a, 0x5d, 0xfe, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x83, 0x4f, 0x61, 0x03, 0x60, 0x01, 0x00, 0x00,
+	// 568 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0x71, 0x6f, 0xd2, 0x40,
+	0x18, 0xc6, 0xd7, 0x41, 0x90, 0xbe, 0xe8, 0xc4, 0x4b, 0x80, 0x4a, 0x36, 0x46, 0xce, 0xc4, 0x90,
+	0x68, 0x88, 0x32, 0xbf, 0xc0, 0x86, 0x9a, 0xb0, 0x39, 0x33, 0x8b, 0xfe, 0x67, 0x42, 0x6e, 0xe5,
+	0x65, 0xb9, 0x48, 0x5b, 0xd6, 0x3b, 0x34, 0x7c, 0x11, 0x3f, 0x92, 0x9f, 0xcb, 0xb4, 0x77, 0x57,
+	0x4a, 0x69, 0x8d, 0xd9, 0x7f, 0xed, 0xfb, 0xbc, 0xfd, 0xf5, 0x7d, 0xef, 0x79, 0x9a, 0x82, 0xcd,
+	0x99, 0x3f, 0x5c, 0x45, 0xa1, 0x0c, 0x49, 0x8d, 0x33, 0x7f, 0xb6, 0xba, 0xa5, 0x1f, 0x81, 0x4c,
+	0xcf, 0xce, 0x57, 0x7c, 0x1c, 0x06, 0x0b, 0x7e, 0xb7, 0x8e, 0x98, 0xe4, 0x61, 0x40, 0xde, 0x00,
+	0xf0, 0x39, 0x06, 0x92, 0x4b, 0x8e, 0xc2, 0xb1, 0xfa, 0x95, 0x41, 0x63, 0xd4, 0x1c, 0xaa, 0x47,
+	0x86, 0x13, 0xa5, 0x6c, 0xdc, 0x4c, 0x0f, 0xfd, 0x6d, 0x41, 0xdd, 0x08, 0x84, 0x40, 0x35, 0x60,
+	0x3e, 0x3a, 0x56, 0xdf, 0x1a, 0xd8, 0x6e, 0x72, 0x4d, 0xde, 0x41, 0xc3, 0x8b, 0x30, 0xe9, 0x60,
+	0x4b, 0xe1, 0x1c, 0x26, 0x4c, 0x62, 0x98, 0xe3, 0x54, 0x72, 0xb3, 0x6d, 0xc4, 0x81, 0x47, 0xcc,
+	0x8b, 0x47, 0x12, 0x4e, 0xa5, 0x5f, 0x19, 0xd8, 0xae, 0xb9, 0x25, 0x2f, 0xa1, 0xb6, 0x0a, 0x97,
+	0xdc, 0xdb, 0x38, 0xd5, 0xbe, 0x35, 0x68, 0x8c, 0x8e, 0x0c, 0xea, 0x26, 0xa9, 0xba, 0x5a, 0xa5,
+	0x97, 0x00, 0x5b, 0x38, 0x39, 0x01, 0x60, 0x9e, 0x87, 0x42, 0xcc, 0x7e, 0xe0, 0x46, 0xcf, 0x67,
+	0xab, 0xca, 0x15, 0x6e, 0x62, 0x59, 0xa0, 0x17, 0xa1, 0x4c, 0xe4, 0x43, 0x25, 0xab, 0xca, 0x15,
+	0x6e, 0xe8, 0x37, 0xa8, 0x29, 0x7a, 0x3c, 0xd7, 0x4f, 0x8c, 0x04, 0x0f, 0x03, 0x0d, 0x31, 0xb7,
+	0xe4, 0x2d, 0x80, 0x90, 0x4c, 0xa2, 0x8f, 0x81, 0x34, 0x6b, 0x3e, 0x33, 0xb3, 0x4d, 0x8d, 0xe2,
+	0x66, 0x9a, 0xa8, 0x0f, 0x76, 0x2a, 0x90, 0x26, 0x54, 0x04, 0x9f, 0x6b, 0x6a, 0x7c, 0x49, 0xda,
+	0x50, 0xc3, 0xc5, 0x02, 0x3d, 0xa9, 0x07, 0xd2, 0x77, 0xff, 0x38, 0x9b, 0x63, 0xb0, 0x23, 0x14,
+	0xe1, 0x3a, 0xf2, 0x50, 0x38, 0xd5, 0x44, 0xdb, 0x16, 0xe8, 0x07, 0x68, 0x8d, 0x23, 0x64, 0x12,
+	0x53, 0x23, 0xf1, 0x7e, 0x8d, 0x42, 0x92, 0xd7, 0x50, 0xd7, 0x8e, 0xaa, 0xa3, 0x29, 0xf2, 0x3c,
+	0xed, 0xa0, 0x0e, 0xb4, 0xf3, 0x18, 0xb1, 0x0a, 0x03, 0x81, 0xb4, 0x03, 0xad, 0x4f, 0x5c, 0xc8,
+	0x49, 0x9a, 0x0e, 0xfd, 0x02, 0x7a, 0x09, 0xed, 0xbc, 0xa0, 0x1e, 0x79, 0x40, 0xe0, 0x5e, 0x41,
+	0xeb, 0x3d, 0x2e, 0x71, 0x7f, 0x8b, 0x82, 0xf0, 0xc5, 0xb3, 0xe6, 0x9b, 0xf5, 0xac, 0xf7, 0x66,
+	0x8b, 0x73, 0x13, 0x02, 0xc3, 0x79, 0x01, 0x4f, 0xcc, 0xae, 0xb3, 0x0c, 0xf0, 0xb1, 0x29, 0x7e,
+	0x8e, 0x53, 0x3d, 0x02, 0xd8, 0xc6, 0x35, 0xf1, 0xa7, 0x38, 0xd4, 0x99, 0x2e, 0x7a, 0x0d, 0x9d,
+	0xbd, 0x57, 0xea, 0x63, 0xd8, 0xc5, 0x59, 0xff, 0x85, 0xfb, 0x6e, 0x76, 0x7b, 0xd8, 0x06, 0xbb,
+	0x5f, 0xc4, 0x61, 0xee, 0x8b, 0xa0, 0xcf, 0xa1, 0xb3, 0x47, 0x57, 0xc3, 0x8e, 0xfe, 0x54, 0xe0,
+	0x74, 0x8a, 0xec, 0x17, 0xe2, 0xdc, 0x1c, 0xab, 0x6a, 0xba, 0x66, 0x01, 0xbb, 0x53, 0x69, 0xfe,
+	0x02, 0x47, 0xbb, 0x21, 0x21, 0x27, 0x99, 0x75, 0xf6, 0x33, 0xd8, 0xed, 0x95, 0xc9, 0xda, 0xaf,
+	0x83, 0x18, 0xb9, 0x1b, 0xa2, 0x2d, 0xb2, 0x30, 0x75, 0xdd, 0x5e, 0x99, 0x9c, 0x45, 0xee, 0xc6,
+	0x63, 0x8b, 0x2c, 0xcc, 0x58, 0xb7, 0x57, 0x26, 0xa7, 0xc8, 0xaf, 0xf0, 0x34, 0x67, 0x32, 0xc9,
+	0xad, 0x96, 0xb7, 0xab, 0x7b, 0x5a, 0xaa, 0x67, 0xa9, 0x39, 0x37, 0x48, 0x6e, 0x94, 0x72, 0x6a,
+	0x89, 0x8d, 0xf4, 0xe0, 0xe2, 0x18, 0x9a, 0x42, 0xf9, 0xb8, 0x10, 0x43, 0x6f, 0xc9, 0x31, 0x90,
+	0x17, 0xf5, 0x09, 0xf3, 0x6f, 0xe2, 0x3f, 0xc5, 0x6d, 0x2d, 0xf9, 0x61, 0x9c, 0xfd, 0x1d, 0x00,
+	0x13, 0xa4, 0x07, 0xa3, 0x3d, 0x06, 0x00, 0x00,
 }
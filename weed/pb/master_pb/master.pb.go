@@ -432,10 +432,12 @@ type VolumeEcShardInformationMessage struct {
 	EcIndexBits uint32 `protobuf:"varint,3,opt,name=ec_index_bits,json=ecIndexBits" json:"ec_index_bits,omitempty"`
 }
 
-func (m *VolumeEcShardInformationMessage) Reset()                    { *m = VolumeEcShardInformationMessage{} }
-func (m *VolumeEcShardInformationMessage) String() string            { return proto.CompactTextString(m) }
-func (*VolumeEcShardInformationMessage) ProtoMessage()               {}
-func (*VolumeEcShardInformationMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (m *VolumeEcShardInformationMessage) Reset()         { *m = VolumeEcShardInformationMessage{} }
+func (m *VolumeEcShardInformationMessage) String() string { return proto.CompactTextString(m) }
+func (*VolumeEcShardInformationMessage) ProtoMessage()    {}
+func (*VolumeEcShardInformationMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{4}
+}
 
 func (m *VolumeEcShardInformationMessage) GetId() uint32 {
 	if m != nil {
@@ -1046,9 +1048,7 @@ func (m *CollectionDeleteResponse) String() string            { return proto.Com
 func (*CollectionDeleteResponse) ProtoMessage()               {}
 func (*CollectionDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
-//
 // volume related
-//
 type DataNodeInfo struct {
 	Id                string                             `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	VolumeCount       uint64                             `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
@@ -1426,10 +1426,12 @@ type GetMasterConfigurationResponse struct {
 	MetricsIntervalSeconds uint32 `protobuf:"varint,2,opt,name=metrics_interval_seconds,json=metricsIntervalSeconds" json:"metrics_interval_seconds,omitempty"`
 }
 
-func (m *GetMasterConfigurationResponse) Reset()                    { *m = GetMasterConfigurationResponse{} }
-func (m *GetMasterConfigurationResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetMasterConfigurationResponse) ProtoMessage()               {}
-func (*GetMasterConfigurationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (m *GetMasterConfigurationResponse) Reset()         { *m = GetMasterConfigurationResponse{} }
+func (m *GetMasterConfigurationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMasterConfigurationResponse) ProtoMessage()    {}
+func (*GetMasterConfigurationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{32}
+}
 
 func (m *GetMasterConfigurationResponse) GetMetricsAddress() string {
 	if m != nil {
@@ -1478,8 +1480,9 @@ func (m *ListMasterClientsResponse) GetGrpcAddresses() []string {
 }
 
 type LeaseAdminTokenRequest struct {
-	PreviousToken    int64 `protobuf:"varint,1,opt,name=previous_token,json=previousToken" json:"previous_token,omitempty"`
-	PreviousLockTime int64 `protobuf:"varint,2,opt,name=previous_lock_time,json=previousLockTime" json:"previous_lock_time,omitempty"`
+	PreviousToken    int64  `protobuf:"varint,1,opt,name=previous_token,json=previousToken" json:"previous_token,omitempty"`
+	PreviousLockTime int64  `protobuf:"varint,2,opt,name=previous_lock_time,json=previousLockTime" json:"previous_lock_time,omitempty"`
+	LockName         string `protobuf:"bytes,3,opt,name=lock_name,json=lockName" json:"lock_name,omitempty"`
 }
 
 func (m *LeaseAdminTokenRequest) Reset()                    { *m = LeaseAdminTokenRequest{} }
@@ -1501,6 +1504,13 @@ func (m *LeaseAdminTokenRequest) GetPreviousLockTime() int64 {
 	return 0
 }
 
+func (m *LeaseAdminTokenRequest) GetLockName() string {
+	if m != nil {
+		return m.LockName
+	}
+	return ""
+}
+
 type LeaseAdminTokenResponse struct {
 	Token    int64 `protobuf:"varint,1,opt,name=token" json:"token,omitempty"`
 	LockTsNs int64 `protobuf:"varint,2,opt,name=lock_ts_ns,json=lockTsNs" json:"lock_ts_ns,omitempty"`
@@ -1526,8 +1536,9 @@ func (m *LeaseAdminTokenResponse) GetLockTsNs() int64 {
 }
 
 type ReleaseAdminTokenRequest struct {
-	PreviousToken    int64 `protobuf:"varint,1,opt,name=previous_token,json=previousToken" json:"previous_token,omitempty"`
-	PreviousLockTime int64 `protobuf:"varint,2,opt,name=previous_lock_time,json=previousLockTime" json:"previous_lock_time,omitempty"`
+	PreviousToken    int64  `protobuf:"varint,1,opt,name=previous_token,json=previousToken" json:"previous_token,omitempty"`
+	PreviousLockTime int64  `protobuf:"varint,2,opt,name=previous_lock_time,json=previousLockTime" json:"previous_lock_time,omitempty"`
+	LockName         string `protobuf:"bytes,3,opt,name=lock_name,json=lockName" json:"lock_name,omitempty"`
 }
 
 func (m *ReleaseAdminTokenRequest) Reset()                    { *m = ReleaseAdminTokenRequest{} }
@@ -1549,6 +1560,13 @@ func (m *ReleaseAdminTokenRequest) GetPreviousLockTime() int64 {
 	return 0
 }
 
+func (m *ReleaseAdminTokenRequest) GetLockName() string {
+	if m != nil {
+		return m.LockName
+	}
+	return ""
+}
+
 type ReleaseAdminTokenResponse struct {
 }
 
@@ -2138,150 +2156,151 @@ var _Seaweed_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("master.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x59, 0x4b, 0x6f, 0x1c, 0xc7,
-	0xf1, 0xd7, 0xee, 0xf2, 0xb1, 0x5b, 0xcb, 0x7d, 0x35, 0x29, 0x6a, 0xb9, 0x7a, 0x90, 0x1a, 0xdb,
-	0x30, 0xa5, 0xbf, 0xff, 0x8c, 0x43, 0x1b, 0x88, 0x11, 0xc7, 0x30, 0x28, 0x8a, 0x56, 0x08, 0x91,
-	0xb4, 0x34, 0x64, 0x64, 0xc0, 0x40, 0x30, 0xee, 0x9d, 0x69, 0x52, 0x03, 0xce, 0x2b, 0xd3, 0xbd,
-	0x14, 0xd7, 0xb9, 0x04, 0xc8, 0x31, 0xa7, 0x20, 0x87, 0x7c, 0x85, 0x5c, 0x72, 0x4a, 0xce, 0xbe,
-	0xe4, 0x1b, 0xe5, 0x92, 0x83, 0x2f, 0x41, 0xbf, 0x66, 0x7a, 0x66, 0x77, 0x49, 0xd3, 0x80, 0x03,
-	0xe8, 0x36, 0x5d, 0x55, 0x5d, 0x5d, 0xf3, 0xab, 0xee, 0xaa, 0x5f, 0xcf, 0xc0, 0x52, 0x88, 0x29,
-	0x23, 0xe9, 0x56, 0x92, 0xc6, 0x2c, 0x46, 0x0d, 0x39, 0x72, 0x92, 0xa1, 0xf5, 0xa7, 0x05, 0x68,
-	0xfc, 0x9a, 0xe0, 0x94, 0x0d, 0x09, 0x66, 0xa8, 0x0d, 0x55, 0x3f, 0xe9, 0x57, 0x36, 0x2a, 0x9b,
-	0x0d, 0xbb, 0xea, 0x27, 0x08, 0xc1, 0x5c, 0x12, 0xa7, 0xac, 0x5f, 0xdd, 0xa8, 0x6c, 0xb6, 0x6c,
-	0xf1, 0x8c, 0xee, 0x03, 0x24, 0xa3, 0x61, 0xe0, 0xbb, 0xce, 0x28, 0x0d, 0xfa, 0x35, 0x61, 0xdb,
-	0x90, 0x92, 0xdf, 0xa4, 0x01, 0xda, 0x84, 0x6e, 0x88, 0x2f, 0x9d, 0x8b, 0x38, 0x18, 0x85, 0xc4,
-	0x71, 0xe3, 0x51, 0xc4, 0xfa, 0x73, 0x62, 0x7a, 0x3b, 0xc4, 0x97, 0xaf, 0x84, 0x78, 0x97, 0x4b,
-	0xd1, 0x06, 0x8f, 0xea, 0xd2, 0x39, 0xf5, 0x03, 0xe2, 0x9c, 0x93, 0x71, 0x7f, 0x7e, 0xa3, 0xb2,
-	0x39, 0x67, 0x43, 0x88, 0x2f, 0xbf, 0xf0, 0x03, 0xf2, 0x9c, 0x8c, 0xd1, 0x3a, 0x34, 0x3d, 0xcc,
-	0xb0, 0xe3, 0x92, 0x88, 0x91, 0xb4, 0xbf, 0x20, 0xd6, 0x02, 0x2e, 0xda, 0x15, 0x12, 0x1e, 0x5f,
-	0x8a, 0xdd, 0xf3, 0xfe, 0xa2, 0xd0, 0x88, 0x67, 0x1e, 0x1f, 0xf6, 0x42, 0x3f, 0x72, 0x44, 0xe4,
-	0x75, 0xb1, 0x74, 0x43, 0x48, 0x5e, 0xf0, 0xf0, 0x3f, 0x83, 0x45, 0x19, 0x1b, 0xed, 0x37, 0x36,
-	0x6a, 0x9b, 0xcd, 0xed, 0x77, 0xb6, 0x32, 0x34, 0xb6, 0x64, 0x78, 0xfb, 0xd1, 0x69, 0x9c, 0x86,
-	0x98, 0xf9, 0x71, 0x74, 0x48, 0x28, 0xc5, 0x67, 0xc4, 0xd6, 0x73, 0xd0, 0x3e, 0x34, 0x23, 0xf2,
-	0xc6, 0xd1, 0x2e, 0x40, 0xb8, 0xd8, 0x9c, 0x70, 0x71, 0xfc, 0x3a, 0x4e, 0xd9, 0x14, 0x3f, 0x10,
-	0x91, 0x37, 0xaf, 0x94, 0xab, 0x97, 0xd0, 0xf1, 0x48, 0x40, 0x18, 0xf1, 0x32, 0x77, 0xcd, 0x1b,
-	0xba, 0x6b, 0x2b, 0x07, 0xda, 0xe5, 0xbb, 0xd0, 0x7e, 0x8d, 0xa9, 0x13, 0xc5, 0x99, 0xc7, 0xa5,
-	0x8d, 0xca, 0x66, 0xdd, 0x5e, 0x7a, 0x8d, 0xe9, 0x51, 0xac, 0xad, 0x9e, 0x41, 0x83, 0xb8, 0x0e,
-	0x7d, 0x8d, 0x53, 0x8f, 0xf6, 0xbb, 0x62, 0xc9, 0xc7, 0x13, 0x4b, 0xee, 0xb9, 0xc7, 0xdc, 0x60,
-	0xca, 0xa2, 0x75, 0x22, 0x55, 0x14, 0x1d, 0x41, 0x8b, 0x83, 0x91, 0x3b, 0xeb, 0xdd, 0xd8, 0x19,
-	0x47, 0x73, 0x4f, 0xfb, 0x7b, 0x05, 0x3d, 0x8d, 0x48, 0xee, 0x13, 0xdd, 0xd8, 0xa7, 0x86, 0x35,
-	0xf3, 0xfb, 0x3e, 0x74, 0x15, 0x2c, 0xb9, 0xdb, 0x65, 0x01, 0x4c, 0x4b, 0x00, 0xa3, 0x0d, 0xad,
-	0x3f, 0x54, 0xa1, 0x97, 0x9d, 0x06, 0x9b, 0xd0, 0x24, 0x8e, 0x28, 0x41, 0x8f, 0xa1, 0xa7, 0xb6,
-	0x33, 0xf5, 0xbf, 0x25, 0x4e, 0xe0, 0x87, 0x3e, 0x13, 0x87, 0x64, 0xce, 0xee, 0x48, 0xc5, 0xb1,
-	0xff, 0x2d, 0x39, 0xe0, 0x62, 0xb4, 0x0a, 0x0b, 0x01, 0xc1, 0x1e, 0x49, 0xc5, 0x99, 0x69, 0xd8,
-	0x6a, 0x84, 0xde, 0x87, 0x4e, 0x48, 0x58, 0xea, 0xbb, 0xd4, 0xc1, 0x9e, 0x97, 0x12, 0x4a, 0xd5,
-	0xd1, 0x69, 0x2b, 0xf1, 0x8e, 0x94, 0xa2, 0x4f, 0xa0, 0xaf, 0x0d, 0x7d, 0xbe, 0xc7, 0x2f, 0x70,
-	0xe0, 0x50, 0xe2, 0xc6, 0x91, 0x47, 0xd5, 0x39, 0x5a, 0x55, 0xfa, 0x7d, 0xa5, 0x3e, 0x96, 0x5a,
-	0xf4, 0x14, 0xba, 0x94, 0xc5, 0x29, 0x3e, 0x23, 0xce, 0x10, 0xbb, 0xe7, 0x84, 0xcf, 0x98, 0x17,
-	0xe0, 0xad, 0x19, 0xe0, 0x1d, 0x4b, 0x93, 0x27, 0xd2, 0xc2, 0xee, 0xd0, 0xc2, 0x98, 0x5a, 0xdf,
-	0xd7, 0xa0, 0x3f, 0xeb, 0x18, 0x88, 0xfa, 0xe0, 0x89, 0x57, 0x6f, 0xd9, 0x55, 0xdf, 0xe3, 0xe7,
-	0x8f, 0x43, 0x22, 0xde, 0x75, 0xce, 0x16, 0xcf, 0xe8, 0x01, 0x80, 0x1b, 0x07, 0x01, 0x71, 0xf9,
-	0x44, 0xf5, 0x92, 0x86, 0x84, 0x9f, 0x4f, 0x71, 0xe4, 0xf3, 0xd2, 0x30, 0x67, 0x37, 0xb8, 0x44,
-	0x56, 0x85, 0x87, 0xb0, 0x24, 0xd3, 0xa7, 0x0c, 0x64, 0x55, 0x68, 0x4a, 0x99, 0x34, 0xf9, 0x00,
-	0x90, 0xde, 0x26, 0xc3, 0x71, 0x66, 0xb8, 0x20, 0x0c, 0xbb, 0x4a, 0xf3, 0x64, 0xac, 0xad, 0xef,
-	0x42, 0x23, 0x25, 0xd8, 0x73, 0xe2, 0x28, 0x18, 0x8b, 0x42, 0x51, 0xb7, 0xeb, 0x5c, 0xf0, 0x65,
-	0x14, 0x8c, 0xd1, 0xff, 0x41, 0x2f, 0x25, 0x49, 0xe0, 0xbb, 0xd8, 0x49, 0x02, 0xec, 0x92, 0x90,
-	0x44, 0xba, 0x66, 0x74, 0x95, 0xe2, 0x85, 0x96, 0xa3, 0x3e, 0x2c, 0x5e, 0x90, 0x94, 0xf2, 0xd7,
-	0x6a, 0x08, 0x13, 0x3d, 0x44, 0x5d, 0xa8, 0x31, 0x16, 0xf4, 0x41, 0x48, 0xf9, 0x23, 0x7a, 0x04,
-	0x5d, 0x37, 0x0e, 0x13, 0xec, 0x32, 0x27, 0x25, 0x17, 0xbe, 0x98, 0xd4, 0x14, 0xea, 0x8e, 0x92,
-	0xdb, 0x4a, 0xcc, 0x5f, 0x27, 0x8c, 0x3d, 0xff, 0xd4, 0x27, 0x9e, 0x83, 0x99, 0x4a, 0xb6, 0x38,
-	0xb8, 0x35, 0xbb, 0xab, 0x35, 0x3b, 0x4c, 0xa6, 0x19, 0x6d, 0xc1, 0x72, 0x4a, 0xc2, 0x98, 0x11,
-	0x47, 0x27, 0x3b, 0xc2, 0x21, 0xe9, 0xb7, 0x04, 0xce, 0x3d, 0xa9, 0x52, 0x39, 0x3e, 0xc2, 0x21,
-	0xe1, 0xde, 0x4b, 0xf6, 0xbc, 0xd6, 0xb6, 0x85, 0x79, 0xb7, 0x60, 0xfe, 0x9c, 0x8c, 0xad, 0xbf,
-	0x55, 0xe0, 0xfe, 0x95, 0x25, 0x67, 0x62, 0x0b, 0x5c, 0x97, 0xee, 0x9f, 0x0a, 0x61, 0x6b, 0x04,
-	0xeb, 0xd7, 0x14, 0x82, 0x6b, 0x62, 0xad, 0x4e, 0xc4, 0x6a, 0x41, 0x8b, 0xb8, 0x8e, 0x1f, 0x79,
-	0xe4, 0xd2, 0x19, 0xfa, 0x4c, 0x1e, 0xd1, 0x96, 0xdd, 0x24, 0xee, 0x3e, 0x97, 0x3d, 0xf1, 0x19,
-	0xb5, 0xbe, 0xab, 0x40, 0xbb, 0x78, 0x86, 0xf8, 0x29, 0x60, 0xe3, 0x84, 0xa8, 0xbe, 0x29, 0x9e,
-	0xd5, 0xd2, 0x55, 0xd5, 0x49, 0x3d, 0xb4, 0x0f, 0x90, 0xa4, 0x71, 0x42, 0x52, 0xe6, 0x13, 0xee,
-	0x97, 0x1f, 0xcb, 0x47, 0x33, 0x8f, 0xe5, 0xd6, 0x8b, 0xcc, 0x76, 0x2f, 0x62, 0xe9, 0xd8, 0x36,
-	0x26, 0x0f, 0x3e, 0x83, 0x4e, 0x49, 0xcd, 0xd1, 0xe1, 0x59, 0x95, 0x01, 0xf0, 0x47, 0xb4, 0x02,
-	0xf3, 0x17, 0x38, 0x18, 0x11, 0x15, 0x82, 0x1c, 0xfc, 0xb2, 0xfa, 0x49, 0xc5, 0x5a, 0x84, 0xf9,
-	0xbd, 0x30, 0x61, 0x63, 0xfe, 0x26, 0x9d, 0xe3, 0x51, 0x42, 0xd2, 0x27, 0x41, 0xec, 0x9e, 0xef,
-	0x5d, 0xb2, 0x14, 0xa3, 0x2f, 0xa1, 0x4d, 0x52, 0x4c, 0x47, 0x29, 0x3f, 0x55, 0x9e, 0x1f, 0x9d,
-	0x09, 0x9f, 0xc5, 0x96, 0x54, 0x9a, 0xb3, 0xb5, 0x27, 0x27, 0xec, 0x0a, 0x7b, 0xbb, 0x45, 0xcc,
-	0xe1, 0xe0, 0x6b, 0x68, 0x15, 0xf4, 0x1c, 0x2c, 0xde, 0xc0, 0x55, 0x56, 0xc4, 0x33, 0x2f, 0x9a,
-	0x09, 0x4e, 0x7d, 0x36, 0x56, 0x44, 0x43, 0x8d, 0x78, 0xa9, 0x50, 0x85, 0xd7, 0xf7, 0x24, 0x68,
-	0x2d, 0xbb, 0x21, 0x25, 0xfb, 0x1e, 0xb5, 0x9e, 0xc1, 0xca, 0x73, 0x42, 0x92, 0xdd, 0x38, 0x8a,
-	0x88, 0xcb, 0x88, 0x67, 0x93, 0xdf, 0x8d, 0x08, 0x65, 0x7c, 0x09, 0x71, 0x26, 0x54, 0x3e, 0xf8,
-	0x33, 0xaf, 0x02, 0x67, 0x69, 0xe2, 0x3a, 0x06, 0x9d, 0xa9, 0x73, 0x01, 0xe7, 0x04, 0xd6, 0x5f,
-	0x2b, 0xd0, 0x96, 0x7b, 0xe9, 0x20, 0x76, 0xc5, 0x0e, 0xe2, 0x88, 0x72, 0x7a, 0xa3, 0x10, 0x1d,
-	0xa5, 0x41, 0x89, 0xf7, 0x54, 0xcb, 0xbc, 0x67, 0x0d, 0xea, 0x82, 0x18, 0xe4, 0x91, 0x2e, 0xf2,
-	0x5e, 0xef, 0x7b, 0x34, 0x2f, 0x69, 0x9e, 0x54, 0xcf, 0x09, 0x75, 0x53, 0xf7, 0x6e, 0x6e, 0x92,
-	0xb7, 0x8d, 0x79, 0xb3, 0x6d, 0x58, 0x27, 0xb0, 0x7c, 0x10, 0xc7, 0xe7, 0xa3, 0x44, 0x86, 0xa7,
-	0xdf, 0xb0, 0x08, 0x4c, 0x65, 0xa3, 0xc6, 0x63, 0xc9, 0x80, 0xb9, 0x6e, 0x9f, 0x5b, 0xff, 0xae,
-	0xc0, 0x4a, 0xd1, 0xad, 0xea, 0x74, 0xdf, 0xc0, 0x72, 0xe6, 0xd7, 0x09, 0x14, 0x16, 0x72, 0x81,
-	0xe6, 0xf6, 0x87, 0xc6, 0x1e, 0x98, 0x36, 0x5b, 0xb3, 0x27, 0x4f, 0x83, 0x68, 0xf7, 0x2e, 0x4a,
-	0x12, 0x3a, 0xb8, 0x84, 0x6e, 0xd9, 0x8c, 0xe7, 0x26, 0x5b, 0x55, 0x21, 0x5e, 0xd7, 0x33, 0xd1,
-	0xcf, 0xa1, 0x91, 0x07, 0x52, 0x15, 0x81, 0x2c, 0x17, 0x02, 0x51, 0x6b, 0xe5, 0x56, 0x7c, 0xef,
-	0x93, 0x34, 0x8d, 0x53, 0x55, 0x8d, 0xe4, 0xc0, 0xfa, 0x14, 0xea, 0x3f, 0x3a, 0xbb, 0xd6, 0x3f,
-	0xaa, 0xd0, 0xda, 0xa1, 0xd4, 0x3f, 0x8b, 0x74, 0x0a, 0x56, 0x60, 0x5e, 0xf6, 0x1d, 0x49, 0x04,
-	0xe4, 0x00, 0x6d, 0x40, 0x53, 0x15, 0x35, 0x03, 0x7a, 0x53, 0x74, 0x6d, 0xbd, 0x54, 0x85, 0x6e,
-	0x4e, 0x86, 0xc6, 0x5b, 0x49, 0x89, 0x05, 0xcf, 0xcf, 0x64, 0xc1, 0x0b, 0x06, 0x0b, 0xbe, 0x0b,
-	0x0d, 0x31, 0x29, 0x8a, 0x3d, 0xa2, 0xe8, 0x71, 0x9d, 0x0b, 0x8e, 0x62, 0x8f, 0xa0, 0x6d, 0x58,
-	0x0d, 0x49, 0x18, 0xa7, 0x63, 0x27, 0xc4, 0x89, 0xc3, 0x49, 0xb8, 0x20, 0x36, 0xe1, 0x50, 0x15,
-	0x66, 0x24, 0xb5, 0x87, 0x38, 0x39, 0xc4, 0x97, 0x9c, 0xdb, 0x1c, 0x0e, 0xd1, 0x36, 0xdc, 0xfe,
-	0x2a, 0xf5, 0x19, 0x1e, 0x06, 0xa4, 0x48, 0xee, 0x65, 0xa1, 0x5e, 0xd6, 0x4a, 0x83, 0xe1, 0x5b,
-	0x7f, 0xa9, 0x40, 0x5b, 0xa3, 0xa6, 0x76, 0x58, 0x17, 0x6a, 0xa7, 0x59, 0x96, 0xf9, 0xa3, 0xce,
-	0x45, 0x75, 0x56, 0x2e, 0x26, 0x6e, 0x18, 0x19, 0xf2, 0x73, 0x26, 0xf2, 0x59, 0xd2, 0xe7, 0x8d,
-	0xa4, 0x73, 0x68, 0xf0, 0x88, 0xbd, 0xd6, 0xd0, 0xf0, 0x67, 0xeb, 0x0c, 0x7a, 0xc7, 0x0c, 0x33,
-	0x9f, 0x32, 0xdf, 0xa5, 0x3a, 0x9d, 0xa5, 0xc4, 0x55, 0xae, 0x4b, 0x5c, 0x75, 0x56, 0xe2, 0x6a,
-	0x59, 0xe2, 0xac, 0x7f, 0x55, 0x00, 0x99, 0x2b, 0x29, 0x08, 0x7e, 0x82, 0xa5, 0x38, 0x64, 0x2c,
-	0x66, 0x9c, 0x2a, 0x72, 0x3a, 0xa6, 0x48, 0x95, 0x90, 0xf0, 0xf4, 0xf1, 0xdd, 0x30, 0xa2, 0xc4,
-	0x93, 0x5a, 0xc9, 0xa8, 0xea, 0x5c, 0x20, 0x94, 0x45, 0x42, 0xb6, 0x50, 0x22, 0x64, 0xd6, 0x0e,
-	0x34, 0x55, 0x73, 0x3a, 0xe1, 0x8d, 0xed, 0xfa, 0xe8, 0x55, 0x74, 0xd5, 0x1c, 0x88, 0x0d, 0x80,
-	0xdd, 0x3c, 0xfa, 0x29, 0xe5, 0xd9, 0xfa, 0x3d, 0xdc, 0xce, 0x2d, 0x0e, 0x7c, 0xca, 0x74, 0x5e,
-	0x3e, 0x86, 0x55, 0x3f, 0x72, 0x83, 0x91, 0x47, 0x9c, 0x88, 0xb7, 0xf7, 0x20, 0xbb, 0xd9, 0x54,
-	0x04, 0x95, 0x5b, 0x51, 0xda, 0x23, 0xa1, 0xd4, 0x37, 0x9c, 0x0f, 0x00, 0xe9, 0x59, 0xc4, 0xcd,
-	0x66, 0x54, 0xc5, 0x8c, 0xae, 0xd2, 0xec, 0xb9, 0xca, 0xda, 0x7a, 0x09, 0xab, 0xe5, 0xc5, 0x55,
-	0xaa, 0x7e, 0x01, 0xcd, 0x1c, 0x76, 0x5d, 0x07, 0x6f, 0x1b, 0xe5, 0x27, 0x9f, 0x67, 0x9b, 0x96,
-	0xd6, 0xff, 0xc3, 0x9d, 0x5c, 0xf5, 0x54, 0x14, 0xfa, 0x2b, 0xba, 0x93, 0x35, 0x80, 0xfe, 0xa4,
-	0xb9, 0x8c, 0xc1, 0xfa, 0x73, 0x0d, 0x96, 0x9e, 0xaa, 0x93, 0xcb, 0x39, 0x8e, 0xc1, 0x6a, 0x24,
-	0xb5, 0x78, 0x08, 0x4b, 0x85, 0x03, 0x29, 0xc9, 0x78, 0xf3, 0xc2, 0xb8, 0x6a, 0x4f, 0xbb, 0x94,
-	0xd7, 0x84, 0x59, 0xf9, 0x52, 0xfe, 0x18, 0x7a, 0xa7, 0x29, 0x21, 0x93, 0xf7, 0xf7, 0x39, 0xbb,
-	0xc3, 0x15, 0xa6, 0xed, 0x16, 0x2c, 0x63, 0x97, 0xf9, 0x17, 0x25, 0x6b, 0xb9, 0xbf, 0x7a, 0x52,
-	0x65, 0xda, 0x7f, 0x91, 0x05, 0xea, 0x47, 0xa7, 0x31, 0xed, 0x2f, 0xfc, 0xf0, 0xfb, 0xb7, 0x7a,
-	0x1b, 0xae, 0xa1, 0xe8, 0x05, 0xb4, 0xf5, 0x3d, 0x4e, 0x79, 0x5a, 0xbc, 0xf1, 0x1d, 0x71, 0x89,
-	0xe4, 0x2a, 0x6a, 0x90, 0xea, 0xc2, 0x9b, 0xd4, 0xe5, 0x9b, 0x48, 0x95, 0x59, 0xd8, 0xfe, 0x59,
-	0x85, 0xba, 0x8d, 0xdd, 0xf3, 0xb7, 0x3b, 0x1f, 0x9f, 0x43, 0x27, 0xeb, 0x11, 0x85, 0x94, 0xdc,
-	0x31, 0x80, 0x34, 0xb7, 0x9e, 0xdd, 0xf2, 0x8c, 0xd1, 0x4c, 0xd8, 0x16, 0x67, 0xc1, 0xf6, 0xf7,
-	0x2a, 0xb4, 0x9f, 0x66, 0x7d, 0xeb, 0xed, 0x06, 0x6f, 0x1b, 0x80, 0x37, 0xda, 0x02, 0x6e, 0x26,
-	0x31, 0xd1, 0xdb, 0xc3, 0x6e, 0xa4, 0xea, 0xe9, 0xe6, 0x78, 0x7d, 0x57, 0x85, 0xa5, 0x93, 0x38,
-	0x89, 0x83, 0xf8, 0x6c, 0xfc, 0x76, 0xa3, 0xb5, 0x07, 0x3d, 0x83, 0xc3, 0x14, 0x40, 0x5b, 0x2b,
-	0x6d, 0xb6, 0x7c, 0x73, 0xd8, 0x1d, 0xaf, 0x30, 0xbe, 0x39, 0x80, 0xcb, 0xd0, 0x53, 0xbc, 0x3e,
-	0x6f, 0x29, 0xd6, 0x1f, 0x2b, 0x80, 0x4c, 0xa9, 0xaa, 0xf5, 0xbf, 0x82, 0x16, 0x53, 0x58, 0x8b,
-	0xf8, 0xd4, 0xcd, 0xc7, 0x3c, 0x0b, 0x66, 0x2e, 0xec, 0x25, 0x66, 0x66, 0xe6, 0x67, 0xb0, 0x32,
-	0xf1, 0x8d, 0x88, 0x13, 0x2a, 0x99, 0x91, 0x5e, 0xe9, 0x33, 0xd1, 0xe1, 0xd0, 0xfa, 0x18, 0x6e,
-	0x4b, 0x12, 0xad, 0xfb, 0x90, 0xee, 0x0f, 0x13, 0x6c, 0xb8, 0x95, 0xb3, 0x61, 0xeb, 0xfb, 0x0a,
-	0xac, 0x96, 0xa7, 0xa9, 0xf8, 0xaf, 0x9a, 0x87, 0x30, 0x20, 0x55, 0x2f, 0x4d, 0x5e, 0x2f, 0xe9,
-	0xf4, 0x47, 0x13, 0xbc, 0xbe, 0xec, 0x7b, 0x4b, 0xd7, 0xd1, 0x9c, 0xda, 0x77, 0x69, 0x51, 0x40,
-	0x07, 0x18, 0x7a, 0x13, 0x66, 0xfc, 0x56, 0xa4, 0xd7, 0x55, 0x31, 0x2d, 0xaa, 0x89, 0x3f, 0x82,
-	0xd8, 0x5b, 0xeb, 0x70, 0xff, 0x19, 0x61, 0x87, 0xc2, 0x66, 0x37, 0x8e, 0x4e, 0xfd, 0xb3, 0x51,
-	0x2a, 0x8d, 0xf2, 0xd4, 0x3e, 0x98, 0x65, 0xa1, 0x60, 0x9a, 0xf2, 0x21, 0xae, 0x72, 0xe3, 0x0f,
-	0x71, 0xd5, 0xab, 0x3e, 0xc4, 0x59, 0x9f, 0x42, 0x9f, 0xef, 0x2c, 0x15, 0x45, 0xe0, 0x93, 0x88,
-	0x65, 0x3c, 0x73, 0x1d, 0x9a, 0xae, 0x90, 0x38, 0xc6, 0x27, 0x03, 0x90, 0x22, 0xce, 0xaf, 0xac,
-	0x27, 0xb0, 0x36, 0x65, 0xb2, 0x0a, 0xfe, 0x3d, 0x68, 0x8b, 0x5b, 0xac, 0x8a, 0x9c, 0xe8, 0xbb,
-	0x5f, 0x8b, 0x4b, 0x77, 0xb4, 0xd0, 0x0a, 0x61, 0xf5, 0x80, 0x60, 0x4a, 0x76, 0xbc, 0xd0, 0x8f,
-	0x4e, 0xe2, 0x73, 0x92, 0xdd, 0x5a, 0xde, 0x83, 0x76, 0x92, 0x92, 0x0b, 0x3f, 0x1e, 0x51, 0x87,
-	0x71, 0x85, 0x88, 0xa0, 0x66, 0xb7, 0xb4, 0x54, 0x58, 0x73, 0xfe, 0x94, 0x99, 0xf1, 0x8b, 0xbe,
-	0xc3, 0xfc, 0x50, 0x7e, 0x4a, 0xa8, 0xd9, 0x5d, 0xad, 0x39, 0x88, 0xdd, 0xf3, 0x13, 0x3f, 0x24,
-	0xd6, 0x21, 0xdc, 0x99, 0x58, 0x4e, 0x05, 0xbc, 0x02, 0xf3, 0xe6, 0x32, 0x72, 0x80, 0xee, 0x01,
-	0x48, 0xaf, 0xd4, 0x89, 0xa8, 0x72, 0x5b, 0xe7, 0x92, 0x13, 0x7a, 0x44, 0xad, 0x18, 0xfa, 0x36,
-	0x09, 0xfe, 0x87, 0xf1, 0xdf, 0x85, 0xb5, 0x29, 0x0b, 0xca, 0x37, 0xd8, 0xfe, 0x4f, 0x1d, 0x16,
-	0x8f, 0x09, 0x7e, 0x43, 0x88, 0x87, 0xf6, 0xa1, 0x75, 0x4c, 0x22, 0x2f, 0xff, 0x5f, 0xb2, 0x62,
-	0x6c, 0xd8, 0x4c, 0x3a, 0xb8, 0x37, 0x4d, 0x9a, 0xf1, 0xb9, 0x5b, 0x9b, 0x95, 0x0f, 0x2b, 0xe8,
-	0x25, 0xb4, 0x0a, 0xdf, 0x2e, 0xd0, 0xba, 0x31, 0x69, 0xda, 0x57, 0x8d, 0xc1, 0xda, 0x04, 0xbb,
-	0xd1, 0x47, 0x24, 0x73, 0xb9, 0x64, 0x5e, 0xcb, 0xd1, 0x83, 0x99, 0xf7, 0x75, 0xe9, 0x70, 0xfd,
-	0x9a, 0xfb, 0xbc, 0x75, 0x0b, 0x7d, 0x0e, 0x0b, 0xf2, 0xfe, 0x86, 0xfa, 0x86, 0x71, 0xe1, 0x22,
-	0x5c, 0x88, 0xab, 0x78, 0xd9, 0xb3, 0x6e, 0xa1, 0xe7, 0x00, 0xf9, 0x0d, 0x08, 0xdd, 0x2b, 0x7c,
-	0xf0, 0x2a, 0x5d, 0xc1, 0x06, 0xf7, 0x67, 0x68, 0x33, 0x67, 0x5f, 0x41, 0xbb, 0xc8, 0xd3, 0xd1,
-	0xc6, 0x54, 0x2a, 0x6e, 0x14, 0xfb, 0xc1, 0xc3, 0x2b, 0x2c, 0x32, 0xc7, 0xbf, 0x85, 0x6e, 0x99,
-	0x7e, 0x23, 0x6b, 0xea, 0xc4, 0x02, 0x95, 0x1f, 0xbc, 0x73, 0xa5, 0x8d, 0x09, 0x42, 0xde, 0x6f,
-	0x0a, 0x20, 0x4c, 0x34, 0xa7, 0x02, 0x08, 0x93, 0x4d, 0x4a, 0x82, 0x50, 0x2c, 0xd2, 0x05, 0x10,
-	0xa6, 0xb6, 0x94, 0x02, 0x08, 0xd3, 0x2b, 0xbc, 0x75, 0x0b, 0xc5, 0xb0, 0x3a, 0xbd, 0x74, 0x22,
-	0xf3, 0xe3, 0xdf, 0x95, 0xf5, 0x77, 0xf0, 0xe8, 0x07, 0x58, 0x66, 0x0b, 0x7e, 0x03, 0xbd, 0x89,
-	0x4a, 0x87, 0x4c, 0x48, 0x67, 0x15, 0xd1, 0xc1, 0xbb, 0x57, 0x1b, 0x65, 0x2b, 0x7c, 0x0d, 0x9d,
-	0x52, 0x61, 0x42, 0x05, 0x28, 0xa6, 0xd6, 0x98, 0x81, 0x75, 0x95, 0x89, 0x19, 0xfd, 0x44, 0xd1,
-	0x28, 0x44, 0x3f, 0xab, 0x86, 0x15, 0xa2, 0x9f, 0x59, 0x77, 0xac, 0x5b, 0xc3, 0x05, 0xf1, 0xb3,
-	0xf6, 0xa3, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x9c, 0x7a, 0x09, 0x55, 0xbc, 0x1d, 0x00, 0x00,
+	// 2326 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0x4b, 0x6f, 0x1c, 0xc7,
+	0xf1, 0xf7, 0xee, 0xf2, 0xb1, 0x5b, 0xcb, 0x5d, 0xee, 0x36, 0x29, 0x6a, 0xb9, 0x7a, 0x90, 0x1a,
+	0xcb, 0x30, 0xa5, 0xbf, 0xff, 0x8c, 0x43, 0x1b, 0x88, 0x11, 0xc7, 0x30, 0x28, 0x8a, 0x56, 0x08,
+	0x89, 0xb4, 0x34, 0x64, 0x64, 0xc0, 0x40, 0x30, 0xee, 0x9d, 0x69, 0x52, 0x03, 0xce, 0x2b, 0xd3,
+	0xbd, 0x14, 0xd7, 0xb9, 0x04, 0xc8, 0x2d, 0xc9, 0x25, 0xc8, 0x21, 0x5f, 0x21, 0x97, 0x9c, 0x92,
+	0xb3, 0x2f, 0xf9, 0x46, 0xb9, 0xe4, 0xe0, 0x4b, 0xd0, 0xaf, 0x99, 0x9e, 0xd9, 0x07, 0x45, 0x03,
+	0x06, 0xa2, 0xdb, 0x74, 0x55, 0x75, 0x75, 0xf5, 0xaf, 0xba, 0xab, 0x7f, 0xdd, 0x03, 0x4b, 0x21,
+	0xa6, 0x8c, 0xa4, 0xdb, 0x49, 0x1a, 0xb3, 0x18, 0x35, 0x64, 0xcb, 0x49, 0x06, 0xd6, 0x1f, 0x17,
+	0xa0, 0xf1, 0x4b, 0x82, 0x53, 0x36, 0x20, 0x98, 0xa1, 0x36, 0x54, 0xfd, 0xa4, 0x57, 0xd9, 0xac,
+	0x6c, 0x35, 0xec, 0xaa, 0x9f, 0x20, 0x04, 0x73, 0x49, 0x9c, 0xb2, 0x5e, 0x75, 0xb3, 0xb2, 0xd5,
+	0xb2, 0xc5, 0x37, 0xba, 0x03, 0x90, 0x0c, 0x07, 0x81, 0xef, 0x3a, 0xc3, 0x34, 0xe8, 0xd5, 0x84,
+	0x6d, 0x43, 0x4a, 0x7e, 0x95, 0x06, 0x68, 0x0b, 0x3a, 0x21, 0xbe, 0x74, 0x2e, 0xe2, 0x60, 0x18,
+	0x12, 0xc7, 0x8d, 0x87, 0x11, 0xeb, 0xcd, 0x89, 0xee, 0xed, 0x10, 0x5f, 0xbe, 0x14, 0xe2, 0x3d,
+	0x2e, 0x45, 0x9b, 0x3c, 0xaa, 0x4b, 0xe7, 0xd4, 0x0f, 0x88, 0x73, 0x4e, 0x46, 0xbd, 0xf9, 0xcd,
+	0xca, 0xd6, 0x9c, 0x0d, 0x21, 0xbe, 0xfc, 0xc2, 0x0f, 0xc8, 0x53, 0x32, 0x42, 0x1b, 0xd0, 0xf4,
+	0x30, 0xc3, 0x8e, 0x4b, 0x22, 0x46, 0xd2, 0xde, 0x82, 0x18, 0x0b, 0xb8, 0x68, 0x4f, 0x48, 0x78,
+	0x7c, 0x29, 0x76, 0xcf, 0x7b, 0x8b, 0x42, 0x23, 0xbe, 0x79, 0x7c, 0xd8, 0x0b, 0xfd, 0xc8, 0x11,
+	0x91, 0xd7, 0xc5, 0xd0, 0x0d, 0x21, 0x79, 0xce, 0xc3, 0xff, 0x0c, 0x16, 0x65, 0x6c, 0xb4, 0xd7,
+	0xd8, 0xac, 0x6d, 0x35, 0x77, 0xde, 0xdd, 0xce, 0xd0, 0xd8, 0x96, 0xe1, 0x1d, 0x44, 0xa7, 0x71,
+	0x1a, 0x62, 0xe6, 0xc7, 0xd1, 0x21, 0xa1, 0x14, 0x9f, 0x11, 0x5b, 0xf7, 0x41, 0x07, 0xd0, 0x8c,
+	0xc8, 0x6b, 0x47, 0xbb, 0x00, 0xe1, 0x62, 0x6b, 0xcc, 0xc5, 0xf1, 0xab, 0x38, 0x65, 0x13, 0xfc,
+	0x40, 0x44, 0x5e, 0xbf, 0x54, 0xae, 0x5e, 0xc0, 0xb2, 0x47, 0x02, 0xc2, 0x88, 0x97, 0xb9, 0x6b,
+	0x5e, 0xd3, 0x5d, 0x5b, 0x39, 0xd0, 0x2e, 0xef, 0x43, 0xfb, 0x15, 0xa6, 0x4e, 0x14, 0x67, 0x1e,
+	0x97, 0x36, 0x2b, 0x5b, 0x75, 0x7b, 0xe9, 0x15, 0xa6, 0x47, 0xb1, 0xb6, 0x7a, 0x02, 0x0d, 0xe2,
+	0x3a, 0xf4, 0x15, 0x4e, 0x3d, 0xda, 0xeb, 0x88, 0x21, 0x1f, 0x8e, 0x0d, 0xb9, 0xef, 0x1e, 0x73,
+	0x83, 0x09, 0x83, 0xd6, 0x89, 0x54, 0x51, 0x74, 0x04, 0x2d, 0x0e, 0x46, 0xee, 0xac, 0x7b, 0x6d,
+	0x67, 0x1c, 0xcd, 0x7d, 0xed, 0xef, 0x25, 0x74, 0x35, 0x22, 0xb9, 0x4f, 0x74, 0x6d, 0x9f, 0x1a,
+	0xd6, 0xcc, 0xef, 0xfb, 0xd0, 0x51, 0xb0, 0xe4, 0x6e, 0x57, 0x04, 0x30, 0x2d, 0x01, 0x8c, 0x36,
+	0xb4, 0x7e, 0x57, 0x85, 0x6e, 0xb6, 0x1b, 0x6c, 0x42, 0x93, 0x38, 0xa2, 0x04, 0x3d, 0x84, 0xae,
+	0x5a, 0xce, 0xd4, 0xff, 0x96, 0x38, 0x81, 0x1f, 0xfa, 0x4c, 0x6c, 0x92, 0x39, 0x7b, 0x59, 0x2a,
+	0x8e, 0xfd, 0x6f, 0xc9, 0x33, 0x2e, 0x46, 0x6b, 0xb0, 0x10, 0x10, 0xec, 0x91, 0x54, 0xec, 0x99,
+	0x86, 0xad, 0x5a, 0xe8, 0x7d, 0x58, 0x0e, 0x09, 0x4b, 0x7d, 0x97, 0x3a, 0xd8, 0xf3, 0x52, 0x42,
+	0xa9, 0xda, 0x3a, 0x6d, 0x25, 0xde, 0x95, 0x52, 0xf4, 0x09, 0xf4, 0xb4, 0xa1, 0xcf, 0xd7, 0xf8,
+	0x05, 0x0e, 0x1c, 0x4a, 0xdc, 0x38, 0xf2, 0xa8, 0xda, 0x47, 0x6b, 0x4a, 0x7f, 0xa0, 0xd4, 0xc7,
+	0x52, 0x8b, 0x1e, 0x43, 0x87, 0xb2, 0x38, 0xc5, 0x67, 0xc4, 0x19, 0x60, 0xf7, 0x9c, 0xf0, 0x1e,
+	0xf3, 0x02, 0xbc, 0x75, 0x03, 0xbc, 0x63, 0x69, 0xf2, 0x48, 0x5a, 0xd8, 0xcb, 0xb4, 0xd0, 0xa6,
+	0xd6, 0xf7, 0x35, 0xe8, 0x4d, 0xdb, 0x06, 0xa2, 0x3e, 0x78, 0x62, 0xea, 0x2d, 0xbb, 0xea, 0x7b,
+	0x7c, 0xff, 0x71, 0x48, 0xc4, 0x5c, 0xe7, 0x6c, 0xf1, 0x8d, 0xee, 0x02, 0xb8, 0x71, 0x10, 0x10,
+	0x97, 0x77, 0x54, 0x93, 0x34, 0x24, 0x7c, 0x7f, 0x8a, 0x2d, 0x9f, 0x97, 0x86, 0x39, 0xbb, 0xc1,
+	0x25, 0xb2, 0x2a, 0xdc, 0x83, 0x25, 0x99, 0x3e, 0x65, 0x20, 0xab, 0x42, 0x53, 0xca, 0xa4, 0xc9,
+	0x07, 0x80, 0xf4, 0x32, 0x19, 0x8c, 0x32, 0xc3, 0x05, 0x61, 0xd8, 0x51, 0x9a, 0x47, 0x23, 0x6d,
+	0x7d, 0x0b, 0x1a, 0x29, 0xc1, 0x9e, 0x13, 0x47, 0xc1, 0x48, 0x14, 0x8a, 0xba, 0x5d, 0xe7, 0x82,
+	0x2f, 0xa3, 0x60, 0x84, 0xfe, 0x0f, 0xba, 0x29, 0x49, 0x02, 0xdf, 0xc5, 0x4e, 0x12, 0x60, 0x97,
+	0x84, 0x24, 0xd2, 0x35, 0xa3, 0xa3, 0x14, 0xcf, 0xb5, 0x1c, 0xf5, 0x60, 0xf1, 0x82, 0xa4, 0x94,
+	0x4f, 0xab, 0x21, 0x4c, 0x74, 0x13, 0x75, 0xa0, 0xc6, 0x58, 0xd0, 0x03, 0x21, 0xe5, 0x9f, 0xe8,
+	0x01, 0x74, 0xdc, 0x38, 0x4c, 0xb0, 0xcb, 0x9c, 0x94, 0x5c, 0xf8, 0xa2, 0x53, 0x53, 0xa8, 0x97,
+	0x95, 0xdc, 0x56, 0x62, 0x3e, 0x9d, 0x30, 0xf6, 0xfc, 0x53, 0x9f, 0x78, 0x0e, 0x66, 0x2a, 0xd9,
+	0x62, 0xe3, 0xd6, 0xec, 0x8e, 0xd6, 0xec, 0x32, 0x99, 0x66, 0xb4, 0x0d, 0x2b, 0x29, 0x09, 0x63,
+	0x46, 0x1c, 0x9d, 0xec, 0x08, 0x87, 0xa4, 0xd7, 0x12, 0x38, 0x77, 0xa5, 0x4a, 0xe5, 0xf8, 0x08,
+	0x87, 0x84, 0x7b, 0x2f, 0xd9, 0xf3, 0x5a, 0xdb, 0x16, 0xe6, 0x9d, 0x82, 0xf9, 0x53, 0x32, 0xb2,
+	0xfe, 0x56, 0x81, 0x3b, 0x33, 0x4b, 0xce, 0xd8, 0x12, 0xb8, 0x2a, 0xdd, 0x3f, 0x16, 0xc2, 0xd6,
+	0x10, 0x36, 0xae, 0x28, 0x04, 0x57, 0xc4, 0x5a, 0x1d, 0x8b, 0xd5, 0x82, 0x16, 0x71, 0x1d, 0x3f,
+	0xf2, 0xc8, 0xa5, 0x33, 0xf0, 0x99, 0xdc, 0xa2, 0x2d, 0xbb, 0x49, 0xdc, 0x03, 0x2e, 0x7b, 0xe4,
+	0x33, 0x6a, 0x7d, 0x57, 0x81, 0x76, 0x71, 0x0f, 0xf1, 0x5d, 0xc0, 0x46, 0x09, 0x51, 0xe7, 0xa6,
+	0xf8, 0x56, 0x43, 0x57, 0xd5, 0x49, 0xea, 0xa1, 0x03, 0x80, 0x24, 0x8d, 0x13, 0x92, 0x32, 0x9f,
+	0x70, 0xbf, 0x7c, 0x5b, 0x3e, 0x98, 0xba, 0x2d, 0xb7, 0x9f, 0x67, 0xb6, 0xfb, 0x11, 0x4b, 0x47,
+	0xb6, 0xd1, 0xb9, 0xff, 0x19, 0x2c, 0x97, 0xd4, 0x1c, 0x1d, 0x9e, 0x55, 0x19, 0x00, 0xff, 0x44,
+	0xab, 0x30, 0x7f, 0x81, 0x83, 0x21, 0x51, 0x21, 0xc8, 0xc6, 0xcf, 0xab, 0x9f, 0x54, 0xac, 0x45,
+	0x98, 0xdf, 0x0f, 0x13, 0x36, 0xe2, 0x33, 0x59, 0x3e, 0x1e, 0x26, 0x24, 0x7d, 0x14, 0xc4, 0xee,
+	0xf9, 0xfe, 0x25, 0x4b, 0x31, 0xfa, 0x12, 0xda, 0x24, 0xc5, 0x74, 0x98, 0xf2, 0x5d, 0xe5, 0xf9,
+	0xd1, 0x99, 0xf0, 0x59, 0x3c, 0x92, 0x4a, 0x7d, 0xb6, 0xf7, 0x65, 0x87, 0x3d, 0x61, 0x6f, 0xb7,
+	0x88, 0xd9, 0xec, 0x7f, 0x0d, 0xad, 0x82, 0x9e, 0x83, 0xc5, 0x0f, 0x70, 0x95, 0x15, 0xf1, 0xcd,
+	0x8b, 0x66, 0x82, 0x53, 0x9f, 0x8d, 0x14, 0xd1, 0x50, 0x2d, 0x5e, 0x2a, 0x54, 0xe1, 0xf5, 0x3d,
+	0x09, 0x5a, 0xcb, 0x6e, 0x48, 0xc9, 0x81, 0x47, 0xad, 0x27, 0xb0, 0xfa, 0x94, 0x90, 0x64, 0x2f,
+	0x8e, 0x22, 0xe2, 0x32, 0xe2, 0xd9, 0xe4, 0x37, 0x43, 0x42, 0x19, 0x1f, 0x42, 0xec, 0x09, 0x95,
+	0x0f, 0xfe, 0xcd, 0xab, 0xc0, 0x59, 0x9a, 0xb8, 0x8e, 0x41, 0x67, 0xea, 0x5c, 0xc0, 0x39, 0x81,
+	0xf5, 0xd7, 0x0a, 0xb4, 0xe5, 0x5a, 0x7a, 0x16, 0xbb, 0x62, 0x05, 0x71, 0x44, 0x39, 0xbd, 0x51,
+	0x88, 0x0e, 0xd3, 0xa0, 0xc4, 0x7b, 0xaa, 0x65, 0xde, 0xb3, 0x0e, 0x75, 0x41, 0x0c, 0xf2, 0x48,
+	0x17, 0xf9, 0x59, 0xef, 0x7b, 0x34, 0x2f, 0x69, 0x9e, 0x54, 0xcf, 0x09, 0x75, 0x53, 0x9f, 0xdd,
+	0xdc, 0x24, 0x3f, 0x36, 0xe6, 0xcd, 0x63, 0xc3, 0x3a, 0x81, 0x95, 0x67, 0x71, 0x7c, 0x3e, 0x4c,
+	0x64, 0x78, 0x7a, 0x86, 0x45, 0x60, 0x2a, 0x9b, 0x35, 0x1e, 0x4b, 0x06, 0xcc, 0x55, 0xeb, 0xdc,
+	0xfa, 0x77, 0x05, 0x56, 0x8b, 0x6e, 0xd5, 0x49, 0xf7, 0x0d, 0xac, 0x64, 0x7e, 0x9d, 0x40, 0x61,
+	0x21, 0x07, 0x68, 0xee, 0x7c, 0x68, 0xac, 0x81, 0x49, 0xbd, 0x35, 0x7b, 0xf2, 0x34, 0x88, 0x76,
+	0xf7, 0xa2, 0x24, 0xa1, 0xfd, 0x4b, 0xe8, 0x94, 0xcd, 0x78, 0x6e, 0xb2, 0x51, 0x15, 0xe2, 0x75,
+	0xdd, 0x13, 0xfd, 0x14, 0x1a, 0x79, 0x20, 0x55, 0x11, 0xc8, 0x4a, 0x21, 0x10, 0x35, 0x56, 0x6e,
+	0xc5, 0xd7, 0x3e, 0x49, 0xd3, 0x38, 0x55, 0xd5, 0x48, 0x36, 0xac, 0x4f, 0xa1, 0xfe, 0x83, 0xb3,
+	0x6b, 0xfd, 0xa3, 0x0a, 0xad, 0x5d, 0x4a, 0xfd, 0xb3, 0x48, 0xa7, 0x60, 0x15, 0xe6, 0xe5, 0xb9,
+	0x23, 0x89, 0x80, 0x6c, 0xa0, 0x4d, 0x68, 0xaa, 0xa2, 0x66, 0x40, 0x6f, 0x8a, 0xae, 0xac, 0x97,
+	0xaa, 0xd0, 0xcd, 0xc9, 0xd0, 0xf8, 0x51, 0x52, 0x62, 0xc1, 0xf3, 0x53, 0x59, 0xf0, 0x82, 0xc1,
+	0x82, 0x6f, 0x41, 0x43, 0x74, 0x8a, 0x62, 0x8f, 0x28, 0x7a, 0x5c, 0xe7, 0x82, 0xa3, 0xd8, 0x23,
+	0x68, 0x07, 0xd6, 0x42, 0x12, 0xc6, 0xe9, 0xc8, 0x09, 0x71, 0xe2, 0x70, 0x12, 0x2e, 0x88, 0x4d,
+	0x38, 0x50, 0x85, 0x19, 0x49, 0xed, 0x21, 0x4e, 0x0e, 0xf1, 0x25, 0xe7, 0x36, 0x87, 0x03, 0xb4,
+	0x03, 0x37, 0xbe, 0x4a, 0x7d, 0x86, 0x07, 0x01, 0x29, 0x92, 0x7b, 0x59, 0xa8, 0x57, 0xb4, 0xd2,
+	0x60, 0xf8, 0xd6, 0x5f, 0x2a, 0xd0, 0xd6, 0xa8, 0xa9, 0x15, 0xd6, 0x81, 0xda, 0x69, 0x96, 0x65,
+	0xfe, 0xa9, 0x73, 0x51, 0x9d, 0x96, 0x8b, 0xb1, 0x1b, 0x46, 0x86, 0xfc, 0x9c, 0x89, 0x7c, 0x96,
+	0xf4, 0x79, 0x23, 0xe9, 0x1c, 0x1a, 0x3c, 0x64, 0xaf, 0x34, 0x34, 0xfc, 0xdb, 0x3a, 0x83, 0xee,
+	0x31, 0xc3, 0xcc, 0xa7, 0xcc, 0x77, 0xa9, 0x4e, 0x67, 0x29, 0x71, 0x95, 0xab, 0x12, 0x57, 0x9d,
+	0x96, 0xb8, 0x5a, 0x96, 0x38, 0xeb, 0x5f, 0x15, 0x40, 0xe6, 0x48, 0x0a, 0x82, 0x1f, 0x61, 0x28,
+	0x0e, 0x19, 0x8b, 0x19, 0xa7, 0x8a, 0x9c, 0x8e, 0x29, 0x52, 0x25, 0x24, 0x3c, 0x7d, 0x7c, 0x35,
+	0x0c, 0x29, 0xf1, 0xa4, 0x56, 0x32, 0xaa, 0x3a, 0x17, 0x08, 0x65, 0x91, 0x90, 0x2d, 0x94, 0x08,
+	0x99, 0xb5, 0x0b, 0x4d, 0x75, 0x38, 0x9d, 0x8c, 0x92, 0x37, 0x89, 0x5e, 0x45, 0x57, 0xcd, 0x81,
+	0xd8, 0x04, 0xd8, 0xcb, 0xa3, 0x9f, 0x50, 0x9e, 0xad, 0xdf, 0xc2, 0x8d, 0xdc, 0xe2, 0x99, 0x4f,
+	0x99, 0xce, 0xcb, 0xc7, 0xb0, 0xe6, 0x47, 0x6e, 0x30, 0xf4, 0x88, 0x13, 0xf1, 0xe3, 0x3d, 0xc8,
+	0x6e, 0x36, 0x15, 0x41, 0xe5, 0x56, 0x95, 0xf6, 0x48, 0x28, 0xf5, 0x0d, 0xe7, 0x03, 0x40, 0xba,
+	0x17, 0x71, 0xb3, 0x1e, 0x55, 0xd1, 0xa3, 0xa3, 0x34, 0xfb, 0xae, 0xb2, 0xb6, 0x5e, 0xc0, 0x5a,
+	0x79, 0x70, 0x95, 0xaa, 0x9f, 0x41, 0x33, 0x87, 0x5d, 0xd7, 0xc1, 0x1b, 0x46, 0xf9, 0xc9, 0xfb,
+	0xd9, 0xa6, 0xa5, 0xf5, 0xff, 0x70, 0x33, 0x57, 0x3d, 0x16, 0x85, 0x7e, 0xc6, 0xe9, 0x64, 0xf5,
+	0xa1, 0x37, 0x6e, 0x2e, 0x63, 0xb0, 0xfe, 0x5c, 0x83, 0xa5, 0xc7, 0x6a, 0xe7, 0x72, 0x8e, 0x63,
+	0xb0, 0x1a, 0x49, 0x2d, 0xee, 0xc1, 0x52, 0x61, 0x43, 0x4a, 0x32, 0xde, 0xbc, 0x30, 0xae, 0xda,
+	0x93, 0x2e, 0xe5, 0x35, 0x61, 0x56, 0xbe, 0x94, 0x3f, 0x84, 0xee, 0x69, 0x4a, 0xc8, 0xf8, 0xfd,
+	0x7d, 0xce, 0x5e, 0xe6, 0x0a, 0xd3, 0x76, 0x1b, 0x56, 0xb0, 0xcb, 0xfc, 0x8b, 0x92, 0xb5, 0x5c,
+	0x5f, 0x5d, 0xa9, 0x32, 0xed, 0xbf, 0xc8, 0x02, 0xf5, 0xa3, 0xd3, 0x98, 0xf6, 0x16, 0xde, 0xfc,
+	0xfe, 0xdd, 0xbc, 0xc8, 0x34, 0x14, 0x3d, 0x87, 0xb6, 0xbe, 0xc7, 0x29, 0x4f, 0x8b, 0xd7, 0xbe,
+	0x23, 0x2e, 0x91, 0x5c, 0x45, 0x0d, 0x52, 0x5d, 0x98, 0x49, 0x5d, 0xce, 0x44, 0xaa, 0xcc, 0xc2,
+	0xf6, 0xcf, 0x2a, 0xd4, 0x6d, 0xec, 0x9e, 0xbf, 0xdd, 0xf9, 0xf8, 0x1c, 0x96, 0xb3, 0x33, 0xa2,
+	0x90, 0x92, 0x9b, 0x06, 0x90, 0xe6, 0xd2, 0xb3, 0x5b, 0x9e, 0xd1, 0x9a, 0x0a, 0xdb, 0xe2, 0x34,
+	0xd8, 0xfe, 0x5e, 0x85, 0xf6, 0xe3, 0xec, 0xdc, 0x7a, 0xbb, 0xc1, 0xdb, 0x01, 0xe0, 0x07, 0x6d,
+	0x01, 0x37, 0x93, 0x98, 0xe8, 0xe5, 0x61, 0x37, 0x52, 0xf5, 0x75, 0x7d, 0xbc, 0xbe, 0xab, 0xc2,
+	0xd2, 0x49, 0x9c, 0xc4, 0x41, 0x7c, 0x36, 0x7a, 0xbb, 0xd1, 0xda, 0x87, 0xae, 0xc1, 0x61, 0x0a,
+	0xa0, 0xad, 0x97, 0x16, 0x5b, 0xbe, 0x38, 0xec, 0x65, 0xaf, 0xd0, 0xbe, 0x3e, 0x80, 0x2b, 0xd0,
+	0x95, 0x4d, 0xe3, 0x48, 0xb1, 0x7e, 0x5f, 0x01, 0x64, 0x4a, 0x55, 0xad, 0xff, 0x05, 0xb4, 0x98,
+	0xc2, 0x5a, 0xc4, 0xa7, 0x6e, 0x3e, 0xe6, 0x5e, 0x30, 0x73, 0x61, 0x2f, 0x31, 0xa3, 0x85, 0x7e,
+	0x02, 0xab, 0x63, 0x6f, 0x44, 0x9c, 0x50, 0xc9, 0x8c, 0x74, 0x4b, 0xcf, 0x44, 0x87, 0x03, 0xeb,
+	0x63, 0xb8, 0x21, 0x49, 0xb4, 0x3e, 0x87, 0xf4, 0xf9, 0x30, 0xc6, 0x86, 0x5b, 0x39, 0x1b, 0xb6,
+	0xbe, 0xaf, 0xc0, 0x5a, 0xb9, 0x9b, 0x8a, 0x7f, 0x56, 0x3f, 0x84, 0x01, 0xa9, 0x7a, 0xe9, 0x39,
+	0x65, 0x3a, 0xfd, 0xd1, 0x18, 0xaf, 0x2f, 0xfb, 0xde, 0xd6, 0x75, 0x34, 0xa7, 0xf6, 0x1d, 0x5a,
+	0x14, 0xd0, 0x3e, 0x86, 0xee, 0x98, 0x19, 0xbf, 0x15, 0xe9, 0x71, 0x55, 0x4c, 0x8b, 0xaa, 0xe3,
+	0x0f, 0x20, 0xf6, 0xd6, 0x06, 0xdc, 0x79, 0x42, 0xd8, 0xa1, 0xb0, 0xd9, 0x8b, 0xa3, 0x53, 0xff,
+	0x6c, 0x98, 0x4a, 0xa3, 0x3c, 0xb5, 0x77, 0xa7, 0x59, 0x28, 0x98, 0x26, 0x3c, 0xc4, 0x55, 0xae,
+	0xfd, 0x10, 0x57, 0x9d, 0xf5, 0x10, 0x67, 0x7d, 0x0a, 0x3d, 0xbe, 0xb2, 0x54, 0x14, 0x81, 0x4f,
+	0x22, 0x96, 0xf1, 0xcc, 0x0d, 0x68, 0xba, 0x42, 0xe2, 0x18, 0x4f, 0x06, 0x20, 0x45, 0x9c, 0x5f,
+	0x59, 0x8f, 0x60, 0x7d, 0x42, 0x67, 0x15, 0xfc, 0x7b, 0xd0, 0x16, 0xb7, 0x58, 0x15, 0x39, 0xd1,
+	0x77, 0xbf, 0x16, 0x97, 0xee, 0x6a, 0xa1, 0xf5, 0x07, 0xbe, 0x4a, 0x08, 0xa6, 0x64, 0x97, 0x3f,
+	0x7b, 0x9f, 0xc4, 0xe7, 0x24, 0xbb, 0xb6, 0xbc, 0x07, 0xed, 0x84, 0x3f, 0x48, 0xc5, 0x43, 0xea,
+	0x30, 0xae, 0x10, 0x21, 0xd4, 0xec, 0x96, 0x96, 0x0a, 0x6b, 0x4e, 0xa0, 0x32, 0x33, 0x7e, 0xd3,
+	0x77, 0x98, 0x1f, 0xca, 0xb7, 0x84, 0x9a, 0xdd, 0xd1, 0x9a, 0x67, 0xb1, 0x7b, 0x7e, 0xe2, 0xcb,
+	0xcb, 0xb5, 0x30, 0x12, 0xbc, 0x46, 0xb2, 0xd2, 0x3a, 0x17, 0xf0, 0x07, 0x28, 0xeb, 0x10, 0x6e,
+	0x8e, 0xc5, 0xa2, 0xa6, 0xb3, 0x0a, 0xf3, 0x66, 0x0c, 0xb2, 0x81, 0x6e, 0x03, 0xc8, 0x21, 0xa9,
+	0x13, 0x51, 0x35, 0xa6, 0x70, 0x77, 0x42, 0x8f, 0xa8, 0xf5, 0xa7, 0x0a, 0xf4, 0x6c, 0x12, 0xfc,
+	0xaf, 0xcc, 0xee, 0x16, 0xac, 0x4f, 0x88, 0x46, 0xce, 0x6f, 0xe7, 0x3f, 0x75, 0x58, 0x3c, 0x26,
+	0xf8, 0x35, 0x21, 0xfc, 0x01, 0xa8, 0x75, 0x4c, 0x22, 0x2f, 0xff, 0xd7, 0xb2, 0x6a, 0x2c, 0xf6,
+	0x4c, 0xda, 0xbf, 0x3d, 0x49, 0x9a, 0x71, 0xc1, 0x77, 0xb6, 0x2a, 0x1f, 0x56, 0xd0, 0x0b, 0x68,
+	0x15, 0xde, 0x3d, 0xd0, 0x86, 0xd1, 0x69, 0xd2, 0x8b, 0x48, 0x7f, 0x7d, 0x8c, 0x19, 0xe9, 0xed,
+	0x95, 0xb9, 0x5c, 0x32, 0xaf, 0xf4, 0xe8, 0xee, 0xd4, 0xbb, 0xbe, 0x74, 0xb8, 0x71, 0xc5, 0x5b,
+	0x80, 0xf5, 0x0e, 0xfa, 0x1c, 0x16, 0xe4, 0xdd, 0x0f, 0xf5, 0x0c, 0xe3, 0xc2, 0x25, 0xba, 0xbf,
+	0x3e, 0x41, 0x93, 0x39, 0x78, 0x0a, 0x90, 0xdf, 0x9e, 0xd0, 0xed, 0xc2, 0x63, 0x59, 0xe9, 0xfa,
+	0xd6, 0xbf, 0x33, 0x45, 0x9b, 0x39, 0xfb, 0x0a, 0xda, 0x45, 0x8e, 0x8f, 0x36, 0x27, 0xd2, 0x78,
+	0xe3, 0xa0, 0xe8, 0xdf, 0x9b, 0x61, 0x91, 0x39, 0xfe, 0x35, 0x74, 0xca, 0xd4, 0x1d, 0x59, 0x13,
+	0x3b, 0x16, 0xae, 0x01, 0xfd, 0x77, 0x67, 0xda, 0x98, 0x20, 0xe4, 0x67, 0x55, 0x01, 0x84, 0xb1,
+	0x83, 0xad, 0x7f, 0x67, 0x8a, 0xd6, 0x04, 0xa1, 0x58, 0xe0, 0x0b, 0x20, 0x4c, 0x3c, 0x8e, 0xfa,
+	0xf7, 0x66, 0x58, 0x64, 0x8e, 0x63, 0x58, 0x9b, 0x5c, 0x76, 0x91, 0xf9, 0x70, 0x38, 0xb3, 0x76,
+	0xf7, 0x1f, 0xbc, 0x81, 0x65, 0x36, 0xe0, 0x37, 0xd0, 0x1d, 0xab, 0x92, 0xc8, 0x84, 0x74, 0x5a,
+	0x01, 0xee, 0xdf, 0x9f, 0x6d, 0x94, 0x8d, 0xf0, 0x35, 0x2c, 0x97, 0xca, 0x16, 0x2a, 0x40, 0x31,
+	0xb1, 0x00, 0xf5, 0xad, 0x59, 0x26, 0x66, 0xf4, 0x63, 0x45, 0xa3, 0x10, 0xfd, 0xb4, 0x02, 0xd7,
+	0xbf, 0x3f, 0xdb, 0x48, 0x8f, 0x30, 0x58, 0x10, 0x3f, 0x7a, 0x3f, 0xfa, 0xef, 0x00, 0x6d, 0x11,
+	0x8c, 0x9d, 0xf8, 0x1d, 0x00, 0x00,
 }
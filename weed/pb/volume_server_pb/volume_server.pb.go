@@ -81,6 +81,7 @@ It has these top-level messages:
 	VolumeServerStatusResponse
 	QueryRequest
 	QueriedStripe
+	QueriedAggregate
 */
 package volume_server_pb
 
@@ -1908,6 +1909,10 @@ type QueryRequest struct {
 	Filter              *QueryRequest_Filter              `protobuf:"bytes,3,opt,name=filter" json:"filter,omitempty"`
 	InputSerialization  *QueryRequest_InputSerialization  `protobuf:"bytes,4,opt,name=input_serialization,json=inputSerialization" json:"input_serialization,omitempty"`
 	OutputSerialization *QueryRequest_OutputSerialization `protobuf:"bytes,5,opt,name=output_serialization,json=outputSerialization" json:"output_serialization,omitempty"`
+	// SQL SELECT statement, e.g. SELECT s.name FROM S3Object s WHERE s.age > 30 AND s.city IN ('Paris', 'Rome')
+	// If set, it takes precedence over the selections and the filter.
+	Expression string                   `protobuf:"bytes,6,opt,name=expression" json:"expression,omitempty"`
+	ObjectPart *QueryRequest_ObjectPart `protobuf:"bytes,7,opt,name=object_part,json=objectPart" json:"object_part,omitempty"`
 }
 
 func (m *QueryRequest) Reset()                    { *m = QueryRequest{} }
@@ -1950,6 +1955,20 @@ func (m *QueryRequest) GetOutputSerialization() *QueryRequest_OutputSerializatio
 	return nil
 }
 
+func (m *QueryRequest) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetObjectPart() *QueryRequest_ObjectPart {
+	if m != nil {
+		return m.ObjectPart
+	}
+	return nil
+}
+
 type QueryRequest_Filter struct {
 	Field   string `protobuf:"bytes,1,opt,name=field" json:"field,omitempty"`
 	Operand string `protobuf:"bytes,2,opt,name=operand" json:"operand,omitempty"`
@@ -2232,8 +2251,39 @@ func (m *QueryRequest_OutputSerialization_JSONOutput) GetRecordDelimiter() strin
 	return ""
 }
 
+// If set, the from_file_ids are consecutive chunks of one object, read as one stream where the records may span the chunks.
+type QueryRequest_ObjectPart struct {
+	// the leading chunks of the object, of at least the max record size, if the from_file_ids do not start the object.
+	// The partial record before the first record delimiter belongs to the previous part,
+	// and the csv header is read from the leading chunks.
+	FirstFileIds []string `protobuf:"bytes,1,rep,name=first_file_ids,json=firstFileIds" json:"first_file_ids,omitempty"`
+	// the following chunks of the object, only read to the end of the last record starting in the from_file_ids
+	NextFileIds []string `protobuf:"bytes,2,rep,name=next_file_ids,json=nextFileIds" json:"next_file_ids,omitempty"`
+}
+
+func (m *QueryRequest_ObjectPart) Reset()                    { *m = QueryRequest_ObjectPart{} }
+func (m *QueryRequest_ObjectPart) String() string            { return proto.CompactTextString(m) }
+func (*QueryRequest_ObjectPart) ProtoMessage()               {}
+func (*QueryRequest_ObjectPart) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70, 3} }
+
+func (m *QueryRequest_ObjectPart) GetFirstFileIds() []string {
+	if m != nil {
+		return m.FirstFileIds
+	}
+	return nil
+}
+
+func (m *QueryRequest_ObjectPart) GetNextFileIds() []string {
+	if m != nil {
+		return m.NextFileIds
+	}
+	return nil
+}
+
 type QueriedStripe struct {
 	Records []byte `protobuf:"bytes,1,opt,name=records,proto3" json:"records,omitempty"`
+	// partial results of the aggregate functions in the expression, to be merged by the caller
+	Aggregates []*QueriedAggregate `protobuf:"bytes,2,rep,name=aggregates" json:"aggregates,omitempty"`
 }
 
 func (m *QueriedStripe) Reset()                    { *m = QueriedStripe{} }
@@ -2248,6 +2298,53 @@ func (m *QueriedStripe) GetRecords() []byte {
 	return nil
 }
 
+func (m *QueriedStripe) GetAggregates() []*QueriedAggregate {
+	if m != nil {
+		return m.Aggregates
+	}
+	return nil
+}
+
+type QueriedAggregate struct {
+	Count int64   `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	Sum   float64 `protobuf:"fixed64,2,opt,name=sum" json:"sum,omitempty"`
+	Min   float64 `protobuf:"fixed64,3,opt,name=min" json:"min,omitempty"`
+	Max   float64 `protobuf:"fixed64,4,opt,name=max" json:"max,omitempty"`
+}
+
+func (m *QueriedAggregate) Reset()                    { *m = QueriedAggregate{} }
+func (m *QueriedAggregate) String() string            { return proto.CompactTextString(m) }
+func (*QueriedAggregate) ProtoMessage()               {}
+func (*QueriedAggregate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *QueriedAggregate) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *QueriedAggregate) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *QueriedAggregate) GetMin() float64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *QueriedAggregate) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*BatchDeleteRequest)(nil), "volume_server_pb.BatchDeleteRequest")
 	proto.RegisterType((*BatchDeleteResponse)(nil), "volume_server_pb.BatchDeleteResponse")
@@ -2328,7 +2425,9 @@ func init() {
 	proto.RegisterType((*QueryRequest_OutputSerialization)(nil), "volume_server_pb.QueryRequest.OutputSerialization")
 	proto.RegisterType((*QueryRequest_OutputSerialization_CSVOutput)(nil), "volume_server_pb.QueryRequest.OutputSerialization.CSVOutput")
 	proto.RegisterType((*QueryRequest_OutputSerialization_JSONOutput)(nil), "volume_server_pb.QueryRequest.OutputSerialization.JSONOutput")
+	proto.RegisterType((*QueryRequest_ObjectPart)(nil), "volume_server_pb.QueryRequest.ObjectPart")
 	proto.RegisterType((*QueriedStripe)(nil), "volume_server_pb.QueriedStripe")
+	proto.RegisterType((*QueriedAggregate)(nil), "volume_server_pb.QueriedAggregate")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3689,214 +3788,222 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("volume_server.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 3329 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x3b, 0x4b, 0x6f, 0x1c, 0xc7,
-	0xd1, 0x5c, 0x2e, 0x1f, 0xbb, 0xb5, 0xbb, 0x22, 0xd5, 0x94, 0xa9, 0xf5, 0x90, 0x92, 0xa8, 0x91,
-	0x1f, 0x92, 0x6c, 0x51, 0x32, 0x6d, 0x7f, 0x96, 0xe5, 0xcf, 0xfe, 0x2c, 0x51, 0xa2, 0x2c, 0x5b,
-	0xa4, 0xec, 0xa1, 0x2c, 0x7f, 0x89, 0x8c, 0x0c, 0x86, 0x33, 0xbd, 0xe4, 0x98, 0xb3, 0xd3, 0xa3,
-	0x99, 0x5e, 0x5a, 0x2b, 0x38, 0x27, 0x07, 0x48, 0x80, 0x20, 0x39, 0x04, 0xb9, 0xe4, 0x12, 0x20,
-	0xc8, 0x3d, 0xd7, 0xfc, 0x05, 0xff, 0x81, 0x00, 0x39, 0xe5, 0x92, 0x73, 0x0e, 0x39, 0x04, 0x08,
-	0x90, 0x4b, 0xd0, 0xaf, 0xd9, 0x79, 0x72, 0x87, 0x11, 0x83, 0x20, 0xb7, 0xe9, 0xea, 0xea, 0xaa,
-	0xae, 0xea, 0xaa, 0xea, 0xea, 0xaa, 0x5d, 0x58, 0x38, 0x20, 0xde, 0xa0, 0x8f, 0xcd, 0x08, 0x87,
-	0x07, 0x38, 0x5c, 0x0d, 0x42, 0x42, 0x09, 0x9a, 0x4f, 0x01, 0xcd, 0x60, 0x47, 0x7f, 0x0c, 0xe8,
-	0x96, 0x45, 0xed, 0xbd, 0xdb, 0xd8, 0xc3, 0x14, 0x1b, 0xf8, 0xc9, 0x00, 0x47, 0x14, 0xbd, 0x08,
-	0x8d, 0x9e, 0xeb, 0x61, 0xd3, 0x75, 0xa2, 0x6e, 0x6d, 0xa5, 0x7e, 0xb1, 0x69, 0xcc, 0xb2, 0xf1,
-	0x3d, 0x27, 0x42, 0x97, 0xe1, 0x64, 0xb4, 0xef, 0x06, 0xa6, 0x4d, 0xc8, 0xbe, 0x8b, 0x4d, 0x7b,
-	0x0f, 0xdb, 0xfb, 0xdd, 0xc9, 0x95, 0xda, 0xc5, 0x86, 0x31, 0xc7, 0x26, 0xd6, 0x39, 0x7c, 0x9d,
-	0x81, 0xf5, 0x07, 0xb0, 0x90, 0x22, 0x1e, 0x05, 0xc4, 0x8f, 0x30, 0xba, 0x0e, 0xb3, 0x21, 0x8e,
-	0x06, 0x1e, 0x15, 0xc4, 0x5b, 0x6b, 0x67, 0x57, 0xb3, 0xfb, 0x5a, 0x8d, 0x97, 0x0c, 0x3c, 0x6a,
-	0x28, 0x74, 0xfd, 0xdb, 0x1a, 0xb4, 0x93, 0x33, 0xe8, 0x34, 0xcc, 0xca, 0x8d, 0x76, 0x6b, 0x2b,
-	0xb5, 0x8b, 0x4d, 0x63, 0x46, 0xec, 0x13, 0x2d, 0xc2, 0x4c, 0x44, 0x2d, 0x3a, 0x88, 0xf8, 0xde,
-	0xa6, 0x0d, 0x39, 0x42, 0xa7, 0x60, 0x1a, 0x87, 0x21, 0x09, 0xbb, 0x75, 0x8e, 0x2e, 0x06, 0x08,
-	0xc1, 0x54, 0xe4, 0x3e, 0xc3, 0xdd, 0xa9, 0x95, 0xda, 0xc5, 0x8e, 0xc1, 0xbf, 0x51, 0x17, 0x66,
-	0x0f, 0x70, 0x18, 0xb9, 0xc4, 0xef, 0x4e, 0x73, 0xb0, 0x1a, 0xea, 0x1f, 0xc3, 0x89, 0x0d, 0xd7,
-	0xc3, 0x77, 0x31, 0x55, 0xfa, 0x2a, 0xdd, 0xc6, 0x39, 0x68, 0x59, 0xb6, 0x8d, 0x03, 0x6a, 0xee,
-	0x3e, 0x73, 0x03, 0xa9, 0x27, 0x10, 0xa0, 0xbb, 0xcf, 0xdc, 0x40, 0xff, 0x71, 0x1d, 0xe6, 0x62,
-	0x62, 0x52, 0x3f, 0x08, 0xa6, 0x1c, 0x8b, 0x5a, 0x9c, 0x54, 0xdb, 0xe0, 0xdf, 0xe8, 0x65, 0x38,
-	0x61, 0x13, 0x9f, 0x62, 0x9f, 0x9a, 0x1e, 0xf6, 0x77, 0xe9, 0x1e, 0xa7, 0xd5, 0x31, 0x3a, 0x12,
-	0x7a, 0x9f, 0x03, 0xd1, 0x79, 0x68, 0x2b, 0x34, 0x3a, 0x0c, 0xb0, 0x94, 0xb2, 0x25, 0x61, 0x0f,
-	0x87, 0x01, 0x46, 0x17, 0xa0, 0xe3, 0x59, 0x11, 0x35, 0xfb, 0xc4, 0x71, 0x7b, 0x2e, 0x76, 0xb8,
-	0xd0, 0x53, 0x46, 0x9b, 0x01, 0x37, 0x25, 0x0c, 0x69, 0xc2, 0x00, 0x7c, 0xab, 0x8f, 0xb9, 0xf4,
-	0x4d, 0x23, 0x1e, 0xb3, 0xed, 0x61, 0x6a, 0xed, 0x76, 0x67, 0x38, 0x9c, 0x7f, 0xa3, 0x33, 0x00,
-	0x6e, 0xc4, 0x65, 0x0c, 0xb0, 0xd3, 0x9d, 0xe5, 0x62, 0x36, 0xdd, 0xe8, 0xae, 0x00, 0xa0, 0x8f,
-	0x60, 0x76, 0x0f, 0x5b, 0x0e, 0x0e, 0xa3, 0x6e, 0x83, 0x9f, 0xf8, 0x6a, 0xfe, 0xc4, 0x33, 0x5a,
-	0x58, 0xfd, 0x48, 0x2c, 0xb8, 0xe3, 0xd3, 0x70, 0x68, 0xa8, 0xe5, 0x68, 0x19, 0x9a, 0xfc, 0xc8,
-	0xd6, 0x89, 0x83, 0xbb, 0x4d, 0x7e, 0xb4, 0x23, 0x80, 0x76, 0x03, 0xda, 0xc9, 0x65, 0x68, 0x1e,
-	0xea, 0xfb, 0x78, 0x28, 0xcf, 0x84, 0x7d, 0xb2, 0xf3, 0x3f, 0xb0, 0xbc, 0x01, 0xe6, 0xea, 0x6b,
-	0x1a, 0x62, 0x70, 0x63, 0xf2, 0x7a, 0x4d, 0x9f, 0x85, 0xe9, 0x3b, 0xfd, 0x80, 0x0e, 0xf5, 0x77,
-	0xa0, 0xfb, 0xc8, 0xb2, 0x07, 0x83, 0xfe, 0x23, 0xbe, 0x45, 0x6e, 0xca, 0xea, 0xa0, 0x97, 0xa0,
-	0x29, 0x37, 0x2e, 0x8f, 0xba, 0x63, 0x34, 0x04, 0xe0, 0x9e, 0xa3, 0x7f, 0x08, 0x2f, 0x16, 0x2c,
-	0x94, 0x87, 0x7a, 0x01, 0x3a, 0xbb, 0x56, 0xb8, 0x63, 0xed, 0x62, 0x33, 0xb4, 0xa8, 0x4b, 0xf8,
-	0xea, 0x9a, 0xd1, 0x96, 0x40, 0x83, 0xc1, 0xf4, 0xc7, 0xa0, 0xa5, 0x28, 0x90, 0x7e, 0x60, 0xd9,
-	0xb4, 0x0a, 0x73, 0xb4, 0x02, 0xad, 0x20, 0xc4, 0x96, 0xe7, 0x11, 0xdb, 0xa2, 0x42, 0xbc, 0xba,
-	0x91, 0x04, 0xe9, 0x67, 0x60, 0xa9, 0x90, 0xb8, 0xd8, 0xa0, 0x7e, 0x3d, 0xb3, 0x7b, 0xd2, 0xef,
-	0xbb, 0x95, 0x58, 0xeb, 0x1f, 0xe4, 0x76, 0xcd, 0x57, 0x4a, 0xc1, 0x57, 0xa0, 0xed, 0x46, 0x66,
-	0x88, 0x2d, 0xc7, 0x24, 0xbe, 0x27, 0x0e, 0xa3, 0x61, 0x80, 0x1b, 0x19, 0xd8, 0x72, 0x1e, 0xf8,
-	0xde, 0x50, 0x7f, 0x37, 0xb3, 0xde, 0xc3, 0x96, 0x3f, 0x08, 0x2a, 0xb1, 0xce, 0xca, 0xa4, 0x96,
-	0x4a, 0x99, 0xde, 0x85, 0xd3, 0x22, 0x5c, 0xac, 0x13, 0xcf, 0xc3, 0x36, 0x75, 0x89, 0xaf, 0xc8,
-	0x9e, 0x05, 0xb0, 0x63, 0xa0, 0xb4, 0x90, 0x04, 0x44, 0xd7, 0xa0, 0x9b, 0x5f, 0x2a, 0xc9, 0xfe,
-	0xa9, 0x06, 0x2f, 0xdc, 0x94, 0x6a, 0x15, 0x8c, 0x2b, 0x1d, 0x51, 0x9a, 0xe5, 0x64, 0x96, 0x65,
-	0xf6, 0x08, 0xeb, 0xb9, 0x23, 0x64, 0x18, 0x21, 0x0e, 0x3c, 0xd7, 0xb6, 0x38, 0x89, 0x29, 0xe1,
-	0xdd, 0x09, 0x10, 0xb3, 0x78, 0x4a, 0x3d, 0xe9, 0xb3, 0xec, 0x13, 0xad, 0xc1, 0x62, 0x1f, 0xf7,
-	0x49, 0x38, 0x34, 0xfb, 0x56, 0x60, 0xf6, 0xad, 0xa7, 0x26, 0x0b, 0x6f, 0x66, 0x7f, 0x87, 0x3b,
-	0x70, 0xc7, 0x40, 0x62, 0x76, 0xd3, 0x0a, 0x36, 0xad, 0xa7, 0xdb, 0xee, 0x33, 0xbc, 0xb9, 0xa3,
-	0x77, 0x61, 0x31, 0x2b, 0x9f, 0x14, 0xfd, 0x7f, 0xe0, 0xb4, 0x80, 0x6c, 0x0f, 0x7d, 0x7b, 0x9b,
-	0xc7, 0xd4, 0x4a, 0x07, 0xf5, 0x8f, 0x1a, 0x74, 0xf3, 0x0b, 0xa5, 0x89, 0x3c, 0xaf, 0xd6, 0x8e,
-	0xac, 0x93, 0x73, 0xd0, 0xa2, 0x96, 0xeb, 0x99, 0xa4, 0xd7, 0x8b, 0x30, 0xe5, 0x8a, 0x98, 0x32,
-	0x80, 0x81, 0x1e, 0x70, 0x08, 0xba, 0x04, 0xf3, 0xb6, 0xf0, 0x0f, 0x33, 0xc4, 0x07, 0x2e, 0xbf,
-	0x05, 0x66, 0xf9, 0xc6, 0xe6, 0x6c, 0xe5, 0x37, 0x02, 0x8c, 0x74, 0xe8, 0xb8, 0xce, 0x53, 0x93,
-	0xc7, 0x7f, 0x7e, 0x89, 0x34, 0x38, 0xb5, 0x96, 0xeb, 0x3c, 0x65, 0x21, 0x8d, 0x69, 0x54, 0x7f,
-	0x04, 0xcb, 0x42, 0xf8, 0x7b, 0xbe, 0x1d, 0xe2, 0x3e, 0xf6, 0xa9, 0xe5, 0xad, 0x93, 0x60, 0x58,
-	0xc9, 0x6c, 0x5e, 0x84, 0x46, 0xe4, 0xfa, 0x36, 0x36, 0x7d, 0x71, 0x99, 0x4d, 0x19, 0xb3, 0x7c,
-	0xbc, 0x15, 0xe9, 0xb7, 0xe0, 0x4c, 0x09, 0x5d, 0xa9, 0xd9, 0xf3, 0xd0, 0xe6, 0x1b, 0x93, 0x17,
-	0x80, 0xbc, 0x52, 0x5a, 0x0c, 0xb6, 0x2e, 0x40, 0xfa, 0x1b, 0x80, 0x04, 0x8d, 0x4d, 0x32, 0xf0,
-	0xab, 0x39, 0xfc, 0x0b, 0xb0, 0x90, 0x5a, 0x22, 0x6d, 0xe3, 0x4d, 0x38, 0x25, 0xc0, 0x9f, 0xfb,
-	0xfd, 0xca, 0xb4, 0x4e, 0xc3, 0x0b, 0x99, 0x45, 0x92, 0xda, 0x9a, 0x62, 0x92, 0x4e, 0x4d, 0x0e,
-	0x25, 0xb6, 0xa8, 0x76, 0x90, 0xce, 0x38, 0x78, 0x6c, 0x13, 0x1b, 0xb6, 0xc2, 0x7d, 0x16, 0x77,
-	0x58, 0x24, 0xaa, 0x44, 0x71, 0x19, 0xb4, 0xa2, 0x95, 0x92, 0xee, 0x17, 0xb0, 0xa8, 0x62, 0x9e,
-	0xdf, 0x73, 0x77, 0x07, 0x21, 0xae, 0x1a, 0xab, 0x93, 0x26, 0x3b, 0x99, 0x33, 0x59, 0xfd, 0xaa,
-	0x72, 0xb3, 0x04, 0x61, 0x79, 0xa4, 0x71, 0x06, 0x53, 0x4b, 0x64, 0x30, 0xfa, 0xef, 0x6a, 0x70,
-	0x52, 0xad, 0xa8, 0x68, 0x57, 0x47, 0x74, 0xac, 0x7a, 0xa9, 0x63, 0x4d, 0x8d, 0x1c, 0xeb, 0x22,
-	0xcc, 0x47, 0x64, 0x10, 0xda, 0xd8, 0x64, 0x59, 0x8b, 0xe9, 0xb3, 0x5b, 0x5a, 0xf8, 0xdd, 0x09,
-	0x01, 0xbf, 0x6d, 0x51, 0x6b, 0x8b, 0x38, 0x58, 0xff, 0x3f, 0x65, 0x76, 0x29, 0x7b, 0xbd, 0x04,
-	0x27, 0x79, 0x72, 0x62, 0x05, 0x01, 0xf6, 0x1d, 0xd3, 0xa2, 0xcc, 0xe8, 0x6b, 0xdc, 0xe8, 0x4f,
-	0xb0, 0x89, 0x9b, 0x1c, 0x7e, 0x93, 0x6e, 0x45, 0xfa, 0x2f, 0x27, 0x61, 0x8e, 0xad, 0x65, 0x4e,
-	0x56, 0x49, 0xde, 0x79, 0xa8, 0xe3, 0xa7, 0x54, 0x0a, 0xca, 0x3e, 0xd1, 0x55, 0x58, 0x90, 0xde,
-	0xec, 0x12, 0x7f, 0xe4, 0xe8, 0x75, 0x11, 0x17, 0x47, 0x53, 0xb1, 0xaf, 0x9f, 0x83, 0x56, 0x44,
-	0x49, 0xa0, 0xe2, 0x86, 0xc8, 0x9c, 0x80, 0x81, 0x64, 0xdc, 0x48, 0xeb, 0x74, 0xba, 0x40, 0xa7,
-	0xec, 0x32, 0xc4, 0xb6, 0x29, 0x76, 0xc5, 0x23, 0x0f, 0xbf, 0x0c, 0xef, 0xd8, 0x42, 0x1b, 0xe8,
-	0x03, 0x58, 0x76, 0x77, 0x7d, 0x12, 0x62, 0x53, 0x2a, 0x92, 0xfb, 0xaf, 0x4f, 0xa8, 0xd9, 0x23,
-	0x03, 0x5f, 0xe5, 0x56, 0x5d, 0x81, 0xb3, 0xcd, 0x51, 0x98, 0x06, 0xb6, 0x08, 0xdd, 0x60, 0xf3,
-	0xfa, 0xdb, 0x30, 0x3f, 0xd2, 0x4a, 0xf5, 0x28, 0xf0, 0x6d, 0x4d, 0x59, 0xdc, 0x43, 0xcb, 0xf5,
-	0xb6, 0xb1, 0xef, 0xe0, 0xf0, 0x39, 0xa3, 0x13, 0xba, 0x06, 0xa7, 0x5c, 0xc7, 0xc3, 0x26, 0x75,
-	0xfb, 0x98, 0x0c, 0xa8, 0x19, 0x61, 0x9b, 0xf8, 0x4e, 0xa4, 0xf4, 0xcb, 0xe6, 0x1e, 0x8a, 0xa9,
-	0x6d, 0x31, 0xa3, 0xff, 0x28, 0xbe, 0x25, 0x92, 0xbb, 0x18, 0x65, 0x50, 0x3e, 0xc6, 0x8c, 0xa0,
-	0x48, 0x06, 0xa5, 0x18, 0x6d, 0x01, 0x14, 0x79, 0x1f, 0x3b, 0x21, 0x89, 0xb4, 0x43, 0x9c, 0x21,
-	0xdf, 0x51, 0xdb, 0x00, 0x01, 0xba, 0x45, 0x9c, 0x21, 0x0f, 0xd7, 0x91, 0xc9, 0x8d, 0xcc, 0xde,
-	0x1b, 0xf8, 0xfb, 0x7c, 0x37, 0x0d, 0xa3, 0xe5, 0x46, 0xf7, 0xad, 0x88, 0xae, 0x33, 0x90, 0xfe,
-	0xfb, 0x9a, 0x8a, 0x17, 0x6c, 0x1b, 0x06, 0xb6, 0xb1, 0x7b, 0xf0, 0x1f, 0x50, 0x07, 0x5b, 0x21,
-	0x8d, 0x20, 0x95, 0x2d, 0x4b, 0x87, 0x43, 0x62, 0x4e, 0xde, 0xaa, 0x7c, 0x66, 0x14, 0xae, 0xd2,
-	0x1b, 0x97, 0xe1, 0xea, 0x4b, 0x75, 0x5d, 0xdc, 0xb1, 0xb7, 0xf7, 0xac, 0xd0, 0x89, 0xee, 0x62,
-	0x1f, 0x87, 0x16, 0x3d, 0x96, 0xf4, 0x45, 0x5f, 0x81, 0xb3, 0x65, 0xd4, 0x25, 0xff, 0xc7, 0xea,
-	0x1a, 0x54, 0x18, 0x06, 0xde, 0x19, 0xb8, 0x9e, 0x73, 0x2c, 0xec, 0x3f, 0xc9, 0x0a, 0x17, 0x13,
-	0x97, 0xf6, 0x73, 0x19, 0x4e, 0x86, 0x1c, 0x44, 0xcd, 0x88, 0x21, 0xc4, 0xaf, 0xdb, 0x8e, 0x31,
-	0x27, 0x27, 0xf8, 0xc2, 0x7b, 0x4e, 0xa4, 0xff, 0x74, 0x52, 0x59, 0x80, 0xa2, 0x76, 0x6c, 0x61,
-	0x75, 0x09, 0x9a, 0x23, 0xf6, 0x75, 0xce, 0xbe, 0x11, 0x49, 0xbe, 0xcc, 0x3a, 0x6d, 0x12, 0x0c,
-	0x4d, 0x6c, 0x8b, 0x8c, 0x82, 0x1f, 0x75, 0x83, 0x3d, 0xe0, 0x82, 0xe1, 0x1d, 0x9b, 0x27, 0x14,
-	0xd5, 0x63, 0x6c, 0x82, 0xda, 0x57, 0x82, 0xda, 0x4c, 0x92, 0xda, 0x57, 0x9c, 0x9a, 0xc2, 0x39,
-	0x70, 0x7b, 0x02, 0x67, 0x76, 0x84, 0xf3, 0xc8, 0xed, 0x31, 0x9c, 0x91, 0x55, 0xa5, 0x95, 0x21,
-	0x4f, 0xf5, 0x6b, 0x58, 0x4a, 0xcf, 0x56, 0xbf, 0xb0, 0x9f, 0x4b, 0x59, 0xfa, 0xd9, 0xac, 0x39,
-	0x65, 0x6e, 0xfd, 0x83, 0xec, 0xb6, 0x2b, 0x67, 0x38, 0xcf, 0xb7, 0xaf, 0x33, 0x59, 0x85, 0xa4,
-	0xd3, 0xa4, 0xff, 0xcf, 0x6e, 0xfb, 0x08, 0xe9, 0xd2, 0xe1, 0x8c, 0xcf, 0x65, 0x5d, 0x20, 0x9b,
-	0x53, 0xfd, 0x2a, 0x8e, 0xaf, 0x12, 0x83, 0x65, 0x34, 0x95, 0xe3, 0x9a, 0xe4, 0x2b, 0x2b, 0x0f,
-	0xb3, 0x92, 0x2d, 0x5a, 0x84, 0x19, 0x79, 0x1f, 0x8a, 0x17, 0x8b, 0x1c, 0xa5, 0x8a, 0x2a, 0x75,
-	0x59, 0x54, 0x51, 0x85, 0x25, 0xf6, 0x2a, 0x9f, 0x16, 0xe1, 0x91, 0x8d, 0x3f, 0xc1, 0x43, 0x7d,
-	0x2b, 0xe3, 0x71, 0x62, 0x6b, 0x87, 0x94, 0x44, 0x44, 0xcd, 0xc1, 0xe1, 0x67, 0xee, 0xc8, 0xd2,
-	0x4a, 0xd3, 0x95, 0x46, 0xe0, 0xe8, 0x3f, 0xab, 0x8d, 0x08, 0xde, 0xf2, 0xc8, 0xce, 0x31, 0x5a,
-	0x65, 0x52, 0x8a, 0x7a, 0x4a, 0x8a, 0x64, 0xd5, 0x68, 0x2a, 0x5d, 0x35, 0x4a, 0x38, 0x51, 0x72,
-	0x3b, 0x65, 0xa1, 0xf9, 0x21, 0x39, 0xbe, 0x97, 0x65, 0x3e, 0x34, 0x8f, 0xa8, 0x4b, 0xfe, 0x37,
-	0x60, 0x89, 0x29, 0x5c, 0x40, 0xf9, 0xbb, 0xa5, 0xfa, 0xdb, 0xee, 0x2f, 0x93, 0xb0, 0x5c, 0xbc,
-	0xb8, 0xca, 0xfb, 0xee, 0x3d, 0xd0, 0xe2, 0xf7, 0x13, 0xbb, 0x1a, 0x23, 0x6a, 0xf5, 0x83, 0xf8,
-	0x72, 0x14, 0x77, 0xe8, 0x69, 0xf9, 0x98, 0x7a, 0xa8, 0xe6, 0xd5, 0x0d, 0x99, 0x7b, 0x7c, 0xd5,
-	0x73, 0x8f, 0x2f, 0xc6, 0xc0, 0xb1, 0x68, 0x19, 0x03, 0x91, 0xc3, 0x9d, 0x76, 0x2c, 0x5a, 0xc6,
-	0x20, 0x5e, 0xcc, 0x19, 0x08, 0xab, 0x6d, 0x49, 0x7c, 0xce, 0xe0, 0x0c, 0x80, 0x4c, 0xaf, 0x06,
-	0xbe, 0x7a, 0x4c, 0x36, 0x45, 0x72, 0x35, 0xf0, 0x4b, 0xb3, 0xcc, 0xd9, 0xd2, 0x2c, 0x33, 0x7d,
-	0x9a, 0x8d, 0xdc, 0x69, 0xfe, 0xba, 0x06, 0x70, 0xdb, 0x8d, 0xf6, 0x85, 0x96, 0x59, 0x5e, 0xeb,
-	0xb8, 0xea, 0x39, 0xc0, 0x3e, 0x19, 0xc4, 0xf2, 0x3c, 0xa9, 0x3b, 0xf6, 0xc9, 0xfc, 0x67, 0x10,
-	0x61, 0x47, 0xaa, 0x87, 0x7f, 0x33, 0x58, 0x2f, 0xc4, 0x58, 0x6a, 0x80, 0x7f, 0xb3, 0x4c, 0x31,
-	0xc0, 0xa1, 0x8d, 0x7d, 0x6a, 0xf2, 0x39, 0x26, 0xed, 0xa4, 0xd1, 0x92, 0xb0, 0x8d, 0x0c, 0x0a,
-	0x27, 0x39, 0x93, 0x42, 0xf9, 0x3c, 0xc2, 0x8e, 0xfe, 0xdb, 0x1a, 0x34, 0x37, 0x71, 0x5f, 0xee,
-	0xef, 0x2c, 0xc0, 0x2e, 0x09, 0xc9, 0x80, 0xba, 0x3e, 0x16, 0xc9, 0xfc, 0xb4, 0x91, 0x80, 0x3c,
-	0xc7, 0x6e, 0x59, 0x84, 0xc1, 0x5e, 0x4f, 0x9e, 0x09, 0xff, 0x66, 0xb0, 0x3d, 0x6c, 0x05, 0xf2,
-	0x18, 0xf8, 0x37, 0x7b, 0x32, 0x45, 0xd4, 0xb2, 0xf7, 0xb9, 0xce, 0xa7, 0x0c, 0x31, 0xd0, 0xff,
-	0x58, 0x03, 0x30, 0x70, 0x9f, 0x50, 0x6e, 0xb2, 0x4c, 0xae, 0x1d, 0xcb, 0xde, 0x67, 0xcf, 0x0e,
-	0x5e, 0x3a, 0x15, 0xfa, 0x6c, 0x49, 0x18, 0x2f, 0x9d, 0x9e, 0x01, 0x50, 0x28, 0x32, 0x0c, 0x36,
-	0x8d, 0xa6, 0x84, 0x88, 0x07, 0x86, 0x8a, 0x08, 0xb2, 0xda, 0x38, 0x0a, 0x8d, 0x62, 0xdb, 0x2a,
-	0x34, 0x2e, 0x41, 0x33, 0x6b, 0x51, 0x3c, 0xa2, 0x70, 0x73, 0xba, 0x00, 0x1d, 0x55, 0x9b, 0xe5,
-	0xf6, 0x2a, 0x45, 0x69, 0x2b, 0x20, 0xb3, 0x51, 0x5e, 0x07, 0x7d, 0x4a, 0xb1, 0x1f, 0x9b, 0x52,
-	0xd3, 0x18, 0x01, 0xf4, 0x6f, 0x00, 0x54, 0x5d, 0xa0, 0x47, 0xd0, 0x1a, 0x4c, 0x33, 0xe2, 0xaa,
-	0xda, 0xbe, 0x9c, 0xaf, 0xbd, 0x8e, 0xd4, 0x60, 0x08, 0xd4, 0x64, 0x1c, 0x9b, 0x4c, 0xc5, 0xb1,
-	0xf1, 0xcf, 0x42, 0xfd, 0xbb, 0x1a, 0xac, 0xc8, 0x2c, 0xd4, 0xc5, 0xe1, 0x26, 0x39, 0x60, 0x19,
-	0xc9, 0x43, 0x22, 0x98, 0x1c, 0x4b, 0x00, 0xbe, 0x0e, 0x5d, 0x07, 0x47, 0xd4, 0xf5, 0x39, 0x43,
-	0x53, 0x1d, 0x0a, 0x2f, 0x57, 0x8b, 0x0d, 0x2d, 0x26, 0xe6, 0x6f, 0x89, 0xe9, 0x2d, 0xab, 0x8f,
-	0xd1, 0x15, 0x58, 0xd8, 0xc7, 0x38, 0x30, 0x3d, 0x62, 0x5b, 0x9e, 0xa9, 0x5c, 0x5b, 0xa6, 0x59,
-	0xf3, 0x6c, 0xea, 0x3e, 0x9b, 0xb9, 0x2d, 0xdc, 0x5b, 0x8f, 0xe0, 0xfc, 0x21, 0x92, 0xc8, 0xf0,
-	0xb6, 0x0c, 0xcd, 0x20, 0x24, 0x36, 0x8e, 0x98, 0xcd, 0xd6, 0xf8, 0x6d, 0x37, 0x02, 0xa0, 0x6b,
-	0xb0, 0x10, 0x0f, 0x3e, 0x15, 0x4e, 0x62, 0xed, 0x8a, 0x02, 0xed, 0xa4, 0x51, 0x34, 0xa5, 0xff,
-	0xa2, 0x06, 0x7a, 0x8e, 0xeb, 0x46, 0x48, 0xfa, 0xc7, 0xa8, 0xc1, 0xab, 0x70, 0x8a, 0xeb, 0x21,
-	0xe4, 0x24, 0x47, 0x8a, 0x10, 0xaf, 0xa1, 0x93, 0x6c, 0x4e, 0x70, 0x53, 0x9a, 0x18, 0xc0, 0x85,
-	0x43, 0xf7, 0xf4, 0x6f, 0xd2, 0xc5, 0x92, 0xba, 0xc4, 0xc5, 0x03, 0x27, 0x75, 0x2b, 0xe9, 0xbf,
-	0xa9, 0xa9, 0x3b, 0x35, 0x3d, 0x2b, 0xf7, 0x72, 0x13, 0x3a, 0x8e, 0x1b, 0xed, 0x9b, 0xa2, 0xf5,
-	0x73, 0x98, 0xfd, 0x8f, 0xa2, 0xa9, 0xd1, 0x76, 0xe2, 0x6f, 0x1c, 0xa1, 0x0f, 0xa1, 0x23, 0x8b,
-	0xa7, 0x89, 0x6e, 0x52, 0x6b, 0x6d, 0x29, 0x4f, 0x22, 0x8e, 0x77, 0x46, 0x5b, 0xac, 0x10, 0x23,
-	0xfd, 0xef, 0x6d, 0x68, 0x7f, 0x36, 0xc0, 0xe1, 0x30, 0x51, 0x78, 0x8e, 0xb0, 0x3c, 0x06, 0xd5,
-	0x5d, 0x4b, 0x40, 0xd8, 0x8d, 0xd3, 0x0b, 0x49, 0xdf, 0x8c, 0x1b, 0x70, 0x93, 0x1c, 0xa5, 0xc5,
-	0x80, 0x1b, 0xb2, 0x09, 0xf7, 0x3e, 0xcc, 0xf4, 0x5c, 0x8f, 0x62, 0xd1, 0xc6, 0x6a, 0xad, 0xbd,
-	0x9c, 0xdf, 0x4f, 0x92, 0xe7, 0xea, 0x06, 0x47, 0x36, 0xe4, 0x22, 0xb4, 0x03, 0x0b, 0xae, 0x1f,
-	0xf0, 0x27, 0x68, 0xe8, 0x5a, 0x9e, 0xfb, 0x6c, 0x54, 0x3a, 0x6d, 0xad, 0xbd, 0x31, 0x86, 0xd6,
-	0x3d, 0xb6, 0x72, 0x3b, 0xb9, 0xd0, 0x40, 0x6e, 0x0e, 0x86, 0x30, 0x9c, 0x22, 0x03, 0x9a, 0x67,
-	0x32, 0xcd, 0x99, 0xac, 0x8d, 0x61, 0xf2, 0x80, 0x2f, 0x4d, 0x73, 0x59, 0x20, 0x79, 0xa0, 0xb6,
-	0x05, 0x33, 0x42, 0x38, 0x16, 0xe4, 0x7b, 0x2e, 0xf6, 0x54, 0x07, 0x4e, 0x0c, 0x58, 0x1c, 0x23,
-	0x01, 0x0e, 0x2d, 0x5f, 0xc5, 0x6b, 0x35, 0x1c, 0x75, 0x82, 0xea, 0x89, 0x4e, 0x90, 0xf6, 0x87,
-	0x69, 0x40, 0x79, 0x09, 0x55, 0x3d, 0x38, 0xc4, 0x11, 0x8b, 0x81, 0xc9, 0x0b, 0x62, 0x2e, 0x01,
-	0xe7, 0x97, 0xc4, 0x17, 0xd0, 0xb4, 0xa3, 0x03, 0x93, 0xab, 0x44, 0x9a, 0xcb, 0x8d, 0x23, 0xab,
-	0x74, 0x75, 0x7d, 0xfb, 0x11, 0x87, 0x1a, 0x0d, 0x3b, 0x3a, 0xe0, 0x5f, 0xe8, 0xfb, 0x00, 0x5f,
-	0x45, 0xc4, 0x97, 0x94, 0xc5, 0xc1, 0xbf, 0x77, 0x74, 0xca, 0x1f, 0x6f, 0x3f, 0xd8, 0x12, 0xa4,
-	0x9b, 0x8c, 0x9c, 0xa0, 0x6d, 0x43, 0x27, 0xb0, 0xc2, 0x27, 0x03, 0x4c, 0x25, 0x79, 0x61, 0x0b,
-	0x1f, 0x1c, 0x9d, 0xfc, 0xa7, 0x82, 0x8c, 0xe0, 0xd0, 0x0e, 0x12, 0x23, 0xed, 0xbb, 0x49, 0x68,
-	0x28, 0xb9, 0xd8, 0x2b, 0x96, 0x5b, 0xb8, 0xa8, 0xe5, 0x98, 0xae, 0xdf, 0x23, 0x52, 0xa3, 0x27,
-	0x18, 0x5c, 0x94, 0x73, 0xf8, 0xf5, 0x75, 0x09, 0xe6, 0x43, 0x6c, 0x93, 0xd0, 0x61, 0xb9, 0xbe,
-	0xdb, 0x77, 0x99, 0xd9, 0x8b, 0xb3, 0x9c, 0x13, 0xf0, 0xdb, 0x0a, 0x8c, 0x5e, 0x85, 0x39, 0x7e,
-	0xec, 0x09, 0xcc, 0xba, 0xa2, 0x89, 0xbd, 0x04, 0xe2, 0x25, 0x98, 0x7f, 0x32, 0x60, 0x81, 0xcf,
-	0xde, 0xb3, 0x42, 0xcb, 0xa6, 0x24, 0xae, 0xaa, 0xcc, 0x71, 0xf8, 0x7a, 0x0c, 0x46, 0x6f, 0xc1,
-	0xa2, 0x40, 0xc5, 0x91, 0x6d, 0x05, 0xf1, 0x0a, 0x1c, 0xca, 0x47, 0xf7, 0x29, 0x3e, 0x7b, 0x87,
-	0x4f, 0xae, 0xab, 0x39, 0xa4, 0x41, 0xc3, 0x26, 0xfd, 0x3e, 0xf6, 0x69, 0x24, 0x1b, 0xa5, 0xf1,
-	0x18, 0xdd, 0x84, 0x33, 0x96, 0xe7, 0x91, 0xaf, 0x4d, 0xbe, 0xd2, 0x31, 0x73, 0xd2, 0x89, 0x27,
-	0xb8, 0xc6, 0x91, 0x3e, 0xe3, 0x38, 0x46, 0x5a, 0x50, 0xed, 0x1c, 0x34, 0xe3, 0x73, 0x64, 0x29,
-	0x4f, 0xc2, 0x20, 0xf9, 0xb7, 0x76, 0x02, 0xda, 0xc9, 0x93, 0xd0, 0xfe, 0x5a, 0x87, 0x85, 0x02,
-	0xa7, 0x42, 0x8f, 0x01, 0x98, 0xb5, 0x0a, 0xd7, 0x92, 0xe6, 0xfa, 0xbf, 0x47, 0x77, 0x4e, 0x66,
-	0xaf, 0x02, 0x6c, 0x30, 0xeb, 0x17, 0x9f, 0xe8, 0x07, 0xd0, 0xe2, 0x16, 0x2b, 0xa9, 0x0b, 0x93,
-	0x7d, 0xff, 0x5f, 0xa0, 0xce, 0x64, 0x95, 0xe4, 0xb9, 0x0f, 0x88, 0x6f, 0xed, 0xcf, 0x35, 0x68,
-	0xc6, 0x8c, 0x59, 0x02, 0x27, 0x0e, 0x8a, 0x9f, 0x75, 0xa4, 0x12, 0x38, 0x0e, 0xdb, 0xe0, 0xa0,
-	0xff, 0x4a, 0x53, 0xd2, 0xde, 0x01, 0x18, 0xc9, 0x5f, 0x28, 0x42, 0xad, 0x50, 0x04, 0xfd, 0x12,
-	0x74, 0x98, 0x66, 0x5d, 0xec, 0x6c, 0xd3, 0xd0, 0x0d, 0xf8, 0x4f, 0x1a, 0x04, 0x4e, 0x24, 0x1f,
-	0xd2, 0x6a, 0xb8, 0xf6, 0xb7, 0x65, 0x68, 0x27, 0x6f, 0x52, 0xf4, 0x25, 0xb4, 0x12, 0x3f, 0xdd,
-	0x40, 0x2f, 0xe5, 0x0f, 0x2d, 0xff, 0xb3, 0x11, 0xed, 0xe5, 0x31, 0x58, 0xf2, 0xad, 0x39, 0x81,
-	0x0c, 0x98, 0x95, 0xed, 0x7e, 0xb4, 0x72, 0xc8, 0x2f, 0x01, 0x04, 0xd5, 0xf3, 0x63, 0x7f, 0x2b,
-	0xa0, 0x4f, 0x5c, 0xab, 0x21, 0x1f, 0x4e, 0xe6, 0xba, 0xef, 0xe8, 0x72, 0x7e, 0x6d, 0x59, 0x6f,
-	0x5f, 0x7b, 0xad, 0x12, 0x6e, 0x2c, 0x03, 0x85, 0x85, 0x82, 0x76, 0x3a, 0x7a, 0x7d, 0x0c, 0x95,
-	0x54, 0x4b, 0x5f, 0xbb, 0x52, 0x11, 0x3b, 0xe6, 0xfa, 0x04, 0x50, 0xbe, 0xd7, 0x8e, 0x5e, 0x1b,
-	0x4b, 0x66, 0xd4, 0xcb, 0xd7, 0x5e, 0xaf, 0x86, 0x5c, 0x2a, 0xa8, 0xe8, 0xb1, 0x8f, 0x15, 0x34,
-	0xd5, 0xc5, 0x1f, 0x2b, 0x68, 0xa6, 0x71, 0x3f, 0x81, 0xf6, 0x61, 0x3e, 0xdb, 0x7f, 0x47, 0x97,
-	0xca, 0x7e, 0x27, 0x94, 0x6b, 0xef, 0x6b, 0x97, 0xab, 0xa0, 0xc6, 0xcc, 0x30, 0x9c, 0x48, 0xf7,
-	0xbb, 0xd1, 0xab, 0xf9, 0xf5, 0x85, 0x1d, 0x7f, 0xed, 0xe2, 0x78, 0xc4, 0xa4, 0x4c, 0xd9, 0x1e,
-	0x78, 0x91, 0x4c, 0x25, 0x0d, 0xf6, 0x22, 0x99, 0xca, 0x5a, 0xea, 0xfa, 0x04, 0xfa, 0x46, 0x35,
-	0x56, 0x33, 0xbd, 0x61, 0xb4, 0x5a, 0x46, 0xa6, 0xb8, 0x39, 0xad, 0x5d, 0xad, 0x8c, 0x9f, 0xf0,
-	0xc6, 0x2f, 0xa1, 0x95, 0x68, 0x11, 0x17, 0xc5, 0x8f, 0x7c, 0xd3, 0xb9, 0x28, 0x7e, 0x14, 0xf5,
-	0x99, 0x27, 0xd0, 0x0e, 0x74, 0x52, 0x4d, 0x63, 0xf4, 0x4a, 0xd9, 0xca, 0x74, 0x6d, 0x55, 0x7b,
-	0x75, 0x2c, 0x5e, 0xcc, 0xc3, 0x54, 0x11, 0x51, 0x86, 0xc0, 0xd2, 0xcd, 0xa5, 0x63, 0xe0, 0x2b,
-	0xe3, 0xd0, 0x52, 0xae, 0x9c, 0x6b, 0x2d, 0x17, 0xba, 0x72, 0x59, 0xeb, 0xba, 0xd0, 0x95, 0xcb,
-	0xbb, 0xd5, 0x13, 0x68, 0x0f, 0xe6, 0x32, 0x6d, 0x65, 0x74, 0xb1, 0x8c, 0x44, 0xb6, 0xa5, 0xad,
-	0x5d, 0xaa, 0x80, 0x19, 0x73, 0xfa, 0x9e, 0xaa, 0x40, 0x70, 0x93, 0xbb, 0x50, 0xbe, 0x74, 0x64,
-	0x67, 0x2f, 0x1d, 0x8e, 0x14, 0x93, 0xfe, 0x1a, 0x4e, 0x15, 0x55, 0x1b, 0xd1, 0x95, 0xa2, 0xba,
-	0x46, 0x69, 0x49, 0x53, 0x5b, 0xad, 0x8a, 0x1e, 0x33, 0xfe, 0x1c, 0x1a, 0xaa, 0xb5, 0x8a, 0x0a,
-	0x2e, 0xa5, 0x4c, 0x33, 0x5a, 0xd3, 0x0f, 0x43, 0x49, 0xb8, 0x4a, 0x5f, 0x45, 0x85, 0x51, 0xcf,
-	0xb3, 0x3c, 0x2a, 0xe4, 0xba, 0xb3, 0xe5, 0x51, 0x21, 0xdf, 0x42, 0xe5, 0xec, 0x62, 0xb3, 0x4b,
-	0xb6, 0x08, 0xcb, 0xcd, 0xae, 0xa0, 0x03, 0x5a, 0x6e, 0x76, 0x85, 0x5d, 0xc7, 0x09, 0xf4, 0x43,
-	0xf5, 0x33, 0x89, 0x6c, 0x67, 0x10, 0x95, 0xc6, 0x96, 0x92, 0x0e, 0xa5, 0x76, 0xad, 0xfa, 0x82,
-	0x98, 0xfd, 0x33, 0x15, 0x09, 0x33, 0x9d, 0xc1, 0xf2, 0x48, 0x58, 0xdc, 0x9f, 0xd4, 0xae, 0x56,
-	0xc6, 0xcf, 0x3b, 0x79, 0xb2, 0x75, 0x56, 0xae, 0xed, 0x82, 0x6e, 0x63, 0xb9, 0xb6, 0x0b, 0xbb,
-	0x71, 0xdc, 0x3f, 0x8a, 0xda, 0x62, 0x45, 0xfe, 0x71, 0x48, 0xdf, 0x4e, 0x5b, 0xad, 0x8a, 0x9e,
-	0x4a, 0x14, 0xf2, 0x7d, 0x2f, 0x34, 0x76, 0xff, 0xa9, 0x3b, 0xe0, 0x4a, 0x45, 0xec, 0xf2, 0xd3,
-	0x55, 0x77, 0xc2, 0x58, 0x01, 0x32, 0x77, 0xc3, 0xd5, 0xca, 0xf8, 0x31, 0xef, 0x40, 0xfd, 0xe8,
-	0x26, 0xd1, 0xb3, 0x42, 0x97, 0xc7, 0xd0, 0x49, 0xf4, 0xdc, 0xb4, 0xd7, 0x2a, 0xe1, 0x16, 0x79,
-	0x6f, 0xb2, 0x8b, 0x74, 0x98, 0x3d, 0xe5, 0x5a, 0x5f, 0x87, 0xd9, 0x53, 0x41, 0x63, 0xaa, 0xc0,
-	0x7b, 0x55, 0xf3, 0x68, 0xbc, 0xf7, 0x66, 0x9a, 0x58, 0xe3, 0xbd, 0x37, 0xd7, 0x97, 0x9a, 0x40,
-	0x3f, 0x19, 0xfd, 0x18, 0x23, 0x5f, 0x83, 0x45, 0x6b, 0xa5, 0xa1, 0xa8, 0xb4, 0xf4, 0xac, 0xbd,
-	0x79, 0xa4, 0x35, 0x09, 0xe5, 0xff, 0xbc, 0xa6, 0x3a, 0xbb, 0x85, 0x45, 0x50, 0xf4, 0x56, 0x05,
-	0xc2, 0xb9, 0x3a, 0xae, 0xf6, 0xf6, 0x11, 0x57, 0x15, 0x59, 0x43, 0xb2, 0xfe, 0x59, 0x6e, 0x0d,
-	0x05, 0x35, 0xd4, 0x72, 0x6b, 0x28, 0x2a, 0xa9, 0xea, 0x13, 0xe8, 0x3e, 0x4c, 0xf3, 0xe7, 0x3a,
-	0x3a, 0x7b, 0xf8, 0x3b, 0x5e, 0x3b, 0x57, 0x3c, 0x1f, 0xbf, 0x46, 0x99, 0x00, 0x3b, 0x33, 0xfc,
-	0x7f, 0x09, 0x6f, 0xfe, 0x33, 0x00, 0x00, 0xff, 0xff, 0x4d, 0x3e, 0xd5, 0xcd, 0xae, 0x30, 0x00,
+	// 3457 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3b, 0x4d, 0x73, 0xdc, 0xc6,
+	0xb1, 0x5e, 0x2e, 0x3f, 0x76, 0x7b, 0x77, 0x49, 0x6a, 0x48, 0x51, 0x2b, 0x90, 0x94, 0x28, 0xc8,
+	0xb2, 0x25, 0x59, 0xa2, 0x64, 0xda, 0x7e, 0x96, 0xe5, 0x67, 0x3f, 0x4b, 0xd4, 0x87, 0x65, 0x8b,
+	0x94, 0x0d, 0xca, 0xf2, 0x7b, 0x4f, 0x2e, 0xa3, 0x86, 0xc0, 0x2c, 0x09, 0x11, 0x8b, 0x81, 0x80,
+	0x59, 0x9a, 0xab, 0x72, 0x4e, 0x4e, 0x55, 0x52, 0x95, 0x4a, 0x0e, 0xa9, 0x5c, 0x72, 0x49, 0x55,
+	0x2a, 0xf7, 0x5c, 0x73, 0xc9, 0x0f, 0xf0, 0x1f, 0x48, 0x55, 0x4e, 0xb9, 0xe4, 0x9c, 0x43, 0x0e,
+	0x39, 0xe5, 0x92, 0x9a, 0x0f, 0x60, 0x81, 0x05, 0xc0, 0x05, 0x23, 0xa6, 0x52, 0xb9, 0x0d, 0x7a,
+	0x7a, 0xba, 0xa7, 0x7b, 0xba, 0x7b, 0x66, 0xba, 0x07, 0x30, 0xb7, 0x4f, 0xdd, 0x5e, 0x97, 0x98,
+	0x21, 0x09, 0xf6, 0x49, 0xb0, 0xea, 0x07, 0x94, 0x51, 0x34, 0x9b, 0x02, 0x9a, 0xfe, 0xb6, 0xfe,
+	0x14, 0xd0, 0x6d, 0xcc, 0xac, 0xdd, 0x3b, 0xc4, 0x25, 0x8c, 0x18, 0xe4, 0x79, 0x8f, 0x84, 0x0c,
+	0x9d, 0x86, 0x5a, 0xc7, 0x71, 0x89, 0xe9, 0xd8, 0x61, 0xbb, 0xb2, 0x52, 0xbd, 0x58, 0x37, 0xa6,
+	0xf8, 0xf7, 0x03, 0x3b, 0x44, 0x97, 0xe1, 0x44, 0xb8, 0xe7, 0xf8, 0xa6, 0x45, 0xe9, 0x9e, 0x43,
+	0x4c, 0x6b, 0x97, 0x58, 0x7b, 0xed, 0xb1, 0x95, 0xca, 0xc5, 0x9a, 0x31, 0xc3, 0x3b, 0xd6, 0x05,
+	0x7c, 0x9d, 0x83, 0xf5, 0x47, 0x30, 0x97, 0x22, 0x1e, 0xfa, 0xd4, 0x0b, 0x09, 0xba, 0x01, 0x53,
+	0x01, 0x09, 0x7b, 0x2e, 0x93, 0xc4, 0x1b, 0x6b, 0x67, 0x56, 0x87, 0xe7, 0xb5, 0x1a, 0x0f, 0xe9,
+	0xb9, 0xcc, 0x88, 0xd0, 0xf5, 0xef, 0x2a, 0xd0, 0x4c, 0xf6, 0xa0, 0x53, 0x30, 0xa5, 0x26, 0xda,
+	0xae, 0xac, 0x54, 0x2e, 0xd6, 0x8d, 0x49, 0x39, 0x4f, 0xb4, 0x00, 0x93, 0x21, 0xc3, 0xac, 0x17,
+	0x8a, 0xb9, 0x4d, 0x18, 0xea, 0x0b, 0xcd, 0xc3, 0x04, 0x09, 0x02, 0x1a, 0xb4, 0xab, 0x02, 0x5d,
+	0x7e, 0x20, 0x04, 0xe3, 0xa1, 0xf3, 0x82, 0xb4, 0xc7, 0x57, 0x2a, 0x17, 0x5b, 0x86, 0x68, 0xa3,
+	0x36, 0x4c, 0xed, 0x93, 0x20, 0x74, 0xa8, 0xd7, 0x9e, 0x10, 0xe0, 0xe8, 0x53, 0xff, 0x04, 0xa6,
+	0xef, 0x39, 0x2e, 0xb9, 0x4f, 0x58, 0xa4, 0xaf, 0xc2, 0x69, 0x9c, 0x85, 0x06, 0xb6, 0x2c, 0xe2,
+	0x33, 0x73, 0xe7, 0x85, 0xe3, 0x2b, 0x3d, 0x81, 0x04, 0xdd, 0x7f, 0xe1, 0xf8, 0xfa, 0x8f, 0xaa,
+	0x30, 0x13, 0x13, 0x53, 0xfa, 0x41, 0x30, 0x6e, 0x63, 0x86, 0x05, 0xa9, 0xa6, 0x21, 0xda, 0xe8,
+	0x02, 0x4c, 0x5b, 0xd4, 0x63, 0xc4, 0x63, 0xa6, 0x4b, 0xbc, 0x1d, 0xb6, 0x2b, 0x68, 0xb5, 0x8c,
+	0x96, 0x82, 0x3e, 0x14, 0x40, 0x74, 0x0e, 0x9a, 0x11, 0x1a, 0xeb, 0xfb, 0x44, 0x49, 0xd9, 0x50,
+	0xb0, 0xc7, 0x7d, 0x9f, 0xa0, 0xf3, 0xd0, 0x72, 0x71, 0xc8, 0xcc, 0x2e, 0xb5, 0x9d, 0x8e, 0x43,
+	0x6c, 0x21, 0xf4, 0xb8, 0xd1, 0xe4, 0xc0, 0x0d, 0x05, 0x43, 0x9a, 0x34, 0x00, 0x0f, 0x77, 0x89,
+	0x90, 0xbe, 0x6e, 0xc4, 0xdf, 0x7c, 0x7a, 0x84, 0xe1, 0x9d, 0xf6, 0xa4, 0x80, 0x8b, 0x36, 0x5a,
+	0x06, 0x70, 0x42, 0x21, 0xa3, 0x4f, 0xec, 0xf6, 0x94, 0x10, 0xb3, 0xee, 0x84, 0xf7, 0x25, 0x00,
+	0x7d, 0x0c, 0x53, 0xbb, 0x04, 0xdb, 0x24, 0x08, 0xdb, 0x35, 0xb1, 0xe2, 0xab, 0xd9, 0x15, 0x1f,
+	0xd2, 0xc2, 0xea, 0xc7, 0x72, 0xc0, 0x5d, 0x8f, 0x05, 0x7d, 0x23, 0x1a, 0x8e, 0x96, 0xa0, 0x2e,
+	0x96, 0x6c, 0x9d, 0xda, 0xa4, 0x5d, 0x17, 0x4b, 0x3b, 0x00, 0x68, 0x37, 0xa1, 0x99, 0x1c, 0x86,
+	0x66, 0xa1, 0xba, 0x47, 0xfa, 0x6a, 0x4d, 0x78, 0x93, 0xaf, 0xff, 0x3e, 0x76, 0x7b, 0x44, 0xa8,
+	0xaf, 0x6e, 0xc8, 0x8f, 0x9b, 0x63, 0x37, 0x2a, 0xfa, 0x14, 0x4c, 0xdc, 0xed, 0xfa, 0xac, 0xaf,
+	0xbf, 0x0b, 0xed, 0x27, 0xd8, 0xea, 0xf5, 0xba, 0x4f, 0xc4, 0x14, 0x85, 0x29, 0x47, 0x0b, 0xbd,
+	0x08, 0x75, 0x35, 0x71, 0xb5, 0xd4, 0x2d, 0xa3, 0x26, 0x01, 0x0f, 0x6c, 0xfd, 0x23, 0x38, 0x9d,
+	0x33, 0x50, 0x2d, 0xea, 0x79, 0x68, 0xed, 0xe0, 0x60, 0x1b, 0xef, 0x10, 0x33, 0xc0, 0xcc, 0xa1,
+	0x62, 0x74, 0xc5, 0x68, 0x2a, 0xa0, 0xc1, 0x61, 0xfa, 0x53, 0xd0, 0x52, 0x14, 0x68, 0xd7, 0xc7,
+	0x16, 0x2b, 0xc3, 0x1c, 0xad, 0x40, 0xc3, 0x0f, 0x08, 0x76, 0x5d, 0x6a, 0x61, 0x26, 0xc5, 0xab,
+	0x1a, 0x49, 0x90, 0xbe, 0x0c, 0x8b, 0xb9, 0xc4, 0xe5, 0x04, 0xf5, 0x1b, 0x43, 0xb3, 0xa7, 0xdd,
+	0xae, 0x53, 0x8a, 0xb5, 0xfe, 0x21, 0x68, 0x79, 0x23, 0x95, 0xe0, 0x2b, 0xd0, 0x74, 0x42, 0x33,
+	0x20, 0xd8, 0x36, 0xa9, 0xe7, 0xca, 0xc5, 0xa8, 0x19, 0xe0, 0x84, 0x06, 0xc1, 0xf6, 0x23, 0xcf,
+	0xed, 0xeb, 0xef, 0x0d, 0x8d, 0x77, 0x09, 0xf6, 0x7a, 0x7e, 0x29, 0xd6, 0xc3, 0x32, 0x45, 0x43,
+	0x95, 0x4c, 0xef, 0xc1, 0x29, 0x19, 0x2e, 0xd6, 0xa9, 0xeb, 0x12, 0x8b, 0x39, 0xd4, 0x8b, 0xc8,
+	0x9e, 0x01, 0xb0, 0x62, 0xa0, 0xb2, 0x90, 0x04, 0x44, 0xd7, 0xa0, 0x9d, 0x1d, 0xaa, 0xc8, 0xfe,
+	0xa9, 0x02, 0x27, 0x6f, 0x29, 0xb5, 0x4a, 0xc6, 0xa5, 0x96, 0x28, 0xcd, 0x72, 0x6c, 0x98, 0xe5,
+	0xf0, 0x12, 0x56, 0x33, 0x4b, 0xc8, 0x31, 0x02, 0xe2, 0xbb, 0x8e, 0x85, 0x05, 0x89, 0x71, 0xe9,
+	0xdd, 0x09, 0x10, 0xb7, 0x78, 0xc6, 0x5c, 0xe5, 0xb3, 0xbc, 0x89, 0xd6, 0x60, 0xa1, 0x4b, 0xba,
+	0x34, 0xe8, 0x9b, 0x5d, 0xec, 0x9b, 0x5d, 0x7c, 0x60, 0xf2, 0xf0, 0x66, 0x76, 0xb7, 0x85, 0x03,
+	0xb7, 0x0c, 0x24, 0x7b, 0x37, 0xb0, 0xbf, 0x81, 0x0f, 0xb6, 0x9c, 0x17, 0x64, 0x63, 0x5b, 0x6f,
+	0xc3, 0xc2, 0xb0, 0x7c, 0x4a, 0xf4, 0xff, 0x82, 0x53, 0x12, 0xb2, 0xd5, 0xf7, 0xac, 0x2d, 0x11,
+	0x53, 0x4b, 0x2d, 0xd4, 0xdf, 0x2b, 0xd0, 0xce, 0x0e, 0x54, 0x26, 0xf2, 0xb2, 0x5a, 0x3b, 0xb2,
+	0x4e, 0xce, 0x42, 0x83, 0x61, 0xc7, 0x35, 0x69, 0xa7, 0x13, 0x12, 0x26, 0x14, 0x31, 0x6e, 0x00,
+	0x07, 0x3d, 0x12, 0x10, 0x74, 0x09, 0x66, 0x2d, 0xe9, 0x1f, 0x66, 0x40, 0xf6, 0x1d, 0xb1, 0x0b,
+	0x4c, 0x89, 0x89, 0xcd, 0x58, 0x91, 0xdf, 0x48, 0x30, 0xd2, 0xa1, 0xe5, 0xd8, 0x07, 0xa6, 0x88,
+	0xff, 0x62, 0x13, 0xa9, 0x09, 0x6a, 0x0d, 0xc7, 0x3e, 0xe0, 0x21, 0x8d, 0x6b, 0x54, 0x7f, 0x02,
+	0x4b, 0x52, 0xf8, 0x07, 0x9e, 0x15, 0x90, 0x2e, 0xf1, 0x18, 0x76, 0xd7, 0xa9, 0xdf, 0x2f, 0x65,
+	0x36, 0xa7, 0xa1, 0x16, 0x3a, 0x9e, 0x45, 0x4c, 0x4f, 0x6e, 0x66, 0xe3, 0xc6, 0x94, 0xf8, 0xde,
+	0x0c, 0xf5, 0xdb, 0xb0, 0x5c, 0x40, 0x57, 0x69, 0xf6, 0x1c, 0x34, 0xc5, 0xc4, 0xd4, 0x06, 0xa0,
+	0xb6, 0x94, 0x06, 0x87, 0xad, 0x4b, 0x90, 0xfe, 0x26, 0x20, 0x49, 0x63, 0x83, 0xf6, 0xbc, 0x72,
+	0x0e, 0x7f, 0x12, 0xe6, 0x52, 0x43, 0x94, 0x6d, 0xbc, 0x05, 0xf3, 0x12, 0xfc, 0x85, 0xd7, 0x2d,
+	0x4d, 0xeb, 0x14, 0x9c, 0x1c, 0x1a, 0xa4, 0xa8, 0xad, 0x45, 0x4c, 0xd2, 0x47, 0x93, 0x43, 0x89,
+	0x2d, 0xc0, 0x7c, 0x7a, 0x4c, 0x22, 0xb6, 0xc9, 0x09, 0xe3, 0x60, 0x8f, 0xc7, 0x1d, 0x1e, 0x89,
+	0x4a, 0x51, 0x5c, 0x02, 0x2d, 0x6f, 0xa4, 0xa2, 0xfb, 0x25, 0x2c, 0x44, 0x31, 0xcf, 0xeb, 0x38,
+	0x3b, 0xbd, 0x80, 0x94, 0x8d, 0xd5, 0x49, 0x93, 0x1d, 0xcb, 0x98, 0xac, 0x7e, 0x0d, 0x4e, 0x65,
+	0x08, 0xab, 0x25, 0x8d, 0x4f, 0x30, 0x95, 0xc4, 0x09, 0x46, 0xff, 0x6d, 0x05, 0x4e, 0x44, 0x23,
+	0x4a, 0xda, 0xd5, 0x11, 0x1d, 0xab, 0x5a, 0xe8, 0x58, 0xe3, 0x03, 0xc7, 0xba, 0x08, 0xb3, 0x21,
+	0xed, 0x05, 0x16, 0x31, 0xf9, 0xa9, 0xc5, 0xf4, 0xf8, 0x2e, 0x2d, 0xfd, 0x6e, 0x5a, 0xc2, 0xef,
+	0x60, 0x86, 0x37, 0xa9, 0x4d, 0xf4, 0xff, 0x01, 0x94, 0x9c, 0xaf, 0x12, 0xee, 0x12, 0x9c, 0x10,
+	0x87, 0x13, 0xec, 0xfb, 0xc4, 0xb3, 0x4d, 0xcc, 0xb8, 0xd1, 0x57, 0x84, 0xd1, 0x4f, 0xf3, 0x8e,
+	0x5b, 0x02, 0x7e, 0x8b, 0x6d, 0x86, 0xfa, 0x2f, 0xc6, 0x60, 0x86, 0x8f, 0xe5, 0x4e, 0x56, 0x4a,
+	0xde, 0x59, 0xa8, 0x92, 0x03, 0xa6, 0x04, 0xe5, 0x4d, 0x74, 0x0d, 0xe6, 0x94, 0x37, 0x3b, 0xd4,
+	0x1b, 0x38, 0x7a, 0x55, 0xc6, 0xc5, 0x41, 0x57, 0xec, 0xeb, 0x67, 0xa1, 0x11, 0x32, 0xea, 0x47,
+	0x71, 0x43, 0x9e, 0x9c, 0x80, 0x83, 0x54, 0xdc, 0x48, 0xeb, 0x74, 0x22, 0x47, 0xa7, 0x7c, 0x33,
+	0x24, 0x96, 0x29, 0x67, 0xd5, 0x9e, 0x8c, 0x36, 0xc3, 0xbb, 0x96, 0xd4, 0x06, 0xfa, 0x10, 0x96,
+	0x9c, 0x1d, 0x8f, 0x06, 0xc4, 0x54, 0x8a, 0x14, 0xfe, 0xeb, 0x51, 0x66, 0x76, 0x68, 0xcf, 0x8b,
+	0xce, 0x56, 0x6d, 0x89, 0xb3, 0x25, 0x50, 0xb8, 0x06, 0x36, 0x29, 0xbb, 0xc7, 0xfb, 0xf5, 0x77,
+	0x60, 0x76, 0xa0, 0x95, 0xf2, 0x51, 0xe0, 0xbb, 0x4a, 0x64, 0x71, 0x8f, 0xb1, 0xe3, 0x6e, 0x11,
+	0xcf, 0x26, 0xc1, 0x4b, 0x46, 0x27, 0x74, 0x1d, 0xe6, 0x1d, 0xdb, 0x25, 0x26, 0x73, 0xba, 0x84,
+	0xf6, 0x98, 0x19, 0x12, 0x8b, 0x7a, 0x76, 0x18, 0xe9, 0x97, 0xf7, 0x3d, 0x96, 0x5d, 0x5b, 0xb2,
+	0x47, 0xff, 0x61, 0xbc, 0x4b, 0x24, 0x67, 0x31, 0x38, 0x41, 0x79, 0x84, 0x70, 0x82, 0xf2, 0x30,
+	0xa8, 0xc4, 0x68, 0x4a, 0xa0, 0x3c, 0xf7, 0xf1, 0x15, 0x52, 0x48, 0xdb, 0xd4, 0xee, 0x8b, 0x19,
+	0x35, 0x0d, 0x90, 0xa0, 0xdb, 0xd4, 0xee, 0x8b, 0x70, 0x1d, 0x9a, 0xc2, 0xc8, 0xac, 0xdd, 0x9e,
+	0xb7, 0x27, 0x66, 0x53, 0x33, 0x1a, 0x4e, 0xf8, 0x10, 0x87, 0x6c, 0x9d, 0x83, 0xf4, 0xdf, 0x55,
+	0xe0, 0xf4, 0x60, 0x1a, 0x06, 0xb1, 0x88, 0xb3, 0xff, 0x6f, 0x50, 0x07, 0x1f, 0xa1, 0x8c, 0x20,
+	0x75, 0x5a, 0x56, 0x0e, 0x87, 0x64, 0x9f, 0xda, 0x55, 0x45, 0xcf, 0x20, 0x5c, 0xa5, 0x27, 0xae,
+	0xc2, 0xd5, 0x57, 0xd1, 0x76, 0x71, 0xd7, 0xda, 0xda, 0xc5, 0x81, 0x1d, 0xde, 0x27, 0x1e, 0x09,
+	0x30, 0x3b, 0x96, 0xe3, 0x8b, 0xbe, 0x02, 0x67, 0x8a, 0xa8, 0x2b, 0xfe, 0x4f, 0x61, 0x29, 0x8d,
+	0x61, 0x90, 0xed, 0x9e, 0xe3, 0xda, 0xc7, 0xc2, 0xfe, 0x53, 0x58, 0x2e, 0x20, 0xae, 0xec, 0xe7,
+	0x32, 0x9c, 0x08, 0x04, 0x88, 0x99, 0x21, 0x47, 0x88, 0x6f, 0xb7, 0x2d, 0x63, 0x46, 0x75, 0x88,
+	0x81, 0x0f, 0xec, 0x50, 0xff, 0xc9, 0x18, 0x9c, 0x4e, 0x53, 0x3b, 0xb6, 0xb0, 0xba, 0x08, 0xf5,
+	0x01, 0xfb, 0xaa, 0x60, 0x5f, 0x0b, 0x15, 0x5f, 0x6e, 0x9d, 0x16, 0xf5, 0xfb, 0x26, 0xb1, 0xe4,
+	0x89, 0x42, 0x2c, 0x75, 0x8d, 0x5f, 0xe0, 0xfc, 0xfe, 0x5d, 0x4b, 0x1c, 0x28, 0xca, 0xc7, 0xd8,
+	0x04, 0xb5, 0x67, 0x92, 0xda, 0x64, 0x92, 0xda, 0x33, 0x41, 0x2d, 0xc2, 0xd9, 0x77, 0x3a, 0x12,
+	0x67, 0x6a, 0x80, 0xf3, 0xc4, 0xe9, 0x70, 0x9c, 0x81, 0x55, 0xa5, 0x95, 0xa1, 0x56, 0xf5, 0x1b,
+	0x58, 0x4c, 0xf7, 0x96, 0xdf, 0xb0, 0x5f, 0x4a, 0x59, 0xfa, 0x19, 0x58, 0xca, 0x67, 0xac, 0x26,
+	0xb6, 0x3f, 0x3c, 0xed, 0xd2, 0x27, 0x9c, 0x97, 0x9b, 0xd7, 0x32, 0x2c, 0xe6, 0xf2, 0x55, 0xd3,
+	0xfa, 0xdf, 0xe1, 0x69, 0x1f, 0xe1, 0xb8, 0x74, 0x38, 0xe3, 0xb3, 0xb0, 0x5c, 0x40, 0x59, 0xb1,
+	0xfe, 0x65, 0x1c, 0x5f, 0x15, 0x06, 0x3f, 0xd1, 0x94, 0x8e, 0x6b, 0x8a, 0xaf, 0xca, 0x3c, 0x4c,
+	0x29, 0xb6, 0x3c, 0xd5, 0xa2, 0xf6, 0x43, 0x79, 0x63, 0x51, 0x5f, 0xa9, 0xa4, 0x4a, 0x55, 0x25,
+	0x55, 0xa2, 0xc4, 0x12, 0xbf, 0x95, 0x4f, 0xc8, 0xf0, 0xc8, 0xbf, 0x3f, 0x25, 0x7d, 0x7d, 0x13,
+	0x4e, 0xe7, 0x4c, 0xed, 0x90, 0x94, 0x88, 0xcc, 0x39, 0xd8, 0x62, 0xcd, 0x6d, 0x95, 0x5a, 0xa9,
+	0x3b, 0xca, 0x08, 0x6c, 0xfd, 0xa7, 0x95, 0x01, 0xc1, 0xdb, 0x2e, 0xdd, 0x3e, 0x46, 0xab, 0x4c,
+	0x4a, 0x51, 0x4d, 0x49, 0x91, 0xcc, 0x1a, 0x8d, 0xa7, 0xb3, 0x46, 0x09, 0x27, 0x4a, 0x4e, 0xa7,
+	0x28, 0x34, 0x3f, 0xa6, 0xc7, 0x77, 0xb3, 0xcc, 0x86, 0xe6, 0x01, 0x75, 0xc5, 0xff, 0x26, 0x2c,
+	0x72, 0x85, 0x4b, 0xa8, 0xb8, 0xb7, 0x94, 0xbf, 0xdb, 0xfd, 0x65, 0x0c, 0x96, 0xf2, 0x07, 0x97,
+	0xb9, 0xdf, 0xbd, 0x0f, 0x5a, 0x7c, 0x7f, 0xe2, 0x5b, 0x63, 0xc8, 0x70, 0xd7, 0x8f, 0x37, 0x47,
+	0xb9, 0x87, 0x9e, 0x52, 0x97, 0xa9, 0xc7, 0x51, 0x7f, 0xb4, 0x43, 0x66, 0x2e, 0x5f, 0xd5, 0xcc,
+	0xe5, 0x8b, 0x33, 0xb0, 0x31, 0x2b, 0x62, 0x20, 0xcf, 0x70, 0xa7, 0x6c, 0xcc, 0x8a, 0x18, 0xc4,
+	0x83, 0x05, 0x03, 0x69, 0xb5, 0x0d, 0x85, 0x2f, 0x18, 0x2c, 0x03, 0xa8, 0xe3, 0x55, 0xcf, 0x8b,
+	0x2e, 0x93, 0x75, 0x79, 0xb8, 0xea, 0x79, 0x85, 0xa7, 0xcc, 0xa9, 0xc2, 0x53, 0x66, 0x7a, 0x35,
+	0x6b, 0x99, 0xd5, 0xfc, 0x55, 0x05, 0xe0, 0x8e, 0x13, 0xee, 0x49, 0x2d, 0xf3, 0x73, 0xad, 0xed,
+	0x44, 0xd7, 0x01, 0xde, 0xe4, 0x10, 0xec, 0xba, 0x4a, 0x77, 0xbc, 0xc9, 0xfd, 0xa7, 0x17, 0x12,
+	0x5b, 0xa9, 0x47, 0xb4, 0x39, 0xac, 0x13, 0x10, 0xa2, 0x34, 0x20, 0xda, 0xfc, 0xa4, 0xe8, 0x93,
+	0xc0, 0x22, 0x1e, 0x33, 0x45, 0x1f, 0x97, 0x76, 0xcc, 0x68, 0x28, 0xd8, 0xbd, 0x21, 0x14, 0x41,
+	0x72, 0x32, 0x85, 0xf2, 0x45, 0x48, 0x6c, 0xfd, 0x37, 0x15, 0xa8, 0x6f, 0x90, 0xae, 0x9a, 0xdf,
+	0x19, 0x80, 0x1d, 0x1a, 0xd0, 0x1e, 0x73, 0x3c, 0x22, 0x0f, 0xf3, 0x13, 0x46, 0x02, 0xf2, 0x12,
+	0xb3, 0xe5, 0x11, 0x86, 0xb8, 0x1d, 0xb5, 0x26, 0xa2, 0xcd, 0x61, 0xbb, 0x04, 0xfb, 0x6a, 0x19,
+	0x44, 0x9b, 0x5f, 0x99, 0x42, 0x86, 0xad, 0x3d, 0xa1, 0xf3, 0x71, 0x43, 0x7e, 0xe8, 0x7f, 0xac,
+	0x00, 0x18, 0xa4, 0x4b, 0x99, 0x30, 0x59, 0x2e, 0xd7, 0x36, 0xb6, 0xf6, 0xf8, 0xb5, 0x43, 0xa4,
+	0x4e, 0xa5, 0x3e, 0x1b, 0x0a, 0x26, 0x52, 0xa7, 0xcb, 0x00, 0x11, 0x8a, 0x0a, 0x83, 0x75, 0xa3,
+	0xae, 0x20, 0xf2, 0x82, 0x11, 0x45, 0x04, 0x95, 0x6d, 0x1c, 0x84, 0x46, 0x39, 0x6d, 0xf5, 0xc5,
+	0x1d, 0x62, 0xd8, 0xa2, 0x6a, 0x9d, 0xc8, 0x9c, 0xce, 0x43, 0x2b, 0xca, 0xcd, 0x0a, 0x7b, 0x55,
+	0xa2, 0x34, 0x23, 0x20, 0xb7, 0x51, 0x91, 0x07, 0x3d, 0x60, 0xc4, 0x8b, 0x4d, 0xa9, 0x6e, 0x0c,
+	0x00, 0xfa, 0xb7, 0x00, 0x51, 0x5e, 0xa0, 0x43, 0xd1, 0x1a, 0x4c, 0x70, 0xe2, 0x51, 0xb6, 0x7d,
+	0x29, 0x9b, 0x7b, 0x1d, 0xa8, 0xc1, 0x90, 0xa8, 0xc9, 0x38, 0x36, 0x96, 0x8a, 0x63, 0xa3, 0xaf,
+	0x85, 0xfa, 0xf7, 0x15, 0x58, 0x51, 0xa7, 0x50, 0x87, 0x04, 0x1b, 0x74, 0x9f, 0x9f, 0x48, 0x1e,
+	0x53, 0xc9, 0xe4, 0x58, 0x02, 0xf0, 0x0d, 0x68, 0xdb, 0x24, 0x64, 0x8e, 0x27, 0x18, 0x9a, 0xd1,
+	0xa2, 0x88, 0x74, 0xb5, 0x9c, 0xd0, 0x42, 0xa2, 0xff, 0xb6, 0xec, 0xde, 0xe4, 0xc9, 0xeb, 0xab,
+	0x30, 0xb7, 0x47, 0x88, 0x6f, 0xba, 0xd4, 0xc2, 0xae, 0x19, 0xb9, 0xb6, 0x3a, 0x66, 0xcd, 0xf2,
+	0xae, 0x87, 0xbc, 0xe7, 0x8e, 0x74, 0x6f, 0x3d, 0x84, 0x73, 0x87, 0x48, 0xa2, 0xc2, 0xdb, 0x12,
+	0xd4, 0xfd, 0x80, 0x5a, 0x24, 0x0c, 0x89, 0x14, 0xa5, 0x6a, 0x0c, 0x00, 0xe8, 0x3a, 0xcc, 0xc5,
+	0x1f, 0x9f, 0x49, 0x27, 0xc1, 0x3b, 0x32, 0x41, 0x3b, 0x66, 0xe4, 0x75, 0xe9, 0x3f, 0xaf, 0x80,
+	0x9e, 0xe1, 0x7a, 0x2f, 0xa0, 0xdd, 0x63, 0xd4, 0xe0, 0x35, 0x98, 0x17, 0x7a, 0x08, 0x04, 0xc9,
+	0x81, 0x22, 0xe4, 0x6d, 0xe8, 0x04, 0xef, 0x93, 0xdc, 0x22, 0x4d, 0xf4, 0xe0, 0xfc, 0xa1, 0x73,
+	0xfa, 0x17, 0xe9, 0x62, 0x11, 0x4e, 0x27, 0x2f, 0x38, 0xa9, 0x5d, 0x49, 0xff, 0x75, 0x05, 0xb4,
+	0xbc, 0x5e, 0x35, 0x97, 0x5b, 0xd0, 0xb2, 0x9d, 0x70, 0xcf, 0x94, 0xa5, 0x9f, 0xc3, 0xec, 0x7f,
+	0x10, 0x4d, 0x8d, 0xa6, 0x1d, 0xb7, 0x49, 0x88, 0x3e, 0x82, 0x96, 0x4a, 0x9e, 0x26, 0xaa, 0x49,
+	0x8d, 0xb5, 0xc5, 0x2c, 0x89, 0x38, 0xde, 0x19, 0x4d, 0x39, 0x42, 0x7e, 0xe9, 0xbf, 0x9f, 0x86,
+	0xe6, 0xe7, 0x3d, 0x12, 0xf4, 0x13, 0x89, 0xe7, 0x90, 0xa8, 0x65, 0x88, 0xaa, 0x6b, 0x09, 0x08,
+	0xdf, 0x71, 0x3a, 0x01, 0xed, 0x9a, 0x71, 0x01, 0x6e, 0x4c, 0xa0, 0x34, 0x38, 0xf0, 0x9e, 0x2a,
+	0xc2, 0x7d, 0x00, 0xbc, 0xc0, 0xc4, 0x88, 0x2c, 0x63, 0x35, 0xd6, 0x2e, 0x64, 0xe7, 0x93, 0xe4,
+	0xc9, 0x6b, 0x2b, 0x8c, 0x04, 0x86, 0x1a, 0x84, 0xb6, 0x61, 0xce, 0xf1, 0x7c, 0x71, 0x05, 0x0d,
+	0x1c, 0xec, 0x3a, 0x2f, 0x06, 0xa9, 0xd3, 0xc6, 0xda, 0x9b, 0x23, 0x68, 0x3d, 0xe0, 0x23, 0xb7,
+	0x92, 0x03, 0x0d, 0xe4, 0x64, 0x60, 0x88, 0xc0, 0x3c, 0xed, 0xb1, 0x2c, 0x93, 0x09, 0xc1, 0x64,
+	0x6d, 0x04, 0x93, 0x47, 0x3d, 0x36, 0x4c, 0xd1, 0x98, 0xa3, 0x59, 0x20, 0xd7, 0x26, 0x39, 0xf0,
+	0x03, 0x12, 0x8a, 0x50, 0x25, 0x4b, 0x52, 0x09, 0x08, 0xfa, 0x04, 0x1a, 0x74, 0xfb, 0x19, 0xb1,
+	0x98, 0xe9, 0xe3, 0x80, 0x89, 0x48, 0xd9, 0x58, 0xbb, 0x34, 0x8a, 0xbb, 0x18, 0xf1, 0x19, 0x0e,
+	0x98, 0x01, 0x34, 0x6e, 0x6b, 0x9b, 0x30, 0x29, 0x15, 0xc9, 0x37, 0x94, 0x8e, 0x43, 0xdc, 0xa8,
+	0xda, 0x27, 0x3f, 0x78, 0xcc, 0xa4, 0x3e, 0x09, 0xb0, 0x17, 0xed, 0x0d, 0xd1, 0xe7, 0xa0, 0xea,
+	0x54, 0x4d, 0x54, 0x9d, 0xb4, 0x3f, 0x4c, 0x00, 0xca, 0x6a, 0x33, 0xca, 0x3d, 0x2b, 0x09, 0x92,
+	0x9b, 0xd1, 0x4c, 0x02, 0x2e, 0x36, 0xa4, 0x2f, 0xa1, 0x6e, 0x85, 0xfb, 0xa6, 0x50, 0xbf, 0x32,
+	0xcd, 0x9b, 0x47, 0x5e, 0xbe, 0xd5, 0xf5, 0xad, 0x27, 0x02, 0x6a, 0xd4, 0xac, 0x70, 0x5f, 0xb4,
+	0xd0, 0xff, 0x03, 0x3c, 0x0b, 0xa9, 0xa7, 0x28, 0x4b, 0x23, 0x7b, 0xff, 0xe8, 0x94, 0x3f, 0xd9,
+	0x7a, 0xb4, 0x29, 0x49, 0xd7, 0x39, 0x39, 0x49, 0xdb, 0x82, 0x96, 0x8f, 0x83, 0xe7, 0x3d, 0xc2,
+	0x14, 0x79, 0x69, 0x77, 0x1f, 0x1e, 0x9d, 0xfc, 0x67, 0x92, 0x8c, 0xe4, 0xd0, 0xf4, 0x13, 0x5f,
+	0xda, 0xf7, 0x63, 0x50, 0x8b, 0xe4, 0xe2, 0x37, 0x66, 0xe1, 0x4d, 0x32, 0x6f, 0x64, 0x3a, 0x5e,
+	0x87, 0x2a, 0x8d, 0x4e, 0x77, 0x9c, 0x28, 0x75, 0x24, 0xb6, 0xca, 0x4b, 0x30, 0x1b, 0x10, 0x8b,
+	0x06, 0x36, 0xbf, 0x57, 0x38, 0x5d, 0x87, 0xbb, 0x98, 0x5c, 0xcb, 0x19, 0x09, 0xbf, 0x13, 0x81,
+	0xd1, 0xeb, 0x30, 0x23, 0x96, 0x3d, 0x81, 0x59, 0x8d, 0x68, 0x12, 0x37, 0x81, 0x78, 0x09, 0x66,
+	0x9f, 0xf7, 0x78, 0x90, 0xb5, 0x76, 0x71, 0x80, 0x2d, 0x46, 0xe3, 0x0c, 0xce, 0x8c, 0x80, 0xaf,
+	0xc7, 0x60, 0xf4, 0x36, 0x2c, 0x48, 0x54, 0x12, 0x5a, 0xd8, 0x8f, 0x47, 0x90, 0x40, 0x5d, 0xf0,
+	0xe7, 0x45, 0xef, 0x5d, 0xd1, 0xb9, 0x1e, 0xf5, 0xf1, 0x62, 0xad, 0x45, 0xbb, 0x5d, 0xe2, 0xb1,
+	0x50, 0x79, 0x40, 0xfc, 0x8d, 0x6e, 0xc1, 0x32, 0x76, 0x5d, 0xfa, 0x8d, 0x29, 0x46, 0xda, 0x66,
+	0x46, 0x3a, 0x79, 0xdd, 0xd7, 0x04, 0xd2, 0xe7, 0x02, 0xc7, 0x48, 0x0b, 0xaa, 0x9d, 0x85, 0x7a,
+	0xbc, 0x8e, 0xfc, 0x78, 0x95, 0x30, 0x48, 0xd1, 0xd6, 0xa6, 0xa1, 0x99, 0x5c, 0x09, 0xed, 0xaf,
+	0x55, 0x98, 0xcb, 0x71, 0x60, 0xf4, 0x14, 0x80, 0x5b, 0xab, 0x74, 0x63, 0x65, 0xae, 0xff, 0x7d,
+	0xf4, 0x40, 0xc0, 0xed, 0x55, 0x82, 0x0d, 0x6e, 0xfd, 0xb2, 0x89, 0xbe, 0x86, 0x86, 0xb0, 0x58,
+	0x45, 0x5d, 0x9a, 0xec, 0x07, 0xff, 0x04, 0x75, 0x2e, 0xab, 0x22, 0x2f, 0x7c, 0x40, 0xb6, 0xb5,
+	0x3f, 0x57, 0xa0, 0x1e, 0x33, 0xe6, 0x87, 0x45, 0xb9, 0x50, 0x62, 0xad, 0xc3, 0xe8, 0xb0, 0x28,
+	0x60, 0xf7, 0x04, 0xe8, 0x3f, 0xd2, 0x94, 0xb4, 0x77, 0x01, 0x06, 0xf2, 0xe7, 0x8a, 0x50, 0xc9,
+	0x15, 0x41, 0x7b, 0x02, 0x30, 0x88, 0x9a, 0xe8, 0x55, 0x98, 0xee, 0x38, 0x41, 0xc8, 0xcc, 0xa1,
+	0x57, 0x24, 0x4d, 0x01, 0x8d, 0x76, 0x31, 0x9d, 0x27, 0x74, 0x0f, 0x58, 0x66, 0xa7, 0xe3, 0x40,
+	0x85, 0xa3, 0x77, 0xa1, 0xc5, 0x57, 0xcc, 0x21, 0xf6, 0x16, 0x0b, 0x1c, 0x5f, 0x3c, 0xcb, 0x90,
+	0xbc, 0x43, 0x95, 0x0c, 0x88, 0x3e, 0xd1, 0x6d, 0x00, 0xbc, 0xb3, 0x13, 0x90, 0x1d, 0xcc, 0x88,
+	0xa4, 0xd5, 0x58, 0xd3, 0xf3, 0x0d, 0xc0, 0x21, 0xf6, 0xad, 0x08, 0xd5, 0x48, 0x8c, 0xd2, 0xbf,
+	0x86, 0xd9, 0xe1, 0x7e, 0x1e, 0xbc, 0xe5, 0xcd, 0x4e, 0x1e, 0x67, 0xe4, 0x07, 0x3f, 0xec, 0x87,
+	0xbd, 0xae, 0x58, 0xd1, 0x8a, 0xc1, 0x9b, 0x1c, 0xd2, 0x75, 0xe4, 0x81, 0xb8, 0x62, 0xf0, 0xa6,
+	0x80, 0xe0, 0x83, 0xf6, 0xb8, 0x82, 0xe0, 0x83, 0xb5, 0xbf, 0x2d, 0x41, 0x33, 0x79, 0x62, 0x41,
+	0x5f, 0x41, 0x23, 0xf1, 0x44, 0x06, 0xbd, 0x9a, 0x9d, 0x6f, 0xf6, 0x79, 0x8e, 0x76, 0x61, 0x04,
+	0x96, 0xba, 0xd3, 0xbf, 0x82, 0x0c, 0x98, 0x52, 0xcf, 0x2a, 0xd0, 0xca, 0x21, 0x2f, 0x2e, 0x24,
+	0xd5, 0x73, 0x23, 0xdf, 0x64, 0xe8, 0xaf, 0x5c, 0xaf, 0x20, 0x0f, 0x4e, 0x64, 0x5e, 0x39, 0xa0,
+	0xcb, 0xd9, 0xb1, 0x45, 0x6f, 0x28, 0xb4, 0x37, 0x4a, 0xe1, 0xc6, 0x32, 0x30, 0x98, 0xcb, 0x79,
+	0xb6, 0x80, 0xae, 0x8c, 0xa0, 0x92, 0x7a, 0x3a, 0xa1, 0x5d, 0x2d, 0x89, 0x1d, 0x73, 0x7d, 0x0e,
+	0x28, 0xfb, 0xa6, 0x01, 0xbd, 0x31, 0x92, 0xcc, 0xe0, 0xcd, 0x84, 0x76, 0xa5, 0x1c, 0x72, 0xa1,
+	0xa0, 0xf2, 0x2d, 0xc3, 0x48, 0x41, 0x53, 0xaf, 0x25, 0xb4, 0xab, 0x25, 0xb1, 0x63, 0xae, 0x7b,
+	0x30, 0x3b, 0xfc, 0xce, 0x01, 0x5d, 0x2a, 0x7a, 0x8f, 0x95, 0x79, 0x46, 0xa1, 0x5d, 0x2e, 0x83,
+	0x1a, 0x33, 0x23, 0x30, 0x9d, 0x7e, 0x57, 0x80, 0x5e, 0xcf, 0x8e, 0xcf, 0x7d, 0x59, 0xa1, 0x5d,
+	0x1c, 0x8d, 0x98, 0x94, 0x69, 0xf8, 0xad, 0x41, 0x9e, 0x4c, 0x05, 0x0f, 0x19, 0xb4, 0xcb, 0x65,
+	0x50, 0x63, 0x66, 0xdf, 0xc2, 0xc9, 0xdc, 0x1a, 0x3c, 0x5a, 0x2d, 0x22, 0x93, 0xff, 0x08, 0x40,
+	0xbb, 0x56, 0x1a, 0x3f, 0xe1, 0x8d, 0x5f, 0x41, 0x23, 0x51, 0x8a, 0xcf, 0x8b, 0x1f, 0xd9, 0xe2,
+	0xbe, 0x76, 0x61, 0x04, 0x56, 0x2c, 0xdb, 0x36, 0xb4, 0x52, 0xc5, 0x79, 0xf4, 0x5a, 0xd1, 0xc8,
+	0x74, 0x0e, 0x5b, 0x7b, 0x7d, 0x24, 0x5e, 0xcc, 0xc3, 0x8c, 0x22, 0xa2, 0x0a, 0x81, 0x85, 0x93,
+	0x4b, 0xc7, 0xc0, 0xd7, 0x46, 0xa1, 0xa5, 0x5c, 0x39, 0x53, 0xc2, 0xcf, 0x75, 0xe5, 0xa2, 0x27,
+	0x02, 0xda, 0x95, 0x72, 0xc8, 0x31, 0xcb, 0x5d, 0x98, 0x19, 0x2a, 0xdf, 0xa3, 0x8b, 0x45, 0x24,
+	0x86, 0x9f, 0x0e, 0x68, 0x97, 0x4a, 0x60, 0xc6, 0x9c, 0xfe, 0x2f, 0xca, 0xf4, 0x08, 0x93, 0x3b,
+	0x5f, 0x3c, 0x74, 0x60, 0x67, 0xaf, 0x1e, 0x8e, 0x14, 0x93, 0xfe, 0x06, 0xe6, 0xf3, 0xb2, 0xba,
+	0xe8, 0x6a, 0x5e, 0xfe, 0xa8, 0x30, 0x75, 0xac, 0xad, 0x96, 0x45, 0x8f, 0x19, 0x7f, 0x01, 0xb5,
+	0xa8, 0x84, 0x8d, 0x72, 0x36, 0xa5, 0xa1, 0xa2, 0xbf, 0xa6, 0x1f, 0x86, 0x92, 0x70, 0x95, 0x2e,
+	0xcc, 0x0e, 0x6a, 0xa3, 0xb2, 0xb6, 0x5c, 0x1c, 0x15, 0x32, 0x55, 0x70, 0xed, 0x72, 0x19, 0xd4,
+	0x04, 0xbb, 0xd8, 0xec, 0x92, 0xa5, 0xd8, 0x62, 0xb3, 0xcb, 0xa9, 0x34, 0x6b, 0x57, 0xca, 0x21,
+	0xc7, 0x8a, 0xfb, 0x01, 0x2c, 0xa4, 0xd3, 0xfc, 0x51, 0x05, 0x16, 0x15, 0xc6, 0x96, 0x82, 0x4a,
+	0xb0, 0x76, 0xbd, 0xfc, 0x80, 0x98, 0xfd, 0x0b, 0x38, 0x99, 0xc6, 0x51, 0x15, 0xd8, 0xe2, 0x48,
+	0x98, 0x5f, 0x07, 0xd6, 0xae, 0x95, 0xc6, 0xcf, 0x3a, 0x79, 0xb2, 0x44, 0x59, 0xac, 0xed, 0x9c,
+	0xaa, 0xae, 0x76, 0xa5, 0x1c, 0x72, 0xd2, 0x3f, 0xf2, 0xca, 0x8f, 0x79, 0xfe, 0x71, 0x48, 0x7d,
+	0x54, 0x5b, 0x2d, 0x8b, 0x9e, 0x3a, 0x28, 0x64, 0xeb, 0x8b, 0x68, 0xe4, 0xfc, 0x53, 0x7b, 0xc0,
+	0xd5, 0x92, 0xd8, 0xc5, 0xab, 0x1b, 0xed, 0x09, 0x23, 0x05, 0x18, 0xda, 0x1b, 0xae, 0x95, 0xc6,
+	0x8f, 0x79, 0xfb, 0x70, 0x22, 0x85, 0xc2, 0x03, 0x08, 0xba, 0x3c, 0x82, 0x4e, 0xa2, 0xb6, 0xa9,
+	0xbd, 0x51, 0x0a, 0x37, 0xcf, 0x7b, 0x93, 0xd5, 0xba, 0xc3, 0xec, 0x29, 0x53, 0x62, 0xd4, 0xae,
+	0x94, 0x43, 0x2e, 0xf6, 0xde, 0xa8, 0x48, 0x37, 0xda, 0x7b, 0x87, 0x8a, 0x85, 0xda, 0xf5, 0xf2,
+	0x03, 0x62, 0xf6, 0x3f, 0x1e, 0x3c, 0x7a, 0xc9, 0xe6, 0xba, 0xd1, 0x5a, 0x61, 0x28, 0x2a, 0x4c,
+	0xf1, 0x6b, 0x6f, 0x1d, 0x69, 0x4c, 0x42, 0xf9, 0x3f, 0xab, 0xc0, 0x62, 0x06, 0x73, 0x90, 0x6c,
+	0x46, 0x6f, 0x97, 0x20, 0x9c, 0xc9, 0x97, 0x6b, 0xef, 0x1c, 0x71, 0x54, 0x9e, 0x35, 0x24, 0xf3,
+	0xcc, 0xc5, 0xd6, 0x90, 0x93, 0xab, 0xd6, 0xae, 0x94, 0x43, 0x8e, 0x97, 0xe3, 0x21, 0x4c, 0x88,
+	0x54, 0x05, 0x3a, 0x73, 0x78, 0x0e, 0x43, 0x3b, 0x5b, 0x78, 0xc5, 0x95, 0x37, 0x66, 0x2e, 0xc0,
+	0xf6, 0xa4, 0xf8, 0xff, 0xe3, 0xad, 0x7f, 0x0c, 0x00, 0xfb, 0x5e, 0x76, 0xaa, 0x16, 0x32, 0x00,
 	0x00,
 }
@@ -0,0 +1,91 @@
+package csv
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqlparser"
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+// Header maps the column names to the column indexes
+type Header map[string]int
+
+func NewHeader(names []string) Header {
+	header := make(Header)
+	for i, name := range names {
+		header[name] = i
+	}
+	return header
+}
+
+// ColumnIndex resolves a column by the header name, or by the position as _1, _2, ...
+func (header Header) ColumnIndex(name string) (index int, found bool) {
+	name = strings.Replace(name, `\`, "", -1)
+	if index, found = header[name]; found {
+		return
+	}
+	if strings.HasPrefix(name, "_") {
+		if position, err := strconv.Atoi(name[1:]); err == nil && position > 0 {
+			return position - 1, true
+		}
+	}
+	return 0, false
+}
+
+// ColumnNames returns the names of the selected columns, or of all columns in the record if nothing is selected
+func (header Header) ColumnNames(projections []string, record []string) (names []string) {
+	if len(projections) > 0 {
+		for _, projection := range projections {
+			names = append(names, strings.Replace(projection, `\`, "", -1))
+		}
+		return
+	}
+	positions := make(map[int]string)
+	for name, i := range header {
+		positions[i] = name
+	}
+	for i := range record {
+		if name, found := positions[i]; found {
+			names = append(names, name)
+		} else {
+			names = append(names, "_"+strconv.Itoa(i+1))
+		}
+	}
+	return
+}
+
+// NewRecord looks up the columns of a csv record when evaluating a WHERE predicate.
+// The columns are text, but are compared as numbers with numeric literals.
+func NewRecord(record []string, header Header) sqlparser.Record {
+	return func(field string) sqltypes.Value {
+		return getField(record, header, field)
+	}
+}
+
+func QueryCsv(record []string, header Header, projections []string, where sqlparser.Expr) (passedFilter bool, values []sqltypes.Value) {
+
+	if where != nil && !where.Eval(NewRecord(record, header)) {
+		return false, nil
+	}
+
+	if len(projections) == 0 {
+		for _, field := range record {
+			values = append(values, sqltypes.NewVarChar(field))
+		}
+		return true, values
+	}
+
+	for _, projection := range projections {
+		values = append(values, getField(record, header, projection))
+	}
+	return true, values
+}
+
+func getField(record []string, header Header, name string) sqltypes.Value {
+	index, found := header.ColumnIndex(name)
+	if !found || index >= len(record) {
+		return sqltypes.NULL
+	}
+	return sqltypes.NewVarChar(record[index])
+}
@@ -0,0 +1,97 @@
+package csv
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqlparser"
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+func readAll(data string, options ReaderOptions) (records [][]string) {
+	reader := NewReader(strings.NewReader(data), options)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return
+		}
+		records = append(records, record)
+	}
+}
+
+func TestReader(t *testing.T) {
+
+	records := readAll("# comment\nname,age\r\n\"Smith, John\",30\n\n\"say \"\"hi\"\"\",\n", ReaderOptions{})
+	assert.Equal(t, [][]string{
+		{"name", "age"},
+		{"Smith, John", "30"},
+		{`say "hi"`, ""},
+	}, records)
+
+	records = readAll("a|'x\\'y'|c;d|e|f", ReaderOptions{
+		RecordDelimiter:      ";",
+		FieldDelimiter:       "|",
+		QuoteCharacter:       "'",
+		QuoteEscapeCharacter: "\\",
+	})
+	assert.Equal(t, [][]string{
+		{"a", "x'y", "c"},
+		{"d", "e", "f"},
+	}, records)
+
+	data := "\"line1\nline2\",b\n"
+	assert.Equal(t, [][]string{{"line1\nline2", "b"}}, readAll(data, ReaderOptions{AllowQuotedRecordDelimiter: true}))
+	assert.Equal(t, [][]string{{"line1"}, {"line2\"", "b"}}, readAll(data, ReaderOptions{}))
+
+	// records crossing the read buffer boundaries
+	var sb strings.Builder
+	for i := 0; i < 20000; i++ {
+		sb.WriteString("abc|\"d|ef\"\r\n")
+	}
+	records = readAll(sb.String(), ReaderOptions{RecordDelimiter: "\r\n", FieldDelimiter: "|"})
+	assert.Equal(t, 20000, len(records))
+	for _, record := range records {
+		assert.Equal(t, []string{"abc", "d|ef"}, record)
+	}
+
+}
+
+func TestQueryCsv(t *testing.T) {
+
+	header := NewHeader([]string{"name", "age", "city"})
+	record := []string{"alice", "30", "Paris"}
+
+	passed, values := QueryCsv(record, header, []string{"name", "_3", "missing"}, &sqlparser.Comparison{Field: "age", Op: ">", Value: sqltypes.NewInt64(25)})
+	assert.True(t, passed)
+	assert.Equal(t, "alice", values[0].ToString())
+	assert.Equal(t, "Paris", values[1].ToString())
+	assert.True(t, values[2].IsNull())
+
+	passed, _ = QueryCsv(record, header, nil, &sqlparser.Comparison{Field: "age", Op: ">", Value: sqltypes.NewInt64(100)})
+	assert.False(t, passed)
+
+	passed, _ = QueryCsv(record, header, nil, &sqlparser.Like{Field: "_1", Pattern: "al%"})
+	assert.True(t, passed)
+
+	passed, values = QueryCsv(record, header, nil, nil)
+	assert.True(t, passed)
+	assert.Equal(t, 3, len(values))
+	assert.Equal(t, []string{"name", "age", "city"}, header.ColumnNames(nil, record))
+
+	buf := ToJson(nil, []string{"name", "x"}, values[:2])
+	assert.Equal(t, `{"name":"alice","x":"30"}`, string(buf))
+
+}
+
+func TestToCsv(t *testing.T) {
+
+	buf := ToCsv(nil, []string{"a", "b,c", `d"e`}, WriterOptions{})
+	assert.Equal(t, "a,\"b,c\",\"d\"\"e\"\n", string(buf))
+
+	buf = ToCsv(nil, []string{"a", "b"}, WriterOptions{QuoteFields: QuoteFieldsAlways, FieldDelimiter: "\t", RecordDelimiter: "\r\n"})
+	assert.Equal(t, "\"a\"\t\"b\"\r\n", string(buf))
+
+}
@@ -0,0 +1,181 @@
+package csv
+
+import (
+	"bytes"
+	"io"
+)
+
+// readBufferSize is the size of each read from the underlying reader
+const readBufferSize = 64 * 1024
+
+const (
+	FileHeaderInfoNone   = "NONE"
+	FileHeaderInfoUse    = "USE"
+	FileHeaderInfoIgnore = "IGNORE"
+)
+
+type ReaderOptions struct {
+	FileHeaderInfo             string // NONE | USE | IGNORE
+	RecordDelimiter            string // default \n
+	FieldDelimiter             string // default ,
+	QuoteCharacter             string // default "
+	QuoteEscapeCharacter       string // default "
+	Comments                   string // default #
+	AllowQuotedRecordDelimiter bool
+}
+
+// Reader splits csv data into records. Unlike encoding/csv, the delimiters,
+// quote and escape characters are configurable as in S3 Select.
+// The data is read in a sliding window, so the whole input is never held in memory.
+type Reader struct {
+	source                     io.Reader
+	sourceErr                  error
+	data                       []byte
+	pos                        int
+	recordDelimiter            []byte
+	fieldDelimiter             []byte
+	quote                      []byte
+	escape                     []byte
+	escapedQuote               []byte
+	comments                   []byte
+	allowQuotedRecordDelimiter bool
+}
+
+func NewReader(source io.Reader, options ReaderOptions) *Reader {
+	quote := withDefault(options.QuoteCharacter, `"`)
+	escape := withDefault(options.QuoteEscapeCharacter, `"`)
+	return &Reader{
+		source:                     source,
+		recordDelimiter:            []byte(withDefault(options.RecordDelimiter, "\n")),
+		fieldDelimiter:             []byte(withDefault(options.FieldDelimiter, ",")),
+		quote:                      []byte(quote),
+		escape:                     []byte(escape),
+		escapedQuote:               []byte(escape + quote),
+		comments:                   []byte(withDefault(options.Comments, "#")),
+		allowQuotedRecordDelimiter: options.AllowQuotedRecordDelimiter,
+	}
+}
+
+func withDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// Read returns the next record, skipping comment lines and empty lines, and io.EOF at the end.
+// Errors other than io.EOF come from the underlying reader.
+func (r *Reader) Read() (record []string, err error) {
+	for r.more() {
+		if r.hasPrefix(r.comments) {
+			r.skipRecord()
+			continue
+		}
+		startsWithQuote := r.hasPrefix(r.quote)
+		record = r.readRecord()
+		if len(record) == 1 && record[0] == "" && !startsWithQuote {
+			// empty line
+			continue
+		}
+		return record, nil
+	}
+	if r.sourceErr != nil && r.sourceErr != io.EOF {
+		return nil, r.sourceErr
+	}
+	return nil, io.EOF
+}
+
+// fill makes sure at least n bytes are buffered after the current position, unless the input ends
+func (r *Reader) fill(n int) {
+	for len(r.data)-r.pos < n && r.sourceErr == nil {
+		// drop the consumed bytes
+		r.data = append(r.data[:0], r.data[r.pos:]...)
+		r.pos = 0
+		if cap(r.data)-len(r.data) < readBufferSize {
+			data := make([]byte, len(r.data), len(r.data)+readBufferSize)
+			copy(data, r.data)
+			r.data = data
+		}
+		var count int
+		count, r.sourceErr = r.source.Read(r.data[len(r.data):cap(r.data)])
+		r.data = r.data[:len(r.data)+count]
+	}
+}
+
+func (r *Reader) more() bool {
+	r.fill(1)
+	return r.pos < len(r.data)
+}
+
+func (r *Reader) skipRecord() {
+	for r.more() {
+		if r.hasPrefix(r.recordDelimiter) {
+			r.pos += len(r.recordDelimiter)
+			return
+		}
+		r.pos++
+	}
+}
+
+func (r *Reader) hasPrefix(prefix []byte) bool {
+	r.fill(len(prefix))
+	return bytes.HasPrefix(r.data[r.pos:], prefix)
+}
+
+func (r *Reader) readRecord() (record []string) {
+
+	var field []byte
+	inQuotes, atFieldStart := false, true
+
+	for r.more() {
+		if inQuotes {
+			switch {
+			case !bytes.Equal(r.escape, r.quote) && r.hasPrefix(r.escapedQuote):
+				field = append(field, r.quote...)
+				r.pos += len(r.escape) + len(r.quote)
+			case r.hasPrefix(r.quote):
+				r.pos += len(r.quote)
+				if bytes.Equal(r.escape, r.quote) && r.hasPrefix(r.quote) {
+					// doubled quotes
+					field = append(field, r.quote...)
+					r.pos += len(r.quote)
+				} else {
+					inQuotes = false
+				}
+			case !r.allowQuotedRecordDelimiter && r.hasPrefix(r.recordDelimiter):
+				r.pos += len(r.recordDelimiter)
+				return append(record, string(field))
+			default:
+				field = append(field, r.data[r.pos])
+				r.pos++
+			}
+			continue
+		}
+		switch {
+		case atFieldStart && r.hasPrefix(r.quote):
+			inQuotes, atFieldStart = true, false
+			r.pos += len(r.quote)
+		case r.hasPrefix(r.fieldDelimiter):
+			record = append(record, string(field))
+			field, atFieldStart = nil, true
+			r.pos += len(r.fieldDelimiter)
+		case r.hasPrefix(r.recordDelimiter):
+			r.pos += len(r.recordDelimiter)
+			return append(record, string(trimCarriageReturn(field, r.recordDelimiter)))
+		default:
+			field = append(field, r.data[r.pos])
+			atFieldStart = false
+			r.pos++
+		}
+	}
+
+	return append(record, string(trimCarriageReturn(field, r.recordDelimiter)))
+}
+
+// trimCarriageReturn accepts \r\n line endings with the default \n record delimiter
+func trimCarriageReturn(field []byte, recordDelimiter []byte) []byte {
+	if len(recordDelimiter) == 1 && recordDelimiter[0] == '\n' && len(field) > 0 && field[len(field)-1] == '\r' {
+		return field[:len(field)-1]
+	}
+	return field
+}
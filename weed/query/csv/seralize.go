@@ -0,0 +1,73 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+const (
+	QuoteFieldsAlways   = "ALWAYS"
+	QuoteFieldsAsNeeded = "ASNEEDED"
+)
+
+type WriterOptions struct {
+	QuoteFields          string // ALWAYS | ASNEEDED
+	RecordDelimiter      string // default \n
+	FieldDelimiter       string // default ,
+	QuoteCharacter       string // default "
+	QuoteEscapeCharacter string // default "
+}
+
+// ToCsv appends one csv record, including the record delimiter
+func ToCsv(buf []byte, fields []string, options WriterOptions) []byte {
+
+	recordDelimiter := withDefault(options.RecordDelimiter, "\n")
+	fieldDelimiter := withDefault(options.FieldDelimiter, ",")
+	quote := withDefault(options.QuoteCharacter, `"`)
+	escape := withDefault(options.QuoteEscapeCharacter, `"`)
+
+	for i, field := range fields {
+		if i > 0 {
+			buf = append(buf, fieldDelimiter...)
+		}
+		needsQuote := options.QuoteFields == QuoteFieldsAlways ||
+			strings.Contains(field, fieldDelimiter) ||
+			strings.Contains(field, recordDelimiter) ||
+			strings.Contains(field, quote) ||
+			strings.ContainsAny(field, "\r\n")
+		if !needsQuote {
+			buf = append(buf, field...)
+			continue
+		}
+		buf = append(buf, quote...)
+		buf = append(buf, strings.Replace(field, quote, escape+quote, -1)...)
+		buf = append(buf, quote...)
+	}
+
+	return append(buf, recordDelimiter...)
+}
+
+// ToJson appends the csv fields as one json object, without the record delimiter
+func ToJson(buf []byte, names []string, values []sqltypes.Value) []byte {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, _ := json.Marshal(names[i])
+		b.Write(name)
+		b.WriteByte(':')
+		if value.IsNull() {
+			b.WriteString("null")
+			continue
+		}
+		text, _ := json.Marshal(value.ToString())
+		b.Write(text)
+	}
+	b.WriteByte('}')
+	return append(buf, b.Bytes()...)
+}
@@ -16,20 +16,35 @@ type Query struct {
 
 func QueryJson(jsonLine string, projections []string, query Query) (passedFilter bool, values []sqltypes.Value) {
 	if filterJson(jsonLine, query) {
-		passedFilter = true
-		fields := gjson.GetMany(jsonLine, projections...)
-		for _, f := range fields {
-			values = append(values, sqltypes.MakeTrusted(sqltypes.Type(f.Type), sqltypes.StringToBytes(f.Raw)))
-		}
-		return
+		return true, Project(jsonLine, projections)
 	}
 	return false, nil
 }
 
+// Project returns the raw json of the projected fields
+func Project(jsonLine string, projections []string) (values []sqltypes.Value) {
+	fields := gjson.GetMany(jsonLine, projections...)
+	for _, f := range fields {
+		values = append(values, sqltypes.MakeTrusted(sqltypes.Type(f.Type), sqltypes.StringToBytes(f.Raw)))
+	}
+	return
+}
+
 func filterJson(jsonLine string, query Query) bool {
 
+	if query.Field == "" {
+		// no filter
+		return gjson.Valid(jsonLine)
+	}
+
 	value := gjson.Get(jsonLine, query.Field)
 
+	return FilterValue(value, query)
+}
+
+// FilterValue checks one value against the query operator and value
+func FilterValue(value gjson.Result, query Query) bool {
+
 	// copied from gjson.go queryMatches() function
 	rpv := query.Value
 
@@ -1,9 +1,12 @@
 package json
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/tidwall/gjson"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqlparser"
 )
 
 func TestGjson(t *testing.T) {
@@ -131,3 +134,56 @@ func TestJsonQueryNumber(t *testing.T) {
 	println(string(buf))
 
 }
+
+func TestWhereJson(t *testing.T) {
+
+	line := `{"name":{"first":"Tom","last":"Anderson"},"age":37,"score":9.5,"active":true,"nick":null,"children":["Sara","Alex"]}`
+	record := NewRecord(line)
+
+	tests := []struct {
+		where    string
+		expected bool
+	}{
+		{"s.age > 30 AND s.name.\"first\" = 'Tom'", true},
+		{"s.age > 40 OR s.score < 10", true},
+		{"s.active = TRUE", true},
+		{"s.nick IS NULL AND s.missing IS NULL", true},
+		{"s.children IS NOT NULL", true},
+		{"s.name.last LIKE 'And%'", true},
+		{"s.age IN (36, 38)", false},
+		{"NOT (s.score >= 9.5)", false},
+	}
+
+	for _, tt := range tests {
+		stmt, err := sqlparser.Parse("SELECT * FROM S3Object s WHERE " + tt.where)
+		if err != nil {
+			t.Errorf("parse %s: %v", tt.where, err)
+			continue
+		}
+		if actual := stmt.Where.Eval(record); actual != tt.expected {
+			t.Errorf("eval %s: %v, expected %v", tt.where, actual, tt.expected)
+		}
+	}
+
+}
+
+func TestForEachRecord(t *testing.T) {
+
+	input := "{\"a\":1}\n\n{\"a\":\n 2}  {\"a\":3}\n"
+	var values []int64
+	err := ForEachRecord(strings.NewReader(input), func(record gjson.Result) (bool, error) {
+		values = append(values, record.Get("a").Int())
+		return true, nil
+	})
+	if err != nil || len(values) != 3 || values[2] != 3 {
+		t.Errorf("records %v: %v", values, err)
+	}
+
+	err = ForEachRecord(strings.NewReader("{\"a\":1}\n{bad}\n"), func(record gjson.Result) (bool, error) {
+		return true, nil
+	})
+	if err == nil {
+		t.Errorf("expecting error for malformed json")
+	}
+
+}
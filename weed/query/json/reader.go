@@ -0,0 +1,27 @@
+package json
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/tidwall/gjson"
+)
+
+// ForEachRecord reads the json values one by one from the reader, either json lines or
+// concatenated json documents, until the iterator returns false or the input ends.
+func ForEachRecord(reader io.Reader, iterator func(record gjson.Result) (bool, error)) error {
+	decoder := json.NewDecoder(reader)
+	for {
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if next, err := iterator(gjson.ParseBytes(raw)); !next || err != nil {
+			return err
+		}
+	}
+}
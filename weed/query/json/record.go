@@ -0,0 +1,36 @@
+package json
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqlparser"
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+// NewRecord looks up the fields of a json line when evaluating a WHERE predicate
+func NewRecord(jsonLine string) sqlparser.Record {
+	return func(field string) sqltypes.Value {
+		return ToValue(gjson.Get(jsonLine, field))
+	}
+}
+
+// ToValue converts a json value to a typed value, where json null and missing values are sqltypes.NULL,
+// and booleans are the text true or false
+func ToValue(value gjson.Result) sqltypes.Value {
+	switch value.Type {
+	case gjson.String:
+		return sqltypes.NewVarChar(value.Str)
+	case gjson.Number:
+		if strings.ContainsAny(value.Raw, ".eE") {
+			return sqltypes.MakeTrusted(sqltypes.Float64, []byte(value.Raw))
+		}
+		return sqltypes.MakeTrusted(sqltypes.Int64, []byte(value.Raw))
+	case gjson.True, gjson.False:
+		return sqltypes.NewVarChar(value.Raw)
+	case gjson.JSON:
+		return sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(value.Raw))
+	}
+	return sqltypes.NULL
+}
@@ -1,6 +1,10 @@
 package json
 
-import "github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+import (
+	"strconv"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
 
 func ToJson(buf []byte, selections []string, values []sqltypes.Value) []byte {
 	buf = append(buf, '{')
@@ -8,9 +12,14 @@ func ToJson(buf []byte, selections []string, values []sqltypes.Value) []byte {
 		if i > 0 {
 			buf = append(buf, ',')
 		}
-		buf = append(buf, selections[i]...)
+		buf = strconv.AppendQuote(buf, selections[i])
 		buf = append(buf, ':')
-		buf = append(buf, value.Raw()...)
+		if value.Len() == 0 {
+			// missing fields
+			buf = append(buf, "null"...)
+		} else {
+			buf = append(buf, value.Raw()...)
+		}
 	}
 	buf = append(buf, '}')
 	return buf
@@ -0,0 +1,91 @@
+package sqlparser
+
+import (
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+// Aggregate is an aggregate function in the projections, e.g. COUNT(*) or SUM(s.amount)
+type Aggregate struct {
+	Func  string // one of COUNT, SUM, MIN, MAX, AVG
+	Field string // empty for COUNT(*)
+	Alias string
+}
+
+// AggregateState is the partial result of an aggregate function.
+// States computed over different parts of the object can be merged.
+type AggregateState struct {
+	Count int64
+	Sum   float64
+	Min   float64
+	Max   float64
+}
+
+func isAggregateFunc(name string) bool {
+	switch name {
+	case "COUNT", "SUM", "MIN", "MAX", "AVG":
+		return true
+	}
+	return false
+}
+
+// Accumulate adds one matched record to the state.
+// Except COUNT, null and non-numeric values are skipped.
+func (a *Aggregate) Accumulate(state *AggregateState, record Record) {
+	if a.Field == "" {
+		state.Count++
+		return
+	}
+	value := record(a.Field)
+	if value.IsNull() {
+		return
+	}
+	if a.Func == "COUNT" {
+		state.Count++
+		return
+	}
+	num, err := value.ParseFloat64()
+	if err != nil {
+		return
+	}
+	state.add(1, num, num, num)
+}
+
+// Merge combines the state of another part
+func (state *AggregateState) Merge(other *AggregateState) {
+	if other.Count == 0 {
+		return
+	}
+	state.add(other.Count, other.Sum, other.Min, other.Max)
+}
+
+func (state *AggregateState) add(count int64, sum, min, max float64) {
+	if state.Count == 0 || min < state.Min {
+		state.Min = min
+	}
+	if state.Count == 0 || max > state.Max {
+		state.Max = max
+	}
+	state.Count += count
+	state.Sum += sum
+}
+
+// Result is the final value of the aggregate function, which is null if no value is aggregated except for COUNT
+func (a *Aggregate) Result(state *AggregateState) sqltypes.Value {
+	if a.Func == "COUNT" {
+		return sqltypes.NewInt64(state.Count)
+	}
+	if state.Count == 0 {
+		return sqltypes.NULL
+	}
+	switch a.Func {
+	case "SUM":
+		return sqltypes.NewFloat64(state.Sum)
+	case "MIN":
+		return sqltypes.NewFloat64(state.Min)
+	case "MAX":
+		return sqltypes.NewFloat64(state.Max)
+	case "AVG":
+		return sqltypes.NewFloat64(state.Sum / float64(state.Count))
+	}
+	return sqltypes.NULL
+}
@@ -0,0 +1,177 @@
+package sqlparser
+
+import (
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+// Record looks up a field of the current record, returning sqltypes.NULL if it is missing
+type Record func(field string) sqltypes.Value
+
+// Expr is a node of the WHERE predicate tree.
+// Comparisons involving NULL or missing fields are false, and NOT simply negates its operand.
+type Expr interface {
+	Eval(record Record) bool
+}
+
+type And struct {
+	Left, Right Expr
+}
+
+type Or struct {
+	Left, Right Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+// Comparison compares a field with a literal value
+type Comparison struct {
+	Field string
+	Op    string // one of =, !=, <, <=, >, >=
+	Value sqltypes.Value
+}
+
+// In checks whether a field equals any of the values
+type In struct {
+	Field  string
+	Values []sqltypes.Value
+	Not    bool
+}
+
+// IsNull checks whether a field is null or missing
+type IsNull struct {
+	Field string
+	Not   bool
+}
+
+// Like matches a field with a pattern, where % matches any characters and _ matches one character
+type Like struct {
+	Field   string
+	Pattern string
+	Escape  rune // 0 if there is no escape character
+	Not     bool
+}
+
+func (e *And) Eval(record Record) bool {
+	return e.Left.Eval(record) && e.Right.Eval(record)
+}
+
+func (e *Or) Eval(record Record) bool {
+	return e.Left.Eval(record) || e.Right.Eval(record)
+}
+
+func (e *Not) Eval(record Record) bool {
+	return !e.Expr.Eval(record)
+}
+
+func (e *Comparison) Eval(record Record) bool {
+	c, ok := Compare(record(e.Field), e.Value)
+	if !ok {
+		return false
+	}
+	switch e.Op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+func (e *In) Eval(record Record) bool {
+	value := record(e.Field)
+	if value.IsNull() {
+		return false
+	}
+	for _, v := range e.Values {
+		if c, ok := Compare(value, v); ok && c == 0 {
+			return !e.Not
+		}
+	}
+	return e.Not
+}
+
+func (e *IsNull) Eval(record Record) bool {
+	return record(e.Field).IsNull() != e.Not
+}
+
+func (e *Like) Eval(record Record) bool {
+	value := record(e.Field)
+	if value.IsNull() {
+		return false
+	}
+	return likeMatch([]rune(e.Pattern), []rune(value.ToString()), e.Escape) != e.Not
+}
+
+// Compare compares two values as numbers if either one is numeric and both can be parsed as numbers,
+// or else as strings. It returns false if either one is null.
+func Compare(a, b sqltypes.Value) (int, bool) {
+	if a.IsNull() || b.IsNull() {
+		return 0, false
+	}
+	if isNumeric(a) || isNumeric(b) {
+		x, xErr := a.ParseFloat64()
+		y, yErr := b.ParseFloat64()
+		if xErr == nil && yErr == nil {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	return strings.Compare(a.ToString(), b.ToString()), true
+}
+
+func isNumeric(v sqltypes.Value) bool {
+	return v.IsIntegral() || v.IsFloat()
+}
+
+func likeMatch(pattern, s []rune, escape rune) bool {
+	for len(pattern) > 0 {
+		c := pattern[0]
+		switch {
+		case escape != 0 && c == escape && len(pattern) > 1:
+			if len(s) == 0 || s[0] != pattern[1] {
+				return false
+			}
+			pattern, s = pattern[2:], s[1:]
+		case c == '%':
+			for pattern = pattern[1:]; len(pattern) > 0 && pattern[0] == '%'; pattern = pattern[1:] {
+			}
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if likeMatch(pattern, s[i:], escape) {
+					return true
+				}
+			}
+			return false
+		case c == '_':
+			if len(s) == 0 {
+				return false
+			}
+			pattern, s = pattern[1:], s[1:]
+		default:
+			if len(s) == 0 || s[0] != c {
+				return false
+			}
+			pattern, s = pattern[1:], s[1:]
+		}
+	}
+	return len(s) == 0
+}
@@ -0,0 +1,109 @@
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+func TestEvalWhere(t *testing.T) {
+
+	record := Record(func(field string) sqltypes.Value {
+		switch field {
+		case "name":
+			return sqltypes.NewVarChar("alice_1")
+		case "age":
+			return sqltypes.NewInt64(30)
+		case "score":
+			return sqltypes.NewVarChar("9.5")
+		case "city":
+			return sqltypes.NewVarChar("Paris")
+		}
+		return sqltypes.NULL
+	})
+
+	tests := []struct {
+		where    string
+		expected bool
+	}{
+		{"s.age = 30", true},
+		{"s.age > 9", true},
+		{"s.age = '30'", true},
+		{"s.score < 10", true},
+		{"s.score < '10'", false},
+		{"s.name >= 'alice'", true},
+		{"s.age > 20 AND s.city = 'Rome'", false},
+		{"s.age > 20 AND (s.city = 'Rome' OR s.city = 'Paris')", true},
+		{"NOT s.age > 20 OR s.city = 'Paris'", true},
+		{"NOT (s.age > 20 OR s.city = 'Paris')", false},
+		{"s.city IN ('Rome', 'Paris')", true},
+		{"s.city NOT IN ('Rome', 'Paris')", false},
+		{"s.age IN (10, 30.0)", true},
+		{"s.missing IN ('x')", false},
+		{"s.missing IS NULL", true},
+		{"s.missing IS NOT NULL", false},
+		{"s.age IS NULL", false},
+		{"s.missing = 1", false},
+		{"s.missing != 1", false},
+		{"s.name LIKE 'a%'", true},
+		{"s.name LIKE 'alice__'", true},
+		{"s.name LIKE 'alice_'", false},
+		{"s.name LIKE '%e!_%' ESCAPE '!'", true},
+		{"s.name LIKE '%c!_%' ESCAPE '!'", false},
+		{"s.name NOT LIKE '%x%'", true},
+		{"s.age BETWEEN 30 AND 40", true},
+		{"s.age NOT BETWEEN 30 AND 40", false},
+	}
+
+	for _, tt := range tests {
+		stmt, err := Parse("SELECT * FROM S3Object s WHERE " + tt.where)
+		if err != nil {
+			t.Errorf("parse %s: %v", tt.where, err)
+			continue
+		}
+		if actual := stmt.Where.Eval(record); actual != tt.expected {
+			t.Errorf("eval %s: %v, expected %v", tt.where, actual, tt.expected)
+		}
+	}
+
+}
+
+func TestAggregate(t *testing.T) {
+
+	values := []sqltypes.Value{sqltypes.NewInt64(3), sqltypes.NULL, sqltypes.NewVarChar("1.5"), sqltypes.NewVarChar("x"), sqltypes.NewInt64(6)}
+
+	stmt, err := Parse("SELECT COUNT(*), COUNT(s.a), SUM(s.a), MIN(s.a), MAX(s.a), AVG(s.a) FROM S3Object s")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	// aggregate the values in two parts, and merge them
+	states := make([]AggregateState, len(stmt.Aggregates))
+	for i, aggregate := range stmt.Aggregates {
+		var first, second AggregateState
+		for j, value := range values {
+			state := &first
+			if j >= 2 {
+				state = &second
+			}
+			aggregate.Accumulate(state, func(field string) sqltypes.Value {
+				return value
+			})
+		}
+		states[i].Merge(&first)
+		states[i].Merge(&second)
+	}
+
+	expected := []string{"5", "4", "10.5", "1.5", "6", "3.5"}
+	for i, aggregate := range stmt.Aggregates {
+		if actual := aggregate.Result(&states[i]).ToString(); actual != expected[i] {
+			t.Errorf("%s: %s, expected %s", aggregate.Func, actual, expected[i])
+		}
+	}
+
+	sum := &Aggregate{Func: "SUM", Field: "a"}
+	if !sum.Result(&AggregateState{}).IsNull() {
+		t.Errorf("empty sum should be null")
+	}
+
+}
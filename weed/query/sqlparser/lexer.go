@@ -0,0 +1,144 @@
+package sqlparser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenComma
+	tokenDot
+	tokenStar
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+func (t token) String() string {
+	if t.typ == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q at %d", t.val, t.pos)
+}
+
+// isKeyword checks identifiers case insensitively, e.g. SELECT, select
+func (t token) isKeyword(keyword string) bool {
+	return t.typ == tokenIdent && strings.EqualFold(t.val, keyword)
+}
+
+func tokenize(input string) (tokens []token, err error) {
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case c == ',':
+			tokens = append(tokens, token{tokenComma, ",", start})
+			i++
+		case c == '.' && !(i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			tokens = append(tokens, token{tokenDot, ".", start})
+			i++
+		case c == '*':
+			tokens = append(tokens, token{tokenStar, "*", start})
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLeftParen, "(", start})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRightParen, ")", start})
+			i++
+		case c == '\'' || c == '"':
+			// quotes are escaped by doubling them, e.g. 'it''s'
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("unterminated quote at %d", start)
+				}
+				if runes[i] == c {
+					if i+1 < len(runes) && runes[i+1] == c {
+						sb.WriteRune(c)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			typ := tokenString
+			if c == '"' {
+				typ = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{typ, sb.String(), start})
+		case unicode.IsDigit(c) || c == '.' || (c == '-' && i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '.') && expectsOperand(tokens)):
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E' ||
+				((runes[i] == '-' || runes[i] == '+') && (runes[i-1] == 'e' || runes[i-1] == 'E'))) {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[start:i]), start})
+		case unicode.IsLetter(c) || c == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, string(runes[start:i]), start})
+		case strings.ContainsRune("=<>!", c):
+			i++
+			if i < len(runes) && (runes[i] == '=' || (c == '<' && runes[i] == '>')) {
+				i++
+			}
+			op := string(runes[start:i])
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected %q at %d", op, start)
+			}
+			tokens = append(tokens, token{tokenOperator, op, start})
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", string(c), start)
+		}
+	}
+
+	tokens = append(tokens, token{tokenEOF, "", len(runes)})
+	return tokens, nil
+}
+
+// expectsOperand tells whether a '-' starts a negative number instead of being an operator
+func expectsOperand(tokens []token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	switch tokens[len(tokens)-1].typ {
+	case tokenOperator, tokenComma, tokenLeftParen:
+		return true
+	case tokenIdent:
+		return isReservedWord(tokens[len(tokens)-1].val)
+	}
+	return false
+}
+
+func isReservedWord(word string) bool {
+	switch strings.ToUpper(word) {
+	case "SELECT", "FROM", "WHERE", "AND", "OR", "NOT", "IN", "IS", "NULL", "LIKE", "LIMIT", "AS", "ESCAPE", "BETWEEN", "TRUE", "FALSE":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,458 @@
+package sqlparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+// Select is a parsed S3 Select expression, e.g.
+//
+//	SELECT s.name, s.age FROM S3Object s WHERE s.age > 30 AND s.city IN ('Paris', 'Rome') LIMIT 10
+//	SELECT COUNT(*), AVG(s.age) FROM S3Object s WHERE s.email IS NOT NULL
+type Select struct {
+	// Projections are field paths relative to the record, empty to select the whole record
+	Projections []string
+	// Aggregates are the aggregate functions, which can not be mixed with field projections
+	Aggregates []*Aggregate
+	Where      Expr
+	Limit      int64
+}
+
+const s3ObjectTable = "S3Object"
+
+type parser struct {
+	tokens []token
+	pos    int
+	alias  string
+}
+
+func Parse(expression string) (*Select, error) {
+
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	if err = p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+
+	var projections [][]string
+	var aggregates []*Aggregate
+	var aggregatePaths [][]string
+	if p.peek().typ == tokenStar {
+		p.next()
+	} else {
+		for {
+			if t := p.peek(); t.typ == tokenIdent && isAggregateFunc(strings.ToUpper(t.val)) && p.peekAt(1).typ == tokenLeftParen {
+				aggregate, path, err := p.parseAggregate()
+				if err != nil {
+					return nil, err
+				}
+				aggregates = append(aggregates, aggregate)
+				aggregatePaths = append(aggregatePaths, path)
+			} else {
+				path, err := p.parseFieldPath()
+				if err != nil {
+					return nil, err
+				}
+				projections = append(projections, path)
+			}
+			if p.peek().typ != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if len(aggregates) > 0 && len(projections) > 0 {
+		return nil, fmt.Errorf("aggregate functions can not be mixed with fields")
+	}
+
+	if err = p.parseFrom(); err != nil {
+		return nil, err
+	}
+
+	stmt := &Select{}
+	for _, path := range projections {
+		field := p.resolve(path)
+		if field == "" {
+			// s.* selects the whole record
+			stmt.Projections = nil
+			break
+		}
+		stmt.Projections = append(stmt.Projections, field)
+	}
+	for i, aggregate := range aggregates {
+		if aggregatePaths[i] != nil {
+			if aggregate.Field = p.resolve(aggregatePaths[i]); aggregate.Field == "" {
+				return nil, fmt.Errorf("can not aggregate the whole record")
+			}
+		}
+		stmt.Aggregates = append(stmt.Aggregates, aggregate)
+	}
+
+	if p.peek().isKeyword("WHERE") {
+		p.next()
+		if stmt.Where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek().isKeyword("LIMIT") {
+		p.next()
+		t := p.next()
+		if t.typ != tokenNumber {
+			return nil, fmt.Errorf("expecting a number after LIMIT, but got %v", t)
+		}
+		if stmt.Limit, err = strconv.ParseInt(t.val, 10, 64); err != nil || stmt.Limit < 0 {
+			return nil, fmt.Errorf("invalid limit %v", t)
+		}
+	}
+
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, fmt.Errorf("unexpected %v", t)
+	}
+
+	return stmt, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if t := p.next(); !t.isKeyword(keyword) {
+		return fmt.Errorf("expecting %s, but got %v", keyword, t)
+	}
+	return nil
+}
+
+func (p *parser) parseFrom() error {
+	if err := p.expectKeyword("FROM"); err != nil {
+		return err
+	}
+	if t := p.next(); !t.isKeyword(s3ObjectTable) {
+		return fmt.Errorf("expecting %s, but got %v", s3ObjectTable, t)
+	}
+	if p.peek().isKeyword("AS") {
+		p.next()
+	}
+	if t := p.peek(); t.typ == tokenIdent && !isReservedWord(t.val) {
+		p.alias = p.next().val
+	}
+	return nil
+}
+
+// parseFieldPath reads names separated by dots, e.g. s.address."zip code", where a trailing * is an empty name
+func (p *parser) parseFieldPath() (path []string, err error) {
+	for {
+		t := p.next()
+		switch {
+		case t.typ == tokenQuotedIdent:
+			path = append(path, t.val)
+		case t.typ == tokenIdent && !isReservedWord(t.val):
+			path = append(path, t.val)
+		case t.typ == tokenStar && len(path) > 0:
+			return append(path, ""), nil
+		default:
+			return nil, fmt.Errorf("expecting a field name, but got %v", t)
+		}
+		if p.peek().typ != tokenDot {
+			return path, nil
+		}
+		p.next()
+	}
+}
+
+// resolve strips the table name or alias, and joins the path as a gjson path
+func (p *parser) resolve(path []string) string {
+	if len(path) > 1 && (strings.EqualFold(path[0], s3ObjectTable) || (p.alias != "" && path[0] == p.alias)) {
+		path = path[1:]
+	}
+	var names []string
+	for _, name := range path {
+		if name == "" {
+			break
+		}
+		names = append(names, escapePath(name))
+	}
+	return strings.Join(names, ".")
+}
+
+// escapePath escapes the gjson special characters
+func escapePath(name string) string {
+	var sb strings.Builder
+	for _, c := range name {
+		if strings.ContainsRune(`.*?|#@\`, c) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// parseAggregate reads an aggregate function with an optional alias, e.g. COUNT(*) AS total,
+// where the path is nil for COUNT(*)
+func (p *parser) parseAggregate() (aggregate *Aggregate, path []string, err error) {
+	aggregate = &Aggregate{Func: strings.ToUpper(p.next().val)}
+	p.next()
+	if aggregate.Func == "COUNT" && p.peek().typ == tokenStar {
+		p.next()
+	} else if path, err = p.parseFieldPath(); err != nil {
+		return nil, nil, err
+	}
+	if t := p.next(); t.typ != tokenRightParen {
+		return nil, nil, fmt.Errorf("expecting ), but got %v", t)
+	}
+	if p.peek().isKeyword("AS") {
+		p.next()
+		t := p.next()
+		if !(t.typ == tokenQuotedIdent || t.typ == tokenIdent && !isReservedWord(t.val)) {
+			return nil, nil, fmt.Errorf("expecting an alias, but got %v", t)
+		}
+		aggregate.Alias = t.val
+	}
+	return aggregate, path, nil
+}
+
+// parseExpr parses the WHERE condition, where NOT binds tighter than AND, and AND binds tighter than OR
+func (p *parser) parseExpr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if p.peek().isKeyword("NOT") {
+		p.next()
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr}, nil
+	}
+	if p.peek().typ == tokenLeftParen {
+		p.next()
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.typ != tokenRightParen {
+			return nil, fmt.Errorf("expecting ), but got %v", t)
+		}
+		return expr, nil
+	}
+	return p.parsePredicate()
+}
+
+// flippedOperators swaps the operands, so 30 < s.age becomes s.age > 30
+var flippedOperators = map[string]string{
+	"=": "=", "!=": "!=", "<": ">", "<=": ">=", ">": "<", ">=": "<=",
+}
+
+func (p *parser) parsePredicate() (Expr, error) {
+
+	if isLiteral(p.peek()) {
+		value, _ := p.parseLiteral()
+		t := p.next()
+		if t.typ != tokenOperator {
+			return nil, fmt.Errorf("expecting a comparison operator, but got %v", t)
+		}
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		return &Comparison{Field: field, Op: flippedOperators[normalizeOperator(t.val)], Value: value}, nil
+	}
+
+	field, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.next()
+	if t.typ == tokenOperator {
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return &Comparison{Field: field, Op: normalizeOperator(t.val), Value: value}, nil
+	}
+
+	if t.isKeyword("IS") {
+		not := false
+		if p.peek().isKeyword("NOT") {
+			p.next()
+			not = true
+		}
+		if err = p.expectKeyword("NULL"); err != nil {
+			return nil, err
+		}
+		return &IsNull{Field: field, Not: not}, nil
+	}
+
+	not := false
+	if t.isKeyword("NOT") {
+		not = true
+		t = p.next()
+	}
+	switch {
+	case t.isKeyword("IN"):
+		return p.parseIn(field, not)
+	case t.isKeyword("LIKE"):
+		return p.parseLike(field, not)
+	case t.isKeyword("BETWEEN"):
+		return p.parseBetween(field, not)
+	}
+
+	return nil, fmt.Errorf("expecting a comparison operator, but got %v", t)
+}
+
+func (p *parser) parseField() (string, error) {
+	path, err := p.parseFieldPath()
+	if err != nil {
+		return "", err
+	}
+	field := p.resolve(path)
+	if field == "" {
+		return "", fmt.Errorf("can not compare the whole record")
+	}
+	return field, nil
+}
+
+func (p *parser) parseIn(field string, not bool) (Expr, error) {
+	if t := p.next(); t.typ != tokenLeftParen {
+		return nil, fmt.Errorf("expecting ( after IN, but got %v", t)
+	}
+	in := &In{Field: field, Not: not}
+	for {
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		in.Values = append(in.Values, value)
+		t := p.next()
+		if t.typ == tokenRightParen {
+			return in, nil
+		}
+		if t.typ != tokenComma {
+			return nil, fmt.Errorf("expecting , or ), but got %v", t)
+		}
+	}
+}
+
+func (p *parser) parseLike(field string, not bool) (Expr, error) {
+	pattern := p.next()
+	if pattern.typ != tokenString {
+		return nil, fmt.Errorf("expecting a string pattern after LIKE, but got %v", pattern)
+	}
+	like := &Like{Field: field, Pattern: pattern.val, Not: not}
+	if p.peek().isKeyword("ESCAPE") {
+		p.next()
+		escape := p.next()
+		if escape.typ != tokenString || len([]rune(escape.val)) != 1 {
+			return nil, fmt.Errorf("expecting one escape character, but got %v", escape)
+		}
+		like.Escape = []rune(escape.val)[0]
+	}
+	return like, nil
+}
+
+func (p *parser) parseBetween(field string, not bool) (Expr, error) {
+	low, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectKeyword("AND"); err != nil {
+		return nil, err
+	}
+	high, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	var expr Expr = &And{
+		Left:  &Comparison{Field: field, Op: ">=", Value: low},
+		Right: &Comparison{Field: field, Op: "<=", Value: high},
+	}
+	if not {
+		expr = &Not{Expr: expr}
+	}
+	return expr, nil
+}
+
+func normalizeOperator(op string) string {
+	if op == "<>" {
+		return "!="
+	}
+	return op
+}
+
+func isLiteral(t token) bool {
+	return t.typ == tokenString || t.typ == tokenNumber || t.isKeyword("TRUE") || t.isKeyword("FALSE")
+}
+
+// parseLiteral reads a string, a number, or a boolean, where booleans are compared as the text true or false
+func (p *parser) parseLiteral() (sqltypes.Value, error) {
+	t := p.next()
+	switch {
+	case t.typ == tokenString:
+		return sqltypes.NewVarChar(t.val), nil
+	case t.typ == tokenNumber:
+		if i, err := strconv.ParseInt(t.val, 10, 64); err == nil {
+			return sqltypes.NewInt64(i), nil
+		}
+		f, err := strconv.ParseFloat(t.val, 64)
+		if err != nil {
+			return sqltypes.NULL, fmt.Errorf("invalid number %v", t)
+		}
+		return sqltypes.NewFloat64(f), nil
+	case t.isKeyword("TRUE"), t.isKeyword("FALSE"):
+		return sqltypes.NewVarChar(strings.ToLower(t.val)), nil
+	}
+	return sqltypes.NULL, fmt.Errorf("expecting a literal value, but got %v", t)
+}
@@ -0,0 +1,131 @@
+package sqlparser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+func TestParseSelect(t *testing.T) {
+
+	tests := []struct {
+		expression  string
+		projections []string
+		where       Expr
+		limit       int64
+	}{
+		{"SELECT * FROM S3Object", nil, nil, 0},
+		{"select s.* from s3object s", nil, nil, 0},
+		{"SELECT s.name, s.address.city FROM S3Object s WHERE s.age >= 30",
+			[]string{"name", "address.city"}, &Comparison{"age", ">=", sqltypes.NewInt64(30)}, 0},
+		{"SELECT S3Object.name FROM S3Object WHERE S3Object.name <> 'it''s'",
+			[]string{"name"}, &Comparison{"name", "!=", sqltypes.NewVarChar("it's")}, 0},
+		{`SELECT s."first name" FROM S3Object AS s WHERE s.level = -1.5 LIMIT 10`,
+			[]string{"first name"}, &Comparison{"level", "=", sqltypes.NewFloat64(-1.5)}, 10},
+		{"SELECT s.name FROM S3Object s WHERE s.name LIKE 'a%b_'",
+			[]string{"name"}, &Like{Field: "name", Pattern: "a%b_"}, 0},
+		{"SELECT s.a FROM S3Object s WHERE s.ok = TRUE",
+			[]string{"a"}, &Comparison{"ok", "=", sqltypes.NewVarChar("true")}, 0},
+		{"SELECT * FROM S3Object s WHERE 30 < s.age",
+			nil, &Comparison{"age", ">", sqltypes.NewInt64(30)}, 0},
+		{"SELECT * FROM S3Object s WHERE s.a = 1 OR s.b = 2 AND NOT s.c = 3",
+			nil, &Or{
+				Left: &Comparison{"a", "=", sqltypes.NewInt64(1)},
+				Right: &And{
+					Left:  &Comparison{"b", "=", sqltypes.NewInt64(2)},
+					Right: &Not{&Comparison{"c", "=", sqltypes.NewInt64(3)}},
+				},
+			}, 0},
+		{"SELECT * FROM S3Object s WHERE (s.a = 1 OR s.b = 2) AND s.c IS NOT NULL",
+			nil, &And{
+				Left: &Or{
+					Left:  &Comparison{"a", "=", sqltypes.NewInt64(1)},
+					Right: &Comparison{"b", "=", sqltypes.NewInt64(2)},
+				},
+				Right: &IsNull{Field: "c", Not: true},
+			}, 0},
+		{"SELECT * FROM S3Object s WHERE s.city NOT IN ('Paris', 'Rome') AND s.d IS NULL",
+			nil, &And{
+				Left:  &In{Field: "city", Values: []sqltypes.Value{sqltypes.NewVarChar("Paris"), sqltypes.NewVarChar("Rome")}, Not: true},
+				Right: &IsNull{Field: "d"},
+			}, 0},
+		{`SELECT * FROM S3Object s WHERE s.name NOT LIKE 'a!%%' ESCAPE '!'`,
+			nil, &Like{Field: "name", Pattern: "a!%%", Escape: '!', Not: true}, 0},
+		{"SELECT * FROM S3Object s WHERE s.age BETWEEN 20 AND 30",
+			nil, &And{
+				Left:  &Comparison{"age", ">=", sqltypes.NewInt64(20)},
+				Right: &Comparison{"age", "<=", sqltypes.NewInt64(30)},
+			}, 0},
+	}
+
+	for _, tt := range tests {
+		stmt, err := Parse(tt.expression)
+		if err != nil {
+			t.Errorf("parse %s: %v", tt.expression, err)
+			continue
+		}
+		if len(stmt.Projections) != len(tt.projections) {
+			t.Errorf("parse %s: projections %v, expected %v", tt.expression, stmt.Projections, tt.projections)
+			continue
+		}
+		for i := range tt.projections {
+			if stmt.Projections[i] != tt.projections[i] {
+				t.Errorf("parse %s: projections %v, expected %v", tt.expression, stmt.Projections, tt.projections)
+			}
+		}
+		if !reflect.DeepEqual(stmt.Where, tt.where) {
+			t.Errorf("parse %s: where %+v, expected %+v", tt.expression, stmt.Where, tt.where)
+		}
+		if stmt.Limit != tt.limit {
+			t.Errorf("parse %s: limit %d, expected %d", tt.expression, stmt.Limit, tt.limit)
+		}
+	}
+
+}
+
+func TestParseSelectErrors(t *testing.T) {
+
+	for _, expression := range []string{
+		"",
+		"SELECT",
+		"SELECT * FROM",
+		"SELECT * FROM table1",
+		"SELECT s.a FROM S3Object s WHERE",
+		"SELECT s.a FROM S3Object s WHERE s.a = 'unterminated",
+		"SELECT s.a FROM S3Object s WHERE s.a LIKE 3",
+		"SELECT s.a FROM S3Object s LIMIT x",
+		"SELECT s.a FROM S3Object s WHERE (s.a = 1",
+		"SELECT s.a FROM S3Object s WHERE s.a IN ()",
+		"SELECT s.a FROM S3Object s WHERE s.a IS 1",
+		"SELECT s.a FROM S3Object s WHERE s.a BETWEEN 1",
+		"SELECT s.a, COUNT(*) FROM S3Object s",
+		"SELECT SUM(*) FROM S3Object s",
+		"SELECT s.a FROM S3Object s extra",
+	} {
+		if _, err := Parse(expression); err == nil {
+			t.Errorf("expecting error for %q", expression)
+		}
+	}
+
+}
+
+func TestParseAggregates(t *testing.T) {
+
+	stmt, err := Parse("SELECT COUNT(*), sum(s.amount) AS total, AVG(s.x.y) FROM S3Object s WHERE s.amount > 0")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	expected := []*Aggregate{
+		{Func: "COUNT"},
+		{Func: "SUM", Field: "amount", Alias: "total"},
+		{Func: "AVG", Field: "x.y"},
+	}
+	if !reflect.DeepEqual(stmt.Aggregates, expected) {
+		t.Errorf("aggregates %+v, expected %+v", stmt.Aggregates, expected)
+	}
+	if len(stmt.Projections) != 0 {
+		t.Errorf("unexpected projections %v", stmt.Projections)
+	}
+
+}
@@ -0,0 +1,207 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+const (
+	// the canned ACL of a bucket or an object is kept in the entry extended attributes, and private if missing
+	extAclKey = "s3-acl"
+
+	amzAclHeader         = "X-Amz-Acl"
+	amzGrantHeaderPrefix = "X-Amz-Grant-"
+
+	cannedAclPrivate           = "private"
+	cannedAclPublicRead        = "public-read"
+	cannedAclPublicReadWrite   = "public-read-write"
+	cannedAclAuthenticatedRead = "authenticated-read"
+
+	aclGroupAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	aclGroupAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+
+	aclPermissionRead        = "READ"
+	aclPermissionWrite       = "WRITE"
+	aclPermissionFullControl = "FULL_CONTROL"
+)
+
+// the other canned ACLs only grant the bucket owner, who owns all objects here
+var privateCannedAcls = map[string]bool{
+	cannedAclPrivate:            true,
+	"bucket-owner-read":         true,
+	"bucket-owner-full-control": true,
+}
+
+// the actions granted on an object by the READ permission, and on a bucket by the READ or WRITE permission
+var (
+	aclObjectReadActions = map[string]bool{
+		"s3:GetObject":        true,
+		"s3:GetObjectVersion": true,
+	}
+	aclBucketReadActions = map[string]bool{
+		"s3:ListBucket":                 true,
+		"s3:ListBucketVersions":         true,
+		"s3:ListBucketMultipartUploads": true,
+	}
+	aclBucketWriteActions = map[string]bool{
+		"s3:PutObject":                true,
+		"s3:DeleteObject":             true,
+		"s3:DeleteObjectVersion":      true,
+		"s3:AbortMultipartUpload":     true,
+		"s3:ListMultipartUploadParts": true,
+	}
+)
+
+// AccessControlPolicyResult is the AccessControlPolicy with typed grantees, which the generated one lacks
+type AccessControlPolicyResult struct {
+	XMLName           xml.Name      `xml:"http://s3.amazonaws.com/doc/2006-03-01/ AccessControlPolicy"`
+	Owner             CanonicalUser `xml:"Owner"`
+	AccessControlList struct {
+		Grant []AclGrant `xml:"Grant"`
+	} `xml:"AccessControlList"`
+}
+
+type AclGrant struct {
+	Grantee    AclGrantee `xml:"Grantee"`
+	Permission string     `xml:"Permission"`
+}
+
+type AclGrantee struct {
+	XMLNS       string `xml:"xmlns:xsi,attr,omitempty"`
+	Type        string `xml:"xsi:type,attr,omitempty"`
+	ID          string `xml:"ID,omitempty"`
+	DisplayName string `xml:"DisplayName,omitempty"`
+	URI         string `xml:"URI,omitempty"`
+}
+
+// parseAclHeaders reads the canned ACL of a request, which is empty if not given.
+// Grants to specific accounts are not supported.
+func parseAclHeaders(header http.Header) (cannedAcl string, code ErrorCode) {
+	for name := range header {
+		if strings.HasPrefix(http.CanonicalHeaderKey(name), amzGrantHeaderPrefix) {
+			return "", ErrNotImplemented
+		}
+	}
+	cannedAcl = header.Get(amzAclHeader)
+	if cannedAcl == "" {
+		return "", ErrNone
+	}
+	if privateCannedAcls[cannedAcl] {
+		return cannedAclPrivate, ErrNone
+	}
+	switch cannedAcl {
+	case cannedAclPublicRead, cannedAclPublicReadWrite, cannedAclAuthenticatedRead:
+		return cannedAcl, ErrNone
+	}
+	return "", ErrInvalidCannedAcl
+}
+
+// setCannedAcl records the canned ACL in the extended attributes, where private is the default
+func setCannedAcl(extended map[string][]byte, cannedAcl string) {
+	if cannedAcl == "" || cannedAcl == cannedAclPrivate {
+		delete(extended, extAclKey)
+		return
+	}
+	extended[extAclKey] = []byte(cannedAcl)
+}
+
+func getCannedAcl(extended map[string][]byte) string {
+	if cannedAcl := string(extended[extAclKey]); cannedAcl != "" {
+		return cannedAcl
+	}
+	return cannedAclPrivate
+}
+
+// isGrantedByCannedAcl checks whether the canned ACL grants the action to the identity, which is nil for anonymous requests
+func isGrantedByCannedAcl(cannedAcl string, identity *Identity, isBucket bool, s3Action string) bool {
+	var canRead, canWrite bool
+	switch cannedAcl {
+	case cannedAclPublicRead:
+		canRead = true
+	case cannedAclPublicReadWrite:
+		canRead, canWrite = true, true
+	case cannedAclAuthenticatedRead:
+		canRead = identity != nil
+	}
+	if !isBucket {
+		return canRead && aclObjectReadActions[s3Action]
+	}
+	return canRead && aclBucketReadActions[s3Action] || canWrite && aclBucketWriteActions[s3Action]
+}
+
+// newAccessControlPolicy lists the grants of the canned ACL, besides the full control of the owner
+func newAccessControlPolicy(entry *filer_pb.Entry, cannedAcl string) AccessControlPolicyResult {
+	var owner CanonicalUser
+	if entry.Attributes != nil {
+		owner = CanonicalUser{
+			ID:          fmt.Sprintf("%x", entry.Attributes.Uid),
+			DisplayName: entry.Attributes.UserName,
+		}
+	}
+
+	policy := AccessControlPolicyResult{Owner: owner}
+	addGrant := func(grantee AclGrantee, permission string) {
+		grantee.XMLNS = "http://www.w3.org/2001/XMLSchema-instance"
+		policy.AccessControlList.Grant = append(policy.AccessControlList.Grant, AclGrant{
+			Grantee:    grantee,
+			Permission: permission,
+		})
+	}
+	addGrant(AclGrantee{Type: "CanonicalUser", ID: owner.ID, DisplayName: owner.DisplayName}, aclPermissionFullControl)
+	switch cannedAcl {
+	case cannedAclPublicRead:
+		addGrant(AclGrantee{Type: "Group", URI: aclGroupAllUsers}, aclPermissionRead)
+	case cannedAclPublicReadWrite:
+		addGrant(AclGrantee{Type: "Group", URI: aclGroupAllUsers}, aclPermissionRead)
+		addGrant(AclGrantee{Type: "Group", URI: aclGroupAllUsers}, aclPermissionWrite)
+	case cannedAclAuthenticatedRead:
+		addGrant(AclGrantee{Type: "Group", URI: aclGroupAuthenticatedUsers}, aclPermissionRead)
+	}
+	return policy
+}
+
+// cannedAclOfPolicy finds the canned ACL with the same grants as the policy in a PutAcl request body.
+// The owner grants are implied, and other grants to accounts are not supported.
+func cannedAclOfPolicy(data []byte) (cannedAcl string, code ErrorCode) {
+
+	// clients may leave out the namespace
+	var policy struct {
+		Owner             CanonicalUser `xml:"Owner"`
+		AccessControlList struct {
+			Grant []AclGrant `xml:"Grant"`
+		} `xml:"AccessControlList"`
+	}
+	if err := xml.Unmarshal(data, &policy); err != nil {
+		return "", ErrMalformedACLError
+	}
+
+	groups := make(map[string]bool)
+	for _, grant := range policy.AccessControlList.Grant {
+		switch grant.Grantee.URI {
+		case "":
+			if grant.Grantee.ID != policy.Owner.ID {
+				return "", ErrNotImplemented
+			}
+		case aclGroupAllUsers, aclGroupAuthenticatedUsers:
+			groups[grant.Grantee.URI+" "+grant.Permission] = true
+		default:
+			return "", ErrNotImplemented
+		}
+	}
+
+	switch {
+	case len(groups) == 0:
+		return cannedAclPrivate, ErrNone
+	case len(groups) == 1 && groups[aclGroupAllUsers+" "+aclPermissionRead]:
+		return cannedAclPublicRead, ErrNone
+	case len(groups) == 2 && groups[aclGroupAllUsers+" "+aclPermissionRead] && groups[aclGroupAllUsers+" "+aclPermissionWrite]:
+		return cannedAclPublicReadWrite, ErrNone
+	case len(groups) == 1 && groups[aclGroupAuthenticatedUsers+" "+aclPermissionRead]:
+		return cannedAclAuthenticatedRead, ErrNone
+	}
+	return "", ErrNotImplemented
+}
@@ -0,0 +1,88 @@
+package s3api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestParseAclHeaders(t *testing.T) {
+
+	tests := []struct {
+		header    http.Header
+		cannedAcl string
+		code      ErrorCode
+	}{
+		{http.Header{}, "", ErrNone},
+		{http.Header{"X-Amz-Acl": {"public-read"}}, cannedAclPublicRead, ErrNone},
+		{http.Header{"X-Amz-Acl": {"bucket-owner-full-control"}}, cannedAclPrivate, ErrNone},
+		{http.Header{"X-Amz-Acl": {"log-delivery-write"}}, "", ErrInvalidCannedAcl},
+		{http.Header{"X-Amz-Grant-Read": {"uri=" + aclGroupAllUsers}}, "", ErrNotImplemented},
+	}
+	for _, tt := range tests {
+		cannedAcl, code := parseAclHeaders(tt.header)
+		assert.Equal(t, tt.code, code, "%v", tt.header)
+		assert.Equal(t, tt.cannedAcl, cannedAcl, "%v", tt.header)
+	}
+
+}
+
+func TestCannedAclExtended(t *testing.T) {
+
+	extended := make(map[string][]byte)
+	assert.Equal(t, cannedAclPrivate, getCannedAcl(extended))
+
+	setCannedAcl(extended, cannedAclPublicRead)
+	assert.Equal(t, cannedAclPublicRead, getCannedAcl(extended))
+
+	setCannedAcl(extended, cannedAclPrivate)
+	assert.Equal(t, 0, len(extended))
+
+}
+
+func TestIsGrantedByCannedAcl(t *testing.T) {
+
+	user := &Identity{Name: "user"}
+
+	assert.True(t, isGrantedByCannedAcl(cannedAclPublicRead, nil, false, "s3:GetObject"))
+	assert.False(t, isGrantedByCannedAcl(cannedAclPublicRead, nil, false, "s3:GetObjectTagging"))
+	assert.False(t, isGrantedByCannedAcl(cannedAclPublicRead, nil, true, "s3:PutObject"))
+	assert.True(t, isGrantedByCannedAcl(cannedAclPublicRead, nil, true, "s3:ListBucket"))
+	assert.True(t, isGrantedByCannedAcl(cannedAclPublicReadWrite, nil, true, "s3:PutObject"))
+	assert.False(t, isGrantedByCannedAcl(cannedAclPublicReadWrite, nil, false, "s3:PutObject"))
+	assert.False(t, isGrantedByCannedAcl(cannedAclAuthenticatedRead, nil, false, "s3:GetObject"))
+	assert.True(t, isGrantedByCannedAcl(cannedAclAuthenticatedRead, user, false, "s3:GetObject"))
+	assert.False(t, isGrantedByCannedAcl(cannedAclPrivate, user, true, "s3:ListBucket"))
+
+}
+
+func TestAccessControlPolicy(t *testing.T) {
+
+	entry := &filer_pb.Entry{
+		Attributes: &filer_pb.FuseAttributes{Uid: 1000, UserName: "owner"},
+	}
+
+	for _, cannedAcl := range []string{cannedAclPrivate, cannedAclPublicRead, cannedAclPublicReadWrite, cannedAclAuthenticatedRead} {
+		data := encodeResponse(newAccessControlPolicy(entry, cannedAcl))
+		parsed, code := cannedAclOfPolicy(data)
+		assert.Equal(t, ErrNone, code, string(data))
+		assert.Equal(t, cannedAcl, parsed, string(data))
+	}
+
+	data := string(encodeResponse(newAccessControlPolicy(entry, cannedAclPublicRead)))
+	assert.True(t, strings.Contains(data, `<ID>3e8</ID><DisplayName>owner</DisplayName>`), data)
+	assert.True(t, strings.Contains(data, `xsi:type="Group"`), data)
+
+	_, code := cannedAclOfPolicy([]byte(`<AccessControlPolicy><Owner><ID>3e8</ID></Owner><AccessControlList>
+<Grant><Grantee><ID>other</ID></Grantee><Permission>READ</Permission></Grant>
+</AccessControlList></AccessControlPolicy>`))
+	assert.Equal(t, ErrNotImplemented, code)
+
+	_, code = cannedAclOfPolicy([]byte(`<AccessControlPolicy>`))
+	assert.Equal(t, ErrMalformedACLError, code)
+
+}
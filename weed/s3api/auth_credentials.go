@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/gorilla/mux"
+	"github.com/karlseguin/ccache"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/iam_pb"
@@ -28,12 +32,36 @@ type Iam interface {
 type IdentityAccessManagement struct {
 	identities []*Identity
 	domain     string
+
+	// identities from the config file, and the ones managed through the filer
+	identitiesLock  sync.RWMutex
+	fileIdentities  []*Identity
+	filerIdentities map[string]*Identity
+	// enabled stays on once configured, so deleting all identities does not open the gateway
+	enabled bool
+
+	// bucket policies are cached for a short while, so changes made through other gateways show up soon
+	bucketPolicies     *ccache.Cache
+	bucketPolicyLoader func(bucket string) (*PolicyDocument, error)
+
+	// canned ACLs of buckets and objects, where the object is empty for the bucket, are cached as the bucket policies
+	cannedAcls      *ccache.Cache
+	cannedAclLoader func(bucket, object string) (string, error)
+
+	// signs the session tokens of temporary credentials
+	stsSigningKey  []byte
+	stsMaxDuration time.Duration
 }
 
+const bucketPolicyCacheTtl = 10 * time.Second
+
 type Identity struct {
 	Name        string
 	Credentials []*Credential
 	Actions     []Action
+	Policy      *PolicyDocument
+	// SessionPolicy limits the temporary credentials further, which are issued with the permissions of the parent
+	SessionPolicy *PolicyDocument
 }
 
 type Credential struct {
@@ -43,7 +71,10 @@ type Credential struct {
 
 func NewIdentityAccessManagement(fileName string, domain string) *IdentityAccessManagement {
 	iam := &IdentityAccessManagement{
-		domain: domain,
+		domain:          domain,
+		bucketPolicies:  ccache.New(ccache.Configure().MaxSize(1000).ItemsToPrune(100)),
+		cannedAcls:      ccache.New(ccache.Configure().MaxSize(10000).ItemsToPrune(100)),
+		filerIdentities: make(map[string]*Identity),
 	}
 	if fileName == "" {
 		return iam
@@ -70,28 +101,91 @@ func (iam *IdentityAccessManagement) loadS3ApiConfiguration(fileName string) err
 		return fmt.Errorf("unmarshal %s error: %v", fileName, err)
 	}
 
+	var identities []*Identity
 	for _, ident := range s3ApiConfiguration.Identities {
-		t := &Identity{
-			Name:        ident.Name,
-			Credentials: nil,
-			Actions:     nil,
-		}
-		for _, action := range ident.Actions {
-			t.Actions = append(t.Actions, Action(action))
-		}
-		for _, cred := range ident.Credentials {
-			t.Credentials = append(t.Credentials, &Credential{
-				AccessKey: cred.AccessKey,
-				SecretKey: cred.SecretKey,
-			})
+		t, err := identityFromPb(ident)
+		if err != nil {
+			return err
 		}
-		iam.identities = append(iam.identities, t)
+		identities = append(identities, t)
 	}
 
+	iam.identitiesLock.Lock()
+	iam.fileIdentities = identities
+	iam.enabled = true
+	iam.mergeIdentities()
+	iam.identitiesLock.Unlock()
+
 	return nil
 }
 
+func identityFromPb(ident *iam_pb.Identity) (*Identity, error) {
+	t := &Identity{
+		Name:        ident.Name,
+		Credentials: nil,
+		Actions:     nil,
+		Policy:      policyFromPb(ident.Policy),
+	}
+	if t.Policy != nil {
+		for _, statement := range t.Policy.Statement {
+			if err := statement.validate(); err != nil {
+				return nil, fmt.Errorf("identity %s policy: %v", ident.Name, err)
+			}
+		}
+	}
+	for _, action := range ident.Actions {
+		t.Actions = append(t.Actions, Action(action))
+	}
+	for _, cred := range ident.Credentials {
+		t.Credentials = append(t.Credentials, &Credential{
+			AccessKey: cred.AccessKey,
+			SecretKey: cred.SecretKey,
+		})
+	}
+	return t, nil
+}
+
+// putFilerIdentity adds or replaces one identity managed through the filer
+func (iam *IdentityAccessManagement) putFilerIdentity(identity *Identity) {
+	iam.identitiesLock.Lock()
+	defer iam.identitiesLock.Unlock()
+	iam.filerIdentities[identity.Name] = identity
+	iam.enabled = true
+	iam.mergeIdentities()
+}
+
+// enable authenticates all requests from now on, even if there is no identity
+func (iam *IdentityAccessManagement) enable() {
+	iam.identitiesLock.Lock()
+	defer iam.identitiesLock.Unlock()
+	iam.enabled = true
+}
+
+func (iam *IdentityAccessManagement) deleteFilerIdentity(name string) {
+	iam.identitiesLock.Lock()
+	defer iam.identitiesLock.Unlock()
+	delete(iam.filerIdentities, name)
+	iam.mergeIdentities()
+}
+
+// mergeIdentities rebuilds the identities list, where the config file takes precedence. The caller holds the lock.
+func (iam *IdentityAccessManagement) mergeIdentities() {
+	identities := append([]*Identity{}, iam.fileIdentities...)
+	for _, identity := range iam.filerIdentities {
+		identities = append(identities, identity)
+	}
+	iam.identities = identities
+}
+
+func (iam *IdentityAccessManagement) isEnabled() bool {
+	iam.identitiesLock.RLock()
+	defer iam.identitiesLock.RUnlock()
+	return iam.enabled
+}
+
 func (iam *IdentityAccessManagement) lookupByAccessKey(accessKey string) (identity *Identity, cred *Credential, found bool) {
+	iam.identitiesLock.RLock()
+	defer iam.identitiesLock.RUnlock()
 	for _, ident := range iam.identities {
 		for _, cred := range ident.Credentials {
 			if cred.AccessKey == accessKey {
@@ -104,12 +198,31 @@ func (iam *IdentityAccessManagement) lookupByAccessKey(accessKey string) (identi
 
 func (iam *IdentityAccessManagement) Auth(f http.HandlerFunc, action Action) http.HandlerFunc {
 
-	if len(iam.identities) == 0 {
-		return f
+	return func(w http.ResponseWriter, r *http.Request) {
+		// without the config file or the filer identities, the gateway is open until the first identity is added
+		if !iam.isEnabled() {
+			f(w, r)
+			return
+		}
+		errCode := iam.authRequest(r, action)
+		if errCode == ErrNone {
+			f(w, r)
+			return
+		}
+		writeErrorResponse(w, errCode, r.URL)
 	}
+}
+
+// Authenticate only checks the signature of the request, and the handler authorizes the request,
+// e.g. on each key of a multi-object delete
+func (iam *IdentityAccessManagement) Authenticate(f http.HandlerFunc) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		errCode := iam.authRequest(r, action)
+		if !iam.isEnabled() {
+			f(w, r)
+			return
+		}
+		_, errCode := iam.authenticate(r)
 		if errCode == ErrNone {
 			f(w, r)
 			return
@@ -120,56 +233,197 @@ func (iam *IdentityAccessManagement) Auth(f http.HandlerFunc, action Action) htt
 
 // check whether the request has valid access keys
 func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action) ErrorCode {
-	var identity *Identity
-	var s3Err ErrorCode
+	identity, s3Err := iam.authenticate(r)
+	glog.V(3).Infof("auth error: %v", s3Err)
+	if s3Err != ErrNone {
+		return s3Err
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if !iam.isAuthorized(r, identity, action, bucket, object) {
+		return ErrAccessDenied
+	}
+
+	return ErrNone
+
+}
+
+// authenticate finds the identity signing the request, which is nil for anonymous requests
+func (iam *IdentityAccessManagement) authenticate(r *http.Request) (identity *Identity, s3Err ErrorCode) {
 	switch getRequestAuthType(r) {
-	case authTypeStreamingSigned:
-		return ErrNone
 	case authTypeUnknown:
 		glog.V(3).Infof("unknown auth type")
-		return ErrAccessDenied
+		return nil, ErrAccessDenied
 	case authTypePresignedV2, authTypeSignedV2:
 		glog.V(3).Infof("v2 auth type")
-		identity, s3Err = iam.isReqAuthenticatedV2(r)
+		return iam.isReqAuthenticatedV2(r)
 	case authTypeSigned, authTypePresigned:
 		glog.V(3).Infof("v4 auth type")
-		identity, s3Err = iam.reqSignatureV4Verify(r)
+		return iam.reqSignatureV4Verify(r)
+	case authTypeStreamingSigned:
+		glog.V(3).Infof("v4 streaming auth type")
+		// the chunk signatures are verified while reading the body
+		identity, _, _, _, _, s3Err = iam.calculateSeedSignature(r)
+		return identity, s3Err
 	case authTypePostPolicy:
 		glog.V(3).Infof("post policy auth type")
-		return ErrNotImplemented
+		return nil, ErrNotImplemented
 	case authTypeJWT:
 		glog.V(3).Infof("jwt auth type")
-		return ErrNotImplemented
+		return nil, ErrNotImplemented
 	case authTypeAnonymous:
-		return ErrAccessDenied
-	default:
-		return ErrNotImplemented
+		return nil, ErrNone
 	}
+	return nil, ErrNotImplemented
+}
 
-	glog.V(3).Infof("auth error: %v", s3Err)
-	if s3Err != ErrNone {
-		return s3Err
+// canBypassGovernance checks whether the request asks and is allowed to bypass the governance retention,
+// which needs the Admin action or the s3:BypassGovernanceRetention permission.
+func (iam *IdentityAccessManagement) canBypassGovernance(r *http.Request, bucket, object string) bool {
+	if r.Header.Get(amzBypassGovernanceHeader) != "true" {
+		return false
 	}
+	return iam.isAuthorizedOn(r, ACTION_ADMIN, "s3:BypassGovernanceRetention", bucket, object)
+}
 
-	glog.V(3).Infof("user name: %v actions: %v", identity.Name, identity.Actions)
+// isAuthorizedOn checks the request for another action or object than the one it is routed by,
+// e.g. each key of a multi-object delete, or the source object of a copy.
+func (iam *IdentityAccessManagement) isAuthorizedOn(r *http.Request, action Action, s3Action string, bucket, object string) bool {
+	return iam.authorizerOf(r)(action, s3Action, bucket, object)
+}
 
-	vars := mux.Vars(r)
-	bucket := vars["bucket"]
+// authorizerOf authenticates the request once, to authorize it on many objects
+func (iam *IdentityAccessManagement) authorizerOf(r *http.Request) func(action Action, s3Action string, bucket, object string) bool {
+	if !iam.isEnabled() {
+		return func(action Action, s3Action string, bucket, object string) bool {
+			return true
+		}
+	}
+	identity, s3Err := iam.authenticate(r)
+	return func(action Action, s3Action string, bucket, object string) bool {
+		return s3Err == ErrNone && iam.isAllowed(identity, action, s3Action, bucket, object)
+	}
+}
 
-	if !identity.canDo(action, bucket) {
-		return ErrAccessDenied
+// isAuthorized checks the identity policy and the bucket policy, where an explicit Deny wins over any Allow.
+// Identities with the Admin action are not restricted by bucket policies.
+// The coarse Read/Write/Admin actions are still honored if no policy allows or denies the request.
+// Anonymous requests, where identity is nil, are only allowed by bucket policies or canned ACLs.
+func (iam *IdentityAccessManagement) isAuthorized(r *http.Request, identity *Identity, action Action, bucket, object string) bool {
+	return iam.isAllowed(identity, action, s3ActionOf(r, bucket, object), bucket, object)
+}
+
+func (iam *IdentityAccessManagement) isAllowed(identity *Identity, action Action, s3Action string, bucket, object string) bool {
+
+	resource := policyResource(bucket, object)
+
+	principal := anonymousUserName
+	if identity != nil {
+		glog.V(3).Infof("user name: %v actions: %v", identity.Name, identity.Actions)
+		if identity.SessionPolicy != nil && identity.SessionPolicy.evaluate("", s3Action, resource) != policyAllow {
+			return false
+		}
+		if identity.isAdmin() {
+			return true
+		}
+		principal = identity.Name
 	}
 
-	return ErrNone
+	identityDecision := policyNotApplicable
+	if identity != nil {
+		identityDecision = identity.Policy.evaluate("", s3Action, resource)
+	}
+	bucketPolicy, err := iam.getBucketPolicy(bucket)
+	if err != nil {
+		glog.Warningf("load bucket %s policy: %v", bucket, err)
+		return false
+	}
+	bucketDecision := bucketPolicy.evaluate(principal, s3Action, resource)
+	glog.V(3).Infof("%s %s on %s: identity policy %d bucket policy %d", principal, s3Action, resource, identityDecision, bucketDecision)
+
+	if identityDecision == policyDeny || bucketDecision == policyDeny {
+		return false
+	}
+	if identityDecision == policyAllow || bucketDecision == policyAllow {
+		return true
+	}
+
+	if identity != nil && identity.canDo(action, bucket) {
+		return true
+	}
 
+	return iam.isGrantedByAcl(identity, s3Action, bucket, object)
 }
 
-func (identity *Identity) canDo(action Action, bucket string) bool {
+// isGrantedByAcl checks the canned ACLs of the object and the bucket
+func (iam *IdentityAccessManagement) isGrantedByAcl(identity *Identity, s3Action string, bucket, object string) bool {
+	if bucket == "" || iam.cannedAclLoader == nil {
+		return false
+	}
+	object = strings.TrimPrefix(object, "/")
+	if object != "" && aclObjectReadActions[s3Action] {
+		if cannedAcl, err := iam.getCannedAcl(bucket, object); err != nil {
+			glog.Warningf("load %s/%s acl: %v", bucket, object, err)
+		} else if isGrantedByCannedAcl(cannedAcl, identity, false, s3Action) {
+			return true
+		}
+	}
+	cannedAcl, err := iam.getCannedAcl(bucket, "")
+	if err != nil {
+		glog.Warningf("load bucket %s acl: %v", bucket, err)
+		return false
+	}
+	return isGrantedByCannedAcl(cannedAcl, identity, true, s3Action)
+}
+
+func (iam *IdentityAccessManagement) getCannedAcl(bucket, object string) (string, error) {
+	item, err := iam.cannedAcls.Fetch(bucket+"/"+object, bucketPolicyCacheTtl, func() (interface{}, error) {
+		return iam.cannedAclLoader(bucket, object)
+	})
+	if err != nil {
+		return "", err
+	}
+	return item.Value().(string), nil
+}
+
+func (iam *IdentityAccessManagement) invalidateCannedAcl(bucket, object string) {
+	iam.cannedAcls.Delete(bucket + "/" + strings.TrimPrefix(object, "/"))
+}
+
+func (iam *IdentityAccessManagement) getBucketPolicy(bucket string) (*PolicyDocument, error) {
+	if bucket == "" || iam.bucketPolicyLoader == nil {
+		return nil, nil
+	}
+	item, err := iam.bucketPolicies.Fetch(bucket, bucketPolicyCacheTtl, func() (interface{}, error) {
+		return iam.bucketPolicyLoader(bucket)
+	})
+	if err != nil {
+		return nil, err
+	}
+	policy, _ := item.Value().(*PolicyDocument)
+	return policy, nil
+}
+
+func (iam *IdentityAccessManagement) invalidateBucketPolicy(bucket string) {
+	iam.bucketPolicies.Delete(bucket)
+}
+
+func (identity *Identity) isAdmin() bool {
 	for _, a := range identity.Actions {
-		if a == "Admin" {
+		if a == ACTION_ADMIN {
 			return true
 		}
 	}
+	return false
+}
+
+func (identity *Identity) canDo(action Action, bucket string) bool {
+	if identity.isAdmin() {
+		return true
+	}
 	for _, a := range identity.Actions {
 		if a == action {
 			return true
@@ -0,0 +1,121 @@
+package s3api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/iam_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// loadFilerIdentities reads the identities managed through the filer,
+// and keeps following their changes so no restart is needed.
+func (s3a *S3ApiServer) loadFilerIdentities() {
+
+	lastTsNs := time.Now().UnixNano()
+
+	// the identities folder is kept after all identities are deleted, and requests stay authenticated
+	dir, name := util.FullPath(filer2.IamIdentitiesDir).DirAndName()
+	if found, err := s3a.exists(dir, name, true); err != nil || found {
+		if err != nil {
+			glog.V(0).Infof("lookup %s: %v", filer2.IamIdentitiesDir, err)
+		}
+		s3a.iam.enable()
+	}
+
+	err := filer_pb.List(s3a, filer2.IamIdentitiesDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		s3a.onIdentityUpdate(nil, entry)
+		return nil
+	}, "", false, math.MaxUint32)
+	if err != nil && err != filer_pb.ErrNotFound {
+		glog.V(0).Infof("list identities %s: %v", filer2.IamIdentitiesDir, err)
+		s3a.iam.enable()
+	}
+
+	go s3a.subscribeIdentities(lastTsNs)
+}
+
+func (s3a *S3ApiServer) subscribeIdentities(lastTsNs int64) {
+
+	for {
+		err := s3a.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+			stream, err := client.SubscribeMetadata(context.Background(), &filer_pb.SubscribeMetadataRequest{
+				ClientName: "s3",
+				PathPrefix: filer2.IamIdentitiesDir + "/",
+				SinceNs:    lastTsNs,
+			})
+			if err != nil {
+				return fmt.Errorf("subscribe: %v", err)
+			}
+
+			for {
+				resp, listenErr := stream.Recv()
+				if listenErr == io.EOF {
+					return nil
+				}
+				if listenErr != nil {
+					return listenErr
+				}
+
+				message := resp.EventNotification
+				newDir := resp.Directory
+				if message.NewParentPath != "" {
+					newDir = message.NewParentPath
+				}
+				if resp.Directory == filer2.IamIdentitiesDir || newDir == filer2.IamIdentitiesDir {
+					var oldEntry, newEntry *filer_pb.Entry
+					if resp.Directory == filer2.IamIdentitiesDir {
+						oldEntry = message.OldEntry
+					}
+					if newDir == filer2.IamIdentitiesDir {
+						newEntry = message.NewEntry
+					}
+					s3a.onIdentityUpdate(oldEntry, newEntry)
+				}
+				lastTsNs = resp.TsNs
+			}
+		})
+		if err != nil {
+			glog.V(0).Infof("subscribing filer identity changes: %v", err)
+			time.Sleep(time.Second)
+		}
+	}
+}
+
+func (s3a *S3ApiServer) onIdentityUpdate(oldEntry, newEntry *filer_pb.Entry) {
+
+	if oldEntry != nil && (newEntry == nil || oldEntry.Name != newEntry.Name) {
+		glog.V(0).Infof("remove identity %s", oldEntry.Name)
+		s3a.iam.deleteFilerIdentity(oldEntry.Name)
+	}
+
+	if newEntry == nil || newEntry.IsDirectory {
+		return
+	}
+
+	ident := &iam_pb.Identity{}
+	if err := proto.Unmarshal(newEntry.Extended[filer2.IamIdentityExtKey], ident); err != nil {
+		glog.Errorf("unmarshal identity %s: %v", newEntry.Name, err)
+		return
+	}
+	if err := filer2.OpenIdentitySecrets(ident, s3a.option.IamKey); err != nil {
+		glog.Errorf("load identity %s: %v", newEntry.Name, err)
+		return
+	}
+	identity, err := identityFromPb(ident)
+	if err != nil {
+		glog.Errorf("load identity %s: %v", newEntry.Name, err)
+		return
+	}
+
+	glog.V(0).Infof("update identity %s", identity.Name)
+	s3a.iam.putFilerIdentity(identity)
+}
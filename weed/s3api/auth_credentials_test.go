@@ -4,7 +4,11 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
 
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
 	"github.com/chrislusf/seaweedfs/weed/pb/iam_pb"
 )
 
@@ -66,3 +70,53 @@ func TestIdentityListFileFormat(t *testing.T) {
 	println(text)
 
 }
+
+func TestFilerIdentityUpdate(t *testing.T) {
+
+	s3a := &S3ApiServer{
+		option: &S3ApiServerOption{IamKey: "iam_key"},
+		iam:    NewIdentityAccessManagement("", ""),
+	}
+	assert.False(t, s3a.iam.isEnabled())
+
+	ident := &iam_pb.Identity{
+		Name: "filer_user",
+		Credentials: []*iam_pb.Credential{
+			{
+				AccessKey: "filer_access_key",
+				SecretKey: "filer_secret_key",
+			},
+		},
+		Actions: []string{
+			ACTION_READ,
+		},
+	}
+	assert.Nil(t, filer2.SealIdentitySecrets(ident, "iam_key"))
+	data, _ := proto.Marshal(ident)
+	entry := &filer_pb.Entry{
+		Name: "filer_user",
+		Extended: map[string][]byte{
+			filer2.IamIdentityExtKey: data,
+		},
+	}
+
+	s3a.onIdentityUpdate(nil, entry)
+	assert.True(t, s3a.iam.isEnabled())
+	identity, cred, found := s3a.iam.lookupByAccessKey("filer_access_key")
+	assert.True(t, found)
+	assert.Equal(t, "filer_user", identity.Name)
+	assert.Equal(t, "filer_secret_key", cred.SecretKey)
+
+	s3a.onIdentityUpdate(entry, nil)
+	_, _, found = s3a.iam.lookupByAccessKey("filer_access_key")
+	assert.False(t, found)
+	// deleting the last identity does not open the gateway
+	assert.True(t, s3a.iam.isEnabled())
+
+	// the secret keys are not loaded without the same iam key
+	s3a.option.IamKey = "other_key"
+	s3a.onIdentityUpdate(nil, entry)
+	_, _, found = s3a.iam.lookupByAccessKey("filer_access_key")
+	assert.False(t, found)
+
+}
@@ -0,0 +1,338 @@
+package s3api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/iam_pb"
+)
+
+// AWS style policy documents, used both for identity policies and bucket policies.
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/access-policy-language-overview.html
+
+const (
+	PolicyEffectAllow = "Allow"
+	PolicyEffectDeny  = "Deny"
+
+	s3ResourcePrefix  = "arn:aws:s3:::"
+	iamUserArnPrefix  = "arn:aws:iam:::user/"
+	anonymousUserName = "anonymous"
+	maxPolicySize     = 20 * 1024
+)
+
+type PolicyDocument struct {
+	Version   string            `json:"Version,omitempty"`
+	Id        string            `json:"Id,omitempty"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+type PolicyStatement struct {
+	Sid       string           `json:"Sid,omitempty"`
+	Effect    string           `json:"Effect"`
+	Principal *PolicyPrincipal `json:"Principal,omitempty"`
+	Action    StringList       `json:"Action"`
+	Resource  StringList       `json:"Resource"`
+}
+
+// StringList accepts either a single string or a list of strings.
+type StringList []string
+
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = []string{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// PolicyPrincipal is either "*" or {"AWS": ["arn:aws:iam:::user/name", ...]}
+type PolicyPrincipal struct {
+	AWS StringList `json:"AWS,omitempty"`
+}
+
+func (p *PolicyPrincipal) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		p.AWS = []string{single}
+		return nil
+	}
+	var principal struct {
+		AWS StringList `json:"AWS"`
+	}
+	if err := json.Unmarshal(data, &principal); err != nil {
+		return err
+	}
+	p.AWS = principal.AWS
+	return nil
+}
+
+func ParsePolicyDocument(data []byte) (*PolicyDocument, error) {
+	policy := &PolicyDocument{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// validateBucketPolicy checks that a bucket policy only references the bucket itself.
+func (p *PolicyDocument) validateBucketPolicy(bucket string) error {
+	if len(p.Statement) == 0 {
+		return fmt.Errorf("policy has no statements")
+	}
+	for _, statement := range p.Statement {
+		if err := statement.validate(); err != nil {
+			return err
+		}
+		if statement.Principal == nil || len(statement.Principal.AWS) == 0 {
+			return fmt.Errorf("statement %s has no principal", statement.Sid)
+		}
+		for _, resource := range statement.Resource {
+			name := strings.TrimPrefix(resource, s3ResourcePrefix)
+			if name != bucket && !strings.HasPrefix(name, bucket+"/") {
+				return fmt.Errorf("resource %s is outside of bucket %s", resource, bucket)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *PolicyStatement) validate() error {
+	if s.Effect != PolicyEffectAllow && s.Effect != PolicyEffectDeny {
+		return fmt.Errorf("invalid effect %q", s.Effect)
+	}
+	if len(s.Action) == 0 {
+		return fmt.Errorf("statement %s has no action", s.Sid)
+	}
+	if len(s.Resource) == 0 {
+		return fmt.Errorf("statement %s has no resource", s.Sid)
+	}
+	for _, action := range s.Action {
+		if action != "*" && !strings.HasPrefix(action, "s3:") {
+			return fmt.Errorf("unsupported action %s", action)
+		}
+	}
+	for _, resource := range s.Resource {
+		if resource != "*" && !strings.HasPrefix(resource, s3ResourcePrefix) {
+			return fmt.Errorf("unsupported resource %s", resource)
+		}
+	}
+	return nil
+}
+
+func policyFromPb(policy *iam_pb.Policy) *PolicyDocument {
+	if policy == nil {
+		return nil
+	}
+	t := &PolicyDocument{
+		Version: policy.Version,
+	}
+	for _, statement := range policy.Statements {
+		t.Statement = append(t.Statement, PolicyStatement{
+			Sid:      statement.Sid,
+			Effect:   statement.Effect,
+			Action:   statement.Actions,
+			Resource: statement.Resources,
+		})
+	}
+	return t
+}
+
+type policyDecision int
+
+const (
+	policyNotApplicable policyDecision = iota
+	policyAllow
+	policyDeny
+)
+
+// evaluate returns policyDeny if any matching statement denies, otherwise
+// policyAllow if any matching statement allows. Principals are only checked
+// when principal is not empty, which is the case for bucket policies.
+func (p *PolicyDocument) evaluate(principal string, s3Action string, resource string) policyDecision {
+	if p == nil {
+		return policyNotApplicable
+	}
+	decision := policyNotApplicable
+	for _, statement := range p.Statement {
+		if principal != "" && !statement.matchPrincipal(principal) {
+			continue
+		}
+		if !matchAny(statement.Action, s3Action) {
+			continue
+		}
+		if !statement.matchResource(resource) {
+			continue
+		}
+		if statement.Effect == PolicyEffectDeny {
+			return policyDeny
+		}
+		if statement.Effect == PolicyEffectAllow {
+			decision = policyAllow
+		}
+	}
+	return decision
+}
+
+func (s *PolicyStatement) matchPrincipal(principal string) bool {
+	if s.Principal == nil {
+		return false
+	}
+	for _, p := range s.Principal.AWS {
+		if p == "*" {
+			return true
+		}
+		if principal != anonymousUserName && strings.TrimPrefix(p, iamUserArnPrefix) == principal {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PolicyStatement) matchResource(resource string) bool {
+	for _, r := range s.Resource {
+		if r == "*" || wildcardMatch(strings.TrimPrefix(r, s3ResourcePrefix), resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || wildcardMatch(strings.ToLower(pattern), strings.ToLower(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardMatch supports * for any sequence and ? for any single character, also across "/".
+func wildcardMatch(pattern, name string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == name
+	}
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(name); i >= 0; i-- {
+				if wildcardMatch(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(name) == 0 {
+				return false
+			}
+		default:
+			if len(name) == 0 || pattern[0] != name[0] {
+				return false
+			}
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// policyResource returns the resource name of a request, without the arn:aws:s3::: prefix.
+func policyResource(bucket, object string) string {
+	if bucket == "" {
+		return "*"
+	}
+	object = strings.TrimPrefix(object, "/")
+	if object == "" {
+		return bucket
+	}
+	return bucket + "/" + object
+}
+
+// s3ActionOf maps a request to the fine grained S3 action name used in policies.
+func s3ActionOf(r *http.Request, bucket, object string) string {
+
+	query := r.URL.Query()
+	_, hasVersionId := query["versionId"]
+	isRead := r.Method == http.MethodGet || r.Method == http.MethodHead
+
+	if bucket == "" {
+		return "s3:ListAllMyBuckets"
+	}
+
+	subResource := func(get, put, del string) string {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			return get
+		case http.MethodDelete:
+			return del
+		default:
+			return put
+		}
+	}
+
+	if object != "" && object != "/" {
+		switch {
+		case has(query, "tagging"):
+			if hasVersionId {
+				return subResource("s3:GetObjectVersionTagging", "s3:PutObjectVersionTagging", "s3:DeleteObjectVersionTagging")
+			}
+			return subResource("s3:GetObjectTagging", "s3:PutObjectTagging", "s3:DeleteObjectTagging")
+		case has(query, "acl"):
+			return subResource("s3:GetObjectAcl", "s3:PutObjectAcl", "s3:PutObjectAcl")
+		case has(query, "uploadId"):
+			return subResource("s3:ListMultipartUploadParts", "s3:PutObject", "s3:AbortMultipartUpload")
+		case has(query, "select"):
+			return "s3:GetObject"
+		case has(query, "retention"):
+			return subResource("s3:GetObjectRetention", "s3:PutObjectRetention", "s3:PutObjectRetention")
+		case has(query, "legal-hold"):
+			return subResource("s3:GetObjectLegalHold", "s3:PutObjectLegalHold", "s3:PutObjectLegalHold")
+		}
+		switch {
+		case isRead && hasVersionId:
+			return "s3:GetObjectVersion"
+		case isRead:
+			return "s3:GetObject"
+		case r.Method == http.MethodDelete && hasVersionId:
+			return "s3:DeleteObjectVersion"
+		case r.Method == http.MethodDelete:
+			return "s3:DeleteObject"
+		}
+		return "s3:PutObject"
+	}
+
+	switch {
+	case has(query, "versioning"):
+		return subResource("s3:GetBucketVersioning", "s3:PutBucketVersioning", "s3:PutBucketVersioning")
+	case has(query, "versions"):
+		return "s3:ListBucketVersions"
+	case has(query, "tagging"):
+		return subResource("s3:GetBucketTagging", "s3:PutBucketTagging", "s3:PutBucketTagging")
+	case has(query, "lifecycle"):
+		return subResource("s3:GetLifecycleConfiguration", "s3:PutLifecycleConfiguration", "s3:PutLifecycleConfiguration")
+	case has(query, "policy"):
+		return subResource("s3:GetBucketPolicy", "s3:PutBucketPolicy", "s3:DeleteBucketPolicy")
+	case has(query, "acl"):
+		return subResource("s3:GetBucketAcl", "s3:PutBucketAcl", "s3:PutBucketAcl")
+	case has(query, "object-lock"):
+		return subResource("s3:GetBucketObjectLockConfiguration", "s3:PutBucketObjectLockConfiguration", "s3:PutBucketObjectLockConfiguration")
+	case has(query, "notification"):
+		return subResource("s3:GetBucketNotification", "s3:PutBucketNotification", "s3:PutBucketNotification")
+	case has(query, "uploads"):
+		return "s3:ListBucketMultipartUploads"
+	case has(query, "delete"):
+		return "s3:DeleteObject"
+	}
+	return subResource("s3:ListBucket", "s3:CreateBucket", "s3:DeleteBucket")
+}
+
+func has(query map[string][]string, key string) bool {
+	_, found := query[key]
+	return found
+}
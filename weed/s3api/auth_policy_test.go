@@ -0,0 +1,231 @@
+package s3api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/iam_pb"
+)
+
+func TestWildcardMatch(t *testing.T) {
+	assert.True(t, wildcardMatch("bucket/*", "bucket/a/b.txt"))
+	assert.True(t, wildcardMatch("bucket/logs/2020-??-*", "bucket/logs/2020-01-02.gz"))
+	assert.True(t, wildcardMatch("s3:get*", "s3:getobject"))
+	assert.False(t, wildcardMatch("bucket/*", "bucket"))
+	assert.False(t, wildcardMatch("bucket/logs*", "bucket/data/logs"))
+	assert.False(t, wildcardMatch("bucket", "bucket2"))
+}
+
+func TestParseBucketPolicy(t *testing.T) {
+
+	policy, err := ParsePolicyDocument([]byte(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::bucket1/public/*"
+    },
+    {
+      "Effect": "Deny",
+      "Principal": {"AWS": ["arn:aws:iam:::user/bob"]},
+      "Action": ["s3:DeleteObject", "s3:PutObject"],
+      "Resource": ["arn:aws:s3:::bucket1/*"]
+    }
+  ]
+}`))
+	assert.Nil(t, err)
+	assert.Nil(t, policy.validateBucketPolicy("bucket1"))
+	assert.NotNil(t, policy.validateBucketPolicy("bucket2"))
+
+	assert.Equal(t, policyAllow, policy.evaluate(anonymousUserName, "s3:GetObject", "bucket1/public/a.txt"))
+	assert.Equal(t, policyNotApplicable, policy.evaluate(anonymousUserName, "s3:GetObject", "bucket1/private/a.txt"))
+	assert.Equal(t, policyDeny, policy.evaluate("bob", "s3:PutObject", "bucket1/public/a.txt"))
+	assert.Equal(t, policyNotApplicable, policy.evaluate("alice", "s3:PutObject", "bucket1/public/a.txt"))
+
+}
+
+func TestIsAuthorized(t *testing.T) {
+
+	bucketPolicy, _ := ParsePolicyDocument([]byte(`{"Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/public/*"},
+    {"Effect": "Deny", "Principal": {"AWS": "bob"}, "Action": "s3:*", "Resource": "arn:aws:s3:::bucket1/secret/*"}
+  ]}`))
+
+	iam := NewIdentityAccessManagement("", "")
+	iam.bucketPolicyLoader = func(bucket string) (*PolicyDocument, error) {
+		if bucket == "bucket1" {
+			return bucketPolicy, nil
+		}
+		return nil, nil
+	}
+
+	bob := &Identity{
+		Name:    "bob",
+		Actions: []Action{ACTION_READ},
+		Policy: policyFromPb(&iam_pb.Policy{
+			Statements: []*iam_pb.Statement{
+				{
+					Effect:    PolicyEffectAllow,
+					Actions:   []string{"s3:Put*"},
+					Resources: []string{"arn:aws:s3:::bucket2/uploads/*"},
+				},
+				{
+					Effect:    PolicyEffectDeny,
+					Actions:   []string{"s3:GetObject"},
+					Resources: []string{"arn:aws:s3:::bucket2/private/*"},
+				},
+			},
+		}),
+	}
+	admin := &Identity{
+		Name:    "admin",
+		Actions: []Action{ACTION_ADMIN},
+	}
+
+	get, _ := http.NewRequest("GET", "http://localhost:8333/bucket/object", nil)
+	put, _ := http.NewRequest("PUT", "http://localhost:8333/bucket/object", nil)
+
+	assert.True(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket1", "/public/a.txt"))
+	assert.False(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket1", "/a.txt"))
+	assert.False(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket2", "/public/a.txt"))
+
+	assert.True(t, iam.isAuthorized(get, bob, ACTION_READ, "bucket1", "/a.txt"))
+	assert.False(t, iam.isAuthorized(get, bob, ACTION_READ, "bucket1", "/secret/a.txt"))
+	assert.False(t, iam.isAuthorized(get, bob, ACTION_READ, "bucket2", "/private/a.txt"))
+	assert.True(t, iam.isAuthorized(put, bob, ACTION_WRITE, "bucket2", "/uploads/a.txt"))
+	assert.False(t, iam.isAuthorized(put, bob, ACTION_WRITE, "bucket2", "/a.txt"))
+
+	assert.True(t, iam.isAuthorized(get, admin, ACTION_READ, "bucket1", "/secret/a.txt"))
+
+	// each key of a multi-object delete, and the source of a copy, is authorized separately
+	iam.enabled = true
+	isAuthorized := iam.authorizerOf(put)
+	assert.True(t, isAuthorized(ACTION_READ, "s3:GetObject", "bucket1", "public/a.txt"))
+	assert.False(t, isAuthorized(ACTION_READ, "s3:GetObject", "bucket1", "a.txt"))
+	assert.False(t, isAuthorized(ACTION_WRITE, "s3:DeleteObject", "bucket1", "public/a.txt"))
+
+}
+
+func TestAuthRequest(t *testing.T) {
+
+	iam := NewIdentityAccessManagement("", "")
+	iam.enabled = true
+	iam.bucketPolicyLoader = func(bucket string) (*PolicyDocument, error) {
+		return nil, nil
+	}
+	iam.identities = []*Identity{
+		{
+			Name:        "bob",
+			Credentials: []*Credential{{AccessKey: "access_key_1", SecretKey: "secret_key_1"}},
+			Actions:     []Action{ACTION_READ, ACTION_WRITE},
+			Policy: policyFromPb(&iam_pb.Policy{
+				Statements: []*iam_pb.Statement{
+					{
+						Effect:    PolicyEffectDeny,
+						Actions:   []string{"s3:PutObject"},
+						Resources: []string{"arn:aws:s3:::bucket1/secret/*"},
+					},
+				},
+			}),
+		},
+	}
+
+	// the aws-chunked uploads are authorized by the seed signature
+	for object, expected := range map[string]ErrorCode{"a.txt": ErrNone, "secret/a.txt": ErrAccessDenied} {
+		put := mustNewRequest("PUT", "http://127.0.0.1:9000/bucket1/"+object, 0, nil, t)
+		put.Header.Set("x-amz-content-sha256", streamingContentSHA256)
+		assert.Nil(t, signRequestV4(put, "access_key_1", "secret_key_1"))
+		assert.Equal(t, authTypeStreamingSigned, getRequestAuthType(put))
+		put = mux.SetURLVars(put, map[string]string{"bucket": "bucket1", "object": object})
+		assert.Equal(t, expected, iam.authRequest(put, ACTION_WRITE), object)
+	}
+
+	// the delete query does not skip the authorization of other requests
+	post := mustNewRequest("POST", "http://127.0.0.1:9000/bucket1/a.txt?uploads&delete", 0, nil, t)
+	post = mux.SetURLVars(post, map[string]string{"bucket": "bucket1", "object": "a.txt"})
+	assert.Equal(t, ErrAccessDenied, iam.authRequest(post, ACTION_WRITE))
+
+}
+
+func TestS3ActionOf(t *testing.T) {
+	tests := []struct {
+		method, url, bucket, object, expected string
+	}{
+		{"GET", "/", "", "", "s3:ListAllMyBuckets"},
+		{"GET", "/b", "b", "", "s3:ListBucket"},
+		{"PUT", "/b", "b", "", "s3:CreateBucket"},
+		{"GET", "/b/o", "b", "/o", "s3:GetObject"},
+		{"HEAD", "/b/o?versionId=1", "b", "/o", "s3:GetObjectVersion"},
+		{"DELETE", "/b/o", "b", "/o", "s3:DeleteObject"},
+		{"PUT", "/b/o?tagging", "b", "/o", "s3:PutObjectTagging"},
+		{"POST", "/b/o?uploads", "b", "/o", "s3:PutObject"},
+		{"PUT", "/b?policy", "b", "", "s3:PutBucketPolicy"},
+		{"GET", "/b?versions", "b", "", "s3:ListBucketVersions"},
+	}
+	for _, tt := range tests {
+		r, _ := http.NewRequest(tt.method, "http://localhost:8333"+tt.url, nil)
+		assert.Equal(t, tt.expected, s3ActionOf(r, tt.bucket, tt.object), tt.method+" "+tt.url)
+	}
+}
+
+func TestIsAuthorizedByCannedAcl(t *testing.T) {
+
+	iam := NewIdentityAccessManagement("", "")
+	iam.bucketPolicyLoader = func(bucket string) (*PolicyDocument, error) {
+		if bucket == "bucket1" {
+			return ParsePolicyDocument([]byte(`{"Statement": [
+    {"Effect": "Deny", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/secret/*"}
+  ]}`))
+		}
+		return nil, nil
+	}
+	acls := map[string]string{
+		"bucket1/":           cannedAclPrivate,
+		"bucket1/public.txt": cannedAclPublicRead,
+		"bucket1/secret/a":   cannedAclPublicRead,
+		"bucket1/users.txt":  cannedAclAuthenticatedRead,
+		"bucket2/":           cannedAclPublicReadWrite,
+	}
+	iam.cannedAclLoader = func(bucket, object string) (string, error) {
+		if acl, found := acls[bucket+"/"+object]; found {
+			return acl, nil
+		}
+		return cannedAclPrivate, nil
+	}
+
+	alice := &Identity{
+		Name:    "alice",
+		Actions: []Action{"Read:bucket3"},
+	}
+
+	get, _ := http.NewRequest("GET", "http://localhost:8333/bucket/object", nil)
+	put, _ := http.NewRequest("PUT", "http://localhost:8333/bucket/object", nil)
+	list, _ := http.NewRequest("GET", "http://localhost:8333/bucket", nil)
+
+	assert.True(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket1", "/public.txt"))
+	assert.False(t, iam.isAuthorized(put, nil, ACTION_WRITE, "bucket1", "/public.txt"))
+	assert.False(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket1", "/private.txt"))
+	assert.False(t, iam.isAuthorized(list, nil, ACTION_READ, "bucket1", ""))
+	// an explicit deny wins over the acl
+	assert.False(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket1", "/secret/a"))
+
+	assert.False(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket1", "/users.txt"))
+	assert.True(t, iam.isAuthorized(get, alice, ACTION_READ, "bucket1", "/users.txt"))
+
+	assert.True(t, iam.isAuthorized(list, nil, ACTION_READ, "bucket2", ""))
+	assert.True(t, iam.isAuthorized(put, nil, ACTION_WRITE, "bucket2", "/a.txt"))
+	// reading objects is granted by the object acl only
+	assert.False(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket2", "/a.txt"))
+
+	// the cached acl is dropped after the object acl changes
+	acls["bucket1/public.txt"] = cannedAclPrivate
+	assert.True(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket1", "/public.txt"))
+	iam.invalidateCannedAcl("bucket1", "/public.txt")
+	assert.False(t, iam.isAuthorized(get, nil, ACTION_READ, "bucket1", "/public.txt"))
+
+}
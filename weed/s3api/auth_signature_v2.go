@@ -123,9 +123,9 @@ func (iam *IdentityAccessManagement) doesSignV2Match(r *http.Request) (*Identity
 
 	// Access credentials.
 	// Validate if access key id same.
-	ident, cred, found := iam.lookupByAccessKey(accessKey)
-	if !found {
-		return nil, ErrInvalidAccessKeyID
+	ident, cred, errCode := iam.lookupCredential(accessKey, sessionTokenOf(r))
+	if errCode != ErrNone {
+		return nil, errCode
 	}
 
 	// r.RequestURI will have raw encoded URI as sent by the client.
@@ -209,9 +209,9 @@ func (iam *IdentityAccessManagement) doesPresignV2SignatureMatch(r *http.Request
 	}
 
 	// Validate if access key id same.
-	ident, cred, found := iam.lookupByAccessKey(accessKey)
-	if !found {
-		return nil, ErrInvalidAccessKeyID
+	ident, cred, errCode := iam.lookupCredential(accessKey, sessionTokenOf(r))
+	if errCode != ErrNone {
+		return nil, errCode
 	}
 
 	// Make sure the request has not expired.
@@ -90,6 +90,11 @@ func getContentSha256Cksum(r *http.Request) string {
 
 // Verify authorization header - http://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
 func (iam *IdentityAccessManagement) doesSignatureMatch(hashedPayload string, r *http.Request) (*Identity, ErrorCode) {
+	return iam.doesServiceSignatureMatch(hashedPayload, r, "s3")
+}
+
+// doesServiceSignatureMatch verifies the authorization header signed for the service, e.g. "s3" or "sts"
+func (iam *IdentityAccessManagement) doesServiceSignatureMatch(hashedPayload string, r *http.Request, service string) (*Identity, ErrorCode) {
 
 	// Copy request.
 	req := *r
@@ -110,9 +115,9 @@ func (iam *IdentityAccessManagement) doesSignatureMatch(hashedPayload string, r
 	}
 
 	// Verify if the access key id matches.
-	identity, cred, found := iam.lookupByAccessKey(signV4Values.Credential.accessKey)
-	if !found {
-		return nil, ErrInvalidAccessKeyID
+	identity, cred, errCode := iam.lookupCredential(signV4Values.Credential.accessKey, sessionTokenOf(r))
+	if errCode != ErrNone {
+		return nil, errCode
 	}
 
 	// Extract date, if not present throw error.
@@ -138,7 +143,7 @@ func (iam *IdentityAccessManagement) doesSignatureMatch(hashedPayload string, r
 	stringToSign := getStringToSign(canonicalRequest, t, signV4Values.Credential.getScope())
 
 	// Get hmac signing key.
-	signingKey := getSigningKey(cred.SecretKey, signV4Values.Credential.scope.date, signV4Values.Credential.scope.region)
+	signingKey := getServiceSigningKey(cred.SecretKey, signV4Values.Credential.scope.date, signV4Values.Credential.scope.region, service)
 
 	// Calculate signature.
 	newSignature := getSignature(signingKey, stringToSign)
@@ -306,9 +311,9 @@ func (iam *IdentityAccessManagement) doesPresignedSignatureMatch(hashedPayload s
 	}
 
 	// Verify if the access key id matches.
-	identity, cred, found := iam.lookupByAccessKey(pSignValues.Credential.accessKey)
-	if !found {
-		return nil, ErrInvalidAccessKeyID
+	identity, cred, errCode := iam.lookupCredential(pSignValues.Credential.accessKey, sessionTokenOf(r))
+	if errCode != ErrNone {
+		return nil, errCode
 	}
 
 	// Extract all the signed headers along with its values.
@@ -345,6 +350,9 @@ func (iam *IdentityAccessManagement) doesPresignedSignatureMatch(hashedPayload s
 	query.Set("X-Amz-Expires", strconv.Itoa(expireSeconds))
 	query.Set("X-Amz-SignedHeaders", getSignedHeaders(extractedSignedHeaders))
 	query.Set("X-Amz-Credential", cred.AccessKey+"/"+getScope(t, pSignValues.Credential.scope.region))
+	if token := req.URL.Query().Get(amzSecurityTokenHeader); token != "" {
+		query.Set(amzSecurityTokenHeader, token)
+	}
 
 	// Save other headers available in the request parameters.
 	for k, v := range req.URL.Query() {
@@ -624,9 +632,14 @@ func sumHMAC(key []byte, data []byte) []byte {
 
 // getSigningKey hmac seed to calculate final signature.
 func getSigningKey(secretKey string, t time.Time, region string) []byte {
+	return getServiceSigningKey(secretKey, t, region, "s3")
+}
+
+// getServiceSigningKey hmac seed of the service, e.g. "s3" or "sts", to calculate final signature.
+func getServiceSigningKey(secretKey string, t time.Time, region string, serviceName string) []byte {
 	date := sumHMAC([]byte("AWS4"+secretKey), []byte(t.Format(yyyymmdd)))
 	regionBytes := sumHMAC(date, []byte(region))
-	service := sumHMAC(regionBytes, []byte("s3"))
+	service := sumHMAC(regionBytes, []byte(serviceName))
 	signingKey := sumHMAC(service, []byte("aws4_request"))
 	return signingKey
 }
@@ -718,3 +731,30 @@ func compareSignatureV4(sig1, sig2 string) bool {
 	// is unique for a sequence of bytes. See also compareSignatureV2.
 	return subtle.ConstantTimeCompare([]byte(sig1), []byte(sig2)) == 1
 }
+
+// doesPolicySignatureV4Match verifies the signature of a browser upload form, which signs the base64 encoded policy.
+// The form has the lower cased field names.
+func (iam *IdentityAccessManagement) doesPolicySignatureV4Match(form map[string]string) (*Identity, ErrorCode) {
+
+	if form["x-amz-algorithm"] != signV4Algorithm {
+		return nil, ErrSignatureVersionNotSupported
+	}
+
+	credHeader, errCode := parseCredentialHeader("Credential=" + form["x-amz-credential"])
+	if errCode != ErrNone {
+		return nil, errCode
+	}
+
+	identity, cred, errCode := iam.lookupCredential(credHeader.accessKey, form[strings.ToLower(amzSecurityTokenHeader)])
+	if errCode != ErrNone {
+		return nil, errCode
+	}
+
+	signingKey := getSigningKey(cred.SecretKey, credHeader.scope.date, credHeader.scope.region)
+	newSignature := getSignature(signingKey, form[postPolicyField])
+	if !compareSignatureV4(newSignature, form[postSignatureV4]) {
+		return nil, ErrSignatureDoesNotMatch
+	}
+
+	return identity, ErrNone
+}
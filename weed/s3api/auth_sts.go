@@ -0,0 +1,201 @@
+package s3api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+// Temporary credentials issued by the STS endpoint. The session token carries the signed claims,
+// so any gateway with the same signing key can verify them without storing the credentials.
+
+const (
+	amzSecurityTokenHeader = "X-Amz-Security-Token"
+
+	// temporary access keys have the prefix of the AWS ones
+	stsAccessKeyPrefix = "ASIA"
+
+	stsMinDuration        = 15 * time.Minute
+	stsDefaultDuration    = time.Hour
+	DefaultStsMaxDuration = 12 * time.Hour
+
+	maxSessionPolicySize = 2048
+)
+
+// sessionClaims are signed into the session token.
+// The parent access key is looked up on every request, so removing it revokes its temporary credentials.
+type sessionClaims struct {
+	AccessKey       string `json:"ak"`
+	ParentAccessKey string `json:"pk"`
+	Expiration      int64  `json:"exp"`
+	Policy          string `json:"policy,omitempty"`
+}
+
+// SetStsSigningKey sets the key to sign session tokens, shared by all gateways serving the same clients.
+// Without a key, a random one is used, and the temporary credentials only work with this gateway until it restarts.
+func (iam *IdentityAccessManagement) SetStsSigningKey(key string, maxDuration time.Duration) {
+	if key != "" {
+		iam.stsSigningKey = []byte(key)
+	} else {
+		iam.stsSigningKey = make([]byte, 32)
+		if _, err := rand.Read(iam.stsSigningKey); err != nil {
+			glog.Fatalf("generate sts signing key: %v", err)
+		}
+		glog.V(0).Infof("sts signing key is not configured, temporary credentials only work with this s3 gateway")
+	}
+	iam.stsMaxDuration = maxDuration
+	if iam.stsMaxDuration < stsMinDuration {
+		iam.stsMaxDuration = DefaultStsMaxDuration
+	}
+}
+
+// issueSessionCredential creates the temporary credential of the parent access key, and its session token
+func (iam *IdentityAccessManagement) issueSessionCredential(parentAccessKey string, expiration time.Time, policy string) (cred *Credential, sessionToken string, err error) {
+
+	randomBytes := make([]byte, 10)
+	if _, err = rand.Read(randomBytes); err != nil {
+		return nil, "", fmt.Errorf("generate access key: %v", err)
+	}
+	accessKey := stsAccessKeyPrefix + base32.StdEncoding.EncodeToString(randomBytes)
+
+	payload, err := json.Marshal(sessionClaims{
+		AccessKey:       accessKey,
+		ParentAccessKey: parentAccessKey,
+		Expiration:      expiration.Unix(),
+		Policy:          policy,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	sessionToken = encodedPayload + "." + base64.RawURLEncoding.EncodeToString(iam.stsSign("token/"+encodedPayload))
+
+	return &Credential{
+		AccessKey: accessKey,
+		SecretKey: iam.sessionSecretKey(accessKey),
+	}, sessionToken, nil
+}
+
+// sessionSecretKey derives the secret key from the temporary access key, which is unique
+func (iam *IdentityAccessManagement) sessionSecretKey(accessKey string) string {
+	return base64.StdEncoding.EncodeToString(iam.stsSign("secret/" + accessKey))[:40]
+}
+
+func (iam *IdentityAccessManagement) stsSign(data string) []byte {
+	mac := hmac.New(sha256.New, iam.stsSigningKey)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// lookupCredential finds the credential of the access key, which is a temporary one if the request has a session token
+func (iam *IdentityAccessManagement) lookupCredential(accessKey, sessionToken string) (*Identity, *Credential, ErrorCode) {
+	if sessionToken == "" {
+		identity, cred, found := iam.lookupByAccessKey(accessKey)
+		if !found {
+			return nil, nil, ErrInvalidAccessKeyID
+		}
+		return identity, cred, ErrNone
+	}
+	return iam.lookupSessionCredential(accessKey, sessionToken)
+}
+
+// lookupSessionCredential verifies the session token, and returns the identity of the parent access key,
+// narrowed by the session policy
+func (iam *IdentityAccessManagement) lookupSessionCredential(accessKey, sessionToken string) (*Identity, *Credential, ErrorCode) {
+
+	if len(iam.stsSigningKey) == 0 {
+		return nil, nil, ErrInvalidToken
+	}
+
+	parts := strings.SplitN(sessionToken, ".", 2)
+	if len(parts) != 2 {
+		return nil, nil, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, iam.stsSign("token/"+parts[0])) {
+		return nil, nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+	var claims sessionClaims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+
+	if claims.AccessKey != accessKey {
+		return nil, nil, ErrInvalidAccessKeyID
+	}
+	if time.Now().Unix() >= claims.Expiration {
+		return nil, nil, ErrExpiredToken
+	}
+
+	parent, _, found := iam.lookupByAccessKey(claims.ParentAccessKey)
+	if !found {
+		glog.V(1).Infof("temporary access key %s: parent access key %s is removed", accessKey, claims.ParentAccessKey)
+		return nil, nil, ErrInvalidToken
+	}
+
+	cred := &Credential{
+		AccessKey: accessKey,
+		SecretKey: iam.sessionSecretKey(accessKey),
+	}
+	identity := &Identity{
+		Name:        parent.Name,
+		Credentials: []*Credential{cred},
+		Actions:     parent.Actions,
+		Policy:      parent.Policy,
+	}
+	if claims.Policy != "" {
+		if identity.SessionPolicy, err = parseSessionPolicy(claims.Policy); err != nil {
+			return nil, nil, ErrInvalidToken
+		}
+	}
+
+	return identity, cred, ErrNone
+}
+
+// parseSessionPolicy reads the policy limiting the temporary credentials, which has no principals
+func parseSessionPolicy(policy string) (*PolicyDocument, error) {
+	if len(policy) > maxSessionPolicySize {
+		return nil, fmt.Errorf("session policy exceeds %d bytes", maxSessionPolicySize)
+	}
+	document, err := ParsePolicyDocument([]byte(policy))
+	if err != nil {
+		return nil, err
+	}
+	if len(document.Statement) == 0 {
+		return nil, fmt.Errorf("policy has no statements")
+	}
+	for _, statement := range document.Statement {
+		if err := statement.validate(); err != nil {
+			return nil, err
+		}
+		if statement.Principal != nil {
+			return nil, fmt.Errorf("statement %s has a principal", statement.Sid)
+		}
+	}
+	return document, nil
+}
+
+// sessionTokenOf reads the session token from the header, or from the query of presigned requests
+func sessionTokenOf(r *http.Request) string {
+	if token := r.Header.Get(amzSecurityTokenHeader); token != "" {
+		return token
+	}
+	query := r.URL.Query()
+	if token := query.Get(amzSecurityTokenHeader); token != "" {
+		return token
+	}
+	return query.Get(strings.ToLower(amzSecurityTokenHeader))
+}
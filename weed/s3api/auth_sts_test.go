@@ -0,0 +1,134 @@
+package s3api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStsTestServer() *S3ApiServer {
+	s3a := &S3ApiServer{
+		option: &S3ApiServerOption{BucketsPath: "/buckets"},
+		iam:    NewIdentityAccessManagement("", ""),
+	}
+	s3a.iam.identities = []*Identity{
+		{
+			Name:        "ci",
+			Credentials: []*Credential{{AccessKey: "ci_access_key", SecretKey: "ci_secret_key"}},
+			Actions:     []Action{ACTION_READ, ACTION_WRITE},
+		},
+	}
+	s3a.iam.SetStsSigningKey("test signing key", DefaultStsMaxDuration)
+	return s3a
+}
+
+func stsRequest(t *testing.T, form url.Values, creds *credentials.Credentials) *http.Request {
+	body := form.Encode()
+	r, _ := http.NewRequest("POST", "http://localhost:8333/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if _, err := v4.NewSigner(creds).Sign(r, bytes.NewReader([]byte(body)), "sts", "us-east-1", time.Now()); err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return r
+}
+
+func TestAssumeRole(t *testing.T) {
+
+	s3a := newStsTestServer()
+	parent := credentials.NewStaticCredentials("ci_access_key", "ci_secret_key", "")
+
+	w := httptest.NewRecorder()
+	s3a.StsHandler(w, stsRequest(t, url.Values{
+		"Action":          {"AssumeRole"},
+		"Version":         {"2011-06-15"},
+		"RoleArn":         {"arn:aws:iam::000000000000:role/ci"},
+		"RoleSessionName": {"build-1"},
+		"DurationSeconds": {"900"},
+		"Policy":          {`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*"}]}`},
+	}, parent))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response AssumeRoleResponse
+	assert.Nil(t, xml.Unmarshal(w.Body.Bytes(), &response))
+	creds := response.Result.Credentials
+	assert.True(t, strings.HasPrefix(creds.AccessKeyId, stsAccessKeyPrefix))
+	assert.True(t, creds.Expiration.After(time.Now().Add(14*time.Minute)))
+	assert.Equal(t, "arn:aws:sts:::assumed-role/ci/build-1", response.Result.AssumedRoleUser.Arn)
+
+	// the temporary credentials sign s3 requests with the session token
+	temporary := credentials.NewStaticCredentials(creds.AccessKeyId, creds.SecretAccessKey, creds.SessionToken)
+	r, _ := http.NewRequest("GET", "http://localhost:8333/bucket1/a.txt", nil)
+	v4.NewSigner(temporary).Sign(r, nil, "s3", "us-east-1", time.Now())
+	identity, errCode := s3a.iam.reqSignatureV4Verify(r)
+	assert.Equal(t, ErrNone, errCode)
+	assert.Equal(t, "ci", identity.Name)
+
+	// the session policy narrows the permissions of the parent
+	assert.True(t, s3a.iam.isAllowed(identity, ACTION_READ, "s3:GetObject", "bucket1", "/a.txt"))
+	assert.False(t, s3a.iam.isAllowed(identity, ACTION_WRITE, "s3:PutObject", "bucket1", "/a.txt"))
+	assert.False(t, s3a.iam.isAllowed(identity, ACTION_READ, "s3:GetObject", "bucket2", "/a.txt"))
+
+	// without the session token, the temporary access key is unknown
+	r, _ = http.NewRequest("GET", "http://localhost:8333/bucket1/a.txt", nil)
+	v4.NewSigner(credentials.NewStaticCredentials(creds.AccessKeyId, creds.SecretAccessKey, "")).Sign(r, nil, "s3", "us-east-1", time.Now())
+	_, errCode = s3a.iam.reqSignatureV4Verify(r)
+	assert.Equal(t, ErrInvalidAccessKeyID, errCode)
+
+	// temporary credentials can not issue more
+	w = httptest.NewRecorder()
+	s3a.StsHandler(w, stsRequest(t, url.Values{"Action": {"GetSessionToken"}}, temporary))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+}
+
+func TestGetSessionToken(t *testing.T) {
+
+	s3a := newStsTestServer()
+	parent := credentials.NewStaticCredentials("ci_access_key", "ci_secret_key", "")
+
+	w := httptest.NewRecorder()
+	s3a.StsHandler(w, stsRequest(t, url.Values{"Action": {"GetSessionToken"}, "DurationSeconds": {"600"}}, parent))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.True(t, strings.Contains(w.Body.String(), "<Code>ValidationError</Code>"), w.Body.String())
+
+	w = httptest.NewRecorder()
+	s3a.StsHandler(w, stsRequest(t, url.Values{"Action": {"GetSessionToken"}}, credentials.NewStaticCredentials("ci_access_key", "wrong", "")))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	s3a.StsHandler(w, stsRequest(t, url.Values{"Action": {"GetSessionToken"}}, parent))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response GetSessionTokenResponse
+	assert.Nil(t, xml.Unmarshal(w.Body.Bytes(), &response))
+	creds := response.Result.Credentials
+
+	identity, _, errCode := s3a.iam.lookupCredential(creds.AccessKeyId, creds.SessionToken)
+	assert.Equal(t, ErrNone, errCode)
+	assert.True(t, s3a.iam.isAllowed(identity, ACTION_WRITE, "s3:PutObject", "bucket2", "/a.txt"))
+
+	// a tampered token, or one of another access key
+	_, _, errCode = s3a.iam.lookupCredential(creds.AccessKeyId, creds.SessionToken+"x")
+	assert.Equal(t, ErrInvalidToken, errCode)
+	_, _, errCode = s3a.iam.lookupCredential("ASIAOTHER", creds.SessionToken)
+	assert.Equal(t, ErrInvalidAccessKeyID, errCode)
+
+	// expired
+	cred, sessionToken, _ := s3a.iam.issueSessionCredential("ci_access_key", time.Now().Add(-time.Second), "")
+	_, _, errCode = s3a.iam.lookupCredential(cred.AccessKey, sessionToken)
+	assert.Equal(t, ErrExpiredToken, errCode)
+
+	// removing the parent access key revokes the temporary credentials
+	s3a.iam.identities = nil
+	_, _, errCode = s3a.iam.lookupCredential(creds.AccessKeyId, creds.SessionToken)
+	assert.Equal(t, ErrInvalidToken, errCode)
+
+}
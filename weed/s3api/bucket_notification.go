@@ -0,0 +1,234 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// the bucket notification configuration is kept as the original xml document in the bucket entry extended attributes
+	extBucketNotificationKey = "s3-notification"
+
+	maxNotificationConfigurationSize = 64 * 1024
+
+	// notification targets, the message queue configured in notification.toml, or the named webhooks
+	notificationQueueArn         = "arn:seaweedfs:sqs:::queue"
+	notificationWebhookArnPrefix = "arn:seaweedfs:sqs:::webhook:"
+)
+
+// the events published for the objects
+const (
+	eventObjectCreatedPut                     = "ObjectCreated:Put"
+	eventObjectCreatedPost                    = "ObjectCreated:Post"
+	eventObjectCreatedCopy                    = "ObjectCreated:Copy"
+	eventObjectCreatedCompleteMultipartUpload = "ObjectCreated:CompleteMultipartUpload"
+	eventObjectRemovedDelete                  = "ObjectRemoved:Delete"
+	eventObjectRemovedDeleteMarkerCreated     = "ObjectRemoved:DeleteMarkerCreated"
+)
+
+var notificationEvents = []string{
+	eventObjectCreatedPut,
+	eventObjectCreatedPost,
+	eventObjectCreatedCopy,
+	eventObjectCreatedCompleteMultipartUpload,
+	eventObjectRemovedDelete,
+	eventObjectRemovedDeleteMarkerCreated,
+}
+
+type BucketNotificationConfiguration struct {
+	XMLName                     xml.Name             `xml:"NotificationConfiguration"`
+	TopicConfigurations         []NotificationTarget `xml:"TopicConfiguration,omitempty"`
+	QueueConfigurations         []NotificationTarget `xml:"QueueConfiguration,omitempty"`
+	CloudFunctionConfigurations []NotificationTarget `xml:"CloudFunctionConfiguration,omitempty"`
+}
+
+// NotificationTarget is a topic, queue or cloud function configuration, all of them naming the target by its ARN
+type NotificationTarget struct {
+	Id            string              `xml:"Id,omitempty"`
+	Topic         string              `xml:"Topic,omitempty"`
+	Queue         string              `xml:"Queue,omitempty"`
+	CloudFunction string              `xml:"CloudFunction,omitempty"`
+	Events        []string            `xml:"Event"`
+	Filter        *NotificationFilter `xml:"Filter,omitempty"`
+}
+
+type NotificationFilter struct {
+	FilterRules []NotificationFilterRule `xml:"S3Key>FilterRule"`
+}
+
+type NotificationFilterRule struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+func parseNotificationConfiguration(data []byte, isTarget func(arn string) bool) (*BucketNotificationConfiguration, error) {
+	config := &BucketNotificationConfiguration{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return config, config.validate(isTarget)
+}
+
+func (config *BucketNotificationConfiguration) targets() (targets []NotificationTarget) {
+	targets = append(targets, config.TopicConfigurations...)
+	targets = append(targets, config.QueueConfigurations...)
+	targets = append(targets, config.CloudFunctionConfigurations...)
+	return
+}
+
+func (config *BucketNotificationConfiguration) validate(isTarget func(arn string) bool) error {
+	for _, target := range config.targets() {
+		if !isTarget(target.arn()) {
+			return fmt.Errorf("unknown notification target %q", target.arn())
+		}
+		if len(target.Events) == 0 {
+			return fmt.Errorf("notification %s has no events", target.Id)
+		}
+		for _, event := range target.Events {
+			if !isNotificationEvent(event) {
+				return fmt.Errorf("unknown notification event %q", event)
+			}
+		}
+		if target.Filter != nil {
+			names := make(map[string]bool)
+			for _, rule := range target.Filter.FilterRules {
+				name := strings.ToLower(rule.Name)
+				if name != "prefix" && name != "suffix" || names[name] {
+					return fmt.Errorf("unexpected notification filter rule %q", rule.Name)
+				}
+				names[name] = true
+			}
+		}
+	}
+	return nil
+}
+
+// isNotificationEvent checks the configured events, e.g. s3:ObjectCreated:Put or s3:ObjectCreated:*
+func isNotificationEvent(configured string) bool {
+	for _, event := range notificationEvents {
+		if eventMatches(configured, event) {
+			return true
+		}
+	}
+	return false
+}
+
+func eventMatches(configured, event string) bool {
+	configured = strings.TrimPrefix(configured, "s3:")
+	if strings.HasSuffix(configured, ":*") {
+		return strings.HasPrefix(event, strings.TrimSuffix(configured, "*"))
+	}
+	return configured == event
+}
+
+// match finds the targets interested in the event of an object key
+func (config *BucketNotificationConfiguration) match(event, key string) (targets []NotificationTarget) {
+	for _, target := range config.targets() {
+		if target.matches(event, key) {
+			targets = append(targets, target)
+		}
+	}
+	return
+}
+
+func (target NotificationTarget) arn() string {
+	switch {
+	case target.Topic != "":
+		return target.Topic
+	case target.Queue != "":
+		return target.Queue
+	}
+	return target.CloudFunction
+}
+
+func (target NotificationTarget) matches(event, key string) bool {
+	if target.Filter != nil {
+		for _, rule := range target.Filter.FilterRules {
+			switch strings.ToLower(rule.Name) {
+			case "prefix":
+				if !strings.HasPrefix(key, rule.Value) {
+					return false
+				}
+			case "suffix":
+				if !strings.HasSuffix(key, rule.Value) {
+					return false
+				}
+			}
+		}
+	}
+	for _, configured := range target.Events {
+		if eventMatches(configured, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// the json event records, as documented in https://docs.aws.amazon.com/AmazonS3/latest/dev/notification-content-structure.html
+
+type EventRecords struct {
+	Records []EventRecord `json:"Records"`
+}
+
+type EventRecord struct {
+	EventVersion      string            `json:"eventVersion"`
+	EventSource       string            `json:"eventSource"`
+	AwsRegion         string            `json:"awsRegion"`
+	EventTime         string            `json:"eventTime"`
+	EventName         string            `json:"eventName"`
+	UserIdentity      EventIdentity     `json:"userIdentity"`
+	RequestParameters map[string]string `json:"requestParameters"`
+	ResponseElements  map[string]string `json:"responseElements"`
+	S3                EventS3           `json:"s3"`
+}
+
+type EventIdentity struct {
+	PrincipalId string `json:"principalId"`
+}
+
+type EventS3 struct {
+	SchemaVersion   string      `json:"s3SchemaVersion"`
+	ConfigurationId string      `json:"configurationId"`
+	Bucket          EventBucket `json:"bucket"`
+	Object          EventObject `json:"object"`
+}
+
+type EventBucket struct {
+	Name          string        `json:"name"`
+	OwnerIdentity EventIdentity `json:"ownerIdentity"`
+	Arn           string        `json:"arn"`
+}
+
+type EventObject struct {
+	Key       string `json:"key"`
+	Size      int64  `json:"size,omitempty"`
+	ETag      string `json:"eTag,omitempty"`
+	VersionId string `json:"versionId,omitempty"`
+	Sequencer string `json:"sequencer"`
+}
+
+func newEventRecord(event string, now time.Time, bucket string, object EventObject, principal, sourceIp string) EventRecord {
+	object.Sequencer = fmt.Sprintf("%016X", now.UnixNano())
+	return EventRecord{
+		EventVersion: "2.1",
+		EventSource:  "aws:s3",
+		AwsRegion:    "us-east-1",
+		EventTime:    now.UTC().Format("2006-01-02T15:04:05.000Z"),
+		EventName:    event,
+		UserIdentity: EventIdentity{PrincipalId: principal},
+		RequestParameters: map[string]string{
+			"sourceIPAddress": sourceIp,
+		},
+		ResponseElements: map[string]string{},
+		S3: EventS3{
+			SchemaVersion: "1.0",
+			Bucket: EventBucket{
+				Name: bucket,
+				Arn:  "arn:aws:s3:::" + bucket,
+			},
+			Object: object,
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package s3api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/notification"
+)
+
+const (
+	notificationBufferSize     = 1024
+	notificationWebhookTimeout = 10 * time.Second
+)
+
+type notificationMessage struct {
+	arn  string
+	key  string
+	data []byte
+}
+
+// notificationSender delivers the event records in the background, so slow targets do not slow down the requests.
+// The records are dropped if the targets can not keep up.
+type notificationSender struct {
+	queue    notification.MessageQueue // the queue configured for the bucket notifications, not the filer's
+	webhooks map[string]string         // webhook name => endpoint
+	messages chan notificationMessage
+	client   *http.Client
+}
+
+func newNotificationSender(queue notification.MessageQueue, webhooks map[string]string) *notificationSender {
+	sender := &notificationSender{
+		queue:    queue,
+		webhooks: webhooks,
+		messages: make(chan notificationMessage, notificationBufferSize),
+		client:   &http.Client{Timeout: notificationWebhookTimeout},
+	}
+	go sender.loop()
+	return sender
+}
+
+// isTarget checks whether the ARN names the configured message queue, or a configured webhook
+func (sender *notificationSender) isTarget(arn string) bool {
+	if arn == notificationQueueArn {
+		return sender.queue != nil
+	}
+	_, found := sender.webhooks[strings.TrimPrefix(arn, notificationWebhookArnPrefix)]
+	return found && strings.HasPrefix(arn, notificationWebhookArnPrefix)
+}
+
+func (sender *notificationSender) send(arn, key string, data []byte) {
+	select {
+	case sender.messages <- notificationMessage{arn: arn, key: key, data: data}:
+	default:
+		glog.Warningf("drop notification of %s to %s", key, arn)
+	}
+}
+
+func (sender *notificationSender) loop() {
+	for message := range sender.messages {
+		if err := sender.deliver(message); err != nil {
+			glog.Errorf("notify %s of %s: %v", message.arn, message.key, err)
+		}
+	}
+}
+
+func (sender *notificationSender) deliver(message notificationMessage) error {
+
+	if message.arn == notificationQueueArn {
+		if sender.queue == nil {
+			return fmt.Errorf("no message queue configured")
+		}
+		return sender.queue.SendRawMessage(message.key, message.data)
+	}
+
+	endpoint, found := sender.webhooks[strings.TrimPrefix(message.arn, notificationWebhookArnPrefix)]
+	if !found {
+		return fmt.Errorf("no webhook configured")
+	}
+	resp, err := sender.client.Post(endpoint, "application/json", bytes.NewReader(message.data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s: %s", endpoint, resp.Status)
+	}
+	return nil
+}
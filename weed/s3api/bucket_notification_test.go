@@ -0,0 +1,105 @@
+package s3api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketNotificationConfiguration(t *testing.T) {
+
+	isTarget := func(arn string) bool {
+		return arn == notificationQueueArn || arn == notificationWebhookArnPrefix+"images"
+	}
+
+	config, err := parseNotificationConfiguration([]byte(`<NotificationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <QueueConfiguration>
+    <Id>uploads</Id>
+    <Queue>arn:seaweedfs:sqs:::queue</Queue>
+    <Event>s3:ObjectCreated:*</Event>
+  </QueueConfiguration>
+  <CloudFunctionConfiguration>
+    <Id>thumbnails</Id>
+    <CloudFunction>arn:seaweedfs:sqs:::webhook:images</CloudFunction>
+    <Event>s3:ObjectCreated:Put</Event>
+    <Event>s3:ObjectRemoved:Delete</Event>
+    <Filter>
+      <S3Key>
+        <FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule>
+        <FilterRule><Name>suffix</Name><Value>.jpg</Value></FilterRule>
+      </S3Key>
+    </Filter>
+  </CloudFunctionConfiguration>
+</NotificationConfiguration>`), isTarget)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(config.targets()))
+
+	targets := config.match(eventObjectCreatedPut, "images/a.jpg")
+	assert.Equal(t, 2, len(targets))
+
+	targets = config.match(eventObjectCreatedCopy, "images/a.jpg")
+	assert.Equal(t, 1, len(targets))
+	assert.Equal(t, "uploads", targets[0].Id)
+
+	targets = config.match(eventObjectRemovedDelete, "images/a.jpg")
+	assert.Equal(t, 1, len(targets))
+	assert.Equal(t, notificationWebhookArnPrefix+"images", targets[0].arn())
+
+	assert.Equal(t, 0, len(config.match(eventObjectRemovedDelete, "images/a.png")))
+	assert.Equal(t, 0, len(config.match(eventObjectRemovedDeleteMarkerCreated, "images/a.jpg")))
+
+	config, err = parseNotificationConfiguration([]byte(`<NotificationConfiguration/>`), isTarget)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(config.targets()))
+
+	invalid := []string{
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:seaweedfs:sqs:::webhook:unknown</Queue><Event>s3:ObjectCreated:*</Event></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:seaweedfs:sqs:::queue</Queue></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:seaweedfs:sqs:::queue</Queue><Event>s3:ObjectRestore:*</Event></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:seaweedfs:sqs:::queue</Queue><Event>s3:ObjectCreated:*</Event><Filter><S3Key><FilterRule><Name>infix</Name><Value>a</Value></FilterRule></S3Key></Filter></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:seaweedfs:sqs:::queue</Queue><Event>s3:ObjectCreated:*</Event><Filter><S3Key><FilterRule><Name>prefix</Name><Value>a</Value></FilterRule><FilterRule><Name>Prefix</Name><Value>b</Value></FilterRule></S3Key></Filter></QueueConfiguration></NotificationConfiguration>`,
+	}
+	for _, data := range invalid {
+		config, err = parseNotificationConfiguration([]byte(data), isTarget)
+		assert.NotNil(t, config, data)
+		assert.Error(t, err, data)
+	}
+
+	config, err = parseNotificationConfiguration([]byte(`<NotificationConfiguration>`), isTarget)
+	assert.Nil(t, config)
+	assert.Error(t, err)
+}
+
+func TestEventRecord(t *testing.T) {
+
+	now := time.Date(2020, 3, 1, 12, 30, 0, 5e6, time.UTC)
+	record := newEventRecord(eventObjectCreatedPut, now, "b1", EventObject{
+		Key:  "images/a.jpg",
+		Size: 1024,
+		ETag: "d41d8cd98f00b204e9800998ecf8427e",
+	}, "admin", "127.0.0.1")
+	record.S3.ConfigurationId = "uploads"
+
+	data, err := json.Marshal(EventRecords{Records: []EventRecord{record}})
+	assert.NoError(t, err)
+
+	var decoded map[string][]map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, 1, len(decoded["Records"]))
+
+	r := decoded["Records"][0]
+	assert.Equal(t, "aws:s3", r["eventSource"])
+	assert.Equal(t, "ObjectCreated:Put", r["eventName"])
+	assert.Equal(t, "2020-03-01T12:30:00.005Z", r["eventTime"])
+	assert.Equal(t, "admin", r["userIdentity"].(map[string]interface{})["principalId"])
+
+	s3 := r["s3"].(map[string]interface{})
+	assert.Equal(t, "uploads", s3["configurationId"])
+	assert.Equal(t, "b1", s3["bucket"].(map[string]interface{})["name"])
+	object := s3["object"].(map[string]interface{})
+	assert.Equal(t, "images/a.jpg", object["key"])
+	assert.Equal(t, float64(1024), object["size"])
+	assert.NotEmpty(t, object["sequencer"])
+}
@@ -56,7 +56,7 @@ func getChunkSignature(secretKey string, seedSignature string, region string, da
 //     - http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html
 // returns signature, error otherwise if the signature mismatches or any other
 // error while parsing and validating.
-func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (cred *Credential, signature string, region string, date time.Time, errCode ErrorCode) {
+func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (identity *Identity, cred *Credential, signature string, region string, date time.Time, errCode ErrorCode) {
 
 	// Copy request.
 	req := *r
@@ -67,7 +67,7 @@ func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (cr
 	// Parse signature version '4' header.
 	signV4Values, errCode := parseSignV4(v4Auth)
 	if errCode != ErrNone {
-		return nil, "", "", time.Time{}, errCode
+		return nil, nil, "", "", time.Time{}, errCode
 	}
 
 	// Payload streaming.
@@ -75,18 +75,18 @@ func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (cr
 
 	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD'
 	if payload != req.Header.Get("X-Amz-Content-Sha256") {
-		return nil, "", "", time.Time{}, ErrContentSHA256Mismatch
+		return nil, nil, "", "", time.Time{}, ErrContentSHA256Mismatch
 	}
 
 	// Extract all the signed headers along with its values.
 	extractedSignedHeaders, errCode := extractSignedHeaders(signV4Values.SignedHeaders, r)
 	if errCode != ErrNone {
-		return nil, "", "", time.Time{}, errCode
+		return nil, nil, "", "", time.Time{}, errCode
 	}
 	// Verify if the access key id matches.
-	_, cred, found := iam.lookupByAccessKey(signV4Values.Credential.accessKey)
-	if !found {
-		return nil, "", "", time.Time{}, ErrInvalidAccessKeyID
+	identity, cred, errCode = iam.lookupCredential(signV4Values.Credential.accessKey, sessionTokenOf(r))
+	if errCode != ErrNone {
+		return nil, nil, "", "", time.Time{}, errCode
 	}
 
 	// Verify if region is valid.
@@ -96,14 +96,14 @@ func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (cr
 	var dateStr string
 	if dateStr = req.Header.Get(http.CanonicalHeaderKey("x-amz-date")); dateStr == "" {
 		if dateStr = r.Header.Get("Date"); dateStr == "" {
-			return nil, "", "", time.Time{}, ErrMissingDateHeader
+			return nil, nil, "", "", time.Time{}, ErrMissingDateHeader
 		}
 	}
 	// Parse date header.
 	var err error
 	date, err = time.Parse(iso8601Format, dateStr)
 	if err != nil {
-		return nil, "", "", time.Time{}, ErrMalformedDate
+		return nil, nil, "", "", time.Time{}, ErrMalformedDate
 	}
 
 	// Query string.
@@ -123,11 +123,11 @@ func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (cr
 
 	// Verify if signature match.
 	if !compareSignatureV4(newSignature, signV4Values.Signature) {
-		return nil, "", "", time.Time{}, ErrSignatureDoesNotMatch
+		return nil, nil, "", "", time.Time{}, ErrSignatureDoesNotMatch
 	}
 
 	// Return caculated signature.
-	return cred, newSignature, region, date, ErrNone
+	return identity, cred, newSignature, region, date, ErrNone
 }
 
 const maxLineLength = 4 * humanize.KiByte // assumed <= bufio.defaultBufSize 4KiB
@@ -142,7 +142,7 @@ var errMalformedEncoding = errors.New("malformed chunked encoding")
 // out of HTTP "chunked" format before returning it.
 // The s3ChunkedReader returns io.EOF when the final 0-length chunk is read.
 func (iam *IdentityAccessManagement) newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, ErrorCode) {
-	ident, seedSignature, region, seedDate, errCode := iam.calculateSeedSignature(req)
+	_, ident, seedSignature, region, seedDate, errCode := iam.calculateSeedSignature(req)
 	if errCode != ErrNone {
 		return nil, errCode
 	}
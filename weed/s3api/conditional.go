@@ -0,0 +1,129 @@
+package s3api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+type conditionHeaderNames struct {
+	ifMatch           string
+	ifNoneMatch       string
+	ifModifiedSince   string
+	ifUnmodifiedSince string
+}
+
+var (
+	conditionHeaders = conditionHeaderNames{
+		ifMatch:           "If-Match",
+		ifNoneMatch:       "If-None-Match",
+		ifModifiedSince:   "If-Modified-Since",
+		ifUnmodifiedSince: "If-Unmodified-Since",
+	}
+	copySourceConditionHeaders = conditionHeaderNames{
+		ifMatch:           "X-Amz-Copy-Source-If-Match",
+		ifNoneMatch:       "X-Amz-Copy-Source-If-None-Match",
+		ifModifiedSince:   "X-Amz-Copy-Source-If-Modified-Since",
+		ifUnmodifiedSince: "X-Amz-Copy-Source-If-Unmodified-Since",
+	}
+	// writes only check the etag
+	writeConditionHeaders = conditionHeaderNames{
+		ifMatch:     "If-Match",
+		ifNoneMatch: "If-None-Match",
+	}
+)
+
+// checkPreconditions evaluates the conditional headers against an existing object.
+// As in RFC 7232, a matching If-Match skips If-Unmodified-Since, and If-None-Match skips If-Modified-Since.
+// It returns ErrPreconditionFailed or ErrNotModified if the request should not proceed.
+func checkPreconditions(header http.Header, names conditionHeaderNames, entry *filer_pb.Entry) ErrorCode {
+
+	etag := filer2.ETag(entry)
+	mtime := time.Unix(entry.Attributes.GetMtime(), 0)
+
+	if ifMatch := header.Get(names.ifMatch); ifMatch != "" {
+		if !etagMatches(ifMatch, etag) {
+			return ErrPreconditionFailed
+		}
+	} else if t, ok := parseConditionTime(header.Get(names.ifUnmodifiedSince)); ok && mtime.After(t) {
+		return ErrPreconditionFailed
+	}
+
+	if ifNoneMatch := header.Get(names.ifNoneMatch); ifNoneMatch != "" {
+		if etagMatches(ifNoneMatch, etag) {
+			return ErrNotModified
+		}
+	} else if t, ok := parseConditionTime(header.Get(names.ifModifiedSince)); ok && !mtime.After(t) {
+		return ErrNotModified
+	}
+
+	return ErrNone
+}
+
+// checkWritePreconditions evaluates If-Match and If-None-Match against the object to be overwritten, which may be nil
+func checkWritePreconditions(header http.Header, entry *filer_pb.Entry) ErrorCode {
+
+	if entry == nil || entry.IsDirectory {
+		if header.Get(writeConditionHeaders.ifMatch) != "" {
+			return ErrPreconditionFailed
+		}
+		return ErrNone
+	}
+
+	if errCode := checkPreconditions(header, writeConditionHeaders, entry); errCode != ErrNone {
+		return ErrPreconditionFailed
+	}
+	return ErrNone
+}
+
+// checkObjectWritePreconditions evaluates the write conditions against the current object.
+// The filer also creates the object exclusively for "If-None-Match: *".
+func (s3a *S3ApiServer) checkObjectWritePreconditions(r *http.Request, bucket, object string) ErrorCode {
+
+	if r.Header.Get(writeConditionHeaders.ifMatch) == "" && r.Header.Get(writeConditionHeaders.ifNoneMatch) == "" {
+		return ErrNone
+	}
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	entry, err := s3a.getEntry(dir, name)
+	if err != nil {
+		glog.Errorf("lookup %s/%s: %v", dir, name, err)
+		return ErrInternalError
+	}
+
+	return checkWritePreconditions(r.Header, entry)
+}
+
+// etagMatches checks an etag against a comma separated list of etags, or "*" matching any
+func etagMatches(list string, etag string) bool {
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		candidate = strings.TrimPrefix(candidate, "W/")
+		if strings.Trim(candidate, "\"") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func parseConditionTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func setLastModified(w http.ResponseWriter, entry *filer_pb.Entry) {
+	w.Header().Set("Last-Modified", time.Unix(entry.Attributes.GetMtime(), 0).UTC().Format(http.TimeFormat))
+}
@@ -0,0 +1,83 @@
+package s3api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestCheckPreconditions(t *testing.T) {
+
+	mtime := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	entry := &filer_pb.Entry{
+		Name:       "x",
+		Attributes: &filer_pb.FuseAttributes{Mtime: mtime.Unix(), Md5: []byte{0xab, 0xcd}},
+	}
+	before := mtime.Add(-time.Hour).Format(http.TimeFormat)
+	after := mtime.Add(time.Hour).Format(http.TimeFormat)
+
+	tests := []struct {
+		headers map[string]string
+		want    ErrorCode
+	}{
+		{map[string]string{}, ErrNone},
+		{map[string]string{"If-Match": `"abcd"`}, ErrNone},
+		{map[string]string{"If-Match": `"1234", "abcd"`}, ErrNone},
+		{map[string]string{"If-Match": `*`}, ErrNone},
+		{map[string]string{"If-Match": `"1234"`}, ErrPreconditionFailed},
+		{map[string]string{"If-Unmodified-Since": after}, ErrNone},
+		{map[string]string{"If-Unmodified-Since": before}, ErrPreconditionFailed},
+		{map[string]string{"If-Match": `"abcd"`, "If-Unmodified-Since": before}, ErrNone},
+		{map[string]string{"If-None-Match": `"abcd"`}, ErrNotModified},
+		{map[string]string{"If-None-Match": `W/"abcd"`}, ErrNotModified},
+		{map[string]string{"If-None-Match": `"1234"`}, ErrNone},
+		{map[string]string{"If-Modified-Since": before}, ErrNone},
+		{map[string]string{"If-Modified-Since": mtime.Format(http.TimeFormat)}, ErrNotModified},
+		{map[string]string{"If-None-Match": `"1234"`, "If-Modified-Since": after}, ErrNone},
+		{map[string]string{"If-Modified-Since": "not a date"}, ErrNone},
+	}
+
+	for _, test := range tests {
+		header := make(http.Header)
+		for k, v := range test.headers {
+			header.Set(k, v)
+		}
+		assert.Equal(t, test.want, checkPreconditions(header, conditionHeaders, entry), "headers %v", test.headers)
+	}
+
+	header := make(http.Header)
+	header.Set("X-Amz-Copy-Source-If-Match", `"1234"`)
+	assert.Equal(t, ErrPreconditionFailed, checkPreconditions(header, copySourceConditionHeaders, entry))
+	assert.Equal(t, ErrNone, checkPreconditions(header, conditionHeaders, entry))
+
+}
+
+func TestCheckWritePreconditions(t *testing.T) {
+
+	entry := &filer_pb.Entry{
+		Name:       "x",
+		Attributes: &filer_pb.FuseAttributes{Md5: []byte{0xab, 0xcd}},
+	}
+
+	createOnly := make(http.Header)
+	createOnly.Set("If-None-Match", "*")
+	assert.Equal(t, ErrNone, checkWritePreconditions(createOnly, nil))
+	assert.Equal(t, ErrPreconditionFailed, checkWritePreconditions(createOnly, entry))
+
+	ifMatch := make(http.Header)
+	ifMatch.Set("If-Match", `"abcd"`)
+	assert.Equal(t, ErrNone, checkWritePreconditions(ifMatch, entry))
+	assert.Equal(t, ErrPreconditionFailed, checkWritePreconditions(ifMatch, nil))
+	ifMatch.Set("If-Match", `"1234"`)
+	assert.Equal(t, ErrPreconditionFailed, checkWritePreconditions(ifMatch, entry))
+
+	// dates are not checked for writes
+	modified := make(http.Header)
+	modified.Set("If-Modified-Since", time.Now().Format(http.TimeFormat))
+	assert.Equal(t, ErrNone, checkWritePreconditions(modified, entry))
+
+}
@@ -0,0 +1,170 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// the bucket cors configuration is kept as the original xml document in the bucket entry extended attributes
+	extBucketCorsKey = "s3-cors"
+
+	maxCorsRules = 100
+	maxCorsSize  = 64 * 1024
+)
+
+type CORSConfiguration struct {
+	XMLName   xml.Name   `xml:"CORSConfiguration"`
+	CORSRules []CORSRule `xml:"CORSRule"`
+}
+
+type CORSRule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  *int     `xml:"MaxAgeSeconds,omitempty"`
+}
+
+var corsAllowedMethods = map[string]bool{
+	"GET":    true,
+	"PUT":    true,
+	"HEAD":   true,
+	"POST":   true,
+	"DELETE": true,
+}
+
+func parseCorsConfiguration(data []byte) (*CORSConfiguration, error) {
+	config := &CORSConfiguration{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return config, config.validate()
+}
+
+func (config *CORSConfiguration) validate() error {
+	if len(config.CORSRules) == 0 {
+		return fmt.Errorf("no cors rules")
+	}
+	if len(config.CORSRules) > maxCorsRules {
+		return fmt.Errorf("more than %d cors rules", maxCorsRules)
+	}
+	for _, rule := range config.CORSRules {
+		if len(rule.AllowedMethods) == 0 || len(rule.AllowedOrigins) == 0 {
+			return fmt.Errorf("cors rule %s needs allowed methods and origins", rule.ID)
+		}
+		for _, method := range rule.AllowedMethods {
+			if !corsAllowedMethods[method] {
+				return fmt.Errorf("unsupported cors method %s", method)
+			}
+		}
+		for _, origin := range rule.AllowedOrigins {
+			if strings.Count(origin, "*") > 1 {
+				return fmt.Errorf("cors origin %s has more than one wildcard", origin)
+			}
+		}
+		for _, header := range rule.AllowedHeaders {
+			if strings.Count(header, "*") > 1 {
+				return fmt.Errorf("cors header %s has more than one wildcard", header)
+			}
+		}
+		if rule.MaxAgeSeconds != nil && *rule.MaxAgeSeconds < 0 {
+			return fmt.Errorf("negative cors max age")
+		}
+	}
+	return nil
+}
+
+// match finds the first rule allowing the origin, the method and all the request headers
+func (config *CORSConfiguration) match(origin, method string, requestHeaders []string) *CORSRule {
+	for i := range config.CORSRules {
+		rule := &config.CORSRules[i]
+		if rule.allowsOrigin(origin) && rule.allowsMethod(method) && rule.allowsHeaders(requestHeaders) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func (rule *CORSRule) allowsOrigin(origin string) bool {
+	for _, allowed := range rule.AllowedOrigins {
+		if wildcardMatch(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *CORSRule) allowsMethod(method string) bool {
+	for _, allowed := range rule.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *CORSRule) allowsHeaders(headers []string) bool {
+	for _, header := range headers {
+		allowed := false
+		for _, pattern := range rule.AllowedHeaders {
+			if wildcardMatch(strings.ToLower(pattern), header) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// setResponseHeaders sets the cors headers of an allowed request. The preflight response also lists the allowed
+// methods and headers, and how long the browser can cache the result.
+func (rule *CORSRule) setResponseHeaders(w http.ResponseWriter, origin string, requestHeaders []string, isPreflight bool) {
+	header := w.Header()
+	header.Add("Vary", "Origin, Access-Control-Request-Headers, Access-Control-Request-Method")
+	if rule.allowsAnyOrigin() {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+	if len(rule.ExposeHeaders) > 0 {
+		header.Set("Access-Control-Expose-Headers", strings.Join(rule.ExposeHeaders, ", "))
+	}
+	if !isPreflight {
+		return
+	}
+	header.Set("Access-Control-Allow-Methods", strings.Join(rule.AllowedMethods, ", "))
+	if len(requestHeaders) > 0 {
+		header.Set("Access-Control-Allow-Headers", strings.Join(requestHeaders, ", "))
+	}
+	if rule.MaxAgeSeconds != nil {
+		header.Set("Access-Control-Max-Age", strconv.Itoa(*rule.MaxAgeSeconds))
+	}
+}
+
+func (rule *CORSRule) allowsAnyOrigin() bool {
+	for _, allowed := range rule.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCorsRequestHeaders splits the Access-Control-Request-Headers header
+func parseCorsRequestHeaders(value string) (headers []string) {
+	for _, header := range strings.Split(value, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			headers = append(headers, strings.ToLower(header))
+		}
+	}
+	return
+}
@@ -0,0 +1,120 @@
+package s3api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/karlseguin/ccache"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCorsConfiguration = `<CORSConfiguration>
+ <CORSRule>
+   <AllowedOrigin>https://*.example.com</AllowedOrigin>
+   <AllowedMethod>PUT</AllowedMethod>
+   <AllowedMethod>GET</AllowedMethod>
+   <AllowedHeader>Content-*</AllowedHeader>
+   <AllowedHeader>x-amz-date</AllowedHeader>
+   <ExposeHeader>ETag</ExposeHeader>
+   <MaxAgeSeconds>3000</MaxAgeSeconds>
+ </CORSRule>
+ <CORSRule>
+   <AllowedOrigin>*</AllowedOrigin>
+   <AllowedMethod>GET</AllowedMethod>
+ </CORSRule>
+</CORSConfiguration>`
+
+func TestCorsConfiguration(t *testing.T) {
+
+	config, err := parseCorsConfiguration([]byte(testCorsConfiguration))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(config.CORSRules))
+
+	rule := config.match("https://app.example.com", "PUT", []string{"content-type", "x-amz-date"})
+	assert.Equal(t, &config.CORSRules[0], rule)
+
+	assert.Nil(t, config.match("https://app.example.com", "PUT", []string{"authorization"}))
+	assert.Nil(t, config.match("https://other.com", "PUT", nil))
+	assert.Nil(t, config.match("https://app.example.com", "DELETE", nil))
+	assert.Equal(t, &config.CORSRules[1], config.match("https://other.com", "GET", nil))
+
+	_, err = parseCorsConfiguration([]byte(`<CORSConfiguration></CORSConfiguration>`))
+	assert.NotNil(t, err)
+	_, err = parseCorsConfiguration([]byte(`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`))
+	assert.NotNil(t, err)
+	_, err = parseCorsConfiguration([]byte(`<CORSConfiguration><CORSRule><AllowedOrigin>*.*.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`))
+	assert.NotNil(t, err)
+
+}
+
+func newCorsTestServer() *S3ApiServer {
+	s3a := &S3ApiServer{
+		option:     &S3ApiServerOption{BucketsPath: "/buckets"},
+		iam:        NewIdentityAccessManagement("", ""),
+		bucketCors: ccache.New(ccache.Configure()),
+	}
+	config, _ := parseCorsConfiguration([]byte(testCorsConfiguration))
+	s3a.bucketCors.Set("b1", config, time.Minute)
+	return s3a
+}
+
+func TestCorsPreflight(t *testing.T) {
+
+	s3a := newCorsTestServer()
+	router := mux.NewRouter()
+	s3a.registerRouter(router)
+
+	req := httptest.NewRequest("OPTIONS", "/b1/some/object", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "PUT")
+	req.Header.Set("Access-Control-Request-Headers", "Content-Type, X-Amz-Date")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "PUT, GET", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "content-type, x-amz-date", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "3000", w.Header().Get("Access-Control-Max-Age"))
+	assert.Equal(t, "ETag", w.Header().Get("Access-Control-Expose-Headers"))
+
+	req.Header.Set("Access-Control-Request-Method", "DELETE")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, "", w.Header().Get("Access-Control-Allow-Origin"))
+
+	req.Header.Del("Origin")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+}
+
+func TestCorsMiddleware(t *testing.T) {
+
+	s3a := newCorsTestServer()
+	handler := s3a.corsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/b1/object", nil)
+	req = mux.SetURLVars(req, map[string]string{"bucket": "b1"})
+	req.Header.Set("Origin", "https://other.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "", w.Header().Get("Access-Control-Allow-Methods"))
+
+	req = httptest.NewRequest("DELETE", "/b1/object", nil)
+	req = mux.SetURLVars(req, map[string]string{"bucket": "b1"})
+	req.Header.Set("Origin", "https://other.com")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "", w.Header().Get("Access-Control-Allow-Origin"))
+
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
 )
 
 type InitiateMultipartUploadResult struct {
@@ -31,6 +32,25 @@ func (s3a *S3ApiServer) createMultipartUpload(input *s3.CreateMultipartUploadInp
 			entry.Extended = make(map[string][]byte)
 		}
 		entry.Extended["key"] = []byte(*input.Key)
+		if input.Tagging != nil {
+			tags, _ := parseTagsHeader(*input.Tagging)
+			replaceTagsInExtended(entry.Extended, tags)
+		}
+		sseOption{
+			Algorithm:         aws.StringValue(input.ServerSideEncryption),
+			CustomerAlgorithm: aws.StringValue(input.SSECustomerAlgorithm),
+			CustomerKeyMd5:    aws.StringValue(input.SSECustomerKeyMD5),
+		}.toExtended(entry.Extended)
+		// the filer does not lock folders, so the upload can still be aborted
+		retention := filer2.ObjectRetention{
+			Mode:        aws.StringValue(input.ObjectLockMode),
+			RetainUntil: aws.TimeValue(input.ObjectLockRetainUntilDate),
+			LegalHold:   aws.StringValue(input.ObjectLockLegalHoldStatus) == filer2.LegalHoldOn,
+		}
+		retention.SetRetention(entry.Extended)
+		retention.SetLegalHold(entry.Extended)
+		filer2.SignObjectLock(util.JoinPath(s3a.genUploadsFolder(*input.Bucket), uploadIdString), entry.Extended, s3a.option.IamKey)
+		setCannedAcl(entry.Extended, aws.StringValue(input.ACL))
 	}); err != nil {
 		glog.Errorf("NewMultipartUpload error: %v", err)
 		return nil, ErrInternalError
@@ -52,10 +72,16 @@ type CompleteMultipartUploadResult struct {
 	s3.CompleteMultipartUploadOutput
 }
 
-func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploadInput) (output *CompleteMultipartUploadResult, code ErrorCode) {
+func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploadInput, versionId string) (output *CompleteMultipartUploadResult, code ErrorCode) {
 
 	uploadDirectory := s3a.genUploadsFolder(*input.Bucket) + "/" + *input.UploadId
 
+	uploadEntry, err := s3a.getEntry(s3a.genUploadsFolder(*input.Bucket), *input.UploadId)
+	if err != nil || uploadEntry == nil {
+		glog.Errorf("completeMultipartUpload %s %s lookup: %v", *input.Bucket, *input.UploadId, err)
+		return nil, ErrNoSuchUpload
+	}
+
 	entries, err := s3a.list(uploadDirectory, "", "", false, 0)
 	if err != nil {
 		glog.Errorf("completeMultipartUpload %s %s error: %v", *input.Bucket, *input.UploadId, err)
@@ -67,18 +93,20 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 
 	for _, entry := range entries {
 		if strings.HasSuffix(entry.Name, ".part") && !entry.IsDirectory {
+			// the chunks of a copied part are placed by their offsets in the part
 			for _, chunk := range entry.Chunks {
 				p := &filer_pb.FileChunk{
 					FileId:    chunk.GetFileIdString(),
-					Offset:    offset,
+					Offset:    offset + chunk.Offset,
 					Size:      chunk.Size,
 					Mtime:     chunk.Mtime,
 					CipherKey: chunk.CipherKey,
 					ETag:      chunk.ETag,
+					IsGzipped: chunk.IsGzipped,
 				}
 				finalParts = append(finalParts, p)
-				offset += int64(chunk.Size)
 			}
+			offset += int64(filer2.TotalSize(entry.Chunks))
 		}
 	}
 
@@ -97,9 +125,24 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 		dirName = dirName[:len(dirName)-1]
 	}
 
-	err = s3a.mkFile(dirName, entryName, finalParts)
+	err = s3a.mkFile(dirName, entryName, finalParts, func(entry *filer_pb.Entry) {
+		entry.Extended = make(map[string][]byte)
+		replaceTagsInExtended(entry.Extended, tagsFromExtended(uploadEntry.Extended))
+		// the parts are encrypted as the upload requested, with the chunk keys still wrapped by the customer key
+		sseFromExtended(uploadEntry.Extended).toExtended(entry.Extended)
+		retention := filer2.GetObjectRetention(uploadEntry.Extended)
+		retention.SetRetention(entry.Extended)
+		retention.SetLegalHold(entry.Extended)
+		filer2.SignObjectLock(util.JoinPath(dirName, entryName), entry.Extended, s3a.option.IamKey)
+		setCannedAcl(entry.Extended, getCannedAcl(uploadEntry.Extended))
+		filer2.SetVersionId(entry.Extended, versionId)
+	})
 
 	if err != nil {
+		if filer2.IsBucketQuotaExceeded(err.Error()) {
+			glog.V(1).Infof("completeMultipartUpload %s/%s: %v", dirName, entryName, err)
+			return nil, ErrBucketQuotaExceeded
+		}
 		glog.Errorf("completeMultipartUpload %s/%s error: %v", dirName, entryName, err)
 		return nil, ErrInternalError
 	}
@@ -0,0 +1,178 @@
+package s3api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// copyPartRange is a range of the copy source, in source offsets, which is copied through the gateway
+type copyPartRange struct {
+	start, stop int64
+}
+
+// parseCopySourceRange parses the x-amz-copy-source-range, "bytes=first-last", into the source offsets [start, stop).
+// Without the header, the whole source is copied.
+func parseCopySourceRange(rangeHeader string, size int64) (start, stop int64, code ErrorCode) {
+
+	if rangeHeader == "" {
+		return 0, size, ErrNone
+	}
+
+	if !strings.HasPrefix(rangeHeader, "bytes=") {
+		return 0, 0, ErrInvalidCopyPartRange
+	}
+	parts := strings.SplitN(strings.TrimPrefix(rangeHeader, "bytes="), "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, ErrInvalidCopyPartRange
+	}
+	first, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || first < 0 {
+		return 0, 0, ErrInvalidCopyPartRange
+	}
+	last, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || last < first {
+		return 0, 0, ErrInvalidCopyPartRange
+	}
+	if last >= size {
+		return 0, 0, ErrInvalidCopyPartRangeSource
+	}
+
+	return first, last + 1, ErrNone
+}
+
+// planCopyPart splits the source range [start, stop) into the chunks which are fully visible in the range,
+// to be shared by the part at the part offsets, and the ranges left to copy: partially visible chunks and holes.
+func planCopyPart(chunks []*filer_pb.FileChunk, start, stop int64) (shared []*filer_pb.FileChunk, copies []copyPartRange) {
+
+	chunksByFileId := make(map[string]*filer_pb.FileChunk)
+	for _, chunk := range chunks {
+		chunksByFileId[chunk.GetFileIdString()] = chunk
+	}
+
+	addCopy := func(from, to int64) {
+		if n := len(copies); n > 0 && copies[n-1].stop == from {
+			copies[n-1].stop = to
+			return
+		}
+		copies = append(copies, copyPartRange{start: from, stop: to})
+	}
+
+	offset := start
+	for _, view := range filer2.ViewFromChunks(chunks, start, stop-start) {
+		if offset < view.LogicOffset {
+			addCopy(offset, view.LogicOffset)
+		}
+		offset = view.LogicOffset + int64(view.Size)
+		chunk, found := chunksByFileId[view.FileId]
+		if !found || chunk.Offset != view.LogicOffset || chunk.Size != view.Size {
+			addCopy(view.LogicOffset, offset)
+			continue
+		}
+		shared = append(shared, &filer_pb.FileChunk{
+			FileId:    chunk.GetFileIdString(),
+			Offset:    chunk.Offset - start,
+			Size:      chunk.Size,
+			Mtime:     chunk.Mtime,
+			ETag:      chunk.ETag,
+			CipherKey: chunk.CipherKey,
+			IsGzipped: chunk.IsGzipped,
+		})
+	}
+	if offset < stop {
+		addCopy(offset, stop)
+	}
+
+	return
+}
+
+// copyPartWithSharedChunks creates the part with the shared chunks of the copy source, and copies only the remaining ranges,
+// through scratch files next to the part, which hand over their chunks to the part.
+// The chunks are counted as shared only when the part is created, so the source is read again afterwards.
+// If it no longer has the chunks, they may be deleted already, and the part is removed with sourceChanged.
+func (s3a *S3ApiServer) copyPartWithSharedChunks(r *http.Request, srcDir, srcName, srcUrl, uploadDir, partName, collection string, start int64,
+	shared []*filer_pb.FileChunk, copies []copyPartRange) (etag string, sourceChanged bool, code ErrorCode) {
+
+	chunks := append([]*filer_pb.FileChunk{}, shared...)
+
+	var scratchNames []string
+	removeScratchFiles := func(isDeleteData bool) {
+		for _, name := range scratchNames {
+			if err := s3a.rm(uploadDir, name, isDeleteData, false); err != nil {
+				glog.V(1).Infof("remove %s/%s: %v", uploadDir, name, err)
+			}
+		}
+	}
+
+	for i, c := range copies {
+		name := fmt.Sprintf("%s.%d", partName, i)
+		dataReader, err := readFromFiler(srcUrl, fmt.Sprintf("bytes=%d-%d", c.start, c.stop-1), sseOption{})
+		if err != nil {
+			glog.V(1).Infof("copy %s range %d-%d: %v", srcUrl, c.start, c.stop-1, err)
+			removeScratchFiles(true)
+			return "", false, ErrInvalidCopySource
+		}
+		_, errCode := s3a.putToFiler(r, fmt.Sprintf("http://%s%s/%s?collection=%s", s3a.option.Filer, uploadDir, name, collection), dataReader, sseOption{}, "")
+		dataReader.Close()
+		scratchNames = append(scratchNames, name)
+		if errCode != ErrNone {
+			removeScratchFiles(true)
+			return "", false, errCode
+		}
+		entry, err := s3a.getEntry(uploadDir, name)
+		if err != nil || entry == nil {
+			glog.Errorf("copy part %s/%s: %v", uploadDir, name, err)
+			removeScratchFiles(true)
+			return "", false, ErrInternalError
+		}
+		for _, chunk := range entry.Chunks {
+			chunk.Offset += c.start - start
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].Offset < chunks[j].Offset
+	})
+
+	if err := s3a.mkFile(uploadDir, partName, chunks, func(entry *filer_pb.Entry) {
+		entry.Extended = make(map[string][]byte)
+		filer2.SetChunkReferences(entry.Extended, shared)
+	}); err != nil {
+		glog.Errorf("copy part %s/%s: %v", uploadDir, partName, err)
+		removeScratchFiles(true)
+		return "", false, ErrInternalError
+	}
+	removeScratchFiles(false)
+
+	if srcEntry, err := s3a.getEntry(srcDir, srcName); err != nil || srcEntry == nil || !hasChunks(srcEntry.Chunks, shared) {
+		glog.V(1).Infof("copy part %s/%s: source %s/%s changed: %v", uploadDir, partName, srcDir, srcName, err)
+		// removing the part releases the shared chunks, and deletes the copied ones
+		if err := s3a.rm(uploadDir, partName, true, false); err != nil {
+			glog.Errorf("remove copied part %s/%s: %v", uploadDir, partName, err)
+			return "", false, ErrInternalError
+		}
+		return "", true, ErrNone
+	}
+
+	return filer2.ETagChunks(chunks), false, ErrNone
+}
+
+func hasChunks(chunks, shared []*filer_pb.FileChunk) bool {
+	fileIds := make(map[string]bool)
+	for _, chunk := range chunks {
+		fileIds[chunk.GetFileIdString()] = true
+	}
+	for _, chunk := range shared {
+		if !fileIds[chunk.GetFileIdString()] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package s3api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestParseCopySourceRange(t *testing.T) {
+
+	testCases := []struct {
+		rangeHeader string
+		start, stop int64
+		code        ErrorCode
+	}{
+		{"", 0, 100, ErrNone},
+		{"bytes=0-99", 0, 100, ErrNone},
+		{"bytes=10-10", 10, 11, ErrNone},
+		{"bytes=10-100", 0, 0, ErrInvalidCopyPartRangeSource},
+		{"bytes=10-", 0, 0, ErrInvalidCopyPartRange},
+		{"bytes=-10", 0, 0, ErrInvalidCopyPartRange},
+		{"bytes=20-10", 0, 0, ErrInvalidCopyPartRange},
+		{"10-20", 0, 0, ErrInvalidCopyPartRange},
+	}
+
+	for _, testCase := range testCases {
+		start, stop, code := parseCopySourceRange(testCase.rangeHeader, 100)
+		if code != testCase.code {
+			t.Errorf("%q: expected error %d, got %d", testCase.rangeHeader, testCase.code, code)
+			continue
+		}
+		if code == ErrNone && (start != testCase.start || stop != testCase.stop) {
+			t.Errorf("%q: expected [%d, %d), got [%d, %d)", testCase.rangeHeader, testCase.start, testCase.stop, start, stop)
+		}
+	}
+
+}
+
+func TestPlanCopyPart(t *testing.T) {
+
+	chunks := []*filer_pb.FileChunk{
+		{FileId: "1,01", Offset: 0, Size: 100, Mtime: 1},
+		{FileId: "1,02", Offset: 100, Size: 100, Mtime: 1},
+		{FileId: "1,03", Offset: 200, Size: 100, Mtime: 1},
+		// overwrites the middle of the fourth chunk
+		{FileId: "1,04", Offset: 300, Size: 100, Mtime: 1},
+		{FileId: "1,05", Offset: 340, Size: 20, Mtime: 2},
+		// after a hole
+		{FileId: "1,06", Offset: 500, Size: 100, Mtime: 1},
+	}
+
+	shared, copies := planCopyPart(chunks, 50, 550)
+
+	var sharedIds []string
+	var sharedOffsets []int64
+	for _, chunk := range shared {
+		sharedIds = append(sharedIds, chunk.FileId)
+		sharedOffsets = append(sharedOffsets, chunk.Offset)
+	}
+	if !reflect.DeepEqual(sharedIds, []string{"1,02", "1,03", "1,05"}) {
+		t.Errorf("unexpected shared chunks %v", sharedIds)
+	}
+	if !reflect.DeepEqual(sharedOffsets, []int64{50, 150, 290}) {
+		t.Errorf("unexpected shared chunk offsets %v", sharedOffsets)
+	}
+
+	expected := []copyPartRange{{50, 100}, {300, 340}, {360, 550}}
+	if !reflect.DeepEqual(copies, expected) {
+		t.Errorf("unexpected copies %v, expecting %v", copies, expected)
+	}
+
+	// the whole source
+	shared, copies = planCopyPart(chunks[:3], 0, 300)
+	if len(shared) != 3 || len(copies) != 0 {
+		t.Errorf("expected all chunks shared, got %d shared and copies %v", len(shared), copies)
+	}
+
+}
+
+func TestHasChunks(t *testing.T) {
+
+	source := []*filer_pb.FileChunk{{FileId: "1,01", Size: 100}, {FileId: "1,02", Offset: 100, Size: 100}}
+	shared, _ := planCopyPart(source, 0, 200)
+
+	if !hasChunks(source, shared) {
+		t.Errorf("the source should still have the shared chunks")
+	}
+	// the source is overwritten with other chunks
+	if hasChunks([]*filer_pb.FileChunk{{FileId: "1,01", Size: 100}, {FileId: "1,03", Offset: 100, Size: 100}}, shared) {
+		t.Errorf("the overwritten source should not have the shared chunks")
+	}
+
+}
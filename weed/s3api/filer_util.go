@@ -7,6 +7,7 @@ import (
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
 )
 
 func (s3a *S3ApiServer) mkdir(parentDirectoryPath string, dirName string, fn func(entry *filer_pb.Entry)) error {
@@ -15,9 +16,9 @@ func (s3a *S3ApiServer) mkdir(parentDirectoryPath string, dirName string, fn fun
 
 }
 
-func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chunks []*filer_pb.FileChunk) error {
+func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chunks []*filer_pb.FileChunk, fn func(entry *filer_pb.Entry)) error {
 
-	return filer_pb.MkFile(s3a, parentDirectoryPath, fileName, chunks)
+	return filer_pb.MkFile(s3a, parentDirectoryPath, fileName, chunks, fn)
 
 }
 
@@ -72,6 +73,81 @@ func (s3a *S3ApiServer) exists(parentDirectoryPath string, entryName string, isD
 
 }
 
+func (s3a *S3ApiServer) getEntry(parentDirectoryPath, entryName string) (entry *filer_pb.Entry, err error) {
+
+	fullPath := util.NewFullPath(parentDirectoryPath, entryName)
+	return filer_pb.GetEntry(s3a, fullPath)
+
+}
+
+func (s3a *S3ApiServer) updateEntry(parentDirectoryPath string, newEntry *filer_pb.Entry) error {
+
+	return s3a.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.UpdateEntryRequest{
+			Directory: parentDirectoryPath,
+			Entry:     newEntry,
+		}
+
+		glog.V(1).Infof("update entry %v/%v: %v", parentDirectoryPath, newEntry.Name, request)
+		if _, err := client.UpdateEntry(context.Background(), request); err != nil {
+			glog.V(0).Infof("update entry %v: %v", request, err)
+			return fmt.Errorf("update entry %s/%s: %v", parentDirectoryPath, newEntry.Name, err)
+		}
+
+		return nil
+	})
+
+}
+
+func (s3a *S3ApiServer) mv(oldDirectory, oldName, newDirectory, newName string) error {
+
+	return s3a.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldDirectory,
+			OldName:      oldName,
+			NewDirectory: newDirectory,
+			NewName:      newName,
+		}
+
+		glog.V(1).Infof("move entry %s/%s => %s/%s", oldDirectory, oldName, newDirectory, newName)
+		if _, err := client.AtomicRenameEntry(context.Background(), request); err != nil {
+			glog.V(0).Infof("move entry %v: %v", request, err)
+			return fmt.Errorf("move entry %s/%s: %v", oldDirectory, oldName, err)
+		}
+
+		return nil
+	})
+
+}
+
+func (s3a *S3ApiServer) getBucketEntry(bucket string) (entry *filer_pb.Entry, err error) {
+
+	entry, err = s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err == nil && (entry == nil || !entry.IsDirectory) {
+		return nil, filer_pb.ErrNotFound
+	}
+	return
+
+}
+
+// updateBucketEntry reads the bucket folder entry, lets fn modify it, and saves it back.
+func (s3a *S3ApiServer) updateBucketEntry(bucket string, fn func(entry *filer_pb.Entry)) error {
+
+	entry, err := s3a.getBucketEntry(bucket)
+	if err != nil {
+		return err
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	fn(entry)
+
+	return s3a.updateEntry(s3a.option.BucketsPath, entry)
+
+}
+
 func objectKey(key *string) *string {
 	if strings.HasPrefix(*key, "/") {
 		t := (*key)[1:]
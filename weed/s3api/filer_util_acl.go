@@ -0,0 +1,25 @@
+package s3api
+
+import (
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// setAcl records the canned ACL of an object
+func (s3a *S3ApiServer) setAcl(parentDirectoryPath string, entryName string, cannedAcl string) error {
+
+	entry, err := s3a.getEntry(parentDirectoryPath, entryName)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return filer_pb.ErrNotFound
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+
+	setCannedAcl(entry.Extended, cannedAcl)
+
+	return s3a.updateEntry(parentDirectoryPath, entry)
+
+}
@@ -0,0 +1,138 @@
+package s3api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// getBucketObjectLock reads the bucket object lock configuration, returning nil if object lock is not enabled
+func (s3a *S3ApiServer) getBucketObjectLock(bucket string) (*ObjectLockConfiguration, error) {
+	entry, err := s3a.getBucketEntry(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Extended == nil || len(entry.Extended[extBucketObjectLockKey]) == 0 {
+		return nil, nil
+	}
+	return parseObjectLockConfiguration(entry.Extended[extBucketObjectLockKey])
+}
+
+// objectLockForWrite finds the retention and legal hold of a new object,
+// either given in the request headers or from the bucket default retention.
+func (s3a *S3ApiServer) objectLockForWrite(bucket string, header http.Header) (retention filer2.ObjectRetention, code ErrorCode) {
+
+	now := time.Now()
+	retention, found, code := parseObjectLockHeaders(header, now)
+	if code != ErrNone {
+		return retention, code
+	}
+
+	config, err := s3a.getBucketObjectLock(bucket)
+	if err == filer_pb.ErrNotFound {
+		return retention, ErrNoSuchBucket
+	}
+	if err != nil {
+		glog.Errorf("get bucket %s object lock: %v", bucket, err)
+		return retention, ErrInternalError
+	}
+	if config == nil {
+		if found {
+			return retention, ErrMissingObjectLockConfiguration
+		}
+		return retention, ErrNone
+	}
+
+	if retention.Mode == "" {
+		defaultRetention := config.defaultRetention(now)
+		retention.Mode, retention.RetainUntil = defaultRetention.Mode, defaultRetention.RetainUntil
+	}
+	if hasObjectLock(retention) {
+		return retention, s3a.checkObjectLockKey()
+	}
+
+	return retention, ErrNone
+}
+
+// checkObjectLockKey refuses to lock objects without the [iam] key, since the filer refuses the unsigned changes
+func (s3a *S3ApiServer) checkObjectLockKey() ErrorCode {
+	if s3a.option.IamKey == "" {
+		return ErrObjectLockKeyNotConfigured
+	}
+	return ErrNone
+}
+
+func hasObjectLock(retention filer2.ObjectRetention) bool {
+	return retention.Mode != "" || retention.LegalHold
+}
+
+// setObjectLock locks a newly written object
+func (s3a *S3ApiServer) setObjectLock(parentDirectoryPath string, entryName string, retention filer2.ObjectRetention) error {
+
+	return s3a.updateObjectLock(parentDirectoryPath, entryName, func(extended map[string][]byte) {
+		retention.SetRetention(extended)
+		retention.SetLegalHold(extended)
+	})
+
+}
+
+// updateObjectLock lets fn change the retention or legal hold of an object, and signs the change for the filer.
+// The filer refuses changes unlocking the object.
+func (s3a *S3ApiServer) updateObjectLock(parentDirectoryPath string, entryName string, fn func(extended map[string][]byte)) error {
+
+	entry, err := s3a.getEntry(parentDirectoryPath, entryName)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return filer_pb.ErrNotFound
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+
+	fn(entry.Extended)
+	filer2.SignObjectLock(util.JoinPath(parentDirectoryPath, entryName), entry.Extended, s3a.option.IamKey)
+
+	return s3a.updateEntry(parentDirectoryPath, entry)
+
+}
+
+// releaseGovernanceRetention removes the governance retention of an object version before it is deleted.
+// Compliance retention and legal holds are kept, and the filer still refuses to delete the object.
+func (s3a *S3ApiServer) releaseGovernanceRetention(bucket, object, versionId string) ErrorCode {
+
+	dir, name, entry, errCode := s3a.resolveObjectVersion(bucket, object, versionId)
+	if errCode != ErrNone {
+		return errCode
+	}
+	retention := filer2.GetObjectRetention(entry.Extended)
+	if retention.LegalHold {
+		return ErrObjectLocked
+	}
+	if retention.Mode != filer2.RetentionGovernance {
+		return ErrNone
+	}
+
+	if err := s3a.updateObjectLock(dir, name, func(extended map[string][]byte) {
+		filer2.ObjectRetention{}.SetRetention(extended)
+		extended[filer2.BypassGovernanceKey] = []byte("true")
+	}); err != nil {
+		glog.Errorf("release governance retention %s/%s: %v", dir, name, err)
+		return objectLockErrorCode(err)
+	}
+
+	return ErrNone
+}
+
+// objectLockErrorCode maps the filer errors when deleting or changing objects
+func objectLockErrorCode(err error) ErrorCode {
+	if filer2.IsObjectLocked(err.Error()) {
+		return ErrObjectLocked
+	}
+	return ErrInternalError
+}
@@ -0,0 +1,25 @@
+package s3api
+
+import (
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// setSse records the encryption of a newly written object
+func (s3a *S3ApiServer) setSse(parentDirectoryPath string, entryName string, sse sseOption) error {
+
+	entry, err := s3a.getEntry(parentDirectoryPath, entryName)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return filer_pb.ErrNotFound
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+
+	sse.toExtended(entry.Extended)
+
+	return s3a.updateEntry(parentDirectoryPath, entry)
+
+}
@@ -0,0 +1,38 @@
+package s3api
+
+import (
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func (s3a *S3ApiServer) getTags(parentDirectoryPath string, entryName string) (tags map[string]string, err error) {
+
+	entry, err := s3a.getEntry(parentDirectoryPath, entryName)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, filer_pb.ErrNotFound
+	}
+
+	return tagsFromExtended(entry.Extended), nil
+
+}
+
+func (s3a *S3ApiServer) setTags(parentDirectoryPath string, entryName string, tags map[string]string) error {
+
+	entry, err := s3a.getEntry(parentDirectoryPath, entryName)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return filer_pb.ErrNotFound
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+
+	replaceTagsInExtended(entry.Extended, tags)
+
+	return s3a.updateEntry(parentDirectoryPath, entry)
+
+}
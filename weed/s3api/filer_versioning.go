@@ -0,0 +1,331 @@
+package s3api
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// Object versions are kept as ordinary filer entries.
+// The current version of an object stays at its normal path,
+// and prior versions and delete markers are moved under
+// /buckets/<bucket>/.versions/<object key>/<version id>.
+// The filer archives the current version when a new version is written.
+
+const (
+	versionsFolder        = filer2.VersionsFolder
+	nullVersionId         = filer2.NullVersionId
+	extVersioningKey      = filer2.BucketVersioningKey
+	extVersionIdKey       = filer2.VersionIdKey
+	extDeleteMarkerKey    = filer2.DeleteMarkerKey
+	VersioningEnabled     = filer2.VersioningEnabled
+	VersioningSuspended   = filer2.VersioningSuspended
+	versionIdHeader       = "x-amz-version-id"
+	deleteMarkerHeader    = "x-amz-delete-marker"
+	deleteMarkerTrueValue = filer2.DeleteMarkerTrueValue
+)
+
+func getVersionId(entry *filer_pb.Entry) string {
+	if entry.Extended != nil {
+		if versionId, found := entry.Extended[extVersionIdKey]; found && len(versionId) > 0 {
+			return string(versionId)
+		}
+	}
+	return nullVersionId
+}
+
+func isDeleteMarker(entry *filer_pb.Entry) bool {
+	if entry.Extended == nil {
+		return false
+	}
+	return string(entry.Extended[extDeleteMarkerKey]) == deleteMarkerTrueValue
+}
+
+func (s3a *S3ApiServer) objectDirAndName(bucket, object string) (dir, name string) {
+	object = strings.TrimPrefix(object, "/")
+	lastSeparator := strings.LastIndex(object, "/")
+	dir = fmt.Sprintf("%s/%s", s3a.option.BucketsPath, bucket)
+	if lastSeparator >= 0 {
+		dir = dir + "/" + object[:lastSeparator]
+	}
+	return dir, object[lastSeparator+1:]
+}
+
+func (s3a *S3ApiServer) genVersionsFolder(bucket, object string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", s3a.option.BucketsPath, bucket, versionsFolder, strings.TrimPrefix(object, "/"))
+}
+
+func (s3a *S3ApiServer) getBucketVersioning(bucket string) (status string, err error) {
+	entry, err := s3a.getBucketEntry(bucket)
+	if err != nil {
+		return "", err
+	}
+	if entry.Extended == nil {
+		return "", nil
+	}
+	return string(entry.Extended[extVersioningKey]), nil
+}
+
+func (s3a *S3ApiServer) setBucketVersioning(bucket string, status string) error {
+	return s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		entry.Extended[extVersioningKey] = []byte(status)
+	})
+}
+
+// prepareVersionedWrite returns the bucket versioning status, which is empty if versioning was never configured,
+// and the version id of the new object, which is written together with the object.
+func (s3a *S3ApiServer) prepareVersionedWrite(bucket, object string) (versioningStatus, versionId string, code ErrorCode) {
+
+	versioningStatus, err := s3a.getBucketVersioning(bucket)
+	if err == filer_pb.ErrNotFound {
+		return "", "", ErrNoSuchBucket
+	}
+	if err != nil {
+		glog.Errorf("get bucket %s versioning: %v", bucket, err)
+		return "", "", ErrInternalError
+	}
+	if versioningStatus == "" {
+		return "", "", ErrNone
+	}
+
+	versionId = nullVersionId
+	if versioningStatus == VersioningEnabled {
+		versionId = filer2.NewVersionId()
+	}
+
+	return versioningStatus, versionId, ErrNone
+}
+
+// finishVersionedWrite removes the prior null version, which is replaced by the new object of a suspended bucket.
+func (s3a *S3ApiServer) finishVersionedWrite(bucket, object, versioningStatus string) (code ErrorCode) {
+
+	if versioningStatus != VersioningSuspended {
+		return ErrNone
+	}
+
+	versionsDir := s3a.genVersionsFolder(bucket, object)
+	if exists, _ := s3a.exists(versionsDir, nullVersionId, false); exists {
+		if err := s3a.rm(versionsDir, nullVersionId, true, false); err != nil {
+			glog.Errorf("delete null version %s/%s: %v", versionsDir, nullVersionId, err)
+			return objectLockErrorCode(err)
+		}
+	}
+
+	return ErrNone
+}
+
+// archiveCurrentVersion moves the current object, if any, into the versions folder, to be replaced by a delete marker.
+func (s3a *S3ApiServer) archiveCurrentVersion(bucket, object, versioningStatus string) error {
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	versionsDir := s3a.genVersionsFolder(bucket, object)
+
+	if versioningStatus == VersioningSuspended {
+		// a suspended bucket only keeps one null version
+		if exists, _ := s3a.exists(versionsDir, nullVersionId, false); exists {
+			if err := s3a.rm(versionsDir, nullVersionId, true, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	entry, err := s3a.getEntry(dir, name)
+	if err != nil {
+		return err
+	}
+	if entry == nil || entry.IsDirectory {
+		return nil
+	}
+
+	versionId := getVersionId(entry)
+	if versionId == nullVersionId && versioningStatus == VersioningSuspended {
+		// will be overwritten in place
+		return nil
+	}
+
+	return s3a.mv(dir, name, versionsDir, versionId)
+}
+
+// deleteObjectVersion removes one object version, or adds a delete marker if versionId is empty.
+func (s3a *S3ApiServer) deleteObjectVersion(bucket, object, versioningStatus, versionId string) (resultVersionId string, deleteMarker bool, code ErrorCode) {
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	versionsDir := s3a.genVersionsFolder(bucket, object)
+
+	if versionId == "" {
+		if versioningStatus == VersioningSuspended {
+			current, err := s3a.getEntry(dir, name)
+			if err != nil {
+				glog.Errorf("lookup %s/%s: %v", dir, name, err)
+				return "", false, ErrInternalError
+			}
+			if current != nil && !current.IsDirectory && getVersionId(current) == nullVersionId {
+				if err = s3a.rm(dir, name, true, false); err != nil {
+					glog.Errorf("delete null version %s/%s: %v", dir, name, err)
+					return "", false, objectLockErrorCode(err)
+				}
+			}
+		}
+		if err := s3a.archiveCurrentVersion(bucket, object, versioningStatus); err != nil {
+			glog.Errorf("archive %s/%s: %v", dir, name, err)
+			return "", false, ErrInternalError
+		}
+		resultVersionId = nullVersionId
+		if versioningStatus == VersioningEnabled {
+			resultVersionId = filer2.NewVersionId()
+		}
+		if err := s3a.createDeleteMarker(versionsDir, resultVersionId); err != nil {
+			glog.Errorf("create delete marker %s/%s: %v", versionsDir, resultVersionId, err)
+			return "", false, ErrInternalError
+		}
+		return resultVersionId, true, ErrNone
+	}
+
+	current, err := s3a.getEntry(dir, name)
+	if err != nil {
+		glog.Errorf("lookup %s/%s: %v", dir, name, err)
+		return "", false, ErrInternalError
+	}
+
+	if current != nil && !current.IsDirectory && getVersionId(current) == versionId {
+		if err = s3a.rm(dir, name, true, false); err != nil {
+			glog.Errorf("delete version %s/%s: %v", dir, name, err)
+			return "", false, objectLockErrorCode(err)
+		}
+		current = nil
+	} else {
+		version, err := s3a.getEntry(versionsDir, versionId)
+		if err != nil {
+			glog.Errorf("lookup %s/%s: %v", versionsDir, versionId, err)
+			return "", false, ErrInternalError
+		}
+		if version == nil || version.IsDirectory {
+			return "", false, ErrNoSuchVersion
+		}
+		deleteMarker = isDeleteMarker(version)
+		if err = s3a.rm(versionsDir, versionId, true, false); err != nil {
+			glog.Errorf("delete version %s/%s: %v", versionsDir, versionId, err)
+			return "", false, objectLockErrorCode(err)
+		}
+	}
+
+	if current == nil {
+		if err = s3a.promoteLatestVersion(bucket, object); err != nil {
+			glog.Errorf("promote latest version %s/%s: %v", dir, name, err)
+			return "", false, ErrInternalError
+		}
+	}
+
+	return versionId, deleteMarker, ErrNone
+}
+
+// promoteLatestVersion makes the newest archived version current again, unless it is a delete marker.
+func (s3a *S3ApiServer) promoteLatestVersion(bucket, object string) error {
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	versionsDir := s3a.genVersionsFolder(bucket, object)
+
+	versions, err := s3a.listVersions(versionsDir)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 || isDeleteMarker(versions[0]) {
+		return nil
+	}
+
+	return s3a.mv(versionsDir, versions[0].Name, dir, name)
+}
+
+// listVersions lists the archived versions of one object, the newest first.
+func (s3a *S3ApiServer) listVersions(versionsDir string) (versions []*filer_pb.Entry, err error) {
+
+	err = filer_pb.List(s3a, versionsDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		if !entry.IsDirectory {
+			versions = append(versions, entry)
+		}
+		return nil
+	}, "", false, math.MaxUint32)
+	if err != nil && strings.Contains(err.Error(), filer_pb.ErrNotFound.Error()) {
+		err = nil
+	}
+
+	sortVersions(versions)
+
+	return
+}
+
+func sortVersions(versions []*filer_pb.Entry) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].Attributes.Mtime != versions[j].Attributes.Mtime {
+			return versions[i].Attributes.Mtime > versions[j].Attributes.Mtime
+		}
+		return versions[i].Name < versions[j].Name
+	})
+}
+
+func (s3a *S3ApiServer) createDeleteMarker(versionsDir, versionId string) error {
+
+	return s3a.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.CreateEntryRequest{
+			Directory: versionsDir,
+			Entry: &filer_pb.Entry{
+				Name:        versionId,
+				IsDirectory: false,
+				Attributes: &filer_pb.FuseAttributes{
+					Mtime:    time.Now().Unix(),
+					Crtime:   time.Now().Unix(),
+					FileMode: uint32(0770),
+					Uid:      filer_pb.OS_UID,
+					Gid:      filer_pb.OS_GID,
+				},
+				Extended: map[string][]byte{
+					extVersionIdKey:    []byte(versionId),
+					extDeleteMarkerKey: []byte(deleteMarkerTrueValue),
+				},
+			},
+		}
+
+		glog.V(1).Infof("create delete marker: %s/%s", versionsDir, versionId)
+		return filer_pb.CreateEntry(client, request)
+	})
+
+}
+
+// resolveObjectVersion finds the filer location of one object version.
+func (s3a *S3ApiServer) resolveObjectVersion(bucket, object, versionId string) (dir, name string, entry *filer_pb.Entry, code ErrorCode) {
+
+	dir, name = s3a.objectDirAndName(bucket, object)
+	current, err := s3a.getEntry(dir, name)
+	if err != nil {
+		glog.Errorf("lookup %s/%s: %v", dir, name, err)
+		return "", "", nil, ErrInternalError
+	}
+	if versionId == "" {
+		if current == nil || current.IsDirectory {
+			return "", "", nil, ErrNoSuchKey
+		}
+		return dir, name, current, ErrNone
+	}
+	if current != nil && !current.IsDirectory && getVersionId(current) == versionId {
+		return dir, name, current, ErrNone
+	}
+
+	versionsDir := s3a.genVersionsFolder(bucket, object)
+	version, err := s3a.getEntry(versionsDir, versionId)
+	if err != nil {
+		glog.Errorf("lookup %s/%s: %v", versionsDir, versionId, err)
+		return "", "", nil, ErrInternalError
+	}
+	if version == nil || version.IsDirectory {
+		return "", "", nil, ErrNoSuchVersion
+	}
+
+	return versionsDir, versionId, version, ErrNone
+}
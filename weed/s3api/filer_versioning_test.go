@@ -0,0 +1,58 @@
+package s3api
+
+import (
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestSortVersions(t *testing.T) {
+
+	versions := []*filer_pb.Entry{
+		{Name: nullVersionId, Attributes: &filer_pb.FuseAttributes{Mtime: 100}},
+		{Name: "0000000000000002", Attributes: &filer_pb.FuseAttributes{Mtime: 200}},
+		{Name: "0000000000000001", Attributes: &filer_pb.FuseAttributes{Mtime: 200}},
+	}
+
+	sortVersions(versions)
+
+	expected := []string{"0000000000000001", "0000000000000002", nullVersionId}
+	for i, version := range versions {
+		if version.Name != expected[i] {
+			t.Errorf("version %d: expected %s, but got %s", i, expected[i], version.Name)
+		}
+	}
+
+}
+
+func TestGetBucketVersioningResult(t *testing.T) {
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`
+
+	encoded := string(encodeResponse(VersioningConfigurationResult{
+		Status: VersioningEnabled,
+	}))
+	if encoded != expected {
+		t.Errorf("unexpected output: %s\nexpecting:%s", encoded, expected)
+	}
+
+}
+
+func TestObjectDirAndName(t *testing.T) {
+
+	s3a := &S3ApiServer{option: &S3ApiServerOption{BucketsPath: "/buckets"}}
+
+	dir, name := s3a.objectDirAndName("b", "/a/b/c.txt")
+	if dir != "/buckets/b/a/b" || name != "c.txt" {
+		t.Errorf("unexpected %s %s", dir, name)
+	}
+	dir, name = s3a.objectDirAndName("b", "/c.txt")
+	if dir != "/buckets/b" || name != "c.txt" {
+		t.Errorf("unexpected %s %s", dir, name)
+	}
+	if versionsDir := s3a.genVersionsFolder("b", "/a/c.txt"); versionsDir != "/buckets/b/.versions/a/c.txt" {
+		t.Errorf("unexpected versions folder %s", versionsDir)
+	}
+
+}
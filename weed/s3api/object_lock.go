@@ -0,0 +1,164 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+)
+
+// The filer refuses to delete or modify locked files, see filer2.ObjectRetention.
+// The gateway only keeps the bucket configuration, and sets the retention and legal hold of the objects.
+
+const (
+	// the bucket object lock configuration is kept as the original xml document in the bucket entry extended attributes
+	extBucketObjectLockKey = "s3-object-lock"
+
+	amzBucketObjectLockEnabledHeader = "X-Amz-Bucket-Object-Lock-Enabled"
+	amzObjectLockModeHeader          = "X-Amz-Object-Lock-Mode"
+	amzObjectLockRetainUntilHeader   = "X-Amz-Object-Lock-Retain-Until-Date"
+	amzObjectLockLegalHoldHeader     = "X-Amz-Object-Lock-Legal-Hold"
+	amzBypassGovernanceHeader        = "X-Amz-Bypass-Governance-Retention"
+
+	objectLockEnabled = "Enabled"
+	legalHoldOff      = "OFF"
+
+	maxObjectLockConfigurationSize = 16 * 1024
+)
+
+type ObjectLockConfiguration struct {
+	XMLName           xml.Name        `xml:"ObjectLockConfiguration"`
+	ObjectLockEnabled string          `xml:"ObjectLockEnabled"`
+	Rule              *ObjectLockRule `xml:"Rule,omitempty"`
+}
+
+type ObjectLockRule struct {
+	DefaultRetention DefaultRetention `xml:"DefaultRetention"`
+}
+
+type DefaultRetention struct {
+	Mode  string `xml:"Mode"`
+	Days  int    `xml:"Days,omitempty"`
+	Years int    `xml:"Years,omitempty"`
+}
+
+type Retention struct {
+	XMLName         xml.Name  `xml:"Retention"`
+	Mode            string    `xml:"Mode"`
+	RetainUntilDate time.Time `xml:"RetainUntilDate"`
+}
+
+type LegalHold struct {
+	XMLName xml.Name `xml:"LegalHold"`
+	Status  string   `xml:"Status"`
+}
+
+func parseObjectLockConfiguration(data []byte) (*ObjectLockConfiguration, error) {
+	config := &ObjectLockConfiguration{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return config, config.validate()
+}
+
+func (config *ObjectLockConfiguration) validate() error {
+	if config.ObjectLockEnabled != objectLockEnabled {
+		return fmt.Errorf("unexpected object lock state %q", config.ObjectLockEnabled)
+	}
+	if config.Rule == nil {
+		return nil
+	}
+	retention := config.Rule.DefaultRetention
+	if !isRetentionMode(retention.Mode) {
+		return fmt.Errorf("unknown retention mode %q", retention.Mode)
+	}
+	if retention.Days < 0 || retention.Years < 0 || (retention.Days > 0) == (retention.Years > 0) {
+		return fmt.Errorf("default retention needs either days or years")
+	}
+	return nil
+}
+
+// defaultRetention is the retention of new objects without their own retention, if the bucket has a default rule
+func (config *ObjectLockConfiguration) defaultRetention(now time.Time) (retention filer2.ObjectRetention) {
+	if config.Rule == nil {
+		return
+	}
+	defaultRetention := config.Rule.DefaultRetention
+	retention.Mode = defaultRetention.Mode
+	retention.RetainUntil = now.AddDate(defaultRetention.Years, 0, defaultRetention.Days)
+	return
+}
+
+func isRetentionMode(mode string) bool {
+	return mode == filer2.RetentionGovernance || mode == filer2.RetentionCompliance
+}
+
+func (retention *Retention) validate(now time.Time) ErrorCode {
+	if !isRetentionMode(retention.Mode) {
+		return ErrMalformedXML
+	}
+	if !retention.RetainUntilDate.After(now) {
+		return ErrInvalidRetentionDate
+	}
+	return ErrNone
+}
+
+func parseLegalHoldStatus(status string) (legalHold bool, ok bool) {
+	switch status {
+	case filer2.LegalHoldOn:
+		return true, true
+	case legalHoldOff:
+		return false, true
+	}
+	return false, false
+}
+
+func legalHoldStatus(legalHold bool) string {
+	if legalHold {
+		return filer2.LegalHoldOn
+	}
+	return legalHoldOff
+}
+
+// parseObjectLockHeaders parses the retention and legal hold requested for a new object.
+// It returns whether any object lock header is given.
+func parseObjectLockHeaders(header http.Header, now time.Time) (retention filer2.ObjectRetention, found bool, code ErrorCode) {
+
+	mode, retainUntil, legalHold := header.Get(amzObjectLockModeHeader), header.Get(amzObjectLockRetainUntilHeader), header.Get(amzObjectLockLegalHoldHeader)
+
+	if mode != "" || retainUntil != "" {
+		if !isRetentionMode(mode) || retainUntil == "" {
+			return retention, true, ErrInvalidObjectLockHeaders
+		}
+		t, err := time.Parse(time.RFC3339, retainUntil)
+		if err != nil {
+			return retention, true, ErrInvalidObjectLockHeaders
+		}
+		if !t.After(now) {
+			return retention, true, ErrInvalidRetentionDate
+		}
+		retention.Mode, retention.RetainUntil = mode, t
+	}
+
+	if legalHold != "" {
+		var ok bool
+		if retention.LegalHold, ok = parseLegalHoldStatus(legalHold); !ok {
+			return retention, true, ErrInvalidObjectLockHeaders
+		}
+	}
+
+	return retention, mode != "" || legalHold != "", ErrNone
+}
+
+func setObjectLockResponseHeaders(w http.ResponseWriter, extended map[string][]byte) {
+	retention := filer2.GetObjectRetention(extended)
+	if retention.Mode != "" {
+		w.Header().Set(amzObjectLockModeHeader, retention.Mode)
+		w.Header().Set(amzObjectLockRetainUntilHeader, retention.RetainUntil.UTC().Format(time.RFC3339))
+	}
+	if retention.LegalHold {
+		w.Header().Set(amzObjectLockLegalHoldHeader, filer2.LegalHoldOn)
+	}
+}
@@ -0,0 +1,120 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+)
+
+func TestObjectLockConfiguration(t *testing.T) {
+
+	config, err := parseObjectLockConfiguration([]byte(`<ObjectLockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <ObjectLockEnabled>Enabled</ObjectLockEnabled>
+  <Rule>
+    <DefaultRetention>
+      <Mode>COMPLIANCE</Mode>
+      <Days>30</Days>
+    </DefaultRetention>
+  </Rule>
+</ObjectLockConfiguration>`))
+	assert.NoError(t, err)
+
+	now := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	retention := config.defaultRetention(now)
+	assert.Equal(t, filer2.RetentionCompliance, retention.Mode)
+	assert.Equal(t, now.AddDate(0, 0, 30), retention.RetainUntil)
+
+	config, err = parseObjectLockConfiguration([]byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+	assert.NoError(t, err)
+	assert.Equal(t, "", config.defaultRetention(now).Mode)
+
+	invalid := []string{
+		`<ObjectLockConfiguration><ObjectLockEnabled>Disabled</ObjectLockEnabled></ObjectLockConfiguration>`,
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>STRICT</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`,
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode></DefaultRetention></Rule></ObjectLockConfiguration>`,
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>1</Days><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`,
+	}
+	for _, data := range invalid {
+		_, err = parseObjectLockConfiguration([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestObjectLockHeaders(t *testing.T) {
+
+	now := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	header := http.Header{}
+	retention, found, code := parseObjectLockHeaders(header, now)
+	assert.Equal(t, ErrNone, code)
+	assert.False(t, found)
+
+	header.Set(amzObjectLockModeHeader, filer2.RetentionGovernance)
+	header.Set(amzObjectLockRetainUntilHeader, "2020-04-01T00:00:00Z")
+	header.Set(amzObjectLockLegalHoldHeader, filer2.LegalHoldOn)
+	retention, found, code = parseObjectLockHeaders(header, now)
+	assert.Equal(t, ErrNone, code)
+	assert.True(t, found)
+	assert.Equal(t, filer2.RetentionGovernance, retention.Mode)
+	assert.Equal(t, time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), retention.RetainUntil)
+	assert.True(t, retention.LegalHold)
+
+	header.Set(amzObjectLockRetainUntilHeader, "2020-02-01T00:00:00Z")
+	_, _, code = parseObjectLockHeaders(header, now)
+	assert.Equal(t, ErrInvalidRetentionDate, code)
+
+	header.Del(amzObjectLockRetainUntilHeader)
+	_, _, code = parseObjectLockHeaders(header, now)
+	assert.Equal(t, ErrInvalidObjectLockHeaders, code)
+
+	header = http.Header{}
+	header.Set(amzObjectLockLegalHoldHeader, "MAYBE")
+	_, _, code = parseObjectLockHeaders(header, now)
+	assert.Equal(t, ErrInvalidObjectLockHeaders, code)
+}
+
+func TestRetentionXml(t *testing.T) {
+
+	now := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	retention := &Retention{}
+	err := xml.Unmarshal([]byte(`<Retention xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Mode>COMPLIANCE</Mode><RetainUntilDate>2020-04-01T00:00:00Z</RetainUntilDate></Retention>`), retention)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrNone, retention.validate(now))
+	assert.Equal(t, ErrInvalidRetentionDate, retention.validate(retention.RetainUntilDate))
+
+	retention.Mode = "STRICT"
+	assert.Equal(t, ErrMalformedXML, retention.validate(now))
+
+	legalHold := &LegalHold{}
+	err = xml.Unmarshal([]byte(`<LegalHold><Status>OFF</Status></LegalHold>`), legalHold)
+	assert.NoError(t, err)
+	on, ok := parseLegalHoldStatus(legalHold.Status)
+	assert.True(t, ok)
+	assert.False(t, on)
+}
+
+func TestObjectLockWithoutIamKey(t *testing.T) {
+
+	s3a := &S3ApiServer{option: &S3ApiServerOption{BucketsPath: "/buckets"}}
+
+	req := mux.SetURLVars(httptest.NewRequest("PUT", "/b1?object-lock", strings.NewReader(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`)), map[string]string{"bucket": "b1"})
+	w := httptest.NewRecorder()
+	s3a.PutObjectLockConfigurationHandler(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = mux.SetURLVars(httptest.NewRequest("PUT", "/b1", nil), map[string]string{"bucket": "b1"})
+	req.Header.Set(amzBucketObjectLockEnabledHeader, "true")
+	w = httptest.NewRecorder()
+	s3a.PutBucketHandler(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+}
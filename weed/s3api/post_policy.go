@@ -0,0 +1,239 @@
+package s3api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// the form fields before the file are kept in memory, the file itself is streamed to the filer
+	maxPostFormFieldSize = 20 * 1024
+	maxPostFormSize      = 1024 * 1024
+
+	postFileField     = "file"
+	postPolicyField   = "policy"
+	postSignatureV4   = "x-amz-signature"
+	postIgnorePrefix  = "x-ignore-"
+	postFilenameParam = "${filename}"
+)
+
+// the form fields without conditions in the policy
+var postPolicyExemptFields = map[string]bool{
+	postFileField:    true,
+	postPolicyField:  true,
+	postSignatureV4:  true,
+	"signature":      true,
+	"awsaccesskeyid": true,
+}
+
+// PostPolicy is the decoded policy document of a browser upload
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-HTTPPOSTConstructPolicy.html
+type PostPolicy struct {
+	Expiration time.Time
+	Conditions []postPolicyCondition
+}
+
+type postPolicyCondition struct {
+	operator string // eq, starts-with or content-length-range
+	field    string // the lower cased form field name, without the leading $
+	value    string
+	min, max int64
+}
+
+func parsePostPolicy(encoded string) (*PostPolicy, error) {
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode policy: %v", err)
+	}
+
+	var raw struct {
+		Expiration string            `json:"expiration"`
+		Conditions []json.RawMessage `json:"conditions"`
+	}
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse policy: %v", err)
+	}
+
+	policy := &PostPolicy{}
+	if policy.Expiration, err = time.Parse(time.RFC3339, raw.Expiration); err != nil {
+		return nil, fmt.Errorf("policy expiration %q: %v", raw.Expiration, err)
+	}
+
+	for _, rawCondition := range raw.Conditions {
+		conditions, err := parsePostPolicyCondition(rawCondition)
+		if err != nil {
+			return nil, err
+		}
+		policy.Conditions = append(policy.Conditions, conditions...)
+	}
+
+	return policy, nil
+}
+
+// parsePostPolicyCondition parses {"field": "value"}, ["eq", "$field", "value"],
+// ["starts-with", "$field", "prefix"] and ["content-length-range", min, max]
+func parsePostPolicyCondition(data json.RawMessage) (conditions []postPolicyCondition, err error) {
+
+	var exact map[string]interface{}
+	if err = json.Unmarshal(data, &exact); err == nil {
+		for field, value := range exact {
+			stringValue, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("condition %s: expect a string value", field)
+			}
+			conditions = append(conditions, postPolicyCondition{
+				operator: "eq",
+				field:    strings.ToLower(strings.TrimPrefix(field, "$")),
+				value:    stringValue,
+			})
+		}
+		return conditions, nil
+	}
+
+	var list []interface{}
+	if err = json.Unmarshal(data, &list); err != nil || len(list) != 3 {
+		return nil, fmt.Errorf("unexpected condition %s", string(data))
+	}
+	operator, _ := list[0].(string)
+	operator = strings.ToLower(operator)
+
+	switch operator {
+	case "eq", "starts-with":
+		field, ok1 := list[1].(string)
+		value, ok2 := list[2].(string)
+		if !ok1 || !ok2 || !strings.HasPrefix(field, "$") {
+			return nil, fmt.Errorf("unexpected condition %s", string(data))
+		}
+		return []postPolicyCondition{{
+			operator: operator,
+			field:    strings.ToLower(strings.TrimPrefix(field, "$")),
+			value:    value,
+		}}, nil
+	case "content-length-range":
+		min, err1 := policyInt(list[1])
+		max, err2 := policyInt(list[2])
+		if err1 != nil || err2 != nil || min < 0 || min > max {
+			return nil, fmt.Errorf("unexpected condition %s", string(data))
+		}
+		return []postPolicyCondition{{
+			operator: operator,
+			min:      min,
+			max:      max,
+		}}, nil
+	}
+
+	return nil, fmt.Errorf("unknown condition %s", string(data))
+}
+
+func policyInt(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), nil
+	case string:
+		return strconv.ParseInt(n, 10, 64)
+	}
+	return 0, fmt.Errorf("unexpected number %v", v)
+}
+
+// check verifies the form fields against the policy conditions, and returns the allowed file size range.
+// The form has the lower cased field names, and the bucket from the request path.
+func (policy *PostPolicy) check(form map[string]string, now time.Time) (minSize, maxSize int64, err error) {
+
+	if !now.Before(policy.Expiration) {
+		return 0, 0, fmt.Errorf("policy expired at %v", policy.Expiration)
+	}
+
+	maxSize = -1
+	covered := make(map[string]bool)
+	for _, condition := range policy.Conditions {
+		switch condition.operator {
+		case "eq":
+			if form[condition.field] != condition.value {
+				return 0, 0, fmt.Errorf("condition failed: [\"eq\", \"$%s\", %q]", condition.field, condition.value)
+			}
+		case "starts-with":
+			if !strings.HasPrefix(form[condition.field], condition.value) {
+				return 0, 0, fmt.Errorf("condition failed: [\"starts-with\", \"$%s\", %q]", condition.field, condition.value)
+			}
+		case "content-length-range":
+			minSize, maxSize = condition.min, condition.max
+			continue
+		}
+		covered[condition.field] = true
+	}
+
+	for field := range form {
+		if field == "bucket" || postPolicyExemptFields[field] || strings.HasPrefix(field, postIgnorePrefix) {
+			continue
+		}
+		if !covered[field] {
+			return 0, 0, fmt.Errorf("form field %s is not covered by the policy conditions", field)
+		}
+	}
+
+	return minSize, maxSize, nil
+}
+
+// readPostForm reads the form fields up to the file part, which is left unread to be streamed.
+// The fields after the file are ignored.
+func readPostForm(reader *multipart.Reader) (form map[string]string, file *multipart.Part, code ErrorCode) {
+
+	form = make(map[string]string)
+	formSize := 0
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return form, nil, ErrPOSTFileRequired
+		}
+		if err != nil {
+			return nil, nil, ErrMalformedPOSTRequest
+		}
+		name := strings.ToLower(part.FormName())
+		if name == "" {
+			continue
+		}
+		if name == postFileField {
+			return form, part, ErrNone
+		}
+		value, err := ioutil.ReadAll(io.LimitReader(part, maxPostFormFieldSize+1))
+		if err != nil {
+			return nil, nil, ErrMalformedPOSTRequest
+		}
+		formSize += len(value)
+		if len(value) > maxPostFormFieldSize || formSize > maxPostFormSize {
+			return nil, nil, ErrMaxPostPreDataLengthExceeded
+		}
+		form[name] = string(value)
+	}
+}
+
+// contentLengthRangeReader fails the upload once the file is larger than the policy allows,
+// or ends smaller, so the filer never completes the write.
+type contentLengthRangeReader struct {
+	reader           io.Reader
+	minSize, maxSize int64
+	size             int64
+	code             ErrorCode
+}
+
+func (r *contentLengthRangeReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.size += int64(n)
+	if r.maxSize >= 0 && r.size > r.maxSize {
+		r.code = ErrEntityTooLarge
+		return n, fmt.Errorf("file is larger than %d bytes", r.maxSize)
+	}
+	if err == io.EOF && r.size < r.minSize {
+		r.code = ErrEntityTooSmall
+		return n, fmt.Errorf("file is smaller than %d bytes", r.minSize)
+	}
+	return n, err
+}
@@ -0,0 +1,279 @@
+package s3api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPostPolicyConditions - Test validates the form fields against the policy conditions.
+func TestPostPolicyConditions(t *testing.T) {
+	now := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	conditions := `[
+		{"bucket": "b1"},
+		["starts-with", "$key", "user/eric/"],
+		{"acl": "public-read"},
+		["eq", "$Content-Type", "image/jpeg"],
+		["starts-with", "$x-amz-meta-tag", ""],
+		["content-length-range", 1, 1048576]
+	]`
+
+	testCases := []struct {
+		expiration string
+		form       map[string]string
+		minSize    int64
+		maxSize    int64
+		shouldPass bool
+	}{
+		// Test case - 1.
+		// All the fields meet the conditions.
+		{"2020-03-02T00:00:00.000Z", map[string]string{"bucket": "b1", "key": "user/eric/a.jpg", "acl": "public-read", "content-type": "image/jpeg", "x-amz-meta-tag": "any"}, 1, 1048576, true},
+		// Test case - 2.
+		// The policy fields and the ignored fields need no conditions.
+		{"2020-03-02T00:00:00Z", map[string]string{"bucket": "b1", "key": "user/eric/a.jpg", "acl": "public-read", "content-type": "image/jpeg", "policy": "p", "x-amz-signature": "s", "x-ignore-me": "1"}, 1, 1048576, true},
+		// Test case - 3.
+		// The policy has expired.
+		{"2020-02-29T00:00:00.000Z", map[string]string{"bucket": "b1", "key": "user/eric/a.jpg", "acl": "public-read", "content-type": "image/jpeg"}, 0, 0, false},
+		// Test case - 4.
+		// The key does not start with the prefix.
+		{"2020-03-02T00:00:00.000Z", map[string]string{"bucket": "b1", "key": "user/bob/a.jpg", "acl": "public-read", "content-type": "image/jpeg"}, 0, 0, false},
+		// Test case - 5.
+		// The bucket does not match.
+		{"2020-03-02T00:00:00.000Z", map[string]string{"bucket": "b2", "key": "user/eric/a.jpg", "acl": "public-read", "content-type": "image/jpeg"}, 0, 0, false},
+		// Test case - 6.
+		// The content type does not match.
+		{"2020-03-02T00:00:00.000Z", map[string]string{"bucket": "b1", "key": "user/eric/a.jpg", "acl": "public-read", "content-type": "image/png"}, 0, 0, false},
+		// Test case - 7.
+		// A form field is not covered by any condition.
+		{"2020-03-02T00:00:00.000Z", map[string]string{"bucket": "b1", "key": "user/eric/a.jpg", "acl": "public-read", "content-type": "image/jpeg", "x-amz-meta-other": "1"}, 0, 0, false},
+	}
+
+	for i, testCase := range testCases {
+		encoded := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(`{"expiration": %q, "conditions": %s}`, testCase.expiration, conditions)))
+		policy, err := parsePostPolicy(encoded)
+		if err != nil {
+			t.Fatalf("Test %d: Unable to parse the policy: %v", i+1, err)
+		}
+		minSize, maxSize, err := policy.check(testCase.form, now)
+		if testCase.shouldPass && err != nil {
+			t.Errorf("Test %d: Expected to pass, but failed with: %v", i+1, err)
+		}
+		if !testCase.shouldPass && err == nil {
+			t.Errorf("Test %d: Expected to fail, but passed", i+1)
+		}
+		if testCase.shouldPass && (minSize != testCase.minSize || maxSize != testCase.maxSize) {
+			t.Errorf("Test %d: Expected the size range [%d, %d], but got [%d, %d]", i+1, testCase.minSize, testCase.maxSize, minSize, maxSize)
+		}
+	}
+}
+
+// TestParsePostPolicy - Test validates the rejection of malformed policies.
+func TestParsePostPolicy(t *testing.T) {
+	testCases := []string{
+		// Test case - 1.
+		// Not json.
+		`expiration`,
+		// Test case - 2.
+		// Missing expiration.
+		`{"conditions": [{"bucket": "b1"}]}`,
+		// Test case - 3.
+		// Unknown operator.
+		`{"expiration": "2020-03-02T00:00:00Z", "conditions": [["ends-with", "$key", ".jpg"]]}`,
+		// Test case - 4.
+		// Field name without $.
+		`{"expiration": "2020-03-02T00:00:00Z", "conditions": [["eq", "key", "a.jpg"]]}`,
+		// Test case - 5.
+		// Inverted size range.
+		`{"expiration": "2020-03-02T00:00:00Z", "conditions": [["content-length-range", 10, 1]]}`,
+		// Test case - 6.
+		// Not a string value.
+		`{"expiration": "2020-03-02T00:00:00Z", "conditions": [{"bucket": 1}]}`,
+	}
+	for i, testCase := range testCases {
+		if _, err := parsePostPolicy(base64.StdEncoding.EncodeToString([]byte(testCase))); err == nil {
+			t.Errorf("Test %d: Expected the policy to be rejected", i+1)
+		}
+	}
+	if _, err := parsePostPolicy("not base64!"); err == nil {
+		t.Errorf("Expected the policy encoding to be rejected")
+	}
+}
+
+// TestDoesPolicySignatureV4Match - Test validates the signature of the browser upload forms.
+func TestDoesPolicySignatureV4Match(t *testing.T) {
+	iam := NewIdentityAccessManagement("", "")
+	iam.identities = []*Identity{
+		{
+			Name: "someone",
+			Credentials: []*Credential{
+				{
+					AccessKey: "access_key_1",
+					SecretKey: "secret_key_1",
+				},
+			},
+			Actions: nil,
+		},
+	}
+
+	policy := base64.StdEncoding.EncodeToString([]byte(`{"expiration": "2030-01-01T00:00:00Z", "conditions": [{"bucket": "b1"}]}`))
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		form    map[string]string
+		s3Error ErrorCode
+	}{
+		// Test case - 1.
+		// Properly signed form.
+		{newPostPolicyFormV4(policy, "access_key_1", "secret_key_1", now), ErrNone},
+		// Test case - 2.
+		// Signed with a wrong secret key.
+		{newPostPolicyFormV4(policy, "access_key_1", "secret_key_2", now), ErrSignatureDoesNotMatch},
+		// Test case - 3.
+		// Signed with an unknown access key.
+		{newPostPolicyFormV4(policy, "access_key_2", "secret_key_1", now), ErrInvalidAccessKeyID},
+		// Test case - 4.
+		// Unsupported algorithm.
+		{map[string]string{"policy": policy, "x-amz-algorithm": "AWS4-HMAC-SHA1"}, ErrSignatureVersionNotSupported},
+		// Test case - 5.
+		// Malformed credential.
+		{map[string]string{"policy": policy, "x-amz-algorithm": signV4Algorithm, "x-amz-credential": "access_key_1"}, ErrCredMalformed},
+	}
+
+	for i, testCase := range testCases {
+		if _, s3Error := iam.doesPolicySignatureV4Match(testCase.form); s3Error != testCase.s3Error {
+			t.Errorf("Test %d: Unexpected s3error returned wanted %d, got %d", i+1, testCase.s3Error, s3Error)
+		}
+	}
+}
+
+// TestReadPostForm - Test validates the form fields are read up to the file, which is left to be streamed.
+func TestReadPostForm(t *testing.T) {
+	policy := base64.StdEncoding.EncodeToString([]byte(`{"expiration": "2030-01-01T00:00:00Z", "conditions": []}`))
+	form := newPostPolicyFormV4(policy, "access_key_1", "secret_key_1", time.Now().UTC())
+	form["Key"] = "user/${filename}"
+
+	req := mustNewPostRequest(t, "http://127.0.0.1:9000/b1", form, "a.txt", []byte("hello world"), map[string]string{"success_action_status": "201"})
+
+	reader, err := req.MultipartReader()
+	if err != nil {
+		t.Fatalf("Unable to read the form: %v", err)
+	}
+	fields, file, errCode := readPostForm(reader)
+	if errCode != ErrNone {
+		t.Fatalf("Unexpected s3error %d", errCode)
+	}
+	if fields["key"] != "user/${filename}" || fields["policy"] != policy {
+		t.Errorf("Unexpected form fields %v", fields)
+	}
+	if _, found := fields["success_action_status"]; found {
+		t.Errorf("Expected the fields after the file to be ignored")
+	}
+	if file.FileName() != "a.txt" {
+		t.Errorf("Expected file name a.txt, got %s", file.FileName())
+	}
+	data, _ := ioutil.ReadAll(file)
+	if string(data) != "hello world" {
+		t.Errorf("Unexpected file content %q", string(data))
+	}
+
+	// a form without file
+	req = mustNewPostRequest(t, "http://127.0.0.1:9000/b1", form, "", nil, nil)
+	reader, _ = req.MultipartReader()
+	if _, _, errCode = readPostForm(reader); errCode != ErrPOSTFileRequired {
+		t.Errorf("Expected ErrPOSTFileRequired, got %d", errCode)
+	}
+
+	// a form with a too large field
+	req = mustNewPostRequest(t, "http://127.0.0.1:9000/b1", map[string]string{"key": strings.Repeat("a", maxPostFormFieldSize+1)}, "a.txt", []byte("hello"), nil)
+	reader, _ = req.MultipartReader()
+	if _, _, errCode = readPostForm(reader); errCode != ErrMaxPostPreDataLengthExceeded {
+		t.Errorf("Expected ErrMaxPostPreDataLengthExceeded, got %d", errCode)
+	}
+}
+
+// TestContentLengthRangeReader - Test validates the file size is enforced while streaming.
+func TestContentLengthRangeReader(t *testing.T) {
+	testCases := []struct {
+		size     int
+		minSize  int64
+		maxSize  int64
+		expected ErrorCode
+	}{
+		// Test case - 1.
+		// No size range.
+		{100, 0, -1, ErrNone},
+		// Test case - 2.
+		// Within the range.
+		{100, 100, 100, ErrNone},
+		// Test case - 3.
+		// Too small.
+		{99, 100, 200, ErrEntityTooSmall},
+		// Test case - 4.
+		// Too large.
+		{201, 100, 200, ErrEntityTooLarge},
+	}
+
+	for i, testCase := range testCases {
+		reader := &contentLengthRangeReader{
+			reader:  bytes.NewReader(make([]byte, testCase.size)),
+			minSize: testCase.minSize,
+			maxSize: testCase.maxSize,
+		}
+		_, err := io.Copy(ioutil.Discard, reader)
+		if reader.code != testCase.expected {
+			t.Errorf("Test %d: Expected s3error %d, got %d", i+1, testCase.expected, reader.code)
+		}
+		if (err == nil) != (testCase.expected == ErrNone) {
+			t.Errorf("Test %d: Unexpected read error %v", i+1, err)
+		}
+	}
+}
+
+// Returns the form fields of a browser upload, signed with AWS Signature V4.
+func newPostPolicyFormV4(policy, accessKey, secretKey string, t time.Time) map[string]string {
+	region := "us-east-1"
+	signingKey := getSigningKey(secretKey, t, region)
+	return map[string]string{
+		"policy":           policy,
+		"x-amz-algorithm":  signV4Algorithm,
+		"x-amz-credential": fmt.Sprintf("%s/%s", accessKey, getScope(t, region)),
+		"x-amz-date":       t.Format(iso8601Format),
+		"x-amz-signature":  getSignature(signingKey, policy),
+	}
+}
+
+// Provides a multipart/form-data POST request, with the fields before the file and the trailing fields after it.
+func mustNewPostRequest(t *testing.T, urlStr string, form map[string]string, fileName string, content []byte, trailing map[string]string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range form {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatalf("Unable to write form field %s: %v", name, err)
+		}
+	}
+	if fileName != "" {
+		part, err := writer.CreateFormFile("file", fileName)
+		if err != nil {
+			t.Fatalf("Unable to create form file: %v", err)
+		}
+		part.Write(content)
+	}
+	for name, value := range trailing {
+		writer.WriteField(name, value)
+	}
+	writer.Close()
+
+	req, err := http.NewRequest("POST", urlStr, &body)
+	if err != nil {
+		t.Fatalf("Unable to initialize new http request %s", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
@@ -0,0 +1,160 @@
+package s3api
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+const maxAclSize = 64 * 1024
+
+// loadCannedAcl reads the canned ACL of the bucket, or of the object if not empty, which is private if missing
+func (s3a *S3ApiServer) loadCannedAcl(bucket, object string) (string, error) {
+	var entry *filer_pb.Entry
+	var err error
+	if object == "" {
+		entry, err = s3a.getBucketEntry(bucket)
+	} else {
+		dir, name := s3a.objectDirAndName(bucket, object)
+		entry, err = s3a.getEntry(dir, name)
+	}
+	if err == filer_pb.ErrNotFound || err == nil && entry == nil {
+		return cannedAclPrivate, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return getCannedAcl(entry.Extended), nil
+}
+
+// cannedAclOfRequest reads the canned ACL from the x-amz-acl header, or else from the AccessControlPolicy in the body
+func cannedAclOfRequest(r *http.Request) (string, ErrorCode) {
+
+	cannedAcl, errCode := parseAclHeaders(r.Header)
+	if errCode != ErrNone || cannedAcl != "" {
+		return cannedAcl, errCode
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxAclSize+1))
+	if err != nil {
+		return "", ErrInternalError
+	}
+	if len(data) > maxAclSize {
+		return "", ErrMalformedACLError
+	}
+	return cannedAclOfPolicy(data)
+}
+
+// GetBucketACLHandler Get Bucket ACL
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketAcl.html
+func (s3a *S3ApiServer) GetBucketACLHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	entry, err := s3a.getBucketEntry(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(newAccessControlPolicy(entry, getCannedAcl(entry.Extended))))
+}
+
+// PutBucketACLHandler Put Bucket ACL, only canned ACLs are supported
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketAcl.html
+func (s3a *S3ApiServer) PutBucketACLHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	cannedAcl, errCode := cannedAclOfRequest(r)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if err := s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		setCannedAcl(entry.Extended, cannedAcl)
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("PutBucketACL %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	s3a.iam.invalidateCannedAcl(bucket, "")
+
+	writeSuccessResponseEmpty(w)
+}
+
+// GetObjectACLHandler Get Object ACL
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectAcl.html
+func (s3a *S3ApiServer) GetObjectACLHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	entry, err := s3a.getEntry(dir, name)
+	if err != nil && err != filer_pb.ErrNotFound {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	if entry == nil || entry.IsDirectory || isDeleteMarker(entry) {
+		writeErrorResponse(w, ErrNoSuchKey, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(newAccessControlPolicy(entry, getCannedAcl(entry.Extended))))
+}
+
+// PutObjectACLHandler Put Object ACL, only canned ACLs are supported
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectAcl.html
+func (s3a *S3ApiServer) PutObjectACLHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	cannedAcl, errCode := cannedAclOfRequest(r)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if errCode := s3a.setObjectCannedAcl(bucket, object, cannedAcl); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	writeSuccessResponseEmpty(w)
+}
+
+// setObjectCannedAcl records the canned ACL of the object, and drops the cached one
+func (s3a *S3ApiServer) setObjectCannedAcl(bucket, object, cannedAcl string) ErrorCode {
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	if err := s3a.setAcl(dir, name, cannedAcl); err != nil {
+		if err == filer_pb.ErrNotFound {
+			return ErrNoSuchKey
+		}
+		glog.Errorf("set %s%s acl: %v", bucket, object, err)
+		return ErrInternalError
+	}
+	s3a.iam.invalidateCannedAcl(bucket, object)
+
+	return ErrNone
+}
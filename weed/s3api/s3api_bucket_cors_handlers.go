@@ -0,0 +1,183 @@
+package s3api
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// cors configurations are checked for every browser request, and cached for a short while as the bucket policies
+const bucketCorsCacheTtl = 10 * time.Second
+
+// loadBucketCors reads the bucket cors configuration from the filer, returning nil if the bucket has none.
+func (s3a *S3ApiServer) loadBucketCors(bucket string) (*CORSConfiguration, error) {
+	entry, err := s3a.getBucketEntry(bucket)
+	if err == filer_pb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.Extended == nil || len(entry.Extended[extBucketCorsKey]) == 0 {
+		return nil, nil
+	}
+	return parseCorsConfiguration(entry.Extended[extBucketCorsKey])
+}
+
+func (s3a *S3ApiServer) getBucketCors(bucket string) (*CORSConfiguration, error) {
+	item, err := s3a.bucketCors.Fetch(bucket, bucketCorsCacheTtl, func() (interface{}, error) {
+		return s3a.loadBucketCors(bucket)
+	})
+	if err != nil {
+		return nil, err
+	}
+	config, _ := item.Value().(*CORSConfiguration)
+	return config, nil
+}
+
+// GetBucketCorsHandler Get bucket CORS
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketCors.html
+func (s3a *S3ApiServer) GetBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	entry, err := s3a.getBucketEntry(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	if entry.Extended == nil || len(entry.Extended[extBucketCorsKey]) == 0 {
+		writeErrorResponse(w, ErrNoSuchCORSConfiguration, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, entry.Extended[extBucketCorsKey])
+}
+
+// PutBucketCorsHandler Put bucket CORS
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+func (s3a *S3ApiServer) PutBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxCorsSize+1))
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	if len(data) > maxCorsSize {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	if _, err = parseCorsConfiguration(data); err != nil {
+		glog.V(1).Infof("parse bucket %s cors: %v", bucket, err)
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	if err = s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		entry.Extended[extBucketCorsKey] = data
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("PutBucketCors %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	s3a.bucketCors.Delete(bucket)
+
+	writeSuccessResponseEmpty(w)
+}
+
+// DeleteBucketCorsHandler Delete bucket CORS
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketCors.html
+func (s3a *S3ApiServer) DeleteBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if err := s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		delete(entry.Extended, extBucketCorsKey)
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("DeleteBucketCors %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	s3a.bucketCors.Delete(bucket)
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+}
+
+// CorsPreflightHandler answers the browser OPTIONS requests, which are not signed
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTOPTIONSobject.html
+func (s3a *S3ApiServer) CorsPreflightHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	origin := r.Header.Get("Origin")
+	method := r.Header.Get("Access-Control-Request-Method")
+	if origin == "" || method == "" {
+		writeErrorResponse(w, ErrInvalidCORSRequest, r.URL)
+		return
+	}
+	requestHeaders := parseCorsRequestHeaders(r.Header.Get("Access-Control-Request-Headers"))
+
+	config, err := s3a.getBucketCors(bucket)
+	if err != nil {
+		glog.Errorf("load bucket %s cors: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	var rule *CORSRule
+	if config != nil {
+		rule = config.match(origin, method, requestHeaders)
+	}
+	if rule == nil {
+		glog.V(2).Infof("cors preflight %s from %s for %s %v is not allowed", bucket, origin, method, requestHeaders)
+		writeErrorResponse(w, ErrCORSForbidden, r.URL)
+		return
+	}
+
+	rule.setResponseHeaders(w, origin, requestHeaders, true)
+	writeSuccessResponseEmpty(w)
+}
+
+// corsMiddleware adds the cors headers to the responses of requests from allowed origins.
+// Requests from other origins are still served, and the browsers hide the responses.
+func (s3a *S3ApiServer) corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		bucket := mux.Vars(r)["bucket"]
+		if origin != "" && bucket != "" && r.Method != http.MethodOptions {
+			if config, err := s3a.getBucketCors(bucket); err != nil {
+				glog.V(1).Infof("load bucket %s cors: %v", bucket, err)
+			} else if config != nil {
+				if rule := config.match(origin, r.Method, nil); rule != nil {
+					rule.setResponseHeaders(w, origin, nil, false)
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -59,11 +60,35 @@ func (s3a *S3ApiServer) PutBucketHandler(w http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
 
+	cannedAcl, errCode := parseAclHeaders(r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	isObjectLockEnabled := strings.ToLower(r.Header.Get(amzBucketObjectLockEnabledHeader)) == "true"
+	if isObjectLockEnabled {
+		if errCode := s3a.checkObjectLockKey(); errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+	}
+
+	fn := func(entry *filer_pb.Entry) {
+		entry.Extended = make(map[string][]byte)
+		if isObjectLockEnabled {
+			// object lock needs versioning, which can not be suspended later
+			entry.Extended[extVersioningKey] = []byte(VersioningEnabled)
+			entry.Extended[extBucketObjectLockKey] = encodeResponse(ObjectLockConfiguration{ObjectLockEnabled: objectLockEnabled})
+		}
+		setCannedAcl(entry.Extended, cannedAcl)
+	}
+
 	// create the folder for bucket, but lazily create actual collection
-	if err := s3a.mkdir(s3a.option.BucketsPath, bucket, nil); err != nil {
+	if err := s3a.mkdir(s3a.option.BucketsPath, bucket, fn); err != nil {
 		writeErrorResponse(w, ErrInternalError, r.URL)
 		return
 	}
+	s3a.iam.invalidateCannedAcl(bucket, "")
 
 	writeSuccessResponseEmpty(w)
 }
@@ -73,7 +98,14 @@ func (s3a *S3ApiServer) DeleteBucketHandler(w http.ResponseWriter, r *http.Reque
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
 
-	err := s3a.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+	// the filer refuses to delete the bucket folder with locked objects, so it is deleted before the collection
+	err := s3a.rm(s3a.option.BucketsPath, bucket, false, true)
+	if err != nil {
+		writeErrorResponse(w, objectLockErrorCode(err), r.URL)
+		return
+	}
+
+	err = s3a.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		// delete collection
 		deleteCollectionRequest := &filer_pb.DeleteCollectionRequest{
@@ -87,12 +119,8 @@ func (s3a *S3ApiServer) DeleteBucketHandler(w http.ResponseWriter, r *http.Reque
 
 		return nil
 	})
-
-	err = s3a.rm(s3a.option.BucketsPath, bucket, false, true)
-
 	if err != nil {
-		writeErrorResponse(w, ErrInternalError, r.URL)
-		return
+		glog.V(1).Infof("DeleteBucket %s: %v", bucket, err)
 	}
 
 	writeResponse(w, http.StatusNoContent, nil, mimeNone)
@@ -0,0 +1,219 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+type LifecycleConfiguration struct {
+	XMLName xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LifecycleConfiguration"`
+	Rules   []LifecycleRule `xml:"Rule"`
+}
+
+type LifecycleRule struct {
+	ID                             string                          `xml:"ID,omitempty"`
+	Status                         string                          `xml:"Status"`
+	Filter                         *LifecycleFilter                `xml:"Filter,omitempty"`
+	Prefix                         *string                         `xml:"Prefix,omitempty"`
+	Expiration                     *LifecycleExpiration            `xml:"Expiration,omitempty"`
+	AbortIncompleteMultipartUpload *AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+}
+
+type LifecycleFilter struct {
+	Prefix string    `xml:"Prefix"`
+	Tag    *Tag      `xml:"Tag,omitempty"`
+	And    *struct{} `xml:"And,omitempty"`
+}
+
+type LifecycleExpiration struct {
+	Days int     `xml:"Days,omitempty"`
+	Date *string `xml:"Date,omitempty"`
+}
+
+type AbortIncompleteMultipartUpload struct {
+	DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+}
+
+const (
+	lifecycleStatusEnabled  = "Enabled"
+	lifecycleStatusDisabled = "Disabled"
+)
+
+// PutBucketLifecycleConfigurationHandler Put Bucket Lifecycle configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+func (s3a *S3ApiServer) PutBucketLifecycleConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	input, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	config := &LifecycleConfiguration{}
+	if err = xml.Unmarshal(input, config); err != nil {
+		glog.V(1).Infof("unmarshal lifecycle %s: %v", bucket, err)
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	rules, errCode := toFilerLifecycleRules(config)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	data, err := filer2.EncodeLifecycleRules(rules)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	if err = s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		entry.Extended[filer2.BucketLifecycleKey] = data
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("PutBucketLifecycleConfiguration %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseEmpty(w)
+}
+
+// GetBucketLifecycleConfigurationHandler Get Bucket Lifecycle configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLifecycleConfiguration.html
+func (s3a *S3ApiServer) GetBucketLifecycleConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	entry, err := s3a.getBucketEntry(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	rules, err := filer2.ParseLifecycleRules(entry.Extended[filer2.BucketLifecycleKey])
+	if err != nil {
+		glog.Errorf("GetBucketLifecycleConfiguration %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	if len(rules) == 0 {
+		writeErrorResponse(w, ErrNoSuchLifecycleConfiguration, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(fromFilerLifecycleRules(rules)))
+}
+
+// DeleteBucketLifecycleHandler Delete Bucket Lifecycle
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketLifecycle.html
+func (s3a *S3ApiServer) DeleteBucketLifecycleHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if err := s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		delete(entry.Extended, filer2.BucketLifecycleKey)
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("DeleteBucketLifecycle %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+}
+
+func toFilerLifecycleRules(config *LifecycleConfiguration) (rules []*filer2.LifecycleRule, code ErrorCode) {
+
+	if len(config.Rules) == 0 {
+		return nil, ErrMalformedXML
+	}
+
+	for _, rule := range config.Rules {
+		if rule.Status != lifecycleStatusEnabled && rule.Status != lifecycleStatusDisabled {
+			return nil, ErrMalformedXML
+		}
+		if rule.Filter != nil && (rule.Filter.Tag != nil || rule.Filter.And != nil) {
+			// only prefix filters are supported
+			return nil, ErrNotImplemented
+		}
+		if rule.Expiration != nil && rule.Expiration.Date != nil {
+			return nil, ErrNotImplemented
+		}
+		t := &filer2.LifecycleRule{
+			Id:      rule.ID,
+			Enabled: rule.Status == lifecycleStatusEnabled,
+		}
+		if rule.Filter != nil {
+			t.Prefix = rule.Filter.Prefix
+		} else if rule.Prefix != nil {
+			t.Prefix = *rule.Prefix
+		}
+		if rule.Expiration != nil {
+			t.ExpirationDays = rule.Expiration.Days
+		}
+		if rule.AbortIncompleteMultipartUpload != nil {
+			t.AbortIncompleteMultipartUploadDays = rule.AbortIncompleteMultipartUpload.DaysAfterInitiation
+		}
+		if t.ExpirationDays <= 0 && t.AbortIncompleteMultipartUploadDays <= 0 {
+			return nil, ErrMalformedXML
+		}
+		rules = append(rules, t)
+	}
+
+	return rules, ErrNone
+}
+
+func fromFilerLifecycleRules(rules []*filer2.LifecycleRule) (config *LifecycleConfiguration) {
+
+	config = &LifecycleConfiguration{}
+
+	for _, rule := range rules {
+		t := LifecycleRule{
+			ID:     rule.Id,
+			Status: lifecycleStatusDisabled,
+			Filter: &LifecycleFilter{
+				Prefix: rule.Prefix,
+			},
+		}
+		if rule.Enabled {
+			t.Status = lifecycleStatusEnabled
+		}
+		if rule.ExpirationDays > 0 {
+			t.Expiration = &LifecycleExpiration{
+				Days: rule.ExpirationDays,
+			}
+		}
+		if rule.AbortIncompleteMultipartUploadDays > 0 {
+			t.AbortIncompleteMultipartUpload = &AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: rule.AbortIncompleteMultipartUploadDays,
+			}
+		}
+		config.Rules = append(config.Rules, t)
+	}
+
+	return
+}
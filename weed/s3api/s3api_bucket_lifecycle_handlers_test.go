@@ -0,0 +1,62 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFilerLifecycleRules(t *testing.T) {
+
+	input := `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule>
+    <ID>logs</ID>
+    <Filter><Prefix>logs/</Prefix></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>30</Days></Expiration>
+  </Rule>
+  <Rule>
+    <ID>uploads</ID>
+    <Prefix></Prefix>
+    <Status>Disabled</Status>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+  </Rule>
+</LifecycleConfiguration>`
+
+	config := &LifecycleConfiguration{}
+	assert.Nil(t, xml.Unmarshal([]byte(input), config))
+
+	rules, code := toFilerLifecycleRules(config)
+	assert.Equal(t, ErrNone, code)
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, "logs/", rules[0].Prefix)
+	assert.True(t, rules[0].Enabled)
+	assert.Equal(t, 30, rules[0].ExpirationDays)
+	assert.False(t, rules[1].Enabled)
+	assert.Equal(t, 7, rules[1].AbortIncompleteMultipartUploadDays)
+
+	back := fromFilerLifecycleRules(rules)
+	assert.Equal(t, "Enabled", back.Rules[0].Status)
+	assert.Equal(t, 30, back.Rules[0].Expiration.Days)
+	assert.Nil(t, back.Rules[1].Expiration)
+
+}
+
+func TestToFilerLifecycleRulesUnsupported(t *testing.T) {
+
+	date := "2030-01-01T00:00:00Z"
+	config := &LifecycleConfiguration{
+		Rules: []LifecycleRule{{
+			Status:     "Enabled",
+			Expiration: &LifecycleExpiration{Date: &date},
+		}},
+	}
+	_, code := toFilerLifecycleRules(config)
+	assert.Equal(t, ErrNotImplemented, code)
+
+	config.Rules[0].Expiration = nil
+	_, code = toFilerLifecycleRules(config)
+	assert.Equal(t, ErrMalformedXML, code)
+
+}
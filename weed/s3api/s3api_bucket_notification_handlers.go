@@ -0,0 +1,197 @@
+package s3api
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// notification configurations are checked for every object change, and cached for a short while as the cors configurations
+const bucketNotificationCacheTtl = 10 * time.Second
+
+// loadBucketNotification reads the bucket notification configuration from the filer, returning nil if the bucket has none.
+func (s3a *S3ApiServer) loadBucketNotification(bucket string) (*BucketNotificationConfiguration, error) {
+	entry, err := s3a.getBucketEntry(bucket)
+	if err == filer_pb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.Extended == nil || len(entry.Extended[extBucketNotificationKey]) == 0 {
+		return nil, nil
+	}
+	// the targets were checked when the configuration was put, and may have been removed from notification.toml since
+	return parseNotificationConfiguration(entry.Extended[extBucketNotificationKey], func(string) bool { return true })
+}
+
+func (s3a *S3ApiServer) getBucketNotification(bucket string) (*BucketNotificationConfiguration, error) {
+	item, err := s3a.bucketNotifications.Fetch(bucket, bucketNotificationCacheTtl, func() (interface{}, error) {
+		return s3a.loadBucketNotification(bucket)
+	})
+	if err != nil {
+		return nil, err
+	}
+	config, _ := item.Value().(*BucketNotificationConfiguration)
+	return config, nil
+}
+
+// GetBucketNotificationConfigurationHandler Get bucket notification configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketNotificationConfiguration.html
+func (s3a *S3ApiServer) GetBucketNotificationConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	entry, err := s3a.getBucketEntry(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	if entry.Extended == nil || len(entry.Extended[extBucketNotificationKey]) == 0 {
+		writeSuccessResponseXML(w, encodeResponse(BucketNotificationConfiguration{}))
+		return
+	}
+
+	writeSuccessResponseXML(w, entry.Extended[extBucketNotificationKey])
+}
+
+// PutBucketNotificationConfigurationHandler Put bucket notification configuration,
+// where an empty configuration turns off the notifications
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketNotificationConfiguration.html
+func (s3a *S3ApiServer) PutBucketNotificationConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxNotificationConfigurationSize+1))
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	if len(data) > maxNotificationConfigurationSize {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	config, err := parseNotificationConfiguration(data, s3a.notifications.isTarget)
+	if config == nil {
+		glog.V(1).Infof("parse bucket %s notification: %v", bucket, err)
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+	if err != nil {
+		glog.V(1).Infof("bucket %s notification: %v", bucket, err)
+		writeErrorResponse(w, ErrInvalidNotificationConfiguration, r.URL)
+		return
+	}
+
+	if err = s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		if len(config.targets()) == 0 {
+			delete(entry.Extended, extBucketNotificationKey)
+			return
+		}
+		entry.Extended[extBucketNotificationKey] = data
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("PutBucketNotificationConfiguration %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	s3a.bucketNotifications.Delete(bucket)
+
+	writeSuccessResponseEmpty(w)
+}
+
+// notifyObjectEvent sends the event record to the targets of the bucket notification configuration.
+// The object size is looked up for the created objects.
+func (s3a *S3ApiServer) notifyObjectEvent(r *http.Request, event, bucket, object, versionId, etag string) {
+
+	config, err := s3a.getBucketNotification(bucket)
+	if err != nil {
+		glog.V(1).Infof("load bucket %s notification: %v", bucket, err)
+		return
+	}
+	if config == nil {
+		return
+	}
+	key := strings.TrimPrefix(object, "/")
+	targets := config.match(event, key)
+	if len(targets) == 0 {
+		return
+	}
+
+	eventObject := EventObject{
+		Key:       strings.Replace(url.QueryEscape(key), "%2F", "/", -1),
+		ETag:      strings.Trim(etag, "\""),
+		VersionId: versionId,
+	}
+	if strings.HasPrefix(event, "ObjectCreated:") {
+		dir, name := s3a.objectDirAndName(bucket, object)
+		if entry, err := s3a.getEntry(dir, name); err == nil && entry != nil {
+			eventObject.Size = int64(filer2.TotalSize(entry.Chunks))
+			if eventObject.ETag == "" {
+				eventObject.ETag = filer2.ETag(entry)
+			}
+		}
+	}
+
+	sourceIp, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		sourceIp = r.RemoteAddr
+	}
+	record := newEventRecord(event, time.Now(), bucket, eventObject, s3a.iam.requestPrincipal(r), sourceIp)
+
+	for _, target := range targets {
+		record.S3.ConfigurationId = target.Id
+		data, err := json.Marshal(EventRecords{Records: []EventRecord{record}})
+		if err != nil {
+			glog.Errorf("encode %s event of %s/%s: %v", event, bucket, key, err)
+			return
+		}
+		s3a.notifications.send(target.arn(), bucket+"/"+key, data)
+	}
+}
+
+// requestPrincipal names the identity of an already authenticated request, without checking the signature again
+func (iam *IdentityAccessManagement) requestPrincipal(r *http.Request) string {
+	var accessKey string
+	switch getRequestAuthType(r) {
+	case authTypeSignedV2:
+		accessKey, _ = validateV2AuthHeader(r.Header.Get("Authorization"))
+	case authTypePresignedV2:
+		accessKey = r.URL.Query().Get("AWSAccessKeyId")
+	case authTypeSigned, authTypeStreamingSigned:
+		signV4Values, _ := parseSignV4(r.Header.Get("Authorization"))
+		accessKey = signV4Values.Credential.accessKey
+	case authTypePresigned:
+		preSignV4Values, _ := parsePreSignV4(r.URL.Query())
+		accessKey = preSignV4Values.Credential.accessKey
+	}
+	if accessKey == "" {
+		return "anonymous"
+	}
+	if identity, _, errCode := iam.lookupCredential(accessKey, sessionTokenOf(r)); errCode == ErrNone {
+		return identity.Name
+	}
+	return accessKey
+}
@@ -0,0 +1,125 @@
+package s3api
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+const (
+	// the bucket policy is kept as the original json document in the bucket entry extended attributes
+	extBucketPolicyKey = "s3-policy"
+)
+
+// loadBucketPolicy reads the bucket policy from the filer, returning nil if the bucket has no policy.
+func (s3a *S3ApiServer) loadBucketPolicy(bucket string) (*PolicyDocument, error) {
+	entry, err := s3a.getBucketEntry(bucket)
+	if err == filer_pb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.Extended == nil || len(entry.Extended[extBucketPolicyKey]) == 0 {
+		return nil, nil
+	}
+	return ParsePolicyDocument(entry.Extended[extBucketPolicyKey])
+}
+
+// GetBucketPolicyHandler Get bucket Policy
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketPolicy.html
+func (s3a *S3ApiServer) GetBucketPolicyHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	entry, err := s3a.getBucketEntry(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	if entry.Extended == nil || len(entry.Extended[extBucketPolicyKey]) == 0 {
+		writeErrorResponse(w, ErrNoSuchBucketPolicy, r.URL)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, entry.Extended[extBucketPolicyKey], mimeJSON)
+}
+
+// PutBucketPolicyHandler Put bucket Policy
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketPolicy.html
+func (s3a *S3ApiServer) PutBucketPolicyHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxPolicySize+1))
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	if len(data) > maxPolicySize {
+		writeErrorResponse(w, ErrMalformedPolicy, r.URL)
+		return
+	}
+
+	policy, err := ParsePolicyDocument(data)
+	if err != nil {
+		glog.V(1).Infof("parse bucket %s policy: %v", bucket, err)
+		writeErrorResponse(w, ErrMalformedPolicy, r.URL)
+		return
+	}
+	if err = policy.validateBucketPolicy(bucket); err != nil {
+		glog.V(1).Infof("invalid bucket %s policy: %v", bucket, err)
+		writeErrorResponse(w, ErrMalformedPolicy, r.URL)
+		return
+	}
+
+	if err = s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		entry.Extended[extBucketPolicyKey] = data
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("PutBucketPolicy %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	s3a.iam.invalidateBucketPolicy(bucket)
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+}
+
+// DeleteBucketPolicyHandler Delete bucket Policy
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketPolicy.html
+func (s3a *S3ApiServer) DeleteBucketPolicyHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if err := s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		delete(entry.Extended, extBucketPolicyKey)
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("DeleteBucketPolicy %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	s3a.iam.invalidateBucketPolicy(bucket)
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+}
@@ -34,6 +34,52 @@ const (
 	ErrBucketAlreadyOwnedByYou
 	ErrNoSuchBucket
 	ErrNoSuchUpload
+	ErrNoSuchKey
+	ErrNoSuchVersion
+	ErrInvalidTag
+	ErrNoSuchTagSet
+	ErrNoSuchLifecycleConfiguration
+	ErrNoSuchBucketPolicy
+	ErrMalformedPolicy
+	ErrInvalidExpressionType
+	ErrParseSelectFailure
+	ErrBucketQuotaExceeded
+	ErrInvalidEncryptionAlgorithm
+	ErrInvalidSSECustomerKey
+	ErrSSECustomerKeyMd5Mismatch
+	ErrIncompatibleSSEHeaders
+	ErrSSECustomerKeyRequired
+	ErrSSECustomerKeyMismatch
+	ErrSSENotApplicable
+	ErrPreconditionFailed
+	ErrNotModified
+	ErrNoSuchCORSConfiguration
+	ErrInvalidCORSRequest
+	ErrCORSForbidden
+	ErrObjectLocked
+	ErrMissingObjectLockConfiguration
+	ErrObjectLockConfigurationNotFound
+	ErrNoSuchObjectLockConfiguration
+	ErrObjectLockKeyNotConfigured
+	ErrInvalidRetentionDate
+	ErrInvalidObjectLockHeaders
+	ErrInvalidBucketState
+	ErrInvalidNotificationConfiguration
+	ErrMalformedPOSTRequest
+	ErrPOSTFileRequired
+	ErrMaxPostPreDataLengthExceeded
+	ErrInvalidPolicyDocument
+	ErrPolicyConditionFailed
+	ErrEntityTooSmall
+	ErrEntityTooLarge
+	ErrInvalidCopyPartRange
+	ErrInvalidCopyPartRangeSource
+	ErrInvalidCannedAcl
+	ErrMalformedACLError
+	ErrInvalidToken
+	ErrExpiredToken
+	ErrInvalidStsAction
+	ErrStsValidation
 	ErrInvalidBucketName
 	ErrInvalidDigest
 	ErrInvalidMaxKeys
@@ -99,6 +145,236 @@ var errorCodeResponse = map[ErrorCode]APIError{
 		Description:    "Your previous request to create the named bucket succeeded and you already own it.",
 		HTTPStatusCode: http.StatusConflict,
 	},
+	ErrNoSuchKey: {
+		Code:           "NoSuchKey",
+		Description:    "The specified key does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchVersion: {
+		Code:           "NoSuchVersion",
+		Description:    "The version ID specified in the request does not match an existing version.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidTag: {
+		Code:           "InvalidTag",
+		Description:    "The tag provided was not a valid tag.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchTagSet: {
+		Code:           "NoSuchTagSet",
+		Description:    "The TagSet does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchLifecycleConfiguration: {
+		Code:           "NoSuchLifecycleConfiguration",
+		Description:    "The lifecycle configuration does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchBucketPolicy: {
+		Code:           "NoSuchBucketPolicy",
+		Description:    "The bucket policy does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrMalformedPolicy: {
+		Code:           "MalformedPolicy",
+		Description:    "Policy has invalid resource.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidExpressionType: {
+		Code:           "InvalidExpressionType",
+		Description:    "The ExpressionType is invalid. Only SQL expressions are supported.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrParseSelectFailure: {
+		Code:           "ParseSelectFailure",
+		Description:    "The SQL expression could not be parsed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrBucketQuotaExceeded: {
+		Code:           "BucketQuotaExceeded",
+		Description:    "The bucket quota of size or number of objects is exceeded.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrInvalidEncryptionAlgorithm: {
+		Code:           "InvalidEncryptionAlgorithmError",
+		Description:    "The encryption request you specified is not valid. The valid value is AES256.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidSSECustomerKey: {
+		Code:           "InvalidArgument",
+		Description:    "The secret key was invalid for the specified algorithm.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSSECustomerKeyMd5Mismatch: {
+		Code:           "InvalidArgument",
+		Description:    "The calculated MD5 hash of the key did not match the hash that was provided.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrIncompatibleSSEHeaders: {
+		Code:           "InvalidArgument",
+		Description:    "Server Side Encryption with Customer provided key is incompatible with the encryption method specified.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSSECustomerKeyRequired: {
+		Code:           "InvalidRequest",
+		Description:    "The object was stored using a form of Server Side Encryption. The correct parameters must be provided to retrieve the object.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSSECustomerKeyMismatch: {
+		Code:           "AccessDenied",
+		Description:    "The provided customer key does not match the key used to encrypt the object.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrSSENotApplicable: {
+		Code:           "InvalidRequest",
+		Description:    "The encryption parameters are not applicable to this object.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrPreconditionFailed: {
+		Code:           "PreconditionFailed",
+		Description:    "At least one of the pre-conditions you specified did not hold.",
+		HTTPStatusCode: http.StatusPreconditionFailed,
+	},
+	ErrNotModified: {
+		Code:           "NotModified",
+		Description:    "The object was not modified since the specified time or still matches the specified etag.",
+		HTTPStatusCode: http.StatusNotModified,
+	},
+	ErrNoSuchCORSConfiguration: {
+		Code:           "NoSuchCORSConfiguration",
+		Description:    "The CORS configuration does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidCORSRequest: {
+		Code:           "BadRequest",
+		Description:    "Insufficient information. Origin and Access-Control-Request-Method request headers needed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrCORSForbidden: {
+		Code:           "AccessForbidden",
+		Description:    "CORSResponse: This CORS request is not allowed.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrObjectLocked: {
+		Code:           "AccessDenied",
+		Description:    "Access Denied because object protected by object lock.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrMissingObjectLockConfiguration: {
+		Code:           "InvalidRequest",
+		Description:    "Bucket is missing Object Lock Configuration.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrObjectLockConfigurationNotFound: {
+		Code:           "ObjectLockConfigurationNotFoundError",
+		Description:    "Object Lock configuration does not exist for this bucket.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchObjectLockConfiguration: {
+		Code:           "NoSuchObjectLockConfiguration",
+		Description:    "The specified object does not have a ObjectLock configuration.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrObjectLockKeyNotConfigured: {
+		Code:           "InvalidRequest",
+		Description:    "Object lock is not supported without the [iam] key in security.toml.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidRetentionDate: {
+		Code:           "InvalidArgument",
+		Description:    "The retain until date must be in the future.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidObjectLockHeaders: {
+		Code:           "InvalidArgument",
+		Description:    "x-amz-object-lock-retain-until-date and x-amz-object-lock-mode must both be supplied with valid values.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidBucketState: {
+		Code:           "InvalidBucketState",
+		Description:    "Object Lock requires versioning to be enabled on the bucket.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrInvalidNotificationConfiguration: {
+		Code:           "InvalidArgument",
+		Description:    "Unable to validate the destination configuration, or the events and filters.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrMalformedPOSTRequest: {
+		Code:           "MalformedPOSTRequest",
+		Description:    "The body of your POST request is not well-formed multipart/form-data.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrPOSTFileRequired: {
+		Code:           "InvalidArgument",
+		Description:    "POST requires exactly one file upload per request.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrMaxPostPreDataLengthExceeded: {
+		Code:           "MaxPostPreDataLengthExceeded",
+		Description:    "Your POST request fields preceding the upload file were too large.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidPolicyDocument: {
+		Code:           "InvalidPolicyDocument",
+		Description:    "The content of the form does not meet the conditions specified in the policy document.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrPolicyConditionFailed: {
+		Code:           "AccessDenied",
+		Description:    "Invalid according to Policy: Policy Condition failed.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrEntityTooSmall: {
+		Code:           "EntityTooSmall",
+		Description:    "Your proposed upload is smaller than the minimum allowed object size.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrEntityTooLarge: {
+		Code:           "EntityTooLarge",
+		Description:    "Your proposed upload exceeds the maximum allowed object size.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidCopyPartRange: {
+		Code:           "InvalidArgument",
+		Description:    "The x-amz-copy-source-range value must be of the form bytes=first-last where first and last are the zero-based offsets of the first and last bytes to copy",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidCopyPartRangeSource: {
+		Code:           "InvalidRange",
+		Description:    "The requested range is not satisfiable",
+		HTTPStatusCode: http.StatusRequestedRangeNotSatisfiable,
+	},
+	ErrInvalidCannedAcl: {
+		Code:           "InvalidArgument",
+		Description:    "The canned ACL is not supported.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrMalformedACLError: {
+		Code:           "MalformedACLError",
+		Description:    "The XML you provided was not well-formed or did not validate against our published schema.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidToken: {
+		Code:           "InvalidToken",
+		Description:    "The provided token is malformed or otherwise invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrExpiredToken: {
+		Code:           "ExpiredToken",
+		Description:    "The provided token has expired.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidStsAction: {
+		Code:           "InvalidAction",
+		Description:    "The action or operation requested is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrStsValidation: {
+		Code:           "ValidationError",
+		Description:    "The request parameters are not valid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidBucketName: {
 		Code:           "InvalidBucketName",
 		Description:    "The specified bucket is not valid.",
@@ -60,6 +60,11 @@ func notFoundHandler(w http.ResponseWriter, r *http.Request) {
 
 func writeErrorResponse(w http.ResponseWriter, errorCode ErrorCode, reqURL *url.URL) {
 	apiError := getAPIError(errorCode)
+	if apiError.HTTPStatusCode == http.StatusNotModified {
+		// a 304 response has no body
+		writeResponse(w, apiError.HTTPStatusCode, nil, mimeNone)
+		return
+	}
 	errorResponse := getRESTErrorResponse(apiError, reqURL.Path)
 	encodedErrorResponse := encodeResponse(errorResponse)
 	writeResponse(w, apiError.HTTPStatusCode, encodedErrorResponse, mimeXML)
@@ -1,7 +1,9 @@
 package s3api
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -10,6 +12,10 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/server"
 	"github.com/chrislusf/seaweedfs/weed/util"
 )
 
@@ -38,25 +44,102 @@ func (s3a *S3ApiServer) CopyObjectHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	cannedAcl, errCode := parseAclHeaders(r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	dstSse, errCode := parseSseHeaders(r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	retention, errCode := s3a.objectLockForWrite(dstBucket, r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	srcEntry, srcSse, errCode := s3a.getCopySource(r, srcBucket, srcObject)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
 	dstUrl := fmt.Sprintf("http://%s%s/%s%s?collection=%s",
 		s3a.option.Filer, s3a.option.BucketsPath, dstBucket, dstObject, dstBucket)
-	srcUrl := fmt.Sprintf("http://%s%s/%s%s",
-		s3a.option.Filer, s3a.option.BucketsPath, srcBucket, srcObject)
+	srcPath := fmt.Sprintf("%s/%s%s", s3a.option.BucketsPath, srcBucket, srcObject)
+
+	// the object shares the source chunks if stored the same way, in the same collection,
+	// otherwise the data is copied through the gateway
+	shareChunks := srcBucket == dstBucket && !srcSse.isEncrypted() && !dstSse.isEncrypted()
+
+	var dataReader io.ReadCloser
+	if !shareChunks {
+		dataReader, err = readFromFiler("http://"+s3a.option.Filer+srcPath, "", srcSse)
+		if err != nil {
+			writeErrorResponse(w, ErrInvalidCopySource, r.URL)
+			return
+		}
+		defer dataReader.Close()
+	}
 
-	_, _, dataReader, err := util.DownloadFile(srcUrl)
-	if err != nil {
-		writeErrorResponse(w, ErrInvalidCopySource, r.URL)
+	versioningStatus, versionId, errCode := s3a.prepareVersionedWrite(dstBucket, dstObject)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
 		return
 	}
-	defer dataReader.Close()
 
-	etag, errCode := s3a.putToFiler(r, dstUrl, dataReader)
+	var etag string
+	if shareChunks {
+		etag, errCode = s3a.copyInFiler(r, dstUrl, srcPath, srcEntry, versionId)
+	} else {
+		etag, errCode = s3a.putToFiler(r, dstUrl, dataReader, dstSse, versionId)
+	}
 
 	if errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
 		return
 	}
 
+	if errCode := s3a.finishVersionedWrite(dstBucket, dstObject, versioningStatus); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	if versionId != "" {
+		w.Header().Set(versionIdHeader, versionId)
+	}
+
+	if dstSse.isEncrypted() {
+		dir, name := s3a.objectDirAndName(dstBucket, dstObject)
+		if err := s3a.setSse(dir, name, dstSse); err != nil {
+			glog.Errorf("CopyObject %s%s encryption: %v", dstBucket, dstObject, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+		dstSse.setResponseHeaders(w)
+	}
+
+	if hasObjectLock(retention) {
+		dir, name := s3a.objectDirAndName(dstBucket, dstObject)
+		if err := s3a.setObjectLock(dir, name, retention); err != nil {
+			glog.Errorf("CopyObject %s%s object lock: %v", dstBucket, dstObject, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+	}
+
+	if cannedAcl != "" {
+		if errCode := s3a.setObjectCannedAcl(dstBucket, dstObject, cannedAcl); errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+	} else {
+		s3a.iam.invalidateCannedAcl(dstBucket, dstObject)
+	}
+
+	s3a.notifyObjectEvent(r, eventObjectCreatedCopy, dstBucket, dstObject, versionId, etag)
+
 	setEtag(w, etag)
 
 	response := CopyObjectResult{
@@ -118,27 +201,61 @@ func (s3a *S3ApiServer) CopyObjectPartHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	dstSse, errCode := s3a.getUploadSse(r, dstBucket, uploadID)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	srcEntry, srcSse, errCode := s3a.getCopySource(r, srcBucket, srcObject)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
 	rangeHeader := r.Header.Get("x-amz-copy-source-range")
+	start, stop, errCode := parseCopySourceRange(rangeHeader, int64(filer2.TotalSize(srcEntry.Chunks)))
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
 
-	dstUrl := fmt.Sprintf("http://%s%s/%s/%04d.part?collection=%s",
-		s3a.option.Filer, s3a.genUploadsFolder(dstBucket), uploadID, partID-1, dstBucket)
+	uploadDir := s3a.genUploadsFolder(dstBucket) + "/" + uploadID
+	partName := fmt.Sprintf("%04d.part", partID-1)
 	srcUrl := fmt.Sprintf("http://%s%s/%s%s",
 		s3a.option.Filer, s3a.option.BucketsPath, srcBucket, srcObject)
 
-	dataReader, err := util.ReadUrlAsReaderCloser(srcUrl, rangeHeader)
-	if err != nil {
-		writeErrorResponse(w, ErrInvalidCopySource, r.URL)
-		return
+	// the part shares the source chunks if stored the same way, in the same collection,
+	// so that only the partially covered chunks are copied through the gateway
+	var etag string
+	sharing := false
+	shared, copies := planCopyPart(srcEntry.Chunks, start, stop)
+	if srcBucket == dstBucket && !srcSse.isEncrypted() && !dstSse.isEncrypted() && len(shared) > 0 {
+		srcDir, srcName := s3a.objectDirAndName(srcBucket, srcObject)
+		var sourceChanged bool
+		etag, sourceChanged, errCode = s3a.copyPartWithSharedChunks(r, srcDir, srcName, srcUrl, uploadDir, partName, dstBucket, start, shared, copies)
+		// the source overwritten meanwhile is copied as a whole
+		sharing = !sourceChanged
+	}
+	if !sharing {
+		dstUrl := fmt.Sprintf("http://%s%s/%s?collection=%s",
+			s3a.option.Filer, uploadDir, partName, dstBucket)
+
+		dataReader, err := readFromFiler(srcUrl, rangeHeader, srcSse)
+		if err != nil {
+			writeErrorResponse(w, ErrInvalidCopySource, r.URL)
+			return
+		}
+		defer dataReader.Close()
+
+		etag, errCode = s3a.putToFiler(r, dstUrl, dataReader, dstSse, "")
 	}
-	defer dataReader.Close()
-
-	etag, errCode := s3a.putToFiler(r, dstUrl, dataReader)
 
 	if errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
 		return
 	}
 
+	dstSse.setResponseHeaders(w)
 	setEtag(w, etag)
 
 	response := CopyPartResult{
@@ -149,3 +266,91 @@ func (s3a *S3ApiServer) CopyObjectPartHandler(w http.ResponseWriter, r *http.Req
 	writeSuccessResponseXML(w, encodeResponse(response))
 
 }
+
+// getCopySource evaluates the copy source conditions, and verifies the customer key given for an SSE-C encrypted copy source
+func (s3a *S3ApiServer) getCopySource(r *http.Request, srcBucket, srcObject string) (entry *filer_pb.Entry, sse sseOption, code ErrorCode) {
+
+	// the request is authorized to write the destination, and needs to read the source too
+	if !s3a.iam.isAuthorizedOn(r, ACTION_READ, "s3:GetObject", srcBucket, srcObject) {
+		return nil, sseOption{}, ErrAccessDenied
+	}
+
+	given, errCode := parseSseCustomerHeaders(r.Header, copySourceSseCustomerHeaders)
+	if errCode != ErrNone {
+		return nil, sseOption{}, errCode
+	}
+
+	dir, name := s3a.objectDirAndName(srcBucket, srcObject)
+	entry, err := s3a.getEntry(dir, name)
+	if err != nil || entry == nil || entry.IsDirectory {
+		return nil, sseOption{}, ErrInvalidCopySource
+	}
+	if errCode = checkPreconditions(r.Header, copySourceConditionHeaders, entry); errCode != ErrNone {
+		return nil, sseOption{}, ErrPreconditionFailed
+	}
+	sse = sseFromExtended(entry.Extended)
+	if errCode = sse.checkCustomerKey(given); errCode != ErrNone {
+		return nil, sseOption{}, errCode
+	}
+
+	return entry, sse.withCustomerKey(given), ErrNone
+}
+
+// copyInFiler asks the filer to create the object with the chunks of the source object, without moving the data
+func (s3a *S3ApiServer) copyInFiler(r *http.Request, dstUrl, srcPath string, srcEntry *filer_pb.Entry, versionId string) (etag string, code ErrorCode) {
+
+	req, err := http.NewRequest("PUT", dstUrl+"&cp.from="+url.QueryEscape(srcPath), nil)
+	if err != nil {
+		glog.Errorf("NewRequest %s: %v", dstUrl, err)
+		return "", ErrInternalError
+	}
+	req.Header.Set("X-Forwarded-For", r.RemoteAddr)
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	if versionId != "" {
+		req.Header.Set(filer2.VersionIdHeader, versionId)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		glog.Errorf("copy %s in filer: %v", srcPath, err)
+		return "", ErrInternalError
+	}
+	defer util.CloseResponse(resp)
+
+	var ret weed_server.FilerPostResult
+	if err = json.NewDecoder(resp.Body).Decode(&ret); err != nil {
+		glog.Errorf("copy %s to %s response: %v", srcPath, dstUrl, err)
+		return "", ErrInternalError
+	}
+	if ret.Error != "" {
+		return "", filerWriteErrorCode("copy in filer", ret.Error)
+	}
+
+	return filer2.ETag(srcEntry), ErrNone
+}
+
+// readFromFiler reads an object or a range of it, passing along the customer key of SSE-C encrypted objects
+func readFromFiler(srcUrl string, rangeHeader string, sse sseOption) (io.ReadCloser, error) {
+
+	req, err := http.NewRequest("GET", srcUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rangeHeader != "" {
+		req.Header.Set("Range", rangeHeader)
+	}
+	sse.setFilerHeaders(req.Header)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		util.CloseResponse(resp)
+		return nil, fmt.Errorf("%s: %s", srcUrl, resp.Status)
+	}
+
+	return resp.Body, nil
+}
@@ -8,10 +8,12 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 
+	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
 	"github.com/chrislusf/seaweedfs/weed/server"
@@ -42,6 +44,34 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	tags, err := parseTagsHeader(r.Header.Get(amzTaggingHeader))
+	if err == nil {
+		err = validateTags(tags, maxObjectTags)
+	}
+	if err != nil {
+		glog.V(1).Infof("PutObject %s tagging: %v", r.URL, err)
+		writeErrorResponse(w, ErrInvalidTag, r.URL)
+		return
+	}
+
+	cannedAcl, errCode := parseAclHeaders(r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	sse, errCode := parseSseHeaders(r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	retention, errCode := s3a.objectLockForWrite(bucket, r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
 	rAuthType := getRequestAuthType(r)
 	dataReader := r.Body
 	var s3ErrCode ErrorCode
@@ -54,15 +84,73 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 	}
 	defer dataReader.Close()
 
+	if errCode := s3a.checkObjectWritePreconditions(r, bucket, object); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	versioningStatus, versionId, errCode := s3a.prepareVersionedWrite(bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
 	uploadUrl := fmt.Sprintf("http://%s%s/%s%s", s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
 
-	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader)
+	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader, sse, versionId)
 
 	if errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
 		return
 	}
 
+	if errCode := s3a.finishVersionedWrite(bucket, object, versioningStatus); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	if versionId != "" {
+		w.Header().Set(versionIdHeader, versionId)
+	}
+
+	if len(tags) > 0 {
+		dir, name := s3a.objectDirAndName(bucket, object)
+		if err := s3a.setTags(dir, name, tags); err != nil {
+			glog.Errorf("PutObject %s%s tagging: %v", bucket, object, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+	}
+
+	if sse.isEncrypted() {
+		dir, name := s3a.objectDirAndName(bucket, object)
+		if err := s3a.setSse(dir, name, sse); err != nil {
+			glog.Errorf("PutObject %s%s encryption: %v", bucket, object, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+		sse.setResponseHeaders(w)
+	}
+
+	if hasObjectLock(retention) {
+		dir, name := s3a.objectDirAndName(bucket, object)
+		if err := s3a.setObjectLock(dir, name, retention); err != nil {
+			glog.Errorf("PutObject %s%s object lock: %v", bucket, object, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+	}
+
+	if cannedAcl != "" {
+		if errCode := s3a.setObjectCannedAcl(bucket, object, cannedAcl); errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+	} else {
+		s3a.iam.invalidateCannedAcl(bucket, object)
+	}
+
+	s3a.notifyObjectEvent(r, eventObjectCreatedPut, bucket, object, versionId, etag)
+
 	setEtag(w, etag)
 
 	writeSuccessResponseEmpty(w)
@@ -79,8 +167,11 @@ func (s3a *S3ApiServer) GetObjectHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	destUrl := fmt.Sprintf("http://%s%s/%s%s",
-		s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
+	destUrl, errCode := s3a.resolveObjectUrl(w, r, bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
 
 	s3a.proxyToFiler(w, r, destUrl, passThroughResponse)
 
@@ -92,19 +183,107 @@ func (s3a *S3ApiServer) HeadObjectHandler(w http.ResponseWriter, r *http.Request
 	bucket := vars["bucket"]
 	object := getObject(vars)
 
-	destUrl := fmt.Sprintf("http://%s%s/%s%s",
-		s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
+	destUrl, errCode := s3a.resolveObjectUrl(w, r, bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
 
 	s3a.proxyToFiler(w, r, destUrl, passThroughResponse)
 
 }
 
+// resolveObjectUrl finds the filer url of the requested object version, evaluates the conditional headers,
+// verifies the customer key of SSE-C encrypted objects, and sets the version and encryption headers.
+func (s3a *S3ApiServer) resolveObjectUrl(w http.ResponseWriter, r *http.Request, bucket, object string) (destUrl string, code ErrorCode) {
+
+	versionId := r.URL.Query().Get("versionId")
+
+	dir, name, entry, errCode := s3a.resolveObjectVersion(bucket, object, versionId)
+	if errCode == ErrNoSuchKey {
+		// let the filer respond
+		return fmt.Sprintf("http://%s%s/%s%s",
+			s3a.option.Filer, s3a.option.BucketsPath, bucket, object), ErrNone
+	}
+	if errCode != ErrNone {
+		return "", errCode
+	}
+
+	if versionId != "" || len(entry.Extended[extVersionIdKey]) > 0 {
+		w.Header().Set(versionIdHeader, getVersionId(entry))
+	}
+	if isDeleteMarker(entry) {
+		w.Header().Set(deleteMarkerHeader, deleteMarkerTrueValue)
+		return "", ErrMethodNotAllowed
+	}
+
+	if errCode := checkPreconditions(r.Header, conditionHeaders, entry); errCode != ErrNone {
+		if errCode == ErrNotModified {
+			setEtag(w, filer2.ETag(entry))
+			setLastModified(w, entry)
+		}
+		return "", errCode
+	}
+	if tagCount := len(tagsFromExtended(entry.Extended)); tagCount > 0 {
+		w.Header().Set(amzTaggingCountHeader, strconv.Itoa(tagCount))
+	}
+	setObjectLockResponseHeaders(w, entry.Extended)
+
+	given, errCode := parseSseCustomerHeaders(r.Header, sseCustomerHeaders)
+	if errCode != ErrNone {
+		return "", errCode
+	}
+	sse := sseFromExtended(entry.Extended)
+	if errCode = sse.checkCustomerKey(given); errCode != ErrNone {
+		return "", errCode
+	}
+	sse.setResponseHeaders(w)
+
+	return fmt.Sprintf("http://%s%s/%s", s3a.option.Filer, dir, name), ErrNone
+}
+
 func (s3a *S3ApiServer) DeleteObjectHandler(w http.ResponseWriter, r *http.Request) {
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
 	object := getObject(vars)
 
+	versionId := r.URL.Query().Get("versionId")
+
+	versioningStatus, err := s3a.getBucketVersioning(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	if versionId != "" && s3a.iam.canBypassGovernance(r, bucket, vars["object"]) {
+		if errCode := s3a.releaseGovernanceRetention(bucket, object, versionId); errCode != ErrNone && errCode != ErrNoSuchVersion {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+	}
+
+	if versioningStatus != "" || versionId != "" {
+		resultVersionId, deleteMarker, errCode := s3a.deleteObjectVersion(bucket, object, versioningStatus, versionId)
+		if errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+		w.Header().Set(versionIdHeader, resultVersionId)
+		if deleteMarker {
+			w.Header().Set(deleteMarkerHeader, deleteMarkerTrueValue)
+			s3a.notifyObjectEvent(r, eventObjectRemovedDeleteMarkerCreated, bucket, object, resultVersionId, "")
+		} else {
+			s3a.notifyObjectEvent(r, eventObjectRemovedDelete, bucket, object, resultVersionId, "")
+		}
+		writeResponse(w, http.StatusNoContent, nil, mimeNone)
+		return
+	}
+
 	destUrl := fmt.Sprintf("http://%s%s/%s%s",
 		s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
 
@@ -112,6 +291,9 @@ func (s3a *S3ApiServer) DeleteObjectHandler(w http.ResponseWriter, r *http.Reque
 		for k, v := range proxyResonse.Header {
 			w.Header()[k] = v
 		}
+		if proxyResonse.StatusCode < http.StatusMultipleChoices {
+			s3a.notifyObjectEvent(r, eventObjectRemovedDelete, bucket, object, "", "")
+		}
 		w.WriteHeader(http.StatusNoContent)
 	})
 
@@ -119,7 +301,10 @@ func (s3a *S3ApiServer) DeleteObjectHandler(w http.ResponseWriter, r *http.Reque
 
 /// ObjectIdentifier carries key name for the object to delete.
 type ObjectIdentifier struct {
-	ObjectName string `xml:"Key"`
+	ObjectName            string `xml:"Key"`
+	VersionId             string `xml:"VersionId,omitempty"`
+	DeleteMarker          bool   `xml:"DeleteMarker,omitempty"`
+	DeleteMarkerVersionId string `xml:"DeleteMarkerVersionId,omitempty"`
 }
 
 // DeleteObjectsRequest - xml carrying the object key names which needs to be deleted.
@@ -169,9 +354,55 @@ func (s3a *S3ApiServer) DeleteMultipleObjectsHandler(w http.ResponseWriter, r *h
 	var deletedObjects []ObjectIdentifier
 	var deleteErrors []DeleteError
 
+	versioningStatus, err := s3a.getBucketVersioning(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	isAuthorized := s3a.iam.authorizerOf(r)
+
 	s3a.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		for _, object := range deleteObjects.Objects {
+			s3Action := "s3:DeleteObject"
+			if object.VersionId != "" {
+				s3Action = "s3:DeleteObjectVersion"
+			}
+			if !isAuthorized(ACTION_WRITE, s3Action, bucket, object.ObjectName) {
+				apiError := getAPIError(ErrAccessDenied)
+				deleteErrors = append(deleteErrors, DeleteError{
+					Code:    apiError.Code,
+					Message: apiError.Description,
+					Key:     object.ObjectName,
+				})
+				continue
+			}
+			if versioningStatus != "" || object.VersionId != "" {
+				resultVersionId, deleteMarker, errCode := s3a.deleteObjectVersion(bucket, object.ObjectName, versioningStatus, object.VersionId)
+				if errCode != ErrNone {
+					apiError := getAPIError(errCode)
+					deleteErrors = append(deleteErrors, DeleteError{
+						Code:    apiError.Code,
+						Message: apiError.Description,
+						Key:     object.ObjectName,
+					})
+					continue
+				}
+				if deleteMarker {
+					object.DeleteMarker = true
+					object.DeleteMarkerVersionId = resultVersionId
+					s3a.notifyObjectEvent(r, eventObjectRemovedDeleteMarkerCreated, bucket, object.ObjectName, resultVersionId, "")
+				} else {
+					s3a.notifyObjectEvent(r, eventObjectRemovedDelete, bucket, object.ObjectName, resultVersionId, "")
+				}
+				deletedObjects = append(deletedObjects, object)
+				continue
+			}
 			lastSeparator := strings.LastIndex(object.ObjectName, "/")
 			parentDirectoryPath, entryName, isDeleteData, isRecursive := "/", object.ObjectName, true, true
 			if lastSeparator > 0 && lastSeparator+1 < len(object.ObjectName) {
@@ -183,6 +414,7 @@ func (s3a *S3ApiServer) DeleteMultipleObjectsHandler(w http.ResponseWriter, r *h
 			err := doDeleteEntry(client, parentDirectoryPath, entryName, isDeleteData, isRecursive)
 			if err == nil {
 				deletedObjects = append(deletedObjects, object)
+				s3a.notifyObjectEvent(r, eventObjectRemovedDelete, bucket, object.ObjectName, "", "")
 			} else {
 				deleteErrors = append(deleteErrors, DeleteError{
 					Code:    "",
@@ -224,6 +456,8 @@ func (s3a *S3ApiServer) proxyToFiler(w http.ResponseWriter, r *http.Request, des
 			proxyReq.Header.Add(header, value)
 		}
 	}
+	// the customer key is already verified against the object
+	sseOption{CustomerKey: r.Header.Get(sseCustomerHeaders.key)}.setFilerHeaders(proxyReq.Header)
 
 	resp, postErr := client.Do(proxyReq)
 
@@ -245,12 +479,12 @@ func passThroughResponse(proxyResonse *http.Response, w http.ResponseWriter) {
 	io.Copy(w, proxyResonse.Body)
 }
 
-func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader io.Reader) (etag string, code ErrorCode) {
+func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader io.Reader, sse sseOption, versionId string) (etag string, code ErrorCode) {
 
 	hash := md5.New()
 	var body = io.TeeReader(dataReader, hash)
 
-	proxyReq, err := http.NewRequest("PUT", uploadUrl, body)
+	proxyReq, err := http.NewRequest("PUT", sse.filerUrl(uploadUrl), body)
 
 	if err != nil {
 		glog.Errorf("NewRequest %s: %v", uploadUrl, err)
@@ -265,6 +499,11 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 			proxyReq.Header.Add(header, value)
 		}
 	}
+	sse.setFilerHeaders(proxyReq.Header)
+	proxyReq.Header.Del(filer2.VersionIdHeader)
+	if versionId != "" {
+		proxyReq.Header.Set(filer2.VersionIdHeader, versionId)
+	}
 
 	resp, postErr := client.Do(proxyReq)
 
@@ -288,13 +527,27 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 		return "", ErrInternalError
 	}
 	if ret.Error != "" {
-		glog.Errorf("upload to filer error: %v", ret.Error)
-		return "", ErrInternalError
+		return "", filerWriteErrorCode("upload to filer", ret.Error)
 	}
 
 	return etag, ErrNone
 }
 
+// filerWriteErrorCode maps the error passed along by the filer for a write
+func filerWriteErrorCode(action string, errorMessage string) ErrorCode {
+	if strings.Contains(errorMessage, "EEXIST") {
+		// created by another request with "If-None-Match: *"
+		glog.V(1).Infof("%s: %v", action, errorMessage)
+		return ErrPreconditionFailed
+	}
+	if filer2.IsBucketQuotaExceeded(errorMessage) {
+		glog.V(1).Infof("%s: %v", action, errorMessage)
+		return ErrBucketQuotaExceeded
+	}
+	glog.Errorf("%s error: %v", action, errorMessage)
+	return ErrInternalError
+}
+
 func setEtag(w http.ResponseWriter, etag string) {
 	if etag != "" {
 		if strings.HasPrefix(etag, "\"") {
@@ -0,0 +1,257 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// GetObjectLockConfigurationHandler Get bucket Object Lock configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLockConfiguration.html
+func (s3a *S3ApiServer) GetObjectLockConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	entry, err := s3a.getBucketEntry(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	if entry.Extended == nil || len(entry.Extended[extBucketObjectLockKey]) == 0 {
+		writeErrorResponse(w, ErrObjectLockConfigurationNotFound, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, entry.Extended[extBucketObjectLockKey])
+}
+
+// PutObjectLockConfigurationHandler Put bucket Object Lock configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLockConfiguration.html
+func (s3a *S3ApiServer) PutObjectLockConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if errCode := s3a.checkObjectLockKey(); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxObjectLockConfigurationSize+1))
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	if len(data) > maxObjectLockConfigurationSize {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	if _, err = parseObjectLockConfiguration(data); err != nil {
+		glog.V(1).Infof("parse bucket %s object lock: %v", bucket, err)
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	versioningStatus, err := s3a.getBucketVersioning(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	if versioningStatus != VersioningEnabled {
+		writeErrorResponse(w, ErrInvalidBucketState, r.URL)
+		return
+	}
+
+	if err = s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		entry.Extended[extBucketObjectLockKey] = data
+	}); err != nil {
+		glog.Errorf("PutObjectLockConfiguration %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseEmpty(w)
+}
+
+// GetObjectRetentionHandler Get object retention
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectRetention.html
+func (s3a *S3ApiServer) GetObjectRetentionHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	_, _, entry, errCode := s3a.getObjectLockVersion(r, bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	retention := filer2.GetObjectRetention(entry.Extended)
+	if retention.Mode == "" {
+		writeErrorResponse(w, ErrNoSuchObjectLockConfiguration, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(Retention{
+		Mode:            retention.Mode,
+		RetainUntilDate: retention.RetainUntil.UTC(),
+	}))
+}
+
+// PutObjectRetentionHandler Put object retention
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectRetention.html
+func (s3a *S3ApiServer) PutObjectRetentionHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	retention := &Retention{}
+	if errCode := readObjectLockXml(r, retention); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	if errCode := retention.validate(time.Now()); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	bypassGovernance := s3a.iam.canBypassGovernance(r, bucket, vars["object"])
+
+	s3a.updateObjectVersionLock(w, r, bucket, object, func(extended map[string][]byte) {
+		filer2.ObjectRetention{
+			Mode:        retention.Mode,
+			RetainUntil: retention.RetainUntilDate,
+		}.SetRetention(extended)
+		if bypassGovernance {
+			extended[filer2.BypassGovernanceKey] = []byte("true")
+		}
+	})
+}
+
+// GetObjectLegalHoldHandler Get object legal hold
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLegalHold.html
+func (s3a *S3ApiServer) GetObjectLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	_, _, entry, errCode := s3a.getObjectLockVersion(r, bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(LegalHold{
+		Status: legalHoldStatus(filer2.GetObjectRetention(entry.Extended).LegalHold),
+	}))
+}
+
+// PutObjectLegalHoldHandler Put object legal hold
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLegalHold.html
+func (s3a *S3ApiServer) PutObjectLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	legalHold := &LegalHold{}
+	if errCode := readObjectLockXml(r, legalHold); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	on, ok := parseLegalHoldStatus(legalHold.Status)
+	if !ok {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	s3a.updateObjectVersionLock(w, r, bucket, object, func(extended map[string][]byte) {
+		filer2.ObjectRetention{LegalHold: on}.SetLegalHold(extended)
+	})
+}
+
+// getObjectLockVersion finds the object version for the retention and legal hold requests,
+// which are only supported if the bucket has object lock enabled
+func (s3a *S3ApiServer) getObjectLockVersion(r *http.Request, bucket, object string) (dir, name string, entry *filer_pb.Entry, code ErrorCode) {
+
+	config, err := s3a.getBucketObjectLock(bucket)
+	if err == filer_pb.ErrNotFound {
+		return "", "", nil, ErrNoSuchBucket
+	}
+	if err != nil {
+		glog.Errorf("get bucket %s object lock: %v", bucket, err)
+		return "", "", nil, ErrInternalError
+	}
+	if config == nil {
+		return "", "", nil, ErrMissingObjectLockConfiguration
+	}
+
+	dir, name, entry, code = s3a.resolveObjectVersion(bucket, object, r.URL.Query().Get("versionId"))
+	if code != ErrNone {
+		return "", "", nil, code
+	}
+	if isDeleteMarker(entry) {
+		return "", "", nil, ErrMethodNotAllowed
+	}
+
+	return dir, name, entry, ErrNone
+}
+
+func (s3a *S3ApiServer) updateObjectVersionLock(w http.ResponseWriter, r *http.Request, bucket, object string, fn func(extended map[string][]byte)) {
+
+	if errCode := s3a.checkObjectLockKey(); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	dir, name, _, errCode := s3a.getObjectLockVersion(r, bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if err := s3a.updateObjectLock(dir, name, fn); err != nil {
+		glog.V(1).Infof("update %s/%s object lock: %v", dir, name, err)
+		writeErrorResponse(w, objectLockErrorCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseEmpty(w)
+}
+
+func readObjectLockXml(r *http.Request, v interface{}) ErrorCode {
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxObjectLockConfigurationSize+1))
+	if err != nil {
+		return ErrInternalError
+	}
+	if len(data) > maxObjectLockConfigurationSize {
+		return ErrMalformedXML
+	}
+	if err = xml.Unmarshal(data, v); err != nil {
+		glog.V(1).Infof("parse %s: %v", r.URL, err)
+		return ErrMalformedXML
+	}
+	return ErrNone
+}
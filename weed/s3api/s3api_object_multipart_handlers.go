@@ -10,6 +10,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
 )
 
 const (
@@ -26,16 +29,61 @@ func (s3a *S3ApiServer) NewMultipartUploadHandler(w http.ResponseWriter, r *http
 	bucket = vars["bucket"]
 	object = vars["object"]
 
-	response, errCode := s3a.createMultipartUpload(&s3.CreateMultipartUploadInput{
-		Bucket: aws.String(bucket),
-		Key:    objectKey(aws.String(object)),
-	})
+	tagging := r.Header.Get(amzTaggingHeader)
+	tags, err := parseTagsHeader(tagging)
+	if err == nil {
+		err = validateTags(tags, maxObjectTags)
+	}
+	if err != nil {
+		glog.V(1).Infof("NewMultipartUpload %s tagging: %v", r.URL, err)
+		writeErrorResponse(w, ErrInvalidTag, r.URL)
+		return
+	}
+
+	cannedAcl, errCode := parseAclHeaders(r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	sse, errCode := parseSseHeaders(r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	retention, errCode := s3a.objectLockForWrite(bucket, r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	input := &s3.CreateMultipartUploadInput{
+		Bucket:               aws.String(bucket),
+		ACL:                  aws.String(cannedAcl),
+		Key:                  objectKey(aws.String(object)),
+		Tagging:              aws.String(tagging),
+		ServerSideEncryption: aws.String(sse.Algorithm),
+		SSECustomerAlgorithm: aws.String(sse.CustomerAlgorithm),
+		SSECustomerKeyMD5:    aws.String(sse.CustomerKeyMd5),
+	}
+	if retention.Mode != "" {
+		input.ObjectLockMode = aws.String(retention.Mode)
+		input.ObjectLockRetainUntilDate = aws.Time(retention.RetainUntil)
+	}
+	if retention.LegalHold {
+		input.ObjectLockLegalHoldStatus = aws.String(filer2.LegalHoldOn)
+	}
+
+	response, errCode := s3a.createMultipartUpload(input)
 
 	if errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
 		return
 	}
 
+	sse.setResponseHeaders(w)
+
 	// println("NewMultipartUploadHandler", string(encodeResponse(response)))
 
 	writeSuccessResponseXML(w, encodeResponse(response))
@@ -51,11 +99,17 @@ func (s3a *S3ApiServer) CompleteMultipartUploadHandler(w http.ResponseWriter, r
 	// Get upload id.
 	uploadID, _, _, _ := getObjectResources(r.URL.Query())
 
+	versioningStatus, versionId, errCode := s3a.prepareVersionedWrite(bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
 	response, errCode := s3a.completeMultipartUpload(&s3.CompleteMultipartUploadInput{
 		Bucket:   aws.String(bucket),
 		Key:      objectKey(aws.String(object)),
 		UploadId: aws.String(uploadID),
-	})
+	}, versionId)
 
 	// println("CompleteMultipartUploadHandler", string(encodeResponse(response)), errCode)
 
@@ -64,6 +118,17 @@ func (s3a *S3ApiServer) CompleteMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if errCode := s3a.finishVersionedWrite(bucket, object, versioningStatus); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	if versionId != "" {
+		w.Header().Set(versionIdHeader, versionId)
+	}
+	s3a.iam.invalidateCannedAcl(bucket, object)
+
+	s3a.notifyObjectEvent(r, eventObjectCreatedCompleteMultipartUpload, bucket, object, versionId, aws.StringValue(response.ETag))
+
 	writeSuccessResponseXML(w, encodeResponse(response))
 
 }
@@ -176,9 +241,9 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 	rAuthType := getRequestAuthType(r)
 
 	uploadID := r.URL.Query().Get("uploadId")
-	exists, err := s3a.exists(s3a.genUploadsFolder(bucket), uploadID, true)
-	if !exists {
-		writeErrorResponse(w, ErrNoSuchUpload, r.URL)
+	sse, errCode := s3a.getUploadSse(r, bucket, uploadID)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
 		return
 	}
 
@@ -207,19 +272,40 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 	uploadUrl := fmt.Sprintf("http://%s%s/%s/%04d.part?collection=%s",
 		s3a.option.Filer, s3a.genUploadsFolder(bucket), uploadID, partID-1, bucket)
 
-	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader)
+	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader, sse, "")
 
 	if errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
 		return
 	}
 
+	sse.setResponseHeaders(w)
 	setEtag(w, etag)
 
 	writeSuccessResponseEmpty(w)
 
 }
 
+// getUploadSse finds the encryption of a multipart upload, and verifies the customer key given for the part
+func (s3a *S3ApiServer) getUploadSse(r *http.Request, bucket, uploadID string) (sse sseOption, code ErrorCode) {
+
+	uploadEntry, err := s3a.getEntry(s3a.genUploadsFolder(bucket), uploadID)
+	if err != nil || uploadEntry == nil || !uploadEntry.IsDirectory {
+		return sseOption{}, ErrNoSuchUpload
+	}
+
+	given, errCode := parseSseCustomerHeaders(r.Header, sseCustomerHeaders)
+	if errCode != ErrNone {
+		return sseOption{}, errCode
+	}
+	sse = sseFromExtended(uploadEntry.Extended)
+	if errCode = sse.checkCustomerKey(given); errCode != ErrNone {
+		return sseOption{}, errCode
+	}
+
+	return sse.withCustomerKey(given), ErrNone
+}
+
 func (s3a *S3ApiServer) genUploadsFolder(bucket string) string {
 	return fmt.Sprintf("%s/%s/.uploads", s3a.option.BucketsPath, bucket)
 }
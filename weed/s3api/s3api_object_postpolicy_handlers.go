@@ -0,0 +1,234 @@
+package s3api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+// PostPolicyBucketHandler uploads an object from a browser form, authorized by the signed policy
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPOST.html
+func (s3a *S3ApiServer) PostPolicyBucketHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		writeErrorResponse(w, ErrMalformedPOSTRequest, r.URL)
+		return
+	}
+	form, file, errCode := readPostForm(reader)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	key := strings.Replace(form["key"], postFilenameParam, file.FileName(), -1)
+	if key == "" {
+		writeErrorResponse(w, ErrMalformedPOSTRequest, r.URL)
+		return
+	}
+	form["key"] = key
+	form["bucket"] = bucket
+	object := "/" + strings.TrimPrefix(key, "/")
+
+	if errCode := s3a.authPostPolicy(form, bucket, key); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	minSize, maxSize := int64(0), int64(-1)
+	if form[postPolicyField] != "" {
+		policy, err := parsePostPolicy(form[postPolicyField])
+		if err != nil {
+			glog.V(1).Infof("post to %s: %v", bucket, err)
+			writeErrorResponse(w, ErrInvalidPolicyDocument, r.URL)
+			return
+		}
+		if minSize, maxSize, err = policy.check(form, time.Now()); err != nil {
+			glog.V(1).Infof("post %s/%s: %v", bucket, key, err)
+			writeErrorResponse(w, ErrPolicyConditionFailed, r.URL)
+			return
+		}
+	}
+
+	// the form fields take the place of the request headers of PutObject
+	header := postFormHeader(form)
+	if header.Get("Content-Type") == "" && file.Header.Get("Content-Type") != "" {
+		header.Set("Content-Type", file.Header.Get("Content-Type"))
+	}
+
+	cannedAcl, errCode := parseAclHeaders(header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	sse, errCode := parseSseHeaders(header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	retention, errCode := s3a.objectLockForWrite(bucket, header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	versioningStatus, versionId, errCode := s3a.prepareVersionedWrite(bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	uploadUrl := fmt.Sprintf("http://%s%s/%s%s", s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
+
+	uploadReq := *r
+	uploadReq.Header = header
+	dataReader := &contentLengthRangeReader{reader: file, minSize: minSize, maxSize: maxSize}
+
+	etag, errCode := s3a.putToFiler(&uploadReq, uploadUrl, dataReader, sse, versionId)
+
+	if dataReader.code != ErrNone {
+		writeErrorResponse(w, dataReader.code, r.URL)
+		return
+	}
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if errCode := s3a.finishVersionedWrite(bucket, object, versioningStatus); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	if versionId != "" {
+		w.Header().Set(versionIdHeader, versionId)
+	}
+
+	if sse.isEncrypted() {
+		dir, name := s3a.objectDirAndName(bucket, object)
+		if err := s3a.setSse(dir, name, sse); err != nil {
+			glog.Errorf("PostPolicy %s%s encryption: %v", bucket, object, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+		sse.setResponseHeaders(w)
+	}
+
+	if hasObjectLock(retention) {
+		dir, name := s3a.objectDirAndName(bucket, object)
+		if err := s3a.setObjectLock(dir, name, retention); err != nil {
+			glog.Errorf("PostPolicy %s%s object lock: %v", bucket, object, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+	}
+
+	if cannedAcl != "" {
+		if errCode := s3a.setObjectCannedAcl(bucket, object, cannedAcl); errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+	} else {
+		s3a.iam.invalidateCannedAcl(bucket, object)
+	}
+
+	s3a.notifyObjectEvent(r, eventObjectCreatedPost, bucket, object, versionId, etag)
+
+	writePostResponse(w, r, form, bucket, key, etag)
+}
+
+// authPostPolicy checks the policy signature, and whether the signer, or anonymous users without signature, can write the object
+func (s3a *S3ApiServer) authPostPolicy(form map[string]string, bucket, key string) ErrorCode {
+
+	if !s3a.iam.isEnabled() {
+		return ErrNone
+	}
+
+	var identity *Identity
+	if form[postSignatureV4] != "" || form["x-amz-credential"] != "" {
+		if form[postPolicyField] == "" {
+			return ErrMissingFields
+		}
+		var errCode ErrorCode
+		if identity, errCode = s3a.iam.doesPolicySignatureV4Match(form); errCode != ErrNone {
+			return errCode
+		}
+	} else if form["signature"] != "" || form["awsaccesskeyid"] != "" {
+		return ErrSignatureVersionNotSupported
+	}
+
+	if !s3a.iam.isAllowed(identity, ACTION_WRITE, "s3:PutObject", bucket, key) {
+		return ErrAccessDenied
+	}
+
+	return ErrNone
+}
+
+// postFormHeader picks the form fields describing the object, which PutObject reads from the request headers
+func postFormHeader(form map[string]string) http.Header {
+	header := make(http.Header)
+	for name, value := range form {
+		switch {
+		case name == "acl":
+			header.Set(amzAclHeader, value)
+		case name == "content-type",
+			strings.HasPrefix(name, "x-amz-server-side-encryption"),
+			strings.HasPrefix(name, "x-amz-object-lock-"):
+			header.Set(name, value)
+		}
+	}
+	return header
+}
+
+// writePostResponse redirects to success_action_redirect, or responds with success_action_status, 204 by default
+func writePostResponse(w http.ResponseWriter, r *http.Request, form map[string]string, bucket, key, etag string) {
+
+	setEtag(w, etag)
+
+	redirect := form["success_action_redirect"]
+	if redirect == "" {
+		redirect = form["redirect"]
+	}
+	if redirect != "" {
+		if redirectUrl, err := url.Parse(redirect); err == nil && redirectUrl.IsAbs() {
+			query := redirectUrl.Query()
+			query.Set("bucket", bucket)
+			query.Set("key", key)
+			query.Set("etag", "\""+etag+"\"")
+			redirectUrl.RawQuery = query.Encode()
+			http.Redirect(w, r, redirectUrl.String(), http.StatusSeeOther)
+			return
+		}
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	location := fmt.Sprintf("%s://%s%s/%s", scheme, r.Host, strings.TrimSuffix(r.URL.Path, "/"), encodePath(key))
+	w.Header().Set("Location", location)
+
+	switch form["success_action_status"] {
+	case "200":
+		writeSuccessResponseEmpty(w)
+	case "201":
+		writeResponse(w, http.StatusCreated, encodeResponse(PostResponse{
+			Location: location,
+			Bucket:   bucket,
+			Key:      key,
+			ETag:     "\"" + etag + "\"",
+		}), mimeXML)
+	default:
+		writeResponse(w, http.StatusNoContent, nil, mimeNone)
+	}
+}
@@ -0,0 +1,526 @@
+package s3api
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/query/csv"
+	"github.com/chrislusf/seaweedfs/weed/query/json"
+	"github.com/chrislusf/seaweedfs/weed/query/sqlparser"
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
+)
+
+const (
+	// number of chunks queried in parallel for one request
+	selectConcurrency = 4
+	// the max size of one record, for the volume servers to read the records spanning the chunks
+	selectMaxRecordSize = 1024 * 1024
+)
+
+type SelectObjectContentRequest struct {
+	XMLName             xml.Name                  `xml:"SelectObjectContentRequest"`
+	Expression          string                    `xml:"Expression"`
+	ExpressionType      string                    `xml:"ExpressionType"`
+	InputSerialization  SelectInputSerialization  `xml:"InputSerialization"`
+	OutputSerialization SelectOutputSerialization `xml:"OutputSerialization"`
+}
+
+type SelectInputSerialization struct {
+	CompressionType string           `xml:"CompressionType"`
+	CSV             *SelectCSVInput  `xml:"CSV"`
+	JSON            *SelectJSONInput `xml:"JSON"`
+	Parquet         *struct{}        `xml:"Parquet"`
+}
+
+type SelectCSVInput struct {
+	FileHeaderInfo             string `xml:"FileHeaderInfo"`
+	RecordDelimiter            string `xml:"RecordDelimiter"`
+	FieldDelimiter             string `xml:"FieldDelimiter"`
+	QuoteCharacter             string `xml:"QuoteCharacter"`
+	QuoteEscapeCharacter       string `xml:"QuoteEscapeCharacter"`
+	Comments                   string `xml:"Comments"`
+	AllowQuotedRecordDelimiter bool   `xml:"AllowQuotedRecordDelimiter"`
+}
+
+type SelectJSONInput struct {
+	Type string `xml:"Type"`
+}
+
+type SelectOutputSerialization struct {
+	CSV  *SelectCSVOutput  `xml:"CSV"`
+	JSON *SelectJSONOutput `xml:"JSON"`
+}
+
+type SelectCSVOutput struct {
+	QuoteFields          string `xml:"QuoteFields"`
+	RecordDelimiter      string `xml:"RecordDelimiter"`
+	FieldDelimiter       string `xml:"FieldDelimiter"`
+	QuoteCharacter       string `xml:"QuoteCharacter"`
+	QuoteEscapeCharacter string `xml:"QuoteEscapeCharacter"`
+}
+
+type SelectJSONOutput struct {
+	RecordDelimiter string `xml:"RecordDelimiter"`
+}
+
+type SelectStats struct {
+	XMLName        xml.Name `xml:"Stats"`
+	BytesScanned   int64    `xml:"BytesScanned"`
+	BytesProcessed int64    `xml:"BytesProcessed"`
+	BytesReturned  int64    `xml:"BytesReturned"`
+}
+
+// SelectObjectContentHandler filters the object content with a SQL expression
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_SelectObjectContent.html
+// Each chunk is queried on a volume server holding it, which also reads the records spanning the next chunks.
+func (s3a *S3ApiServer) SelectObjectContentHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	input := &SelectObjectContentRequest{}
+	if err := xml.NewDecoder(r.Body).Decode(input); err != nil {
+		glog.V(1).Infof("unmarshal select request %s%s: %v", bucket, object, err)
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	queryRequest, stmt, errCode := toQueryRequest(input)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	_, _, entry, errCode := s3a.resolveObjectVersion(bucket, object, r.URL.Query().Get("versionId"))
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	if isDeleteMarker(entry) {
+		writeErrorResponse(w, ErrNoSuchKey, r.URL)
+		return
+	}
+
+	chunks, _ := filer2.CompactFileChunks(entry.Chunks)
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].Offset < chunks[j].Offset
+	})
+	for _, chunk := range chunks {
+		if len(chunk.CipherKey) > 0 {
+			writeErrorResponse(w, ErrNotImplemented, r.URL)
+			return
+		}
+	}
+
+	locations, err := s3a.lookupChunkLocations(chunks)
+	if err != nil {
+		glog.Errorf("select %s%s lookup chunks: %v", bucket, object, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	setCommonHeaders(w)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+
+	stats, err := s3a.streamSelectResults(w, toQueryParts(chunks, queryRequest), locations, queryRequest, stmt)
+	if err != nil {
+		glog.Errorf("select %s%s: %v", bucket, object, err)
+		writeErrorEvent(w, "InternalError", err.Error())
+		return
+	}
+
+	if err = writeStatsEvent(w, encodeResponse(stats)); err != nil {
+		return
+	}
+	writeEndEvent(w)
+}
+
+// toQueryRequest translates the select request into the volume server query, without the file ids.
+// The expression is parsed here to fail early, and parsed again by the volume servers.
+func toQueryRequest(input *SelectObjectContentRequest) (queryRequest *volume_server_pb.QueryRequest, stmt *sqlparser.Select, code ErrorCode) {
+
+	if input.ExpressionType != "SQL" {
+		return nil, nil, ErrInvalidExpressionType
+	}
+
+	stmt, err := sqlparser.Parse(input.Expression)
+	if err != nil {
+		glog.V(1).Infof("parse select expression %q: %v", input.Expression, err)
+		return nil, nil, ErrParseSelectFailure
+	}
+
+	queryRequest = &volume_server_pb.QueryRequest{
+		Selections: stmt.Projections,
+		Expression: input.Expression,
+		InputSerialization: &volume_server_pb.QueryRequest_InputSerialization{
+			CompressionType: strings.ToUpper(input.InputSerialization.CompressionType),
+		},
+		OutputSerialization: &volume_server_pb.QueryRequest_OutputSerialization{},
+	}
+
+	in := input.InputSerialization
+	switch {
+	case in.CSV != nil:
+		queryRequest.InputSerialization.CsvInput = &volume_server_pb.QueryRequest_InputSerialization_CSVInput{
+			FileHeaderInfo:             strings.ToUpper(in.CSV.FileHeaderInfo),
+			RecordDelimiter:            in.CSV.RecordDelimiter,
+			FieldDelimiter:             in.CSV.FieldDelimiter,
+			QuoteCharactoer:            in.CSV.QuoteCharacter,
+			QuoteEscapeCharacter:       in.CSV.QuoteEscapeCharacter,
+			Comments:                   in.CSV.Comments,
+			AllowQuotedRecordDelimiter: in.CSV.AllowQuotedRecordDelimiter,
+		}
+	case in.JSON != nil:
+		queryRequest.InputSerialization.JsonInput = &volume_server_pb.QueryRequest_InputSerialization_JSONInput{
+			Type: strings.ToUpper(in.JSON.Type),
+		}
+	default:
+		return nil, nil, ErrNotImplemented
+	}
+
+	out := input.OutputSerialization
+	switch {
+	case out.CSV != nil:
+		queryRequest.OutputSerialization.CsvOutput = &volume_server_pb.QueryRequest_OutputSerialization_CSVOutput{
+			QuoteFields:          strings.ToUpper(out.CSV.QuoteFields),
+			RecordDelimiter:      out.CSV.RecordDelimiter,
+			FieldDelimiter:       out.CSV.FieldDelimiter,
+			QuoteCharactoer:      out.CSV.QuoteCharacter,
+			QuoteEscapeCharacter: out.CSV.QuoteEscapeCharacter,
+		}
+	case out.JSON != nil:
+		queryRequest.OutputSerialization.JsonOutput = &volume_server_pb.QueryRequest_OutputSerialization_JSONOutput{
+			RecordDelimiter: out.JSON.RecordDelimiter,
+		}
+	default:
+		return nil, nil, ErrMalformedXML
+	}
+
+	return queryRequest, stmt, ErrNone
+}
+
+func outputRecordDelimiter(queryRequest *volume_server_pb.QueryRequest) string {
+	out := queryRequest.OutputSerialization
+	if out.CsvOutput != nil && out.CsvOutput.RecordDelimiter != "" {
+		return out.CsvOutput.RecordDelimiter
+	}
+	if out.JsonOutput != nil && out.JsonOutput.RecordDelimiter != "" {
+		return out.JsonOutput.RecordDelimiter
+	}
+	return "\n"
+}
+
+func (s3a *S3ApiServer) lookupChunkLocations(chunks []*filer_pb.FileChunk) (locations map[string]*filer_pb.Locations, err error) {
+
+	var vids []string
+	seen := make(map[string]bool)
+	for _, chunk := range chunks {
+		vid := strings.Split(chunk.GetFileIdString(), ",")[0]
+		if !seen[vid] {
+			seen[vid] = true
+			vids = append(vids, vid)
+		}
+	}
+	if len(vids) == 0 {
+		return nil, nil
+	}
+
+	err = s3a.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.LookupVolume(context.Background(), &filer_pb.LookupVolumeRequest{
+			VolumeIds: vids,
+		})
+		if err != nil {
+			return err
+		}
+		locations = resp.LocationsMap
+		return nil
+	})
+
+	return
+}
+
+// selectQueryPart is the query of some object chunks, on a volume server holding the first of them
+type selectQueryPart struct {
+	chunks  []*filer_pb.FileChunk
+	request *volume_server_pb.QueryRequest
+}
+
+// toQueryParts splits the query of the object by chunks. The volume server of each chunk queries the records
+// starting in the chunk, reading the records spanning the chunks from the leading and the next chunks.
+// If the records can not be split by the record delimiter, the object is queried as one stream.
+func toQueryParts(chunks []*filer_pb.FileChunk, queryRequest *volume_server_pb.QueryRequest) (parts []*selectQueryPart) {
+
+	var fileIds []string
+	for _, chunk := range chunks {
+		fileIds = append(fileIds, chunk.GetFileIdString())
+	}
+
+	newPart := func(chunks []*filer_pb.FileChunk, fileIds []string, objectPart *volume_server_pb.QueryRequest_ObjectPart) *selectQueryPart {
+		request := proto.Clone(queryRequest).(*volume_server_pb.QueryRequest)
+		request.FromFileIds = fileIds
+		request.ObjectPart = objectPart
+		return &selectQueryPart{chunks: chunks, request: request}
+	}
+
+	if len(chunks) == 1 {
+		return append(parts, newPart(chunks, fileIds, nil))
+	}
+	if !isSplittableSelectInput(queryRequest.InputSerialization) {
+		return append(parts, newPart(chunks, fileIds, &volume_server_pb.QueryRequest_ObjectPart{}))
+	}
+
+	for i, chunk := range chunks {
+		objectPart := &volume_server_pb.QueryRequest_ObjectPart{}
+		var size uint64
+		for j := 0; j < i && size < selectMaxRecordSize; j++ {
+			objectPart.FirstFileIds = append(objectPart.FirstFileIds, fileIds[j])
+			size += chunks[j].Size
+		}
+		size = 0
+		for j := i + 1; j < len(chunks) && size < selectMaxRecordSize; j++ {
+			objectPart.NextFileIds = append(objectPart.NextFileIds, fileIds[j])
+			size += chunks[j].Size
+		}
+		parts = append(parts, newPart([]*filer_pb.FileChunk{chunk}, fileIds[i:i+1], objectPart))
+	}
+
+	return parts
+}
+
+// isSplittableSelectInput checks whether the records are split by a one byte delimiter, which is not quoted or compressed
+func isSplittableSelectInput(in *volume_server_pb.QueryRequest_InputSerialization) bool {
+	if in.CompressionType != "" && in.CompressionType != "NONE" {
+		return false
+	}
+	switch {
+	case in.CsvInput != nil:
+		return !in.CsvInput.AllowQuotedRecordDelimiter && len(in.CsvInput.RecordDelimiter) <= 1
+	case in.JsonInput != nil:
+		return in.JsonInput.Type == "LINES"
+	}
+	return false
+}
+
+type chunkQueryResult struct {
+	records    []byte
+	aggregates []*volume_server_pb.QueriedAggregate
+	err        error
+}
+
+// streamSelectResults queries the parts in parallel, and writes the records in the chunk order.
+// For aggregate functions, the partial results of all parts are merged into one record.
+func (s3a *S3ApiServer) streamSelectResults(w io.Writer, parts []*selectQueryPart, locations map[string]*filer_pb.Locations,
+	queryRequest *volume_server_pb.QueryRequest, stmt *sqlparser.Select) (stats *SelectStats, err error) {
+
+	results := make([]chan chunkQueryResult, len(parts))
+	for i := range results {
+		results[i] = make(chan chunkQueryResult, 1)
+	}
+	// the slots are freed only after the results are written out
+	slots := make(chan struct{}, selectConcurrency)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for i, part := range parts {
+			select {
+			case slots <- struct{}{}:
+			case <-done:
+				return
+			}
+			go func(i int, part *selectQueryPart) {
+				records, aggregates, err := s3a.queryPart(part, locations)
+				results[i] <- chunkQueryResult{records, aggregates, err}
+			}(i, part)
+		}
+	}()
+
+	sw := newSelectResultWriter(w, queryRequest, stmt)
+	for i, part := range parts {
+		result := <-results[i]
+		<-slots
+		if result.err != nil {
+			return nil, fmt.Errorf("query chunks %v: %v", part.request.FromFileIds, result.err)
+		}
+		size := int64(filer2.TotalSize(part.chunks))
+		sw.stats.BytesScanned += size
+		sw.stats.BytesProcessed += size
+
+		reachedLimit, err := sw.write(result.records, result.aggregates)
+		if err != nil {
+			return nil, err
+		}
+		if reachedLimit {
+			break
+		}
+	}
+
+	return sw.finish()
+}
+
+// selectResultWriter writes the queried records in order until the limit,
+// and merges the partial results of the aggregate functions
+type selectResultWriter struct {
+	w               io.Writer
+	queryRequest    *volume_server_pb.QueryRequest
+	stmt            *sqlparser.Select
+	stats           *SelectStats
+	recordDelimiter []byte
+	recordCount     int64
+	states          []sqlparser.AggregateState
+}
+
+func newSelectResultWriter(w io.Writer, queryRequest *volume_server_pb.QueryRequest, stmt *sqlparser.Select) *selectResultWriter {
+	return &selectResultWriter{
+		w:               w,
+		queryRequest:    queryRequest,
+		stmt:            stmt,
+		stats:           &SelectStats{},
+		recordDelimiter: []byte(outputRecordDelimiter(queryRequest)),
+		states:          make([]sqlparser.AggregateState, len(stmt.Aggregates)),
+	}
+}
+
+func (sw *selectResultWriter) write(records []byte, aggregates []*volume_server_pb.QueriedAggregate) (reachedLimit bool, err error) {
+
+	if len(sw.states) > 0 {
+		// each stripe has the partial results of all the aggregate functions
+		for j, aggregate := range aggregates {
+			sw.states[j%len(sw.states)].Merge(&sqlparser.AggregateState{
+				Count: aggregate.Count,
+				Sum:   aggregate.Sum,
+				Min:   aggregate.Min,
+				Max:   aggregate.Max,
+			})
+		}
+		return false, nil
+	}
+
+	if sw.stmt.Limit > 0 {
+		records, sw.recordCount, reachedLimit = limitRecords(records, sw.recordDelimiter, sw.recordCount, sw.stmt.Limit)
+	}
+	if len(records) > 0 {
+		sw.stats.BytesReturned += int64(len(records))
+		if err = writeRecordsEvent(sw.w, records); err != nil {
+			return false, err
+		}
+		if f, ok := sw.w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	return reachedLimit, nil
+}
+
+func (sw *selectResultWriter) finish() (*SelectStats, error) {
+
+	if len(sw.states) > 0 {
+		records := aggregateRecord(sw.queryRequest, sw.stmt.Aggregates, sw.states)
+		sw.stats.BytesReturned += int64(len(records))
+		if err := writeRecordsEvent(sw.w, records); err != nil {
+			return nil, err
+		}
+	}
+
+	return sw.stats, nil
+}
+
+// aggregateRecord formats the results of the aggregate functions, which are named by the alias, or _1, _2, ...
+func aggregateRecord(queryRequest *volume_server_pb.QueryRequest, aggregates []*sqlparser.Aggregate, states []sqlparser.AggregateState) []byte {
+
+	var names []string
+	var values []sqltypes.Value
+	for i, aggregate := range aggregates {
+		name := aggregate.Alias
+		if name == "" {
+			name = fmt.Sprintf("_%d", i+1)
+		}
+		names = append(names, name)
+		values = append(values, aggregate.Result(&states[i]))
+	}
+
+	if out := queryRequest.OutputSerialization.CsvOutput; out != nil {
+		var fields []string
+		for _, value := range values {
+			fields = append(fields, value.ToString())
+		}
+		return csv.ToCsv(nil, fields, csv.WriterOptions{
+			QuoteFields:          out.QuoteFields,
+			RecordDelimiter:      out.RecordDelimiter,
+			FieldDelimiter:       out.FieldDelimiter,
+			QuoteCharacter:       out.QuoteCharactoer,
+			QuoteEscapeCharacter: out.QuoteEscapeCharacter,
+		})
+	}
+
+	record := json.ToJson(nil, names, values)
+	return append(record, outputRecordDelimiter(queryRequest)...)
+}
+
+// limitRecords keeps the records until the total count reaches the limit
+func limitRecords(records, recordDelimiter []byte, count, limit int64) (limited []byte, newCount int64, reachedLimit bool) {
+	pos := 0
+	for count < limit {
+		i := bytes.Index(records[pos:], recordDelimiter)
+		if i < 0 {
+			return records, count, false
+		}
+		pos += i + len(recordDelimiter)
+		count++
+	}
+	return records[:pos], count, true
+}
+
+func (s3a *S3ApiServer) queryPart(part *selectQueryPart, locations map[string]*filer_pb.Locations) (records []byte, aggregates []*volume_server_pb.QueriedAggregate, err error) {
+
+	fileId := part.request.FromFileIds[0]
+	vid := strings.Split(fileId, ",")[0]
+	chunkLocations, found := locations[vid]
+	if !found || len(chunkLocations.Locations) == 0 {
+		return nil, nil, fmt.Errorf("volume %s not found", vid)
+	}
+
+	request := part.request
+	for _, location := range chunkLocations.Locations {
+		records, aggregates = nil, nil
+		err = operation.WithVolumeServerClient(location.Url, s3a.option.GrpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+			stream, err := client.Query(context.Background(), request)
+			if err != nil {
+				return err
+			}
+			for {
+				stripe, err := stream.Recv()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				records = append(records, stripe.Records...)
+				aggregates = append(aggregates, stripe.Aggregates...)
+			}
+		})
+		if err == nil {
+			return records, aggregates, nil
+		}
+		glog.V(1).Infof("query %s on %s: %v", fileId, location.Url, err)
+	}
+
+	return nil, nil, err
+}
@@ -0,0 +1,162 @@
+package s3api
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/query/sqlparser"
+)
+
+func TestToQueryRequest(t *testing.T) {
+
+	input := &SelectObjectContentRequest{
+		Expression:     "SELECT s.name FROM S3Object s WHERE s.age > 30 AND s.city IN ('Paris', 'Rome') LIMIT 5",
+		ExpressionType: "SQL",
+		InputSerialization: SelectInputSerialization{
+			CompressionType: "gzip",
+			JSON:            &SelectJSONInput{Type: "LINES"},
+		},
+		OutputSerialization: SelectOutputSerialization{
+			JSON: &SelectJSONOutput{RecordDelimiter: ","},
+		},
+	}
+
+	queryRequest, stmt, code := toQueryRequest(input)
+	assert.Equal(t, ErrNone, code)
+	assert.Equal(t, int64(5), stmt.Limit)
+	assert.Equal(t, []string{"name"}, queryRequest.Selections)
+	assert.Equal(t, input.Expression, queryRequest.Expression)
+	assert.Equal(t, "GZIP", queryRequest.InputSerialization.CompressionType)
+	assert.Equal(t, "LINES", queryRequest.InputSerialization.JsonInput.Type)
+	assert.Equal(t, ",", outputRecordDelimiter(queryRequest))
+
+	input.ExpressionType = "XPATH"
+	_, _, code = toQueryRequest(input)
+	assert.Equal(t, ErrInvalidExpressionType, code)
+
+	input.ExpressionType = "SQL"
+	input.Expression = "SELECT FROM"
+	_, _, code = toQueryRequest(input)
+	assert.Equal(t, ErrParseSelectFailure, code)
+
+}
+
+func TestAggregateRecord(t *testing.T) {
+
+	stmt, err := sqlparser.Parse("SELECT COUNT(*), AVG(s.a) AS average, MAX(s.b) FROM S3Object s")
+	assert.Nil(t, err)
+	states := []sqlparser.AggregateState{
+		{Count: 4},
+		{Count: 2, Sum: 3, Min: 1, Max: 2},
+		{},
+	}
+
+	queryRequest := &volume_server_pb.QueryRequest{
+		OutputSerialization: &volume_server_pb.QueryRequest_OutputSerialization{
+			JsonOutput: &volume_server_pb.QueryRequest_OutputSerialization_JSONOutput{},
+		},
+	}
+	assert.Equal(t, "{\"_1\":4,\"average\":1.5,\"_3\":null}\n", string(aggregateRecord(queryRequest, stmt.Aggregates, states)))
+
+	queryRequest.OutputSerialization = &volume_server_pb.QueryRequest_OutputSerialization{
+		CsvOutput: &volume_server_pb.QueryRequest_OutputSerialization_CSVOutput{},
+	}
+	assert.Equal(t, "4,1.5,\n", string(aggregateRecord(queryRequest, stmt.Aggregates, states)))
+
+}
+
+func TestLimitRecords(t *testing.T) {
+
+	records := []byte("a\nb\nc\n")
+
+	limited, count, reached := limitRecords(records, []byte("\n"), 0, 5)
+	assert.Equal(t, records, limited)
+	assert.Equal(t, int64(3), count)
+	assert.False(t, reached)
+
+	limited, count, reached = limitRecords(records, []byte("\n"), 3, 5)
+	assert.Equal(t, "a\nb\n", string(limited))
+	assert.Equal(t, int64(5), count)
+	assert.True(t, reached)
+
+}
+
+func TestToQueryParts(t *testing.T) {
+
+	chunks := []*filer_pb.FileChunk{
+		{FileId: "1,01", Offset: 0, Size: selectMaxRecordSize / 2},
+		{FileId: "2,02", Offset: selectMaxRecordSize / 2, Size: selectMaxRecordSize / 2},
+		{FileId: "3,03", Offset: selectMaxRecordSize, Size: selectMaxRecordSize},
+		{FileId: "4,04", Offset: 2 * selectMaxRecordSize, Size: 10},
+	}
+	queryRequest, _, code := toQueryRequest(&SelectObjectContentRequest{
+		Expression:          "SELECT s.name FROM S3Object s WHERE s.age > 30",
+		ExpressionType:      "SQL",
+		InputSerialization:  SelectInputSerialization{CSV: &SelectCSVInput{FileHeaderInfo: "USE"}},
+		OutputSerialization: SelectOutputSerialization{CSV: &SelectCSVOutput{}},
+	})
+	assert.Equal(t, ErrNone, code)
+
+	// each chunk is queried on its volume server, reading the leading and the next chunks of the max record size
+	parts := toQueryParts(chunks, queryRequest)
+	assert.Equal(t, 4, len(parts))
+	expected := []struct {
+		first, next []string
+	}{
+		{nil, []string{"2,02", "3,03"}},
+		{[]string{"1,01"}, []string{"3,03"}},
+		{[]string{"1,01", "2,02"}, []string{"4,04"}},
+		{[]string{"1,01", "2,02"}, nil},
+	}
+	for i, part := range parts {
+		assert.Equal(t, []string{chunks[i].FileId}, part.request.FromFileIds)
+		assert.Equal(t, expected[i].first, part.request.ObjectPart.FirstFileIds, "part %d", i)
+		assert.Equal(t, expected[i].next, part.request.ObjectPart.NextFileIds, "part %d", i)
+		assert.Equal(t, queryRequest.Expression, part.request.Expression)
+	}
+	assert.Nil(t, queryRequest.ObjectPart)
+
+	// the compressed records are queried as one stream
+	queryRequest.InputSerialization.CompressionType = "GZIP"
+	parts = toQueryParts(chunks, queryRequest)
+	assert.Equal(t, 1, len(parts))
+	assert.Equal(t, []string{"1,01", "2,02", "3,03", "4,04"}, parts[0].request.FromFileIds)
+	assert.NotNil(t, parts[0].request.ObjectPart)
+	assert.Equal(t, 0, len(parts[0].request.ObjectPart.FirstFileIds))
+
+	// a single chunk is queried as a whole file
+	parts = toQueryParts(chunks[:1], queryRequest)
+	assert.Equal(t, 1, len(parts))
+	assert.Nil(t, parts[0].request.ObjectPart)
+
+}
+
+func TestEventStreamMessage(t *testing.T) {
+
+	var buf bytes.Buffer
+	assert.Nil(t, writeRecordsEvent(&buf, []byte("{\"a\":1}\n")))
+	message := buf.Bytes()
+
+	totalLength := binary.BigEndian.Uint32(message[0:4])
+	headersLength := binary.BigEndian.Uint32(message[4:8])
+	assert.Equal(t, uint32(len(message)), totalLength)
+	assert.Equal(t, crc32.ChecksumIEEE(message[0:8]), binary.BigEndian.Uint32(message[8:12]))
+	assert.Equal(t, crc32.ChecksumIEEE(message[:len(message)-4]), binary.BigEndian.Uint32(message[len(message)-4:]))
+
+	headers := message[12 : 12+headersLength]
+	assert.Equal(t, byte(len(":event-type")), headers[0])
+	assert.Equal(t, ":event-type", string(headers[1:12]))
+	assert.Equal(t, byte(eventStreamHeaderValueTypeString), headers[12])
+	assert.Equal(t, uint16(len("Records")), binary.BigEndian.Uint16(headers[13:15]))
+	assert.Equal(t, "Records", string(headers[15:22]))
+
+	payload := message[12+headersLength : len(message)-4]
+	assert.Equal(t, "{\"a\":1}\n", string(payload))
+
+}
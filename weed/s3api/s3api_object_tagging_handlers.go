@@ -0,0 +1,229 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// GetObjectTaggingHandler - GET object tagging
+// API reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectTagging.html
+func (s3a *S3ApiServer) GetObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	dir, name, errCode := s3a.resolveTaggedObject(w, r, bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	tags, err := s3a.getTags(dir, name)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			glog.Errorf("GetObjectTaggingHandler %s: %v", r.URL, err)
+			writeErrorResponse(w, ErrNoSuchKey, r.URL)
+		} else {
+			glog.Errorf("GetObjectTaggingHandler %s: %v", r.URL, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+		}
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(FromTags(tags)))
+
+}
+
+// PutObjectTaggingHandler Put object tagging
+// API reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectTagging.html
+func (s3a *S3ApiServer) PutObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	tags, errCode := readTagging(r, maxObjectTags)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	dir, name, errCode := s3a.resolveTaggedObject(w, r, bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if err := s3a.setTags(dir, name, tags); err != nil {
+		if err == filer_pb.ErrNotFound {
+			glog.Errorf("PutObjectTaggingHandler %s: %v", r.URL, err)
+			writeErrorResponse(w, ErrNoSuchKey, r.URL)
+		} else {
+			glog.Errorf("PutObjectTaggingHandler %s: %v", r.URL, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+		}
+		return
+	}
+
+	writeSuccessResponseEmpty(w)
+
+}
+
+// DeleteObjectTaggingHandler Delete object tagging
+// API reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteObjectTagging.html
+func (s3a *S3ApiServer) DeleteObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := getObject(vars)
+
+	dir, name, errCode := s3a.resolveTaggedObject(w, r, bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if err := s3a.setTags(dir, name, nil); err != nil {
+		if err == filer_pb.ErrNotFound {
+			glog.Errorf("DeleteObjectTaggingHandler %s: %v", r.URL, err)
+			writeErrorResponse(w, ErrNoSuchKey, r.URL)
+		} else {
+			glog.Errorf("DeleteObjectTaggingHandler %s: %v", r.URL, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+		}
+		return
+	}
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+
+}
+
+// resolveTaggedObject finds the object version, if any, that the tagging request refers to
+func (s3a *S3ApiServer) resolveTaggedObject(w http.ResponseWriter, r *http.Request, bucket, object string) (dir, name string, code ErrorCode) {
+
+	versionId := r.URL.Query().Get("versionId")
+
+	dir, name, entry, errCode := s3a.resolveObjectVersion(bucket, object, versionId)
+	if errCode != ErrNone {
+		return "", "", errCode
+	}
+	if versionId != "" {
+		w.Header().Set(versionIdHeader, versionId)
+	}
+	if isDeleteMarker(entry) {
+		return "", "", ErrMethodNotAllowed
+	}
+
+	return dir, name, ErrNone
+}
+
+// GetBucketTaggingHandler - GET bucket tagging
+// API reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketTagging.html
+func (s3a *S3ApiServer) GetBucketTaggingHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	tags, err := s3a.getTags(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+		} else {
+			glog.Errorf("GetBucketTaggingHandler %s: %v", bucket, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+		}
+		return
+	}
+	if len(tags) == 0 {
+		writeErrorResponse(w, ErrNoSuchTagSet, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(FromTags(tags)))
+
+}
+
+// PutBucketTaggingHandler Put bucket tagging
+// API reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketTagging.html
+func (s3a *S3ApiServer) PutBucketTaggingHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	tags, errCode := readTagging(r, maxBucketTags)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if err := s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		replaceTagsInExtended(entry.Extended, tags)
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+		} else {
+			glog.Errorf("PutBucketTaggingHandler %s: %v", bucket, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+		}
+		return
+	}
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+
+}
+
+// DeleteBucketTaggingHandler Delete bucket tagging
+// API reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketTagging.html
+func (s3a *S3ApiServer) DeleteBucketTaggingHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if err := s3a.updateBucketEntry(bucket, func(entry *filer_pb.Entry) {
+		replaceTagsInExtended(entry.Extended, nil)
+	}); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+		} else {
+			glog.Errorf("DeleteBucketTaggingHandler %s: %v", bucket, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+		}
+		return
+	}
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+
+}
+
+func readTagging(r *http.Request, maxTags int) (tags map[string]string, code ErrorCode) {
+
+	input, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		glog.Errorf("read tagging %s: %v", r.URL, err)
+		return nil, ErrInternalError
+	}
+
+	tagging := &Tagging{}
+	if err = xml.Unmarshal(input, tagging); err != nil {
+		glog.Errorf("unmarshal tagging %s: %v", r.URL, err)
+		return nil, ErrMalformedXML
+	}
+
+	tags = tagging.ToTags()
+	if len(tags) != len(tagging.TagSet.Tag) {
+		return nil, ErrInvalidTag
+	}
+	if err = validateTags(tags, maxTags); err != nil {
+		glog.V(1).Infof("validate tagging %s: %v", r.URL, err)
+		return nil, ErrInvalidTag
+	}
+
+	return tags, ErrNone
+}
@@ -0,0 +1,520 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+type VersioningConfigurationResult struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ VersioningConfiguration"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+// PutBucketVersioningHandler enables or suspends versioning of a bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketVersioning.html
+func (s3a *S3ApiServer) PutBucketVersioningHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	configBytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	config := &VersioningConfigurationResult{}
+	if err := xml.Unmarshal(configBytes, config); err != nil {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+	if config.Status != VersioningEnabled && config.Status != VersioningSuspended {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	if config.Status != VersioningEnabled {
+		lockConfig, err := s3a.getBucketObjectLock(bucket)
+		if err != nil && err != filer_pb.ErrNotFound {
+			glog.Errorf("get bucket %s object lock: %v", bucket, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+		if lockConfig != nil {
+			writeErrorResponse(w, ErrInvalidBucketState, r.URL)
+			return
+		}
+	}
+
+	if err := s3a.setBucketVersioning(bucket, config.Status); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("PutBucketVersioning %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseEmpty(w)
+}
+
+// GetBucketVersioningHandler returns the versioning state of a bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketVersioning.html
+func (s3a *S3ApiServer) GetBucketVersioningHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	status, err := s3a.getBucketVersioning(bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		glog.Errorf("GetBucketVersioning %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(VersioningConfigurationResult{
+		Status: status,
+	}))
+}
+
+type ListObjectVersionsResult struct {
+	XMLName             xml.Name            `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult"`
+	Name                string              `xml:"Name"`
+	Prefix              string              `xml:"Prefix"`
+	KeyMarker           string              `xml:"KeyMarker"`
+	VersionIdMarker     string              `xml:"VersionIdMarker"`
+	NextKeyMarker       string              `xml:"NextKeyMarker,omitempty"`
+	NextVersionIdMarker string              `xml:"NextVersionIdMarker,omitempty"`
+	MaxKeys             int                 `xml:"MaxKeys"`
+	Delimiter           string              `xml:"Delimiter,omitempty"`
+	IsTruncated         bool                `xml:"IsTruncated"`
+	Versions            []VersionEntry      `xml:"Version,omitempty"`
+	DeleteMarkers       []DeleteMarkerEntry `xml:"DeleteMarker,omitempty"`
+	CommonPrefixes      []PrefixEntry       `xml:"CommonPrefixes,omitempty"`
+}
+
+// ListObjectVersionsHandler lists current and prior versions of the objects in a bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+func (s3a *S3ApiServer) ListObjectVersionsHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	prefix, keyMarker, versionIdMarker, delimiter, maxKeys := getListObjectVersionsArgs(r.URL.Query())
+	if maxKeys < 0 {
+		writeErrorResponse(w, ErrInvalidMaxKeys, r.URL)
+		return
+	}
+
+	if _, err := s3a.getBucketEntry(bucket); err != nil {
+		if err == filer_pb.ErrNotFound {
+			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	response, err := s3a.listObjectVersions(bucket, prefix, keyMarker, versionIdMarker, delimiter, maxKeys)
+	if err != nil {
+		glog.Errorf("ListObjectVersions %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+func (s3a *S3ApiServer) listObjectVersions(bucket, prefix, keyMarker, versionIdMarker, delimiter string, maxKeys int) (response *ListObjectVersionsResult, err error) {
+
+	l := &versionLister{
+		bucketDir: fmt.Sprintf("%s/%s", s3a.option.BucketsPath, bucket),
+		listDir: func(dir, startFrom string, inclusive bool, limit int) (entries []*filer_pb.Entry, err error) {
+			err = filer_pb.List(s3a, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
+				entries = append(entries, entry)
+				return nil
+			}, startFrom, inclusive, uint32(limit))
+			if err != nil && strings.Contains(err.Error(), filer_pb.ErrNotFound.Error()) {
+				err = nil
+			}
+			return
+		},
+		prefix:          prefix,
+		keyMarker:       keyMarker,
+		versionIdMarker: versionIdMarker,
+		delimiter:       delimiter,
+		maxKeys:         maxKeys,
+	}
+
+	return l.list(bucket)
+}
+
+// versionLister lists the object versions in the order of the keys, as they are listed directory by directory,
+// and the versions of each key from the newest to the oldest. The current versions and the prior versions
+// under the .versions folder are walked together, page by page, and the walk stops after maxKeys versions.
+type versionLister struct {
+	bucketDir       string
+	listDir         func(dir, startFrom string, inclusive bool, limit int) ([]*filer_pb.Entry, error)
+	prefix          string
+	keyMarker       string
+	versionIdMarker string
+	delimiter       string
+	maxKeys         int
+
+	response     *ListObjectVersionsResult
+	count        int
+	seenPrefixes map[string]bool
+}
+
+var errVersionListTruncated = fmt.Errorf("truncated")
+
+const versionListPageSize = 1024
+
+func (l *versionLister) list(bucket string) (*ListObjectVersionsResult, error) {
+
+	l.response = &ListObjectVersionsResult{
+		Name:            bucket,
+		Prefix:          l.prefix,
+		KeyMarker:       l.keyMarker,
+		VersionIdMarker: l.versionIdMarker,
+		MaxKeys:         l.maxKeys,
+		Delimiter:       l.delimiter,
+	}
+	l.seenPrefixes = make(map[string]bool)
+	if l.delimiter != "" && strings.HasSuffix(l.keyMarker, l.delimiter) {
+		// continue after a common prefix
+		l.seenPrefixes[l.keyMarker] = true
+	}
+
+	startDir := ""
+	if i := strings.LastIndex(l.prefix, "/"); i >= 0 {
+		startDir = l.prefix[:i]
+	}
+
+	err := l.walk(startDir)
+	if err == errVersionListTruncated {
+		l.response.IsTruncated = true
+		err = nil
+	}
+	if !l.response.IsTruncated {
+		l.response.NextKeyMarker, l.response.NextVersionIdMarker = "", ""
+	}
+
+	return l.response, err
+}
+
+// walk visits the keys under the directory, relative to the bucket.
+// The files under the directory are the current versions, and the directories under the same path in
+// the .versions folder hold the prior versions of a key and the keys below it.
+func (l *versionLister) walk(dir string) error {
+
+	startFrom, namePrefix := "", ""
+	if rest, found := keyUnder(l.prefix, dir); found && !strings.Contains(rest, "/") {
+		startFrom, namePrefix = rest, rest
+	}
+	if rest, found := keyUnder(l.keyMarker, dir); found {
+		if i := strings.Index(rest, "/"); i >= 0 {
+			rest = rest[:i]
+		}
+		if rest > startFrom {
+			startFrom = rest
+		}
+	}
+
+	currentDir, versionsDir := l.bucketDir, l.bucketDir+"/"+versionsFolder
+	if dir != "" {
+		currentDir, versionsDir = currentDir+"/"+dir, versionsDir+"/"+dir
+	}
+	current := &dirIterator{listDir: l.listDir, dir: currentDir, startFrom: startFrom}
+	versions := &dirIterator{listDir: l.listDir, dir: versionsDir, startFrom: startFrom, onlyDirectories: true}
+
+	for {
+		if commonPrefix, found := l.commonPrefix(dir + "/"); dir != "" && found && l.seenPrefixes[commonPrefix] {
+			// the rest of the keys roll up into a listed common prefix
+			return nil
+		}
+		currentEntry, err := current.peek()
+		if err != nil {
+			return err
+		}
+		versionsEntry, err := versions.peek()
+		if err != nil {
+			return err
+		}
+		if currentEntry == nil && versionsEntry == nil {
+			return nil
+		}
+
+		var name string
+		switch {
+		case versionsEntry == nil || currentEntry != nil && currentEntry.Name < versionsEntry.Name:
+			name = currentEntry.Name
+			versionsEntry = nil
+			current.next()
+		case currentEntry == nil || versionsEntry.Name < currentEntry.Name:
+			name = versionsEntry.Name
+			currentEntry = nil
+			versions.next()
+		default:
+			name = currentEntry.Name
+			current.next()
+			versions.next()
+		}
+
+		if !strings.HasPrefix(name, namePrefix) {
+			// past the names with the prefix
+			return nil
+		}
+		if dir == "" && (name == filer2.MultipartUploadsFolder || name == versionsFolder) {
+			continue
+		}
+		key := joinKey(dir, name)
+		beforeMarker := compareKeys(key, l.keyMarker) < 0
+		if beforeMarker && !strings.HasPrefix(l.keyMarker, key+"/") {
+			continue
+		}
+
+		isFile := currentEntry != nil && !currentEntry.IsDirectory
+		if (isFile || versionsEntry != nil) && !beforeMarker && strings.HasPrefix(key, l.prefix) {
+			var currentVersion *filer_pb.Entry
+			if isFile {
+				currentVersion = currentEntry
+			}
+			if err := l.listKey(key, currentVersion, versionsEntry != nil); err != nil {
+				return err
+			}
+		}
+
+		if (currentEntry != nil && currentEntry.IsDirectory || versionsEntry != nil) &&
+			(strings.HasPrefix(key+"/", l.prefix) || strings.HasPrefix(l.prefix, key+"/")) {
+			if commonPrefix, found := l.commonPrefix(key + "/"); found && currentEntry != nil && currentEntry.IsDirectory {
+				// all the keys below roll up into one common prefix
+				if err := l.addCommonPrefix(commonPrefix); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := l.walk(key); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// listKey adds the current version, if any, and the prior versions of the key
+func (l *versionLister) listKey(key string, current *filer_pb.Entry, hasPriorVersions bool) error {
+
+	if commonPrefix, found := l.commonPrefix(key); found {
+		return l.addCommonPrefix(commonPrefix)
+	}
+
+	var versions []*filer_pb.Entry
+	if current != nil {
+		versions = append(versions, current)
+	}
+	if hasPriorVersions {
+		var priorVersions []*filer_pb.Entry
+		dir := l.bucketDir + "/" + versionsFolder + "/" + key
+		startFrom := ""
+		for {
+			entries, err := l.listDir(dir, startFrom, false, versionListPageSize)
+			if err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				startFrom = entry.Name
+				if !entry.IsDirectory {
+					priorVersions = append(priorVersions, entry)
+				}
+			}
+			if len(entries) < versionListPageSize {
+				break
+			}
+		}
+		sortVersions(priorVersions)
+		versions = append(versions, priorVersions...)
+	}
+
+	start := 0
+	if key == l.keyMarker {
+		// continue after the version id marker, or after the key without it
+		start = len(versions)
+		for i, version := range versions {
+			if l.versionIdMarker != "" && getVersionId(version) == l.versionIdMarker {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	for i := start; i < len(versions); i++ {
+		if err := l.add(key, versions[i], i == 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *versionLister) add(key string, entry *filer_pb.Entry, isLatest bool) error {
+
+	if l.count >= l.maxKeys {
+		return errVersionListTruncated
+	}
+	l.count++
+
+	versionId := getVersionId(entry)
+	l.response.NextKeyMarker, l.response.NextVersionIdMarker = key, versionId
+
+	lastModified := time.Unix(entry.Attributes.Mtime, 0).UTC()
+	owner := CanonicalUser{
+		ID:          fmt.Sprintf("%x", entry.Attributes.Uid),
+		DisplayName: entry.Attributes.UserName,
+	}
+	if isDeleteMarker(entry) {
+		l.response.DeleteMarkers = append(l.response.DeleteMarkers, DeleteMarkerEntry{
+			Key:          key,
+			VersionId:    versionId,
+			IsLatest:     isLatest,
+			LastModified: lastModified,
+			Owner:        owner,
+		})
+		return nil
+	}
+	l.response.Versions = append(l.response.Versions, VersionEntry{
+		Key:          key,
+		VersionId:    versionId,
+		IsLatest:     isLatest,
+		LastModified: lastModified,
+		ETag:         "\"" + filer2.ETag(entry) + "\"",
+		Size:         int64(filer2.TotalSize(entry.Chunks)),
+		Owner:        owner,
+		StorageClass: "STANDARD",
+	})
+	return nil
+}
+
+// commonPrefix returns the common prefix of the key, or of the keys starting with it, if rolled up by the delimiter
+func (l *versionLister) commonPrefix(key string) (string, bool) {
+	if l.delimiter == "" || len(key) < len(l.prefix) || !strings.HasPrefix(key, l.prefix) {
+		return "", false
+	}
+	i := strings.Index(key[len(l.prefix):], l.delimiter)
+	if i < 0 {
+		return "", false
+	}
+	return key[:len(l.prefix)+i+len(l.delimiter)], true
+}
+
+func (l *versionLister) addCommonPrefix(commonPrefix string) error {
+	if l.seenPrefixes[commonPrefix] {
+		return nil
+	}
+	if l.count >= l.maxKeys {
+		return errVersionListTruncated
+	}
+	l.count++
+	l.seenPrefixes[commonPrefix] = true
+	l.response.CommonPrefixes = append(l.response.CommonPrefixes, PrefixEntry{Prefix: commonPrefix})
+	l.response.NextKeyMarker, l.response.NextVersionIdMarker = commonPrefix, ""
+	return nil
+}
+
+// dirIterator lists a directory page by page
+type dirIterator struct {
+	listDir         func(dir, startFrom string, inclusive bool, limit int) ([]*filer_pb.Entry, error)
+	dir             string
+	startFrom       string
+	onlyDirectories bool
+	listed          bool
+	isLastPage      bool
+	entries         []*filer_pb.Entry
+}
+
+func (it *dirIterator) peek() (*filer_pb.Entry, error) {
+	for {
+		for len(it.entries) > 0 && it.onlyDirectories && !it.entries[0].IsDirectory {
+			it.entries = it.entries[1:]
+		}
+		if len(it.entries) > 0 || it.isLastPage {
+			break
+		}
+		entries, err := it.listDir(it.dir, it.startFrom, !it.listed, versionListPageSize)
+		if err != nil {
+			return nil, err
+		}
+		it.listed = true
+		it.isLastPage = len(entries) < versionListPageSize
+		if len(entries) > 0 {
+			it.startFrom = entries[len(entries)-1].Name
+		}
+		it.entries = entries
+	}
+	if len(it.entries) == 0 {
+		return nil, nil
+	}
+	return it.entries[0], nil
+}
+
+func (it *dirIterator) next() {
+	it.entries = it.entries[1:]
+}
+
+func joinKey(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
+
+// keyUnder returns the rest of the key after the directory, if the key is below it
+func keyUnder(key, dir string) (string, bool) {
+	if dir == "" {
+		return key, key != ""
+	}
+	if strings.HasPrefix(key, dir+"/") {
+		return key[len(dir)+1:], true
+	}
+	return "", false
+}
+
+// compareKeys orders the keys as they are listed, directory by directory
+func compareKeys(a, b string) int {
+	aParts, bParts := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if aParts[i] != bParts[i] {
+			return strings.Compare(aParts[i], bParts[i])
+		}
+	}
+	return len(aParts) - len(bParts)
+}
+
+func getListObjectVersionsArgs(values url.Values) (prefix, keyMarker, versionIdMarker, delimiter string, maxkeys int) {
+	prefix = values.Get("prefix")
+	keyMarker = values.Get("key-marker")
+	versionIdMarker = values.Get("version-id-marker")
+	delimiter = values.Get("delimiter")
+	if values.Get("max-keys") != "" {
+		maxkeys, _ = strconv.Atoi(values.Get("max-keys"))
+	} else {
+		maxkeys = maxObjectListSizeLimit
+	}
+	return
+}
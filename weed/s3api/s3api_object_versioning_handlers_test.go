@@ -0,0 +1,148 @@
+package s3api
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// newTestVersionLister lists the files at the paths, relative to the bucket, with the version id as the file name
+// under the .versions folder, and the mtime in the order of the paths
+func newTestVersionLister(paths ...string) *versionLister {
+
+	dirs := make(map[string]map[string]*filer_pb.Entry)
+	for i, p := range paths {
+		p = "/buckets/b1/" + p
+		for {
+			lastSlash := strings.LastIndex(p, "/")
+			dir, name := p[:lastSlash], p[lastSlash+1:]
+			if dirs[dir] == nil {
+				dirs[dir] = make(map[string]*filer_pb.Entry)
+			}
+			if _, found := dirs[dir][name]; !found {
+				entry := &filer_pb.Entry{Name: name, IsDirectory: p != "/buckets/b1/"+paths[i], Attributes: &filer_pb.FuseAttributes{Mtime: int64(i)}}
+				if !entry.IsDirectory && strings.HasPrefix(paths[i], versionsFolder+"/") {
+					entry.Extended = map[string][]byte{extVersionIdKey: []byte(name)}
+				}
+				dirs[dir][name] = entry
+			}
+			if dir == "/buckets/b1" {
+				break
+			}
+			p = dir
+		}
+	}
+
+	return &versionLister{
+		bucketDir: "/buckets/b1",
+		listDir: func(dir, startFrom string, inclusive bool, limit int) (entries []*filer_pb.Entry, err error) {
+			var names []string
+			for name := range dirs[dir] {
+				if name > startFrom || inclusive && name == startFrom {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if len(entries) >= limit {
+					break
+				}
+				entries = append(entries, dirs[dir][name])
+			}
+			return
+		},
+	}
+}
+
+func listedVersions(response *ListObjectVersionsResult) (listed []string) {
+	for _, version := range response.Versions {
+		listed = append(listed, version.Key+"@"+version.VersionId)
+	}
+	for _, commonPrefix := range response.CommonPrefixes {
+		listed = append(listed, commonPrefix.Prefix)
+	}
+	return
+}
+
+func TestListObjectVersionsPagination(t *testing.T) {
+
+	paths := []string{
+		".versions/a/v1",
+		".versions/a/v2",
+		"a",
+		".versions/a/b/v3",
+		"ab",
+		".versions/c/v4",
+		".uploads/u1/1.part",
+	}
+	expected := []string{"a@null", "a@v2", "a@v1", "a/b@v3", "ab@null", "c@v4"}
+
+	for _, maxKeys := range []int{1, 2, 3, 100} {
+		var listed []string
+		keyMarker, versionIdMarker := "", ""
+		for page := 0; page < len(expected)+1; page++ {
+			l := newTestVersionLister(paths...)
+			l.keyMarker, l.versionIdMarker, l.maxKeys = keyMarker, versionIdMarker, maxKeys
+			response, err := l.list("b1")
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			if len(response.Versions) > maxKeys {
+				t.Errorf("max keys %d: listed %d versions", maxKeys, len(response.Versions))
+			}
+			listed = append(listed, listedVersions(response)...)
+			if !response.IsTruncated {
+				break
+			}
+			keyMarker, versionIdMarker = response.NextKeyMarker, response.NextVersionIdMarker
+		}
+		if strings.Join(listed, ",") != strings.Join(expected, ",") {
+			t.Errorf("max keys %d: expected %v, but got %v", maxKeys, expected, listed)
+		}
+	}
+
+}
+
+func TestListObjectVersionsPrefixAndDelimiter(t *testing.T) {
+
+	paths := []string{
+		"a",
+		"dir/x",
+		".versions/dir/y/v1",
+		"dir/sub/z",
+		"dir2/w",
+	}
+
+	l := newTestVersionLister(paths...)
+	l.prefix, l.delimiter, l.maxKeys = "dir/", "/", 100
+	response, err := l.list("b1")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if listed := strings.Join(listedVersions(response), ","); listed != "dir/x@null,dir/y@v1,dir/sub/" {
+		t.Errorf("unexpected listed versions %s", listed)
+	}
+
+	l = newTestVersionLister(paths...)
+	l.delimiter, l.maxKeys = "/", 2
+	response, err = l.list("b1")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if listed := strings.Join(listedVersions(response), ","); listed != "a@null,dir/" || !response.IsTruncated || response.NextKeyMarker != "dir/" {
+		t.Errorf("unexpected listed versions %s, next key marker %s", listed, response.NextKeyMarker)
+	}
+
+	l = newTestVersionLister(paths...)
+	l.keyMarker, l.delimiter, l.maxKeys = "dir/", "/", 2
+	response, err = l.list("b1")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if listed := strings.Join(listedVersions(response), ","); listed != "dir2/" || response.IsTruncated {
+		t.Errorf("unexpected listed versions %s after the common prefix", listed)
+	}
+
+}
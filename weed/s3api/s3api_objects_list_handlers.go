@@ -130,7 +130,7 @@ func (s3a *S3ApiServer) listFilerEntries(bucket, originalPrefix string, maxKeys
 			}
 			lastEntryName = entry.Name
 			if entry.IsDirectory {
-				if entry.Name != ".uploads" {
+				if entry.Name != ".uploads" && entry.Name != versionsFolder {
 					commonPrefixes = append(commonPrefixes, PrefixEntry{
 						Prefix: fmt.Sprintf("%s%s/", dir, entry.Name),
 					})
@@ -0,0 +1,90 @@
+package s3api
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+)
+
+// Messages of the application/vnd.amazon.eventstream encoding used by SelectObjectContent.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTSelectObjectAppendix.html
+//
+//	prelude: total length (4 bytes) | headers length (4 bytes) | prelude crc (4 bytes)
+//	headers: name length (1 byte) | name | value type (1 byte, 7 for string) | value length (2 bytes) | value
+//	payload
+//	message crc (4 bytes)
+
+const (
+	eventStreamHeaderValueTypeString = 7
+	eventStreamPreludeLength         = 12
+	eventStreamCrcLength             = 4
+)
+
+type eventStreamHeader struct {
+	name  string
+	value string
+}
+
+func encodeEventStreamMessage(headers []eventStreamHeader, payload []byte) []byte {
+
+	var headerBuf bytes.Buffer
+	for _, h := range headers {
+		headerBuf.WriteByte(byte(len(h.name)))
+		headerBuf.WriteString(h.name)
+		headerBuf.WriteByte(eventStreamHeaderValueTypeString)
+		binary.Write(&headerBuf, binary.BigEndian, uint16(len(h.value)))
+		headerBuf.WriteString(h.value)
+	}
+
+	totalLength := eventStreamPreludeLength + headerBuf.Len() + len(payload) + eventStreamCrcLength
+
+	message := make([]byte, 0, totalLength)
+	message = appendUint32(message, uint32(totalLength))
+	message = appendUint32(message, uint32(headerBuf.Len()))
+	message = appendUint32(message, crc32.ChecksumIEEE(message))
+	message = append(message, headerBuf.Bytes()...)
+	message = append(message, payload...)
+	message = appendUint32(message, crc32.ChecksumIEEE(message))
+
+	return message
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	return append(buf, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func writeRecordsEvent(w io.Writer, records []byte) error {
+	_, err := w.Write(encodeEventStreamMessage([]eventStreamHeader{
+		{":event-type", "Records"},
+		{":content-type", "application/octet-stream"},
+		{":message-type", "event"},
+	}, records))
+	return err
+}
+
+func writeStatsEvent(w io.Writer, stats []byte) error {
+	_, err := w.Write(encodeEventStreamMessage([]eventStreamHeader{
+		{":event-type", "Stats"},
+		{":content-type", "text/xml"},
+		{":message-type", "event"},
+	}, stats))
+	return err
+}
+
+func writeEndEvent(w io.Writer) error {
+	_, err := w.Write(encodeEventStreamMessage([]eventStreamHeader{
+		{":event-type", "End"},
+		{":message-type", "event"},
+	}, nil))
+	return err
+}
+
+func writeErrorEvent(w io.Writer, errorCode, errorMessage string) error {
+	_, err := w.Write(encodeEventStreamMessage([]eventStreamHeader{
+		{":error-code", errorCode},
+		{":error-message", errorMessage},
+		{":message-type", "error"},
+	}, nil))
+	return err
+}
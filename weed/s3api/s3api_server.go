@@ -2,9 +2,13 @@ package s3api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/karlseguin/ccache"
 	"google.golang.org/grpc"
+
+	"github.com/chrislusf/seaweedfs/weed/notification"
 )
 
 type S3ApiServerOption struct {
@@ -14,19 +18,39 @@ type S3ApiServerOption struct {
 	DomainName       string
 	BucketsPath      string
 	GrpcDialOption   grpc.DialOption
+	// StsSigningKey signs the session tokens of temporary credentials, and StsMaxDuration limits their lifetime
+	StsSigningKey  string
+	StsMaxDuration time.Duration
+	// IamKey decrypts the secret keys of the identities managed through the filer
+	IamKey string
+	// NotificationQueue receives the bucket notifications, apart from the filer's message queue
+	NotificationQueue notification.MessageQueue
+	// NotificationWebhooks maps the webhook names to the endpoints receiving the bucket notifications
+	NotificationWebhooks map[string]string
 }
 
 type S3ApiServer struct {
-	option *S3ApiServerOption
-	iam    *IdentityAccessManagement
+	option              *S3ApiServerOption
+	iam                 *IdentityAccessManagement
+	bucketCors          *ccache.Cache
+	bucketNotifications *ccache.Cache
+	notifications       *notificationSender
 }
 
 func NewS3ApiServer(router *mux.Router, option *S3ApiServerOption) (s3ApiServer *S3ApiServer, err error) {
 	s3ApiServer = &S3ApiServer{
-		option: option,
-		iam:    NewIdentityAccessManagement(option.Config, option.DomainName),
+		option:              option,
+		iam:                 NewIdentityAccessManagement(option.Config, option.DomainName),
+		bucketCors:          ccache.New(ccache.Configure().MaxSize(1000).ItemsToPrune(100)),
+		bucketNotifications: ccache.New(ccache.Configure().MaxSize(1000).ItemsToPrune(100)),
+		notifications:       newNotificationSender(option.NotificationQueue, option.NotificationWebhooks),
 	}
 
+	s3ApiServer.iam.bucketPolicyLoader = s3ApiServer.loadBucketPolicy
+	s3ApiServer.iam.cannedAclLoader = s3ApiServer.loadCannedAcl
+	s3ApiServer.iam.SetStsSigningKey(option.StsSigningKey, option.StsMaxDuration)
+	s3ApiServer.loadFilerIdentities()
+
 	s3ApiServer.registerRouter(router)
 
 	return s3ApiServer, nil
@@ -43,6 +67,12 @@ func (s3a *S3ApiServer) registerRouter(router *mux.Router) {
 
 	for _, bucket := range routers {
 
+		bucket.Use(s3a.corsMiddleware)
+
+		// CorsPreflight
+		bucket.Methods("OPTIONS").Path("/{object:.+}").HandlerFunc(s3a.CorsPreflightHandler)
+		bucket.Methods("OPTIONS").HandlerFunc(s3a.CorsPreflightHandler)
+
 		// HeadObject
 		bucket.Methods("HEAD").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.HeadObjectHandler, ACTION_READ))
 		// HeadBucket
@@ -52,6 +82,8 @@ func (s3a *S3ApiServer) registerRouter(router *mux.Router) {
 		bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(s3a.iam.Auth(s3a.CopyObjectPartHandler, ACTION_WRITE)).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
 		// PutObjectPart
 		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.PutObjectPartHandler, ACTION_WRITE)).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
+		// SelectObjectContent
+		bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.SelectObjectContentHandler, ACTION_READ)).Queries("select", "", "select-type", "2")
 		// CompleteMultipartUpload
 		bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.CompleteMultipartUploadHandler, ACTION_WRITE)).Queries("uploadId", "{uploadId:.*}")
 		// NewMultipartUpload
@@ -63,6 +95,74 @@ func (s3a *S3ApiServer) registerRouter(router *mux.Router) {
 		// ListMultipartUploads
 		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.ListMultipartUploadsHandler, ACTION_WRITE)).Queries("uploads", "")
 
+		// GetBucketVersioning
+		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.GetBucketVersioningHandler, ACTION_READ)).Queries("versioning", "")
+		// PutBucketVersioning
+		bucket.Methods("PUT").HandlerFunc(s3a.iam.Auth(s3a.PutBucketVersioningHandler, ACTION_ADMIN)).Queries("versioning", "")
+		// ListObjectVersions
+		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.ListObjectVersionsHandler, ACTION_READ)).Queries("versions", "")
+
+		// GetObjectTagging
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.GetObjectTaggingHandler, ACTION_READ)).Queries("tagging", "")
+		// PutObjectTagging
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.PutObjectTaggingHandler, ACTION_WRITE)).Queries("tagging", "")
+		// DeleteObjectTagging
+		bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.DeleteObjectTaggingHandler, ACTION_WRITE)).Queries("tagging", "")
+		// GetObjectRetention
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.GetObjectRetentionHandler, ACTION_READ)).Queries("retention", "")
+		// PutObjectRetention
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.PutObjectRetentionHandler, ACTION_WRITE)).Queries("retention", "")
+		// GetObjectLegalHold
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.GetObjectLegalHoldHandler, ACTION_READ)).Queries("legal-hold", "")
+		// PutObjectLegalHold
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.PutObjectLegalHoldHandler, ACTION_WRITE)).Queries("legal-hold", "")
+		// GetBucketTagging
+		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.GetBucketTaggingHandler, ACTION_READ)).Queries("tagging", "")
+		// PutBucketTagging
+		bucket.Methods("PUT").HandlerFunc(s3a.iam.Auth(s3a.PutBucketTaggingHandler, ACTION_ADMIN)).Queries("tagging", "")
+		// DeleteBucketTagging
+		bucket.Methods("DELETE").HandlerFunc(s3a.iam.Auth(s3a.DeleteBucketTaggingHandler, ACTION_ADMIN)).Queries("tagging", "")
+
+		// GetBucketLifecycleConfiguration
+		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.GetBucketLifecycleConfigurationHandler, ACTION_READ)).Queries("lifecycle", "")
+		// PutBucketLifecycleConfiguration
+		bucket.Methods("PUT").HandlerFunc(s3a.iam.Auth(s3a.PutBucketLifecycleConfigurationHandler, ACTION_ADMIN)).Queries("lifecycle", "")
+		// DeleteBucketLifecycle
+		bucket.Methods("DELETE").HandlerFunc(s3a.iam.Auth(s3a.DeleteBucketLifecycleHandler, ACTION_ADMIN)).Queries("lifecycle", "")
+
+		// GetBucketPolicy
+		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.GetBucketPolicyHandler, ACTION_ADMIN)).Queries("policy", "")
+		// PutBucketPolicy
+		bucket.Methods("PUT").HandlerFunc(s3a.iam.Auth(s3a.PutBucketPolicyHandler, ACTION_ADMIN)).Queries("policy", "")
+		// DeleteBucketPolicy
+		bucket.Methods("DELETE").HandlerFunc(s3a.iam.Auth(s3a.DeleteBucketPolicyHandler, ACTION_ADMIN)).Queries("policy", "")
+
+		// GetBucketCors
+		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.GetBucketCorsHandler, ACTION_READ)).Queries("cors", "")
+		// PutBucketCors
+		bucket.Methods("PUT").HandlerFunc(s3a.iam.Auth(s3a.PutBucketCorsHandler, ACTION_ADMIN)).Queries("cors", "")
+		// DeleteBucketCors
+		bucket.Methods("DELETE").HandlerFunc(s3a.iam.Auth(s3a.DeleteBucketCorsHandler, ACTION_ADMIN)).Queries("cors", "")
+
+		// GetObjectLockConfiguration
+		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.GetObjectLockConfigurationHandler, ACTION_READ)).Queries("object-lock", "")
+		// PutObjectLockConfiguration
+		bucket.Methods("PUT").HandlerFunc(s3a.iam.Auth(s3a.PutObjectLockConfigurationHandler, ACTION_ADMIN)).Queries("object-lock", "")
+
+		// GetBucketNotificationConfiguration
+		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.GetBucketNotificationConfigurationHandler, ACTION_READ)).Queries("notification", "")
+		// PutBucketNotificationConfiguration
+		bucket.Methods("PUT").HandlerFunc(s3a.iam.Auth(s3a.PutBucketNotificationConfigurationHandler, ACTION_ADMIN)).Queries("notification", "")
+
+		// GetObjectACL
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.GetObjectACLHandler, ACTION_READ)).Queries("acl", "")
+		// PutObjectACL
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(s3a.iam.Auth(s3a.PutObjectACLHandler, ACTION_WRITE)).Queries("acl", "")
+		// GetBucketACL
+		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.GetBucketACLHandler, ACTION_READ)).Queries("acl", "")
+		// PutBucketACL
+		bucket.Methods("PUT").HandlerFunc(s3a.iam.Auth(s3a.PutBucketACLHandler, ACTION_ADMIN)).Queries("acl", "")
+
 		// CopyObject
 		bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(s3a.iam.Auth(s3a.CopyObjectHandler, ACTION_WRITE))
 		// PutObject
@@ -83,30 +183,22 @@ func (s3a *S3ApiServer) registerRouter(router *mux.Router) {
 		bucket.Methods("GET").HandlerFunc(s3a.iam.Auth(s3a.ListObjectsV1Handler, ACTION_READ))
 
 		// DeleteMultipleObjects
-		bucket.Methods("POST").HandlerFunc(s3a.iam.Auth(s3a.DeleteMultipleObjectsHandler, ACTION_WRITE)).Queries("delete", "")
+		bucket.Methods("POST").HandlerFunc(s3a.iam.Authenticate(s3a.DeleteMultipleObjectsHandler)).Queries("delete", "")
+		// PostPolicy, authorized by the signed policy in the form
+		bucket.Methods("POST").HeadersRegexp("Content-Type", "multipart/form-data*").HandlerFunc(s3a.PostPolicyBucketHandler)
 		/*
 
 			// not implemented
 			// GetBucketLocation
 			bucket.Methods("GET").HandlerFunc(s3a.GetBucketLocationHandler).Queries("location", "")
-			// GetBucketPolicy
-			bucket.Methods("GET").HandlerFunc(s3a.GetBucketPolicyHandler).Queries("policy", "")
-			// GetObjectACL
-			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(s3a.GetObjectACLHandler).Queries("acl", "")
-			// GetBucketACL
-			bucket.Methods("GET").HandlerFunc(s3a.GetBucketACLHandler).Queries("acl", "")
-			// PutBucketPolicy
-			bucket.Methods("PUT").HandlerFunc(s3a.PutBucketPolicyHandler).Queries("policy", "")
-			// DeleteBucketPolicy
-			bucket.Methods("DELETE").HandlerFunc(s3a.DeleteBucketPolicyHandler).Queries("policy", "")
-			// PostPolicy
-			bucket.Methods("POST").HeadersRegexp("Content-Type", "multipart/form-data*").HandlerFunc(s3a.PostPolicyBucketHandler)
 		*/
 
 	}
 
 	// ListBuckets
 	apiRouter.Methods("GET").Path("/").HandlerFunc(s3a.iam.Auth(s3a.ListBucketsHandler, ACTION_ADMIN))
+	// AssumeRole and GetSessionToken, authenticated by the handler
+	apiRouter.Methods("POST").Path("/").HandlerFunc(s3a.StsHandler)
 
 	// NotFound
 	apiRouter.NotFoundHandler = http.HandlerFunc(notFoundHandler)
@@ -0,0 +1,235 @@
+package s3api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+const (
+	stsNamespace      = "https://sts.amazonaws.com/doc/2011-06-15/"
+	maxStsRequestSize = 16 * 1024
+)
+
+type StsCredentials struct {
+	AccessKeyId     string    `xml:"AccessKeyId"`
+	SecretAccessKey string    `xml:"SecretAccessKey"`
+	SessionToken    string    `xml:"SessionToken"`
+	Expiration      time.Time `xml:"Expiration"`
+}
+
+type StsResponseMetadata struct {
+	RequestId string `xml:"RequestId"`
+}
+
+type AssumeRoleResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleResponse"`
+	Result  struct {
+		Credentials     StsCredentials `xml:"Credentials"`
+		AssumedRoleUser struct {
+			Arn           string `xml:"Arn"`
+			AssumedRoleId string `xml:"AssumedRoleId"`
+		} `xml:"AssumedRoleUser"`
+	} `xml:"AssumeRoleResult"`
+	ResponseMetadata StsResponseMetadata `xml:"ResponseMetadata"`
+}
+
+type GetSessionTokenResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ GetSessionTokenResponse"`
+	Result  struct {
+		Credentials StsCredentials `xml:"Credentials"`
+	} `xml:"GetSessionTokenResult"`
+	ResponseMetadata StsResponseMetadata `xml:"ResponseMetadata"`
+}
+
+type StsErrorResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ ErrorResponse"`
+	Error   struct {
+		Type    string `xml:"Type"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Error"`
+	RequestId string `xml:"RequestId"`
+}
+
+// StsHandler issues temporary credentials with the permissions of the calling access key,
+// optionally narrowed by a session policy. The role of AssumeRole only names the session.
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRole.html
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_GetSessionToken.html
+func (s3a *S3ApiServer) StsHandler(w http.ResponseWriter, r *http.Request) {
+
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxStsRequestSize+1))
+	if err != nil {
+		writeStsErrorResponse(w, ErrInternalError)
+		return
+	}
+	if len(data) > maxStsRequestSize {
+		writeStsErrorResponse(w, ErrStsValidation)
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	parentAccessKey, errCode := s3a.iam.authenticateSts(r, data)
+	if errCode != ErrNone {
+		writeStsErrorResponse(w, errCode)
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		writeStsErrorResponse(w, ErrStsValidation)
+		return
+	}
+
+	switch r.Form.Get("Action") {
+	case "AssumeRole":
+		s3a.assumeRole(w, r, parentAccessKey)
+	case "GetSessionToken":
+		s3a.getSessionToken(w, r, parentAccessKey)
+	default:
+		writeStsErrorResponse(w, ErrInvalidStsAction)
+	}
+}
+
+func (s3a *S3ApiServer) assumeRole(w http.ResponseWriter, r *http.Request, parentAccessKey string) {
+
+	roleArn, sessionName, policy := r.Form.Get("RoleArn"), r.Form.Get("RoleSessionName"), r.Form.Get("Policy")
+	if roleArn == "" || sessionName == "" {
+		writeStsErrorResponse(w, ErrStsValidation)
+		return
+	}
+	if policy != "" {
+		if _, err := parseSessionPolicy(policy); err != nil {
+			glog.V(1).Infof("AssumeRole %s session policy: %v", roleArn, err)
+			writeStsErrorResponse(w, ErrStsValidation)
+			return
+		}
+	}
+
+	duration, errCode := s3a.iam.stsDuration(r.Form.Get("DurationSeconds"), stsDefaultDuration)
+	if errCode != ErrNone {
+		writeStsErrorResponse(w, errCode)
+		return
+	}
+
+	credentials, errCode := s3a.iam.newStsCredentials(parentAccessKey, duration, policy)
+	if errCode != ErrNone {
+		writeStsErrorResponse(w, errCode)
+		return
+	}
+
+	var response AssumeRoleResponse
+	response.Result.Credentials = credentials
+	roleName := roleArn[strings.LastIndex(roleArn, "/")+1:]
+	response.Result.AssumedRoleUser.Arn = fmt.Sprintf("arn:aws:sts:::assumed-role/%s/%s", roleName, sessionName)
+	response.Result.AssumedRoleUser.AssumedRoleId = credentials.AccessKeyId + ":" + sessionName
+	response.ResponseMetadata.RequestId = fmt.Sprintf("%d", time.Now().UnixNano())
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+func (s3a *S3ApiServer) getSessionToken(w http.ResponseWriter, r *http.Request, parentAccessKey string) {
+
+	duration, errCode := s3a.iam.stsDuration(r.Form.Get("DurationSeconds"), s3a.iam.stsMaxDuration)
+	if errCode != ErrNone {
+		writeStsErrorResponse(w, errCode)
+		return
+	}
+
+	credentials, errCode := s3a.iam.newStsCredentials(parentAccessKey, duration, "")
+	if errCode != ErrNone {
+		writeStsErrorResponse(w, errCode)
+		return
+	}
+
+	var response GetSessionTokenResponse
+	response.Result.Credentials = credentials
+	response.ResponseMetadata.RequestId = fmt.Sprintf("%d", time.Now().UnixNano())
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+// authenticateSts checks the signature of the STS request, and returns the calling access key.
+// Temporary credentials can not be used to issue more of them.
+func (iam *IdentityAccessManagement) authenticateSts(r *http.Request, body []byte) (accessKey string, errCode ErrorCode) {
+
+	if getRequestAuthType(r) != authTypeSigned {
+		return "", ErrAccessDenied
+	}
+	if sessionTokenOf(r) != "" {
+		return "", ErrAccessDenied
+	}
+
+	signV4Values, errCode := parseSignV4(r.Header.Get("Authorization"))
+	if errCode != ErrNone {
+		return "", errCode
+	}
+
+	hashedPayload := sha256.Sum256(body)
+	if _, errCode = iam.doesServiceSignatureMatch(hex.EncodeToString(hashedPayload[:]), r, "sts"); errCode != ErrNone {
+		return "", errCode
+	}
+
+	return signV4Values.Credential.accessKey, ErrNone
+}
+
+// stsDuration parses the DurationSeconds parameter, which must be between 15 minutes and the configured maximum
+func (iam *IdentityAccessManagement) stsDuration(durationSeconds string, defaultDuration time.Duration) (time.Duration, ErrorCode) {
+	if durationSeconds == "" {
+		if defaultDuration > iam.stsMaxDuration {
+			return iam.stsMaxDuration, ErrNone
+		}
+		return defaultDuration, ErrNone
+	}
+	seconds, err := strconv.ParseInt(durationSeconds, 10, 64)
+	if err != nil {
+		return 0, ErrStsValidation
+	}
+	duration := time.Duration(seconds) * time.Second
+	if duration < stsMinDuration || duration > iam.stsMaxDuration {
+		return 0, ErrStsValidation
+	}
+	return duration, ErrNone
+}
+
+func (iam *IdentityAccessManagement) newStsCredentials(parentAccessKey string, duration time.Duration, policy string) (StsCredentials, ErrorCode) {
+
+	expiration := time.Now().Add(duration).UTC().Truncate(time.Second)
+	cred, sessionToken, err := iam.issueSessionCredential(parentAccessKey, expiration, policy)
+	if err != nil {
+		glog.Errorf("issue temporary credentials of %s: %v", parentAccessKey, err)
+		return StsCredentials{}, ErrInternalError
+	}
+
+	return StsCredentials{
+		AccessKeyId:     cred.AccessKey,
+		SecretAccessKey: cred.SecretKey,
+		SessionToken:    sessionToken,
+		Expiration:      expiration,
+	}, ErrNone
+}
+
+// writeStsErrorResponse writes the error in the format of the STS query protocol
+func writeStsErrorResponse(w http.ResponseWriter, errorCode ErrorCode) {
+	apiError := getAPIError(errorCode)
+
+	var response StsErrorResponse
+	response.Error.Type = "Sender"
+	if apiError.HTTPStatusCode >= http.StatusInternalServerError {
+		response.Error.Type = "Receiver"
+	}
+	response.Error.Code = apiError.Code
+	response.Error.Message = apiError.Description
+	response.RequestId = fmt.Sprintf("%d", time.Now().UnixNano())
+
+	writeResponse(w, apiError.HTTPStatusCode, encodeResponse(response), mimeXML)
+}
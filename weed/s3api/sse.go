@@ -0,0 +1,169 @@
+package s3api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+)
+
+const (
+	amzServerSideEncryptionHeader = "X-Amz-Server-Side-Encryption"
+
+	sseAlgorithmAES256 = "AES256"
+
+	// the encryption is kept in the entry extended attributes,
+	// and the filer keeps the MD5 of the customer key in filer2.CustomerKeyMd5Key
+	extSseKey                  = "s3-sse"
+	extSseCustomerAlgorithmKey = "s3-sse-customer-algorithm"
+)
+
+type sseCustomerHeaderNames struct {
+	algorithm string
+	key       string
+	keyMd5    string
+}
+
+var (
+	sseCustomerHeaders = sseCustomerHeaderNames{
+		algorithm: "X-Amz-Server-Side-Encryption-Customer-Algorithm",
+		key:       "X-Amz-Server-Side-Encryption-Customer-Key",
+		keyMd5:    "X-Amz-Server-Side-Encryption-Customer-Key-Md5",
+	}
+	copySourceSseCustomerHeaders = sseCustomerHeaderNames{
+		algorithm: "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Algorithm",
+		key:       "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key",
+		keyMd5:    "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key-Md5",
+	}
+)
+
+// sseOption is the server side encryption of an object.
+// With SSE-S3 the filer encrypts the chunks with its own keys.
+// With SSE-C the filer also encrypts the chunk keys with the customer key, which is never stored.
+type sseOption struct {
+	Algorithm         string // SSE-S3
+	CustomerAlgorithm string // SSE-C
+	CustomerKey       string // base64 encoded as given by the client, only known during the request
+	CustomerKeyMd5    string
+}
+
+// parseSseHeaders parses the encryption requested for a new object
+func parseSseHeaders(header http.Header) (sse sseOption, code ErrorCode) {
+
+	if sse, code = parseSseCustomerHeaders(header, sseCustomerHeaders); code != ErrNone {
+		return
+	}
+
+	algorithm := header.Get(amzServerSideEncryptionHeader)
+	if algorithm == "" {
+		return sse, ErrNone
+	}
+	if algorithm != sseAlgorithmAES256 {
+		return sseOption{}, ErrInvalidEncryptionAlgorithm
+	}
+	if sse.isCustomerKey() {
+		return sseOption{}, ErrIncompatibleSSEHeaders
+	}
+	sse.Algorithm = algorithm
+
+	return sse, ErrNone
+}
+
+// parseSseCustomerHeaders parses and verifies the customer key, either for the object or the copy source
+func parseSseCustomerHeaders(header http.Header, names sseCustomerHeaderNames) (sse sseOption, code ErrorCode) {
+
+	algorithm, key, keyMd5 := header.Get(names.algorithm), header.Get(names.key), header.Get(names.keyMd5)
+	if algorithm == "" && key == "" && keyMd5 == "" {
+		return sseOption{}, ErrNone
+	}
+	if algorithm != sseAlgorithmAES256 {
+		return sseOption{}, ErrInvalidEncryptionAlgorithm
+	}
+	customerKey, err := filer2.ParseCustomerKey(key)
+	if err != nil || customerKey == nil {
+		return sseOption{}, ErrInvalidSSECustomerKey
+	}
+	if keyMd5 != filer2.CustomerKeyMd5(customerKey) {
+		return sseOption{}, ErrSSECustomerKeyMd5Mismatch
+	}
+
+	return sseOption{
+		CustomerAlgorithm: algorithm,
+		CustomerKey:       key,
+		CustomerKeyMd5:    keyMd5,
+	}, ErrNone
+}
+
+func sseFromExtended(extended map[string][]byte) sseOption {
+	return sseOption{
+		Algorithm:         string(extended[extSseKey]),
+		CustomerAlgorithm: string(extended[extSseCustomerAlgorithmKey]),
+		CustomerKeyMd5:    string(extended[filer2.CustomerKeyMd5Key]),
+	}
+}
+
+func (sse sseOption) isEncrypted() bool {
+	return sse.Algorithm != "" || sse.isCustomerKey()
+}
+
+func (sse sseOption) isCustomerKey() bool {
+	return sse.CustomerKeyMd5 != ""
+}
+
+// withCustomerKey returns the stored encryption of an object together with the key given in the request
+func (sse sseOption) withCustomerKey(given sseOption) sseOption {
+	sse.CustomerKey = given.CustomerKey
+	return sse
+}
+
+// checkCustomerKey verifies the customer key given to read or extend an object
+func (sse sseOption) checkCustomerKey(given sseOption) ErrorCode {
+	switch {
+	case !sse.isCustomerKey() && given.isCustomerKey():
+		return ErrSSENotApplicable
+	case sse.isCustomerKey() && !given.isCustomerKey():
+		return ErrSSECustomerKeyRequired
+	case sse.CustomerKeyMd5 != given.CustomerKeyMd5:
+		return ErrSSECustomerKeyMismatch
+	}
+	return ErrNone
+}
+
+func (sse sseOption) toExtended(extended map[string][]byte) {
+	if sse.Algorithm != "" {
+		extended[extSseKey] = []byte(sse.Algorithm)
+	}
+	if sse.isCustomerKey() {
+		extended[extSseCustomerAlgorithmKey] = []byte(sse.CustomerAlgorithm)
+		extended[filer2.CustomerKeyMd5Key] = []byte(sse.CustomerKeyMd5)
+	}
+}
+
+// filerUrl asks the filer to encrypt the chunks for SSE-S3. For SSE-C the customer key header is enough.
+func (sse sseOption) filerUrl(fileUrl string) string {
+	if sse.Algorithm == "" {
+		return fileUrl
+	}
+	if strings.Contains(fileUrl, "?") {
+		return fileUrl + "&cipher=true"
+	}
+	return fileUrl + "?cipher=true"
+}
+
+// setFilerHeaders passes the customer key to the filer, and drops any customer key header from the client
+func (sse sseOption) setFilerHeaders(header http.Header) {
+	header.Del(filer2.CustomerKeyHeader)
+	if sse.CustomerKey != "" {
+		header.Set(filer2.CustomerKeyHeader, sse.CustomerKey)
+	}
+}
+
+func (sse sseOption) setResponseHeaders(w http.ResponseWriter) {
+	if sse.Algorithm != "" {
+		w.Header().Set(amzServerSideEncryptionHeader, sse.Algorithm)
+	}
+	if sse.isCustomerKey() {
+		w.Header().Set(sseCustomerHeaders.algorithm, sse.CustomerAlgorithm)
+		w.Header().Set(sseCustomerHeaders.keyMd5, sse.CustomerKeyMd5)
+	}
+}
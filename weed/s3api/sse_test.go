@@ -0,0 +1,98 @@
+package s3api
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func sseCustomerKeyHeaders(key util.CipherKey) http.Header {
+	header := make(http.Header)
+	header.Set(sseCustomerHeaders.algorithm, sseAlgorithmAES256)
+	header.Set(sseCustomerHeaders.key, base64.StdEncoding.EncodeToString(key))
+	header.Set(sseCustomerHeaders.keyMd5, filer2.CustomerKeyMd5(key))
+	return header
+}
+
+func TestParseSseHeaders(t *testing.T) {
+
+	sse, errCode := parseSseHeaders(http.Header{})
+	assert.Equal(t, ErrNone, errCode)
+	assert.False(t, sse.isEncrypted())
+
+	header := make(http.Header)
+	header.Set(amzServerSideEncryptionHeader, "AES256")
+	sse, errCode = parseSseHeaders(header)
+	assert.Equal(t, ErrNone, errCode)
+	assert.Equal(t, "AES256", sse.Algorithm)
+	assert.False(t, sse.isCustomerKey())
+
+	header.Set(amzServerSideEncryptionHeader, "aws:kms")
+	_, errCode = parseSseHeaders(header)
+	assert.Equal(t, ErrInvalidEncryptionAlgorithm, errCode)
+
+	key := util.GenCipherKey()
+	header = sseCustomerKeyHeaders(key)
+	sse, errCode = parseSseHeaders(header)
+	assert.Equal(t, ErrNone, errCode)
+	assert.True(t, sse.isCustomerKey())
+	assert.Equal(t, filer2.CustomerKeyMd5(key), sse.CustomerKeyMd5)
+
+	header.Set(amzServerSideEncryptionHeader, "AES256")
+	_, errCode = parseSseHeaders(header)
+	assert.Equal(t, ErrIncompatibleSSEHeaders, errCode)
+
+	header = sseCustomerKeyHeaders(key)
+	header.Set(sseCustomerHeaders.keyMd5, filer2.CustomerKeyMd5(util.GenCipherKey()))
+	_, errCode = parseSseHeaders(header)
+	assert.Equal(t, ErrSSECustomerKeyMd5Mismatch, errCode)
+
+	header = sseCustomerKeyHeaders(key)
+	header.Set(sseCustomerHeaders.key, base64.StdEncoding.EncodeToString(key[:16]))
+	_, errCode = parseSseHeaders(header)
+	assert.Equal(t, ErrInvalidSSECustomerKey, errCode)
+
+}
+
+func TestCheckCustomerKey(t *testing.T) {
+
+	key := util.GenCipherKey()
+	given, _ := parseSseCustomerHeaders(sseCustomerKeyHeaders(key), sseCustomerHeaders)
+
+	extended := make(map[string][]byte)
+	given.toExtended(extended)
+	stored := sseFromExtended(extended)
+	assert.Equal(t, "", stored.CustomerKey, "the customer key should not be stored")
+
+	assert.Equal(t, ErrNone, stored.checkCustomerKey(given))
+	assert.Equal(t, ErrSSECustomerKeyRequired, stored.checkCustomerKey(sseOption{}))
+	other, _ := parseSseCustomerHeaders(sseCustomerKeyHeaders(util.GenCipherKey()), sseCustomerHeaders)
+	assert.Equal(t, ErrSSECustomerKeyMismatch, stored.checkCustomerKey(other))
+
+	plain := sseFromExtended(nil)
+	assert.Equal(t, ErrNone, plain.checkCustomerKey(sseOption{}))
+	assert.Equal(t, ErrSSENotApplicable, plain.checkCustomerKey(given))
+
+}
+
+func TestSseFilerRequest(t *testing.T) {
+
+	sse := sseOption{Algorithm: "AES256"}
+	assert.Equal(t, "http://filer/b/o?cipher=true", sse.filerUrl("http://filer/b/o"))
+	assert.Equal(t, "http://filer/b/o?collection=b&cipher=true", sse.filerUrl("http://filer/b/o?collection=b"))
+	assert.Equal(t, "http://filer/b/o", sseOption{CustomerKey: "k"}.filerUrl("http://filer/b/o"))
+
+	header := make(http.Header)
+	header.Set(filer2.CustomerKeyHeader, "from the client")
+	sse.setFilerHeaders(header)
+	assert.Equal(t, "", header.Get(filer2.CustomerKeyHeader))
+
+	sseOption{CustomerKey: "k"}.setFilerHeaders(header)
+	assert.Equal(t, "k", header.Get(filer2.CustomerKeyHeader))
+
+}
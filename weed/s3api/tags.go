@@ -0,0 +1,117 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	// tags are kept in the entry extended attributes, one key per tag
+	TagPrefix             = "X-Amz-Tagging-"
+	amzTaggingHeader      = "X-Amz-Tagging"
+	amzTaggingCountHeader = "x-amz-tagging-count"
+
+	maxObjectTags   = 10
+	maxBucketTags   = 50
+	maxTagKeyLength = 128
+	maxTagValLength = 256
+)
+
+type Tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type TagSet struct {
+	Tag []Tag `xml:"Tag"`
+}
+
+type Tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  TagSet   `xml:"TagSet"`
+	Xmlns   string   `xml:"xmlns,attr"`
+}
+
+func (t *Tagging) ToTags() map[string]string {
+	output := make(map[string]string)
+	for _, tag := range t.TagSet.Tag {
+		output[tag.Key] = tag.Value
+	}
+	return output
+}
+
+func FromTags(tags map[string]string) (t *Tagging) {
+	t = &Tagging{Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/"}
+	for k, v := range tags {
+		t.TagSet.Tag = append(t.TagSet.Tag, Tag{
+			Key:   k,
+			Value: v,
+		})
+	}
+	sort.Slice(t.TagSet.Tag, func(i, j int) bool {
+		return t.TagSet.Tag[i].Key < t.TagSet.Tag[j].Key
+	})
+	return
+}
+
+// parseTagsHeader parses the url encoded x-amz-tagging header, e.g. "k1=v1&k2=v2"
+func parseTagsHeader(tagging string) (map[string]string, error) {
+	tags := make(map[string]string)
+	if tagging == "" {
+		return tags, nil
+	}
+	values, err := url.ParseQuery(tagging)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range values {
+		if len(v) != 1 {
+			return nil, fmt.Errorf("tag key %s is repeated", k)
+		}
+		tags[k] = v[0]
+	}
+	return tags, nil
+}
+
+func validateTags(tags map[string]string, maxTags int) error {
+	if len(tags) > maxTags {
+		return fmt.Errorf("too many tags: %d > %d", len(tags), maxTags)
+	}
+	for k, v := range tags {
+		if len(k) == 0 || len(k) > maxTagKeyLength {
+			return fmt.Errorf("invalid tag key length %d", len(k))
+		}
+		if len(v) > maxTagValLength {
+			return fmt.Errorf("invalid tag value length %d", len(v))
+		}
+		if strings.HasPrefix(strings.ToLower(k), "aws:") {
+			return fmt.Errorf("tag key %s uses the reserved aws: prefix", k)
+		}
+	}
+	return nil
+}
+
+func tagsFromExtended(extended map[string][]byte) map[string]string {
+	tags := make(map[string]string)
+	for k, v := range extended {
+		if strings.HasPrefix(k, TagPrefix) {
+			tags[k[len(TagPrefix):]] = string(v)
+		}
+	}
+	return tags
+}
+
+// replaceTagsInExtended replaces all existing tags in the extended attributes
+func replaceTagsInExtended(extended map[string][]byte, tags map[string]string) {
+	for k := range extended {
+		if strings.HasPrefix(k, TagPrefix) {
+			delete(extended, k)
+		}
+	}
+	for k, v := range tags {
+		extended[TagPrefix+k] = []byte(v)
+	}
+}
@@ -0,0 +1,64 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXMLUnmarshall(t *testing.T) {
+
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<Tagging xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+   <TagSet>
+      <Tag>
+         <Key>key1</Key>
+         <Value>value1</Value>
+      </Tag>
+   </TagSet>
+</Tagging>
+`
+
+	tags := &Tagging{}
+
+	xml.Unmarshal([]byte(input), tags)
+
+	assert.Equal(t, len(tags.TagSet.Tag), 1)
+	assert.Equal(t, tags.TagSet.Tag[0].Key, "key1")
+	assert.Equal(t, tags.TagSet.Tag[0].Value, "value1")
+
+}
+
+func TestXMLMarshall(t *testing.T) {
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<Tagging xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><TagSet><Tag><Key>a</Key><Value>1</Value></Tag><Tag><Key>b</Key><Value>2</Value></Tag></TagSet></Tagging>`
+
+	encoded := string(encodeResponse(FromTags(map[string]string{"b": "2", "a": "1"})))
+	if encoded != expected {
+		t.Errorf("unexpected output: %s\nexpecting:%s", encoded, expected)
+	}
+
+}
+
+func TestParseTagsHeader(t *testing.T) {
+
+	tags, err := parseTagsHeader("team=storage&retention=30%20days")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "storage", "retention": "30 days"}, tags)
+
+	_, err = parseTagsHeader("a=1&a=2")
+	assert.NotNil(t, err)
+
+	assert.NotNil(t, validateTags(map[string]string{"aws:reserved": "x"}, maxObjectTags))
+	assert.Nil(t, validateTags(tags, maxObjectTags))
+
+	extended := map[string][]byte{"s3-version-id": []byte("v1")}
+	replaceTagsInExtended(extended, tags)
+	assert.Equal(t, tags, tagsFromExtended(extended))
+	replaceTagsInExtended(extended, nil)
+	assert.Equal(t, 0, len(tagsFromExtended(extended)))
+	assert.Equal(t, 1, len(extended))
+
+}
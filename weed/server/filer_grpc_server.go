@@ -151,13 +151,26 @@ func (fs *FilerServer) CreateEntry(ctx context.Context, req *filer_pb.CreateEntr
 		return
 	}
 
-	createErr := fs.filer.CreateEntry(ctx, &filer2.Entry{
+	newEntry := &filer2.Entry{
 		FullPath: util.JoinPath(req.Directory, req.Entry.Name),
 		Attr:     filer2.PbToEntryAttribute(req.Entry.Attributes),
+		Extended: req.Entry.Extended,
 		Chunks:   chunks,
-	}, req.OExcl)
+	}
+	oldEntry, _ := fs.filer.FindEntry(ctx, newEntry.FullPath)
+	if lockErr := filer2.CheckObjectLockChange(oldEntry, newEntry, fs.iamKey, time.Now()); lockErr != nil {
+		glog.V(0).Infof("CreateEntry %s: %v", newEntry.FullPath, lockErr)
+		resp.Error = lockErr.Error()
+		return
+	}
+
+	createErr := fs.filer.CreateEntry(ctx, newEntry, req.OExcl)
 
 	if createErr == nil {
+		// the filer already deleted the garbage chunks kept from the old entry
+		if oldEntry != nil {
+			garbages = filer2.MinusChunks(garbages, oldEntry.Chunks)
+		}
 		fs.filer.DeleteChunks(garbages)
 	} else {
 		glog.V(3).Infof("CreateEntry %s: %v", filepath.Join(req.Directory, req.Entry.Name), createErr)
@@ -189,6 +202,11 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 		Chunks:   chunks,
 	}
 
+	if err := filer2.CheckObjectLockChange(entry, newEntry, fs.iamKey, time.Now()); err != nil {
+		glog.V(0).Infof("UpdateEntry %s: %v", fullpath, err)
+		return &filer_pb.UpdateEntryResponse{}, err
+	}
+
 	glog.V(3).Infof("updating %s: %+v, chunks %d: %v => %+v, chunks %d: %v, extended: %v => %v",
 		fullpath, entry.Attr, len(entry.Chunks), entry.Chunks,
 		req.Entry.Attributes, len(req.Entry.Chunks), req.Entry.Chunks,
@@ -213,6 +231,11 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 		return &filer_pb.UpdateEntryResponse{}, err
 	}
 
+	// the other links still use the chunks of a hard link replaced by the new entry
+	if filer2.HardLinkCount(entry.Extended) > 1 && filer2.HardLinkId(entry.Extended) != filer2.HardLinkId(newEntry.Extended) {
+		unusedChunks = nil
+	}
+
 	if err = fs.filer.UpdateEntry(ctx, entry, newEntry); err == nil {
 		fs.filer.DeleteChunks(unusedChunks)
 		fs.filer.DeleteChunks(garbages)
@@ -0,0 +1,287 @@
+package weed_server
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/iam_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	accessKeyLength = 20
+	secretKeyLength = 40
+	accessKeyChars  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	secretKeyChars  = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+)
+
+func (fs *FilerServer) CreateIdentity(ctx context.Context, req *iam_pb.CreateIdentityRequest) (*iam_pb.CreateIdentityResponse, error) {
+
+	identity := req.Identity
+	if identity == nil || identity.Name == "" || strings.Contains(identity.Name, "/") {
+		return nil, fmt.Errorf("invalid identity name")
+	}
+
+	fs.iamLock.Lock()
+	defer fs.iamLock.Unlock()
+
+	identities, err := fs.listIdentities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range identities {
+		if t.Name == identity.Name {
+			return nil, fmt.Errorf("identity %s already exists", identity.Name)
+		}
+	}
+	for _, cred := range identity.Credentials {
+		if cred.AccessKey == "" || cred.SecretKey == "" {
+			return nil, fmt.Errorf("identity %s has empty access key or secret key", identity.Name)
+		}
+		if err := checkSecretKey(cred); err != nil {
+			return nil, err
+		}
+		if owner := findAccessKeyOwner(identities, cred.AccessKey); owner != "" {
+			return nil, fmt.Errorf("access key %s is used by identity %s", cred.AccessKey, owner)
+		}
+	}
+
+	glog.V(0).Infof("create identity %s", identity.Name)
+
+	return &iam_pb.CreateIdentityResponse{}, fs.saveIdentity(ctx, identity)
+}
+
+func (fs *FilerServer) ListIdentities(ctx context.Context, req *iam_pb.ListIdentitiesRequest) (*iam_pb.ListIdentitiesResponse, error) {
+
+	identities, err := fs.listIdentities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the secret keys are only returned once, when the access keys are created
+	for _, identity := range identities {
+		for _, cred := range identity.Credentials {
+			cred.SecretKey = ""
+		}
+	}
+
+	return &iam_pb.ListIdentitiesResponse{
+		Identities: identities,
+	}, nil
+}
+
+func (fs *FilerServer) DeleteIdentity(ctx context.Context, req *iam_pb.DeleteIdentityRequest) (*iam_pb.DeleteIdentityResponse, error) {
+
+	fs.iamLock.Lock()
+	defer fs.iamLock.Unlock()
+
+	if _, err := fs.findIdentity(ctx, req.Name); err != nil {
+		return nil, err
+	}
+
+	glog.V(0).Infof("delete identity %s", req.Name)
+
+	err := fs.filer.DeleteEntryMetaAndData(ctx, util.NewFullPath(filer2.IamIdentitiesDir, req.Name), false, false, false)
+
+	return &iam_pb.DeleteIdentityResponse{}, err
+}
+
+func (fs *FilerServer) CreateAccessKey(ctx context.Context, req *iam_pb.CreateAccessKeyRequest) (*iam_pb.CreateAccessKeyResponse, error) {
+
+	fs.iamLock.Lock()
+	defer fs.iamLock.Unlock()
+
+	identity, err := fs.findIdentity(ctx, req.IdentityName)
+	if err != nil {
+		return nil, err
+	}
+
+	cred := req.Credential
+	if cred == nil {
+		cred = &iam_pb.Credential{}
+	}
+	if cred.AccessKey == "" {
+		if cred.AccessKey, err = randomString(accessKeyLength, accessKeyChars); err != nil {
+			return nil, err
+		}
+	}
+	if cred.SecretKey == "" {
+		if cred.SecretKey, err = randomString(secretKeyLength, secretKeyChars); err != nil {
+			return nil, err
+		}
+	}
+	if err = checkSecretKey(cred); err != nil {
+		return nil, err
+	}
+
+	identities, err := fs.listIdentities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if owner := findAccessKeyOwner(identities, cred.AccessKey); owner != "" {
+		return nil, fmt.Errorf("access key %s is used by identity %s", cred.AccessKey, owner)
+	}
+
+	glog.V(0).Infof("create access key %s for identity %s", cred.AccessKey, identity.Name)
+
+	identity.Credentials = append(identity.Credentials, cred)
+	if err = fs.saveIdentity(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	return &iam_pb.CreateAccessKeyResponse{
+		Credential: cred,
+	}, nil
+}
+
+func (fs *FilerServer) DeleteAccessKey(ctx context.Context, req *iam_pb.DeleteAccessKeyRequest) (*iam_pb.DeleteAccessKeyResponse, error) {
+
+	fs.iamLock.Lock()
+	defer fs.iamLock.Unlock()
+
+	identity, err := fs.findIdentity(ctx, req.IdentityName)
+	if err != nil {
+		return nil, err
+	}
+
+	var credentials []*iam_pb.Credential
+	for _, cred := range identity.Credentials {
+		if cred.AccessKey != req.AccessKey {
+			credentials = append(credentials, cred)
+		}
+	}
+	if len(credentials) == len(identity.Credentials) {
+		return nil, fmt.Errorf("identity %s has no access key %s", req.IdentityName, req.AccessKey)
+	}
+
+	glog.V(0).Infof("delete access key %s of identity %s", req.AccessKey, identity.Name)
+
+	identity.Credentials = credentials
+
+	return &iam_pb.DeleteAccessKeyResponse{}, fs.saveIdentity(ctx, identity)
+}
+
+// checkSecretKey refuses the secret keys looking sealed, which would be stored as they are and fail to open
+func checkSecretKey(cred *iam_pb.Credential) error {
+	if strings.HasPrefix(cred.SecretKey, filer2.IamSealedSecretPrefix) {
+		return fmt.Errorf("secret key of %s can not start with %q", cred.AccessKey, filer2.IamSealedSecretPrefix)
+	}
+	return nil
+}
+
+func (fs *FilerServer) listIdentities(ctx context.Context) (identities []*iam_pb.Identity, err error) {
+
+	entries, err := fs.filer.ListDirectoryEntries(ctx, util.FullPath(filer2.IamIdentitiesDir), "", false, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		identity, err := entryToIdentity(entry)
+		if err != nil {
+			glog.Errorf("identity %s: %v", entry.FullPath, err)
+			continue
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, nil
+}
+
+func (fs *FilerServer) findIdentity(ctx context.Context, name string) (*iam_pb.Identity, error) {
+
+	entry, err := fs.filer.FindEntry(ctx, util.NewFullPath(filer2.IamIdentitiesDir, name))
+	if err == filer_pb.ErrNotFound {
+		return nil, fmt.Errorf("identity %s not found", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entryToIdentity(entry)
+}
+
+func (fs *FilerServer) saveIdentity(ctx context.Context, identity *iam_pb.Identity) error {
+
+	// the new secret keys may still be returned to the caller
+	identity = proto.Clone(identity).(*iam_pb.Identity)
+	if err := filer2.SealIdentitySecrets(identity, fs.iamKey); err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(identity)
+	if err != nil {
+		return fmt.Errorf("marshal identity %s: %v", identity.Name, err)
+	}
+
+	fullpath := util.NewFullPath(filer2.IamIdentitiesDir, identity.Name)
+	now := time.Now()
+	entry := &filer2.Entry{
+		FullPath: fullpath,
+		Attr: filer2.Attr{
+			Mtime:  now,
+			Crtime: now,
+			Mode:   os.FileMode(0600),
+			Uid:    OS_UID,
+			Gid:    OS_GID,
+		},
+		Extended: map[string][]byte{
+			filer2.IamIdentityExtKey: data,
+		},
+	}
+
+	oldEntry, err := fs.filer.FindEntry(ctx, fullpath)
+	if err == filer_pb.ErrNotFound {
+		return fs.filer.CreateEntry(ctx, entry, true)
+	}
+	if err != nil {
+		return err
+	}
+	entry.Crtime = oldEntry.Crtime
+
+	return fs.filer.UpdateEntry(ctx, oldEntry, entry)
+}
+
+func entryToIdentity(entry *filer2.Entry) (*iam_pb.Identity, error) {
+	data, found := entry.Extended[filer2.IamIdentityExtKey]
+	if !found {
+		return nil, fmt.Errorf("missing identity attribute")
+	}
+	identity := &iam_pb.Identity{}
+	if err := proto.Unmarshal(data, identity); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func findAccessKeyOwner(identities []*iam_pb.Identity, accessKey string) string {
+	for _, identity := range identities {
+		for _, cred := range identity.Credentials {
+			if cred.AccessKey == accessKey {
+				return identity.Name
+			}
+		}
+	}
+	return ""
+}
+
+func randomString(length int, chars string) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = chars[int(b[i])%len(chars)]
+	}
+	return string(b), nil
+}
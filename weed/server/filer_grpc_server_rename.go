@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/chrislusf/seaweedfs/weed/glog"
@@ -11,6 +12,10 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/util"
 )
 
+// AtomicRenameEntry moves the entry, and the entries under a folder, in one transaction.
+// If the old and new entries are kept in different filer stores, the move is not atomic:
+// the new entries are committed first, and the old entries are deleted in a second transaction,
+// so a failure in between leaves the entries at both paths, but never at neither.
 func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.AtomicRenameEntryRequest) (*filer_pb.AtomicRenameEntryResponse, error) {
 
 	glog.V(1).Infof("AtomicRenameEntry %v", req)
@@ -21,6 +26,12 @@ func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.Atom
 	}
 
 	oldParent := util.FullPath(filepath.ToSlash(req.OldDirectory))
+	newParent := util.FullPath(filepath.ToSlash(req.NewDirectory))
+
+	if newPath := string(newParent.Child(req.NewName)); strings.HasPrefix(newPath, string(oldParent.Child(req.OldName))+"/") {
+		fs.filer.RollbackTransaction(ctx)
+		return nil, fmt.Errorf("%s/%s can not be moved into itself", req.OldDirectory, req.OldName)
+	}
 
 	oldEntry, err := fs.filer.FindEntry(ctx, oldParent.Child(req.OldName))
 	if err != nil {
@@ -29,110 +40,115 @@ func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.Atom
 	}
 
 	var events MoveEvents
-	moveErr := fs.moveEntry(ctx, oldParent, oldEntry, util.FullPath(filepath.ToSlash(req.NewDirectory)), req.NewName, &events)
-	if moveErr != nil {
+	if moveErr := fs.copyEntryTree(ctx, oldParent, oldEntry, newParent, req.NewName, &events); moveErr != nil {
 		fs.filer.RollbackTransaction(ctx)
-		return nil, fmt.Errorf("%s/%s move error: %v", req.OldDirectory, req.OldName, err)
-	} else {
+		return nil, fmt.Errorf("%s/%s move error: %v", req.OldDirectory, req.OldName, moveErr)
+	}
+
+	if !fs.filer.IsInOneStore(oldParent.Child(req.OldName), newParent.Child(req.NewName)) {
 		if commitError := fs.filer.CommitTransaction(ctx); commitError != nil {
 			fs.filer.RollbackTransaction(ctx)
-			return nil, fmt.Errorf("%s/%s move commit error: %v", req.OldDirectory, req.OldName, err)
+			return nil, fmt.Errorf("%s/%s move commit error: %v", req.OldDirectory, req.OldName, commitError)
 		}
-	}
-
-	return &filer_pb.AtomicRenameEntryResponse{}, nil
-}
-
-func (fs *FilerServer) moveEntry(ctx context.Context, oldParent util.FullPath, entry *filer2.Entry, newParent util.FullPath, newName string, events *MoveEvents) error {
-
-	if err := fs.moveSelfEntry(ctx, oldParent, entry, newParent, newName, events, func() error {
-		if entry.IsDirectory() {
-			if err := fs.moveFolderSubEntries(ctx, oldParent, entry, newParent, newName, events); err != nil {
-				return err
-			}
+		if ctx, err = fs.filer.BeginTransaction(ctx); err != nil {
+			return nil, fmt.Errorf("%s/%s is copied to %s/%s, but not deleted: %v", req.OldDirectory, req.OldName, req.NewDirectory, req.NewName, err)
 		}
-		return nil
-	}); err != nil {
-		return fmt.Errorf("fail to move %s => %s: %v", oldParent.Child(entry.Name()), newParent.Child(newName), err)
 	}
 
-	return nil
-}
-
-func (fs *FilerServer) moveFolderSubEntries(ctx context.Context, oldParent util.FullPath, entry *filer2.Entry, newParent util.FullPath, newName string, events *MoveEvents) error {
-
-	currentDirPath := oldParent.Child(entry.Name())
-	newDirPath := newParent.Child(newName)
-
-	glog.V(1).Infof("moving folder %s => %s", currentDirPath, newDirPath)
-
-	lastFileName := ""
-	includeLastFile := false
-	for {
-
-		entries, err := fs.filer.ListDirectoryEntries(ctx, currentDirPath, lastFileName, includeLastFile, 1024)
-		if err != nil {
-			return err
-		}
-
-		// println("found", len(entries), "entries under", currentDirPath)
-
-		for _, item := range entries {
-			lastFileName = item.Name()
-			// println("processing", lastFileName)
-			err := fs.moveEntry(ctx, currentDirPath, item, newDirPath, item.Name(), events)
-			if err != nil {
-				return err
-			}
-		}
-		if len(entries) < 1024 {
-			break
-		}
+	if moveErr := fs.deleteMovedEntryTree(ctx, oldParent, oldEntry, newParent.Child(req.NewName), &events); moveErr != nil {
+		fs.filer.RollbackTransaction(ctx)
+		return nil, fmt.Errorf("%s/%s move error: %v", req.OldDirectory, req.OldName, moveErr)
 	}
-	return nil
+	if commitError := fs.filer.CommitTransaction(ctx); commitError != nil {
+		fs.filer.RollbackTransaction(ctx)
+		return nil, fmt.Errorf("%s/%s move commit error: %v", req.OldDirectory, req.OldName, commitError)
+	}
+
+	return &filer_pb.AtomicRenameEntryResponse{}, nil
 }
 
-func (fs *FilerServer) moveSelfEntry(ctx context.Context, oldParent util.FullPath, entry *filer2.Entry, newParent util.FullPath, newName string, events *MoveEvents,
-	moveFolderSubEntries func() error) error {
+// copyEntryTree creates the new entry, and the new entries of the folder children
+func (fs *FilerServer) copyEntryTree(ctx context.Context, oldParent util.FullPath, entry *filer2.Entry, newParent util.FullPath, newName string, events *MoveEvents) error {
 
 	oldPath, newPath := oldParent.Child(entry.Name()), newParent.Child(newName)
 
-	glog.V(1).Infof("moving entry %s => %s", oldPath, newPath)
-
 	if oldPath == newPath {
 		glog.V(1).Infof("skip moving entry %s => %s", oldPath, newPath)
 		return nil
 	}
 
+	glog.V(1).Infof("moving entry %s => %s", oldPath, newPath)
+
 	// add to new directory
 	newEntry := &filer2.Entry{
 		FullPath: newPath,
 		Attr:     entry.Attr,
+		Extended: entry.Extended,
 		Chunks:   entry.Chunks,
 	}
-	createErr := fs.filer.CreateEntry(ctx, newEntry, false)
-	if createErr != nil {
-		return createErr
+	if createErr := fs.filer.CreateEntry(ctx, newEntry, false); createErr != nil {
+		return fmt.Errorf("fail to move %s => %s: %v", oldPath, newPath, createErr)
 	}
 
 	events.newEntries = append(events.newEntries, newEntry)
 
-	if moveFolderSubEntries != nil {
-		if moveChildrenErr := moveFolderSubEntries(); moveChildrenErr != nil {
-			return moveChildrenErr
+	if !entry.IsDirectory() {
+		return nil
+	}
+	return fs.eachFolderSubEntry(ctx, oldPath, func(item *filer2.Entry) error {
+		return fs.copyEntryTree(ctx, oldPath, item, newPath, item.Name(), events)
+	})
+}
+
+// deleteMovedEntryTree deletes the old entries of the folder children, and then the old entry
+func (fs *FilerServer) deleteMovedEntryTree(ctx context.Context, oldParent util.FullPath, entry *filer2.Entry, newPath util.FullPath, events *MoveEvents) error {
+
+	oldPath := oldParent.Child(entry.Name())
+
+	if oldPath == newPath {
+		return nil
+	}
+
+	if entry.IsDirectory() {
+		if err := fs.eachFolderSubEntry(ctx, oldPath, func(item *filer2.Entry) error {
+			return fs.deleteMovedEntryTree(ctx, oldPath, item, newPath.Child(item.Name()), events)
+		}); err != nil {
+			return err
 		}
 	}
 
 	// delete old entry
-	deleteErr := fs.filer.DeleteEntryMetaAndData(ctx, oldPath, false, false, false)
-	if deleteErr != nil {
-		return deleteErr
+	if deleteErr := fs.filer.DeleteMovedEntry(ctx, oldPath); deleteErr != nil {
+		return fmt.Errorf("fail to delete moved %s: %v", oldPath, deleteErr)
 	}
 
 	events.oldEntries = append(events.oldEntries, entry)
 
 	return nil
+}
 
+func (fs *FilerServer) eachFolderSubEntry(ctx context.Context, dirPath util.FullPath, fn func(item *filer2.Entry) error) error {
+
+	lastFileName := ""
+	includeLastFile := false
+	for {
+
+		entries, err := fs.filer.ListDirectoryEntries(ctx, dirPath, lastFileName, includeLastFile, 1024)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range entries {
+			lastFileName = item.Name()
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if len(entries) < 1024 {
+			break
+		}
+	}
+	return nil
 }
 
 type MoveEvents struct {
@@ -18,6 +18,7 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/util"
 
 	"github.com/chrislusf/seaweedfs/weed/filer2"
+	_ "github.com/chrislusf/seaweedfs/weed/filer2/bbolt"
 	_ "github.com/chrislusf/seaweedfs/weed/filer2/cassandra"
 	_ "github.com/chrislusf/seaweedfs/weed/filer2/etcd"
 	_ "github.com/chrislusf/seaweedfs/weed/filer2/leveldb"
@@ -62,6 +63,11 @@ type FilerServer struct {
 	// notifying clients
 	listenersLock sync.Mutex
 	listenersCond *sync.Cond
+
+	// serializing identity and access key changes
+	iamLock sync.Mutex
+	// iamKey encrypts the secret keys of the identities, and verifies the object lock changes of the S3 gateway
+	iamKey string
 }
 
 func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption) (fs *FilerServer, err error) {
@@ -93,6 +99,7 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 		}
 	}
 	util.LoadConfiguration("notification", false)
+	fs.iamKey = v.GetString("iam.key")
 
 	fs.option.recursiveDelete = v.GetBool("filer.options.recursive_delete")
 	v.SetDefault("filer.options.buckets_folder", "/buckets")
@@ -112,6 +119,15 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 
 	fs.filer.LoadBuckets()
 
+	v.SetDefault("filer.options.lifecycle_interval_minutes", 60)
+	if lifecycleInterval := v.GetInt("filer.options.lifecycle_interval_minutes"); lifecycleInterval > 0 {
+		go fs.filer.LoopProcessingLifecycle(time.Duration(lifecycleInterval) * time.Minute)
+	}
+	if trashRetention := v.GetInt("filer.options.trash_retention_days"); trashRetention > 0 {
+		fs.filer.TrashRetention = time.Duration(trashRetention) * 24 * time.Hour
+		go fs.filer.LoopPurgingTrash(time.Hour)
+	}
+
 	grace.OnInterrupt(func() {
 		fs.filer.Shutdown()
 	})
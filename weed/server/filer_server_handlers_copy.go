@@ -0,0 +1,55 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// copyEntry handles "POST /path/to/dst?cp.from=/path/to/src", creating dst with the chunks of src, without copying the data.
+// The chunks are only shared within one collection, since deleting a collection drops its chunks.
+func (fs *FilerServer) copyEntry(ctx context.Context, w http.ResponseWriter, r *http.Request, srcPath util.FullPath, collection string) {
+
+	srcEntry, err := fs.filer.FindEntry(ctx, srcPath)
+	if err != nil {
+		glog.V(1).Infof("copy %s to %s: %v", srcPath, r.URL.Path, err)
+		httpStatus := http.StatusInternalServerError
+		if err == filer_pb.ErrNotFound {
+			httpStatus = http.StatusNotFound
+		}
+		writeJsonError(w, r, httpStatus, fmt.Errorf("copy from %s: %v", srcPath, err))
+		return
+	}
+
+	srcCollection := srcEntry.Collection
+	if srcCollection == "" {
+		srcCollection, _, _ = fs.detectCollection(string(srcPath), "", "")
+	}
+	if srcCollection != collection {
+		glog.V(1).Infof("copy %s to %s: collection %s to %s", srcPath, r.URL.Path, srcCollection, collection)
+		writeJsonError(w, r, http.StatusBadRequest, fmt.Errorf("copy %s from collection %s to %s", srcPath, srcCollection, collection))
+		return
+	}
+
+	entry, err := fs.filer.CopyEntry(ctx, srcEntry, util.FullPath(r.URL.Path), isCreateOnly(r), r.Header.Get(filer2.VersionIdHeader))
+	if err != nil {
+		glog.V(0).Infof("copy %s to %s: %v", srcPath, r.URL.Path, err)
+		httpStatus := http.StatusInternalServerError
+		if filer2.IsBucketQuotaExceeded(err.Error()) {
+			httpStatus = http.StatusForbidden
+		}
+		writeJsonError(w, r, httpStatus, err)
+		return
+	}
+
+	setEtag(w, filer2.ETagEntry(entry))
+	writeJsonQuiet(w, r, http.StatusCreated, FilerPostResult{
+		Name: entry.Name(),
+		Size: int64(filer2.TotalSize(entry.Chunks)),
+	})
+}
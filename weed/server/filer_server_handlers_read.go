@@ -59,6 +59,18 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	// files written with a customer key can only be read with the same key
+	customerKey, err := filer2.ParseCustomerKey(r.Header.Get(filer2.CustomerKeyHeader))
+	if err == nil {
+		err = filer2.UnwrapCipherKeys(entry, customerKey)
+	}
+	if err != nil {
+		glog.V(1).Infof("read %s: %v", path, err)
+		stats.FilerRequestCounter.WithLabelValues("read.forbidden").Inc()
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	if len(entry.Chunks) == 0 {
 		glog.V(1).Infof("no file chunks for %s, attr=%+v", path, entry.Attr)
 		stats.FilerRequestCounter.WithLabelValues("read.nocontent").Inc()
@@ -99,12 +99,40 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 		ttlSeconds = int32(ttl.Minutes()) * 60
 	}
 
-	if autoChunked := fs.autoChunk(ctx, w, r, replication, collection, dataCenter, ttlSeconds, ttlString, fsync); autoChunked {
+	if srcPath := query.Get("cp.from"); srcPath != "" {
+		fs.copyEntry(ctx, w, r, util.FullPath(srcPath), collection)
 		return
 	}
 
-	if fs.option.Cipher {
-		reply, err := fs.encrypt(ctx, w, r, replication, collection, dataCenter, ttlSeconds, ttlString, fsync)
+	if err := fs.filer.CheckBucketQuota(ctx, util.FullPath(r.URL.Path), r.ContentLength); err != nil {
+		glog.V(1).Infof("write %s: %v", r.URL.Path, err)
+		writeJsonError(w, r, http.StatusForbidden, err)
+		return
+	}
+
+	if isCreateOnly(r) {
+		if _, err := fs.filer.FindEntry(ctx, util.FullPath(r.URL.Path)); err == nil {
+			glog.V(1).Infof("write %s: already exists", r.URL.Path)
+			writeJsonError(w, r, http.StatusPreconditionFailed, fmt.Errorf("EEXIST: entry %s already exists", r.URL.Path))
+			return
+		}
+	}
+
+	// the chunk keys are encrypted with the customer key if given
+	customerKey, err := filer2.ParseCustomerKey(r.Header.Get(filer2.CustomerKeyHeader))
+	if err != nil {
+		glog.V(1).Infof("write %s: %v", r.URL.Path, err)
+		writeJsonError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	cipher := fs.option.Cipher || customerKey != nil || query.Get("cipher") == "true"
+
+	if autoChunked := fs.autoChunk(ctx, w, r, replication, collection, dataCenter, ttlSeconds, ttlString, fsync, cipher, customerKey); autoChunked {
+		return
+	}
+
+	if cipher {
+		reply, err := fs.encrypt(ctx, w, r, replication, collection, dataCenter, ttlSeconds, ttlString, fsync, customerKey)
 		if err != nil {
 			writeJsonError(w, r, http.StatusInternalServerError, err)
 		} else if reply != nil {
@@ -204,7 +232,8 @@ func (fs *FilerServer) updateFilerStore(ctx context.Context, r *http.Request, w
 		}
 	}
 	// glog.V(4).Infof("saving %s => %+v", path, entry)
-	if dbErr := fs.filer.CreateEntry(ctx, entry, false); dbErr != nil {
+	setVersionId(r, entry)
+	if dbErr := fs.filer.CreateEntry(ctx, entry, isCreateOnly(r)); dbErr != nil {
 		fs.filer.DeleteChunks(entry.Chunks)
 		glog.V(0).Infof("failing to write %s to filer server : %v", path, dbErr)
 		writeJsonError(w, r, http.StatusInternalServerError, dbErr)
@@ -215,6 +244,23 @@ func (fs *FilerServer) updateFilerStore(ctx context.Context, r *http.Request, w
 	return nil
 }
 
+// setVersionId keeps the version id given by the s3 gateway for an object in a versioned bucket
+func setVersionId(r *http.Request, entry *filer2.Entry) {
+	versionId := r.Header.Get(filer2.VersionIdHeader)
+	if versionId == "" {
+		return
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	filer2.SetVersionId(entry.Extended, versionId)
+}
+
+// isCreateOnly checks for "If-None-Match: *", which only writes the file if it does not exist yet
+func isCreateOnly(r *http.Request) bool {
+	return r.Header.Get("If-None-Match") == "*"
+}
+
 // send request to volume server
 func (fs *FilerServer) uploadToVolumeServer(r *http.Request, u *url.URL, auth security.EncodedJwt, w http.ResponseWriter, fileId string) (ret *operation.UploadResult, md5value []byte, err error) {
 
@@ -322,6 +368,8 @@ func (fs *FilerServer) DeleteHandler(w http.ResponseWriter, r *http.Request) {
 		httpStatus := http.StatusInternalServerError
 		if err == filer_pb.ErrNotFound {
 			httpStatus = http.StatusNotFound
+		} else if filer2.IsObjectLocked(err.Error()) {
+			httpStatus = http.StatusForbidden
 		}
 		writeJsonError(w, r, httpStatus, err)
 		return
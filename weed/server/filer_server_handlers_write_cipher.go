@@ -17,7 +17,7 @@ import (
 
 // handling single chunk POST or PUT upload
 func (fs *FilerServer) encrypt(ctx context.Context, w http.ResponseWriter, r *http.Request,
-	replication string, collection string, dataCenter string, ttlSeconds int32, ttlString string, fsync bool) (filerResult *FilerPostResult, err error) {
+	replication string, collection string, dataCenter string, ttlSeconds int32, ttlString string, fsync bool, customerKey util.CipherKey) (filerResult *FilerPostResult, err error) {
 
 	fileId, urlLocation, auth, err := fs.assignNewFileInfo(w, r, replication, collection, dataCenter, ttlString, fsync)
 
@@ -32,6 +32,9 @@ func (fs *FilerServer) encrypt(ctx context.Context, w http.ResponseWriter, r *ht
 	sizeLimit := int64(fs.option.MaxMB) * 1024 * 1024
 
 	pu, err := needle.ParseUpload(r, sizeLimit)
+	if err != nil {
+		return nil, err
+	}
 	uncompressedData := pu.Data
 	if pu.IsGzipped {
 		uncompressedData = pu.UncompressedData
@@ -73,12 +76,20 @@ func (fs *FilerServer) encrypt(ctx context.Context, w http.ResponseWriter, r *ht
 		Chunks: fileChunks,
 	}
 
+	if customerKey != nil {
+		if err = filer2.WrapCipherKeys(entry, customerKey); err != nil {
+			fs.filer.DeleteChunks(entry.Chunks)
+			return nil, err
+		}
+	}
+
 	filerResult = &FilerPostResult{
 		Name: pu.FileName,
 		Size: int64(pu.OriginalDataSize),
 	}
 
-	if dbErr := fs.filer.CreateEntry(ctx, entry, false); dbErr != nil {
+	setVersionId(r, entry)
+	if dbErr := fs.filer.CreateEntry(ctx, entry, isCreateOnly(r)); dbErr != nil {
 		fs.filer.DeleteChunks(entry.Chunks)
 		err = dbErr
 		filerResult.Error = dbErr.Error()
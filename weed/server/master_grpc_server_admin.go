@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/wdclient"
 )
 
 /*
@@ -25,7 +26,7 @@ When shell unlock
 
 Master
 ------
-Master maintains:
+Master maintains for each lock name:
   * randomNumber
   * lastLockTime
 When master receives the lease/renew request from shell
@@ -47,6 +48,8 @@ When master receives the release lock request from shell
   set the lastLockTime to zero
 
 
+The locks of different names do not block each other. The shell lock uses the default name.
+
 The volume server does not need to verify.
 This makes the lock/unlock optional, similar to what golang code usually does.
 
@@ -56,13 +59,22 @@ const (
 	LockDuration = 10 * time.Second
 )
 
+type adminLock struct {
+	accessSecret   int64
+	accessLockTime time.Time
+}
+
 func (ms *MasterServer) LeaseAdminToken(ctx context.Context, req *master_pb.LeaseAdminTokenRequest) (*master_pb.LeaseAdminTokenResponse, error) {
 	resp := &master_pb.LeaseAdminTokenResponse{}
 
-	if ms.adminAccessSecret != 0 && ms.adminAccessLockTime.Add(LockDuration).After(time.Now()) {
-		if req.PreviousToken != 0 && ms.isValidToken(time.Unix(0, req.PreviousLockTime), req.PreviousToken) {
+	ms.adminLocksLock.Lock()
+	defer ms.adminLocksLock.Unlock()
+
+	lock := ms.getAdminLock(req.LockName)
+	if lock.accessSecret != 0 && lock.accessLockTime.Add(LockDuration).After(time.Now()) {
+		if req.PreviousToken != 0 && lock.isValidToken(time.Unix(0, req.PreviousLockTime), req.PreviousToken) {
 			// for renew
-			ts, token := ms.generateToken()
+			ts, token := lock.generateToken()
 			resp.Token, resp.LockTsNs = token, ts.UnixNano()
 			return resp, nil
 		}
@@ -70,24 +82,40 @@ func (ms *MasterServer) LeaseAdminToken(ctx context.Context, req *master_pb.Leas
 		return resp, fmt.Errorf("already locked")
 	}
 	// for fresh lease request
-	ts, token := ms.generateToken()
+	ts, token := lock.generateToken()
 	resp.Token, resp.LockTsNs = token, ts.UnixNano()
 	return resp, nil
 }
 
-func (ms *MasterServer) isValidToken(ts time.Time, token int64) bool {
-	return ms.adminAccessLockTime.Equal(ts) && ms.adminAccessSecret == token
+func (ms *MasterServer) getAdminLock(lockName string) *adminLock {
+	if lockName == "" {
+		lockName = wdclient.AdminLockName
+	}
+	lock, found := ms.adminLocks[lockName]
+	if !found {
+		lock = &adminLock{}
+		ms.adminLocks[lockName] = lock
+	}
+	return lock
+}
+
+func (lock *adminLock) isValidToken(ts time.Time, token int64) bool {
+	return lock.accessLockTime.Equal(ts) && lock.accessSecret == token
 }
-func (ms *MasterServer) generateToken() (ts time.Time, token int64) {
-	ms.adminAccessLockTime = time.Now()
-	ms.adminAccessSecret = rand.Int63()
-	return ms.adminAccessLockTime, ms.adminAccessSecret
+func (lock *adminLock) generateToken() (ts time.Time, token int64) {
+	lock.accessLockTime = time.Now()
+	lock.accessSecret = rand.Int63()
+	return lock.accessLockTime, lock.accessSecret
 }
 
 func (ms *MasterServer) ReleaseAdminToken(ctx context.Context, req *master_pb.ReleaseAdminTokenRequest) (*master_pb.ReleaseAdminTokenResponse, error) {
 	resp := &master_pb.ReleaseAdminTokenResponse{}
-	if ms.isValidToken(time.Unix(0, req.PreviousLockTime), req.PreviousToken) {
-		ms.adminAccessSecret = 0
+
+	ms.adminLocksLock.Lock()
+	defer ms.adminLocksLock.Unlock()
+
+	if lock := ms.getAdminLock(req.LockName); lock.isValidToken(time.Unix(0, req.PreviousLockTime), req.PreviousToken) {
+		lock.accessSecret = 0
 	}
 	return resp, nil
 }
@@ -66,8 +66,8 @@ type MasterServer struct {
 
 	MasterClient *wdclient.MasterClient
 
-	adminAccessSecret   int64
-	adminAccessLockTime time.Time
+	adminLocksLock sync.Mutex
+	adminLocks     map[string]*adminLock
 }
 
 func NewMasterServer(r *mux.Router, option *MasterOption, peers []string) *MasterServer {
@@ -94,6 +94,7 @@ func NewMasterServer(r *mux.Router, option *MasterOption, peers []string) *Maste
 		option:          option,
 		preallocateSize: preallocateSize,
 		clientChans:     make(map[string]chan *master_pb.VolumeLocation),
+		adminLocks:      make(map[string]*adminLock),
 		grpcDialOption:  grpcDialOption,
 		MasterClient:    wdclient.NewMasterClient(grpcDialOption, "master", option.Host, 0, peers),
 	}
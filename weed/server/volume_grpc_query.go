@@ -1,69 +1,405 @@
 package weed_server
 
 import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/operation"
 	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/query/csv"
 	"github.com/chrislusf/seaweedfs/weed/query/json"
+	"github.com/chrislusf/seaweedfs/weed/query/sqlparser"
+	"github.com/chrislusf/seaweedfs/weed/query/sqltypes"
 	"github.com/chrislusf/seaweedfs/weed/storage/needle"
 	"github.com/tidwall/gjson"
 )
 
+// queryStripeSize is the size of records sent in one stripe
+const queryStripeSize = 1024 * 1024
+
 func (vs *VolumeServer) Query(req *volume_server_pb.QueryRequest, stream volume_server_pb.VolumeServer_QueryServer) error {
 
+	query, err := newVolumeQuery(req)
+	if err != nil {
+		glog.V(0).Infof("volume query failed to parse %s: %v", req.Expression, err)
+		return err
+	}
+
+	if req.ObjectPart != nil {
+		if err = vs.queryObjectPart(req, query, &stripeSender{stream: stream}); err != nil {
+			glog.V(0).Infof("volume query object part %v: %v", req.FromFileIds, err)
+		}
+		return err
+	}
+
 	for _, fid := range req.FromFileIds {
 
-		vid, id_cookie, err := operation.ParseFileId(fid)
+		n, err := vs.readQueryNeedle(fid)
 		if err != nil {
-			glog.V(0).Infof("volume query failed to parse fid %s: %v", fid, err)
+			glog.V(0).Infof("volume query failed to read fid %s: %v", fid, err)
 			return err
 		}
 
-		n := new(needle.Needle)
-		volumeId, _ := needle.NewVolumeId(vid)
-		n.ParsePath(id_cookie)
-
-		cookie := n.Cookie
-		if _, err := vs.store.ReadVolumeNeedle(volumeId, n); err != nil {
-			glog.V(0).Infof("volume query failed to read fid %s: %v", fid, err)
+		input, err := queryInput(n, req.InputSerialization.CompressionType)
+		if err != nil {
+			glog.V(0).Infof("volume query failed to decompress fid %s: %v", fid, err)
 			return err
 		}
 
-		if n.Cookie != cookie {
-			glog.V(0).Infof("volume query failed to read fid cookie %s: %v", fid, err)
+		if err = queryRecords(input, nil, req, query, &stripeSender{stream: stream}); err != nil {
+			glog.V(0).Infof("volume query fid %s: %v", fid, err)
 			return err
 		}
 
-		if req.InputSerialization.CsvInput != nil {
+	}
+
+	return nil
+}
+
+// readQueryNeedle reads the needle of the file id from the local volumes
+func (vs *VolumeServer) readQueryNeedle(fid string) (*needle.Needle, error) {
+
+	vid, id_cookie, err := operation.ParseFileId(fid)
+	if err != nil {
+		return nil, err
+	}
+
+	n := new(needle.Needle)
+	volumeId, _ := needle.NewVolumeId(vid)
+	n.ParsePath(id_cookie)
+
+	cookie := n.Cookie
+	if _, err := vs.store.ReadVolumeNeedle(volumeId, n); err != nil {
+		return nil, err
+	}
+
+	if n.Cookie != cookie {
+		return nil, fmt.Errorf("unexpected cookie %x", n.Cookie)
+	}
+
+	return n, nil
+}
+
+// queryRecords queries the records of the input, where the csv header is read from headerInput if it is not nil
+func queryRecords(input, headerInput io.Reader, req *volume_server_pb.QueryRequest, query *volumeQuery, sender *stripeSender) (err error) {
+
+	query.resetAggregates()
+
+	if req.InputSerialization.CsvInput != nil {
+		err = queryCsv(input, headerInput, req, query, sender)
+	}
+
+	if req.InputSerialization.JsonInput != nil {
+		err = queryJson(input, req, query, sender)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return sender.send(query.queriedAggregates())
+}
+
+// queryInput decompresses the needle data as a stream, first by the needle flag, then by the requested compression type.
+// Gzipped data uploaded with the gzip content encoding is only decompressed once.
+func queryInput(n *needle.Needle, compressionType string) (input io.Reader, err error) {
 
+	input = bytes.NewReader(n.Data)
+	if n.IsGzipped() {
+		if input, err = gzip.NewReader(input); err != nil {
+			return nil, err
 		}
+	}
 
-		if req.InputSerialization.JsonInput != nil {
+	return decompressQueryInput(input, n.IsGzipped(), compressionType)
+}
 
-			stripe := &volume_server_pb.QueriedStripe{
-				Records: nil,
-			}
+func decompressQueryInput(input io.Reader, isGunzipped bool, compressionType string) (io.Reader, error) {
 
-			filter := json.Query{
-				Field: req.Filter.Field,
-				Op:    req.Filter.Operand,
-				Value: req.Filter.Value,
+	switch strings.ToUpper(compressionType) {
+	case "", "NONE":
+		return input, nil
+	case "GZIP":
+		if isGunzipped {
+			buffered := bufio.NewReader(input)
+			if magic, _ := buffered.Peek(2); !bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+				return buffered, nil
 			}
-			gjson.ForEachLine(string(n.Data), func(line gjson.Result) bool {
-				passedFilter, values := json.QueryJson(line.Raw, req.Selections, filter)
-				if !passedFilter {
-					return true
-				}
-				stripe.Records = json.ToJson(stripe.Records, req.Selections, values)
-				return true
-			})
-			err = stream.Send(stripe)
-			if err != nil {
-				return err
+			input = buffered
+		}
+		return gzip.NewReader(input)
+	case "BZIP2":
+		return bzip2.NewReader(input), nil
+	}
+
+	return nil, fmt.Errorf("unsupported compression type %s", compressionType)
+}
+
+// stripeSender sends the records in stripes of about queryStripeSize bytes,
+// so the records of a large file are not all held in memory
+type stripeSender struct {
+	stream  stripeStream
+	records []byte
+}
+
+type stripeStream interface {
+	Send(stripe *volume_server_pb.QueriedStripe) error
+}
+
+func (s *stripeSender) sendIfFull() error {
+	if len(s.records) < queryStripeSize {
+		return nil
+	}
+	return s.send(nil)
+}
+
+func (s *stripeSender) send(aggregates []*volume_server_pb.QueriedAggregate) error {
+	stripe := &volume_server_pb.QueriedStripe{
+		Records:    s.records,
+		Aggregates: aggregates,
+	}
+	s.records = nil
+	return s.stream.Send(stripe)
+}
+
+func queryJson(input io.Reader, req *volume_server_pb.QueryRequest, query *volumeQuery, sender *stripeSender) error {
+
+	csvOutput := req.OutputSerialization != nil && req.OutputSerialization.CsvOutput != nil
+	recordDelimiter := queryOutputRecordDelimiter(req)
+
+	return json.ForEachRecord(input, func(line gjson.Result) (bool, error) {
+		record := json.NewRecord(line.Raw)
+		if query.where != nil && !query.where.Eval(record) {
+			return true, nil
+		}
+		if len(query.aggregates) > 0 {
+			query.accumulate(record)
+			return true, nil
+		}
+		values := json.Project(line.Raw, query.selections)
+		switch {
+		case csvOutput:
+			sender.records = csv.ToCsv(sender.records, jsonToCsvFields(line, query.selections, values), csvWriterOptions(req))
+		case len(query.selections) == 0:
+			sender.records = append(sender.records, line.Raw...)
+			sender.records = append(sender.records, recordDelimiter...)
+		default:
+			sender.records = json.ToJson(sender.records, query.selections, values)
+			sender.records = append(sender.records, recordDelimiter...)
+		}
+		return true, sender.sendIfFull()
+	})
+}
+
+// volumeQuery is the query on each file, either from the SQL expression, or from the selections and the filter
+type volumeQuery struct {
+	selections []string
+	where      sqlparser.Expr
+	aggregates []*sqlparser.Aggregate
+	states     []sqlparser.AggregateState
+}
+
+func newVolumeQuery(req *volume_server_pb.QueryRequest) (*volumeQuery, error) {
+
+	if req.Expression == "" {
+		return &volumeQuery{
+			selections: req.Selections,
+			where:      filterToExpr(req.Filter),
+		}, nil
+	}
+
+	stmt, err := sqlparser.Parse(req.Expression)
+	if err != nil {
+		return nil, err
+	}
+	return &volumeQuery{
+		selections: stmt.Projections,
+		where:      stmt.Where,
+		aggregates: stmt.Aggregates,
+	}, nil
+}
+
+func (q *volumeQuery) resetAggregates() {
+	q.states = make([]sqlparser.AggregateState, len(q.aggregates))
+}
+
+func (q *volumeQuery) accumulate(record sqlparser.Record) {
+	for i, aggregate := range q.aggregates {
+		aggregate.Accumulate(&q.states[i], record)
+	}
+}
+
+func (q *volumeQuery) queriedAggregates() (aggregates []*volume_server_pb.QueriedAggregate) {
+	for _, state := range q.states {
+		aggregates = append(aggregates, &volume_server_pb.QueriedAggregate{
+			Count: state.Count,
+			Sum:   state.Sum,
+			Min:   state.Min,
+			Max:   state.Max,
+		})
+	}
+	return
+}
+
+// filterToExpr converts the single field filter, where % and !% match the wildcard * and ?
+func filterToExpr(filter *volume_server_pb.QueryRequest_Filter) sqlparser.Expr {
+	if filter == nil || filter.Field == "" {
+		return nil
+	}
+	switch filter.Operand {
+	case "":
+		return &sqlparser.IsNull{Field: filter.Field, Not: true}
+	case "%", "!%":
+		return &sqlparser.Like{
+			Field:   filter.Field,
+			Pattern: wildcardToLike(filter.Value),
+			Escape:  '\\',
+			Not:     filter.Operand == "!%",
+		}
+	}
+	value := sqltypes.NewVarChar(filter.Value)
+	if num, err := strconv.ParseFloat(filter.Value, 64); err == nil {
+		value = sqltypes.NewFloat64(num)
+	}
+	return &sqlparser.Comparison{
+		Field: filter.Field,
+		Op:    filter.Operand,
+		Value: value,
+	}
+}
+
+func wildcardToLike(pattern string) string {
+	var sb strings.Builder
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			sb.WriteByte('%')
+		case '?':
+			sb.WriteByte('_')
+		case '%', '_', '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(c)
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+func queryCsv(input, headerInput io.Reader, req *volume_server_pb.QueryRequest, query *volumeQuery, sender *stripeSender) error {
+
+	csvInput := req.InputSerialization.CsvInput
+	reader := newCsvQueryReader(input, csvInput)
+
+	header := csv.NewHeader(nil)
+	if csvInput.FileHeaderInfo == csv.FileHeaderInfoUse || csvInput.FileHeaderInfo == csv.FileHeaderInfoIgnore {
+		headerReader := reader
+		if headerInput != nil {
+			headerReader = newCsvQueryReader(headerInput, csvInput)
+		}
+		names, err := headerReader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if csvInput.FileHeaderInfo == csv.FileHeaderInfoUse {
+			header = csv.NewHeader(names)
+		}
+	}
+
+	jsonOutput := req.OutputSerialization != nil && req.OutputSerialization.JsonOutput != nil
+	recordDelimiter := queryOutputRecordDelimiter(req)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		passedFilter, values := csv.QueryCsv(record, header, query.selections, query.where)
+		if !passedFilter {
+			continue
+		}
+		if len(query.aggregates) > 0 {
+			query.accumulate(csv.NewRecord(record, header))
+			continue
+		}
+		if jsonOutput {
+			sender.records = csv.ToJson(sender.records, header.ColumnNames(query.selections, record), values)
+			sender.records = append(sender.records, recordDelimiter...)
+		} else {
+			var fields []string
+			for _, value := range values {
+				fields = append(fields, value.ToString())
 			}
+			sender.records = csv.ToCsv(sender.records, fields, csvWriterOptions(req))
+		}
+		if err = sender.sendIfFull(); err != nil {
+			return err
 		}
+	}
+}
+
+func newCsvQueryReader(input io.Reader, csvInput *volume_server_pb.QueryRequest_InputSerialization_CSVInput) *csv.Reader {
+	return csv.NewReader(input, csv.ReaderOptions{
+		FileHeaderInfo:             csvInput.FileHeaderInfo,
+		RecordDelimiter:            csvInput.RecordDelimiter,
+		FieldDelimiter:             csvInput.FieldDelimiter,
+		QuoteCharacter:             csvInput.QuoteCharactoer,
+		QuoteEscapeCharacter:       csvInput.QuoteEscapeCharacter,
+		Comments:                   csvInput.Comments,
+		AllowQuotedRecordDelimiter: csvInput.AllowQuotedRecordDelimiter,
+	})
+}
 
+// jsonToCsvFields converts the selected json values to text, where json strings are unquoted
+func jsonToCsvFields(line gjson.Result, selections []string, values []sqltypes.Value) (fields []string) {
+	if len(selections) == 0 {
+		line.ForEach(func(key, value gjson.Result) bool {
+			fields = append(fields, value.String())
+			return true
+		})
+		return
+	}
+	for _, value := range values {
+		fields = append(fields, gjson.Parse(string(value.Raw())).String())
 	}
+	return
+}
 
-	return nil
+// csvWriterOptions is the default csv output if the request does not set it
+func csvWriterOptions(req *volume_server_pb.QueryRequest) csv.WriterOptions {
+	if req.OutputSerialization == nil || req.OutputSerialization.CsvOutput == nil {
+		return csv.WriterOptions{}
+	}
+	output := req.OutputSerialization.CsvOutput
+	return csv.WriterOptions{
+		QuoteFields:          output.QuoteFields,
+		RecordDelimiter:      output.RecordDelimiter,
+		FieldDelimiter:       output.FieldDelimiter,
+		QuoteCharacter:       output.QuoteCharactoer,
+		QuoteEscapeCharacter: output.QuoteEscapeCharacter,
+	}
+}
+
+func queryOutputRecordDelimiter(req *volume_server_pb.QueryRequest) string {
+	if output := req.OutputSerialization; output != nil {
+		if output.JsonOutput != nil && output.JsonOutput.RecordDelimiter != "" {
+			return output.JsonOutput.RecordDelimiter
+		}
+		if output.CsvOutput != nil && output.CsvOutput.RecordDelimiter != "" {
+			return output.CsvOutput.RecordDelimiter
+		}
+	}
+	return "\n"
 }
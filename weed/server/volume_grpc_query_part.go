@@ -0,0 +1,215 @@
+package weed_server
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/query/csv"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// queryObjectPart queries the records starting in the from_file_ids, which are read as one stream.
+// The object of many chunks is queried in parts, one for each chunk, and the records may span the chunks:
+// the partial record at the start belongs to the previous part, and the last record is read to its end from the next chunks.
+func (vs *VolumeServer) queryObjectPart(req *volume_server_pb.QueryRequest, query *volumeQuery, sender *stripeSender) error {
+
+	part := req.ObjectPart
+
+	chunks := &chunksReader{fileIds: req.FromFileIds, open: vs.openQueryChunk}
+	defer chunks.Close()
+	var input io.Reader = chunks
+
+	isFirstPart := len(part.FirstFileIds) == 0
+	if !isFirstPart || len(part.NextFileIds) > 0 {
+		delimiter, err := queryPartRecordDelimiter(req)
+		if err != nil {
+			return err
+		}
+		next := &chunksReader{fileIds: part.NextFileIds, open: vs.openQueryChunk}
+		defer next.Close()
+		input = newPartRecordsReader(chunks, next, delimiter, !isFirstPart)
+	}
+
+	var headerInput io.Reader
+	if csvInput := req.InputSerialization.CsvInput; csvInput != nil && !isFirstPart &&
+		(csvInput.FileHeaderInfo == csv.FileHeaderInfoUse || csvInput.FileHeaderInfo == csv.FileHeaderInfoIgnore) {
+		first := &chunksReader{fileIds: part.FirstFileIds, open: vs.openQueryChunk}
+		defer first.Close()
+		headerInput = first
+	}
+
+	// the chunks are read after the content encoding is decoded
+	input, err := decompressQueryInput(input, true, req.InputSerialization.CompressionType)
+	if err != nil {
+		return err
+	}
+
+	return queryRecords(input, headerInput, req, query, sender)
+}
+
+// queryPartRecordDelimiter is the record delimiter to split the object, which has to be one byte,
+// and not be quoted in the records or compressed
+func queryPartRecordDelimiter(req *volume_server_pb.QueryRequest) (byte, error) {
+
+	in := req.InputSerialization
+	if compressionType := strings.ToUpper(in.CompressionType); compressionType != "" && compressionType != "NONE" {
+		return 0, fmt.Errorf("can not split %s compressed records", compressionType)
+	}
+
+	delimiter := "\n"
+	switch {
+	case in.CsvInput != nil:
+		if in.CsvInput.AllowQuotedRecordDelimiter {
+			return 0, fmt.Errorf("can not split records with quoted record delimiters")
+		}
+		if in.CsvInput.RecordDelimiter != "" {
+			delimiter = in.CsvInput.RecordDelimiter
+		}
+	case in.JsonInput != nil:
+		if strings.ToUpper(in.JsonInput.Type) != "LINES" {
+			return 0, fmt.Errorf("can not split json %s", in.JsonInput.Type)
+		}
+	}
+
+	if len(delimiter) != 1 {
+		return 0, fmt.Errorf("can not split records by the delimiter %q", delimiter)
+	}
+	return delimiter[0], nil
+}
+
+// openQueryChunk reads the chunk from the local volumes, or else from the volume servers holding it
+func (vs *VolumeServer) openQueryChunk(fileId string) (io.ReadCloser, error) {
+
+	vid, _, err := operation.ParseFileId(fileId)
+	if err != nil {
+		return nil, err
+	}
+	volumeId, err := needle.NewVolumeId(vid)
+	if err != nil {
+		return nil, err
+	}
+
+	if vs.store.HasVolume(volumeId) {
+		n, err := vs.readQueryNeedle(fileId)
+		if err != nil {
+			return nil, err
+		}
+		input, err := queryInput(n, "")
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(input), nil
+	}
+
+	fileUrl, err := operation.LookupFileId(vs.GetMaster(), fileId)
+	if err != nil {
+		return nil, err
+	}
+	return util.ReadUrlAsReaderCloser(fileUrl, "")
+}
+
+// chunksReader reads the chunks one after another, opening each chunk only when it is reached
+type chunksReader struct {
+	fileIds []string
+	open    func(fileId string) (io.ReadCloser, error)
+	current io.ReadCloser
+}
+
+func (r *chunksReader) Read(p []byte) (n int, err error) {
+	for {
+		if r.current == nil {
+			if len(r.fileIds) == 0 {
+				return 0, io.EOF
+			}
+			current, err := r.open(r.fileIds[0])
+			if err != nil {
+				return 0, fmt.Errorf("read chunk %s: %v", r.fileIds[0], err)
+			}
+			r.current, r.fileIds = current, r.fileIds[1:]
+		}
+		n, err = r.current.Read(p)
+		if err != io.EOF {
+			return n, err
+		}
+		r.current.Close()
+		r.current = nil
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (r *chunksReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	return r.current.Close()
+}
+
+// partRecordsReader cuts the records starting in the part out of the object.
+// A record belongs to the part holding the delimiter before it, and the first record to the first part.
+type partRecordsReader struct {
+	part          *bufio.Reader
+	next          io.Reader
+	delimiter     byte
+	skipFirst     bool
+	isPartDrained bool
+	isDone        bool
+}
+
+func newPartRecordsReader(part, next io.Reader, delimiter byte, skipFirst bool) *partRecordsReader {
+	return &partRecordsReader{
+		part:      bufio.NewReader(part),
+		next:      next,
+		delimiter: delimiter,
+		skipFirst: skipFirst,
+	}
+}
+
+func (r *partRecordsReader) Read(p []byte) (n int, err error) {
+
+	if r.skipFirst {
+		r.skipFirst = false
+		// the partial record ends with the first delimiter, and without it no record starts in the part
+		for {
+			if _, err = r.part.ReadSlice(r.delimiter); err != bufio.ErrBufferFull {
+				break
+			}
+		}
+		if err != nil {
+			r.isDone = true
+		}
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+	}
+	if r.isDone {
+		return 0, io.EOF
+	}
+
+	if !r.isPartDrained {
+		n, err = r.part.Read(p)
+		if err != io.EOF {
+			return n, err
+		}
+		r.isPartDrained = true
+		if n > 0 {
+			return n, nil
+		}
+	}
+
+	// the last record ends with the first delimiter in the next chunks
+	n, err = r.next.Read(p)
+	if i := bytes.IndexByte(p[:n], r.delimiter); i >= 0 {
+		r.isDone = true
+		return i + 1, nil
+	}
+	return n, err
+}
@@ -0,0 +1,84 @@
+package weed_server
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+)
+
+type stripeRecorder struct {
+	records []byte
+}
+
+func (r *stripeRecorder) Send(stripe *volume_server_pb.QueriedStripe) error {
+	r.records = append(r.records, stripe.Records...)
+	return nil
+}
+
+func queryTestRecords(t *testing.T, input, headerInput io.Reader, req *volume_server_pb.QueryRequest) string {
+	query, err := newVolumeQuery(req)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	recorder := &stripeRecorder{}
+	if err = queryRecords(input, headerInput, req, query, &stripeSender{stream: recorder}); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	return string(recorder.records)
+}
+
+func TestQueryCsvWithoutOutputSerialization(t *testing.T) {
+
+	req := &volume_server_pb.QueryRequest{
+		Expression: "SELECT s.name FROM S3Object s WHERE s.age > 30",
+		InputSerialization: &volume_server_pb.QueryRequest_InputSerialization{
+			CsvInput: &volume_server_pb.QueryRequest_InputSerialization_CSVInput{FileHeaderInfo: "USE"},
+		},
+	}
+
+	if records := queryTestRecords(t, strings.NewReader("name,age\nalice,31\nbob,20\n"), nil, req); records != "alice\n" {
+		t.Errorf("unexpected records %q", records)
+	}
+
+}
+
+func TestQueryObjectParts(t *testing.T) {
+
+	req := &volume_server_pb.QueryRequest{
+		Expression: "SELECT s.name FROM S3Object s WHERE s.age > 30",
+		InputSerialization: &volume_server_pb.QueryRequest_InputSerialization{
+			CsvInput: &volume_server_pb.QueryRequest_InputSerialization_CSVInput{FileHeaderInfo: "USE"},
+		},
+	}
+
+	// the records are queried once, wherever the object is split into three chunks
+	for _, content := range []string{"name,age\nalice,31\nbob,42\ncarol,27\ndave,35", "name,age\nalice,31\nbob,42\ncarol,27\ndave,35\n"} {
+		for i := 1; i < len(content); i++ {
+			for j := i + 1; j < len(content); j++ {
+				chunks := map[string]string{"1,01": content[:i], "1,02": content[i:j], "1,03": content[j:]}
+				open := func(fileId string) (io.ReadCloser, error) {
+					return ioutil.NopCloser(strings.NewReader(chunks[fileId])), nil
+				}
+				fileIds := []string{"1,01", "1,02", "1,03"}
+
+				var records string
+				for k, fileId := range fileIds {
+					part := &chunksReader{fileIds: []string{fileId}, open: open}
+					next := &chunksReader{fileIds: fileIds[k+1:], open: open}
+					var headerInput io.Reader
+					if k > 0 {
+						headerInput = &chunksReader{fileIds: fileIds, open: open}
+					}
+					records += queryTestRecords(t, newPartRecordsReader(part, next, '\n', k > 0), headerInput, req)
+				}
+				if records != "alice\nbob\ndave\n" {
+					t.Errorf("split %q at %d and %d: unexpected records %q", content, i, j, records)
+				}
+			}
+		}
+	}
+
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
 )
 
@@ -27,6 +28,7 @@ func (c *commandBucketCreate) Help() string {
 
 	Example:
 		bucket.create -name <bucket_name> -replication 001
+		bucket.create -name <bucket_name> -quotaBytes 1073741824 -quotaObjects 10000
 `
 }
 
@@ -35,6 +37,8 @@ func (c *commandBucketCreate) Do(args []string, commandEnv *CommandEnv, writer i
 	bucketCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
 	bucketName := bucketCommand.String("name", "", "bucket name")
 	replication := bucketCommand.String("replication", "", "replication setting for the bucket")
+	quotaBytes := bucketCommand.Int64("quotaBytes", 0, "maximum total size of the objects in bytes, 0 for unlimited")
+	quotaObjects := bucketCommand.Int64("quotaObjects", 0, "maximum number of objects, 0 for unlimited")
 	if err = bucketCommand.Parse(args); err != nil {
 		return nil
 	}
@@ -65,6 +69,19 @@ func (c *commandBucketCreate) Do(args []string, commandEnv *CommandEnv, writer i
 			},
 		}
 
+		if *quotaBytes > 0 || *quotaObjects > 0 {
+			quota, err := filer2.EncodeBucketQuota(filer2.BucketQuota{
+				MaxBytes:   *quotaBytes,
+				MaxObjects: *quotaObjects,
+			})
+			if err != nil {
+				return err
+			}
+			entry.Extended = map[string][]byte{
+				filer2.BucketQuotaKey: quota,
+			}
+		}
+
 		if err := filer_pb.CreateEntry(client, &filer_pb.CreateEntryRequest{
 			Directory: filerBucketsPath,
 			Entry:     entry,
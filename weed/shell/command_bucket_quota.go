@@ -0,0 +1,150 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandBucketQuota{})
+}
+
+type commandBucketQuota struct {
+}
+
+func (c *commandBucketQuota) Name() string {
+	return "bucket.quota"
+}
+
+func (c *commandBucketQuota) Help() string {
+	return `show or set the quota of a bucket
+
+	bucket.quota -name <bucket_name>                        # show the quota and the usage
+	bucket.quota -name <bucket_name> -quotaBytes 1073741824 # limit the total size of the objects
+	bucket.quota -name <bucket_name> -quotaObjects 10000    # limit the number of objects
+	bucket.quota -name <bucket_name> -quotaBytes 0 -quotaObjects 0 # remove the limits
+
+	Setting the quota also walks the bucket to recount its usage, as fs.du does.
+	Each filer reloads the usage every 10 seconds, so the writes through several filers
+	may exceed the quota by what they write in between.
+`
+}
+
+func (c *commandBucketQuota) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	bucketCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	bucketName := bucketCommand.String("name", "", "bucket name")
+	quotaBytes := bucketCommand.Int64("quotaBytes", -1, "maximum total size of the objects in bytes, 0 for unlimited")
+	quotaObjects := bucketCommand.Int64("quotaObjects", -1, "maximum number of objects, 0 for unlimited")
+	if err = bucketCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *bucketName == "" {
+		return fmt.Errorf("empty bucket name")
+	}
+
+	filerBucketsPath, err := readFilerBucketsPath(commandEnv)
+	if err != nil {
+		return fmt.Errorf("read buckets: %v", err)
+	}
+
+	bucketPath := util.NewFullPath(filerBucketsPath, *bucketName)
+	entry, err := filer_pb.GetEntry(commandEnv, bucketPath)
+	if err != nil {
+		return fmt.Errorf("read bucket %s: %v", *bucketName, err)
+	}
+	if entry == nil || !entry.IsDirectory {
+		return fmt.Errorf("bucket %s not found", *bucketName)
+	}
+
+	quota, err := filer2.ParseBucketQuota(entry.Extended[filer2.BucketQuotaKey])
+	if err != nil {
+		return fmt.Errorf("bucket %s quota: %v", *bucketName, err)
+	}
+
+	if *quotaBytes < 0 && *quotaObjects < 0 {
+		usage, err := filer2.ParseBucketUsage(entry.Extended[filer2.BucketUsageKey])
+		if err != nil {
+			return fmt.Errorf("bucket %s usage: %v", *bucketName, err)
+		}
+		fmt.Fprintf(writer, "bucket %s\n", *bucketName)
+		fmt.Fprintf(writer, "  bytes:   %d / %s\n", usage.Bytes, quotaLimit(quota.MaxBytes))
+		fmt.Fprintf(writer, "  objects: %d / %s\n", usage.Objects, quotaLimit(quota.MaxObjects))
+		return nil
+	}
+
+	if *quotaBytes >= 0 {
+		quota.MaxBytes = *quotaBytes
+	}
+	if *quotaObjects >= 0 {
+		quota.MaxObjects = *quotaObjects
+	}
+
+	usage, err := bucketUsage(commandEnv, bucketPath, true)
+	if err != nil {
+		return fmt.Errorf("count bucket %s usage: %v", *bucketName, err)
+	}
+
+	quotaData, err := filer2.EncodeBucketQuota(quota)
+	if err != nil {
+		return err
+	}
+	usageData, err := filer2.EncodeBucketUsage(usage)
+	if err != nil {
+		return err
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	entry.Extended[filer2.BucketQuotaKey] = quotaData
+	entry.Extended[filer2.BucketUsageSeedKey] = usageData
+
+	err = commandEnv.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		_, err := client.UpdateEntry(context.Background(), &filer_pb.UpdateEntryRequest{
+			Directory: filerBucketsPath,
+			Entry:     entry,
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("update bucket %s: %v", *bucketName, err)
+	}
+
+	fmt.Fprintf(writer, "bucket %s quota bytes: %s objects: %s, usage bytes: %d objects: %d\n",
+		*bucketName, quotaLimit(quota.MaxBytes), quotaLimit(quota.MaxObjects), usage.Bytes, usage.Objects)
+
+	return nil
+}
+
+func quotaLimit(limit int64) string {
+	if limit <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", limit)
+}
+
+// bucketUsage counts the files in the bucket, except the multipart uploads in progress
+func bucketUsage(filerClient filer_pb.FilerClient, dir util.FullPath, isBucketRoot bool) (usage filer2.BucketUsage, err error) {
+	err = filer_pb.ReadDirAllEntries(filerClient, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		if !entry.IsDirectory {
+			usage.Bytes += int64(filer2.TotalSize(entry.Chunks))
+			usage.Objects++
+			return nil
+		}
+		if isBucketRoot && entry.Name == filer2.MultipartUploadsFolder {
+			return nil
+		}
+		subUsage, err := bucketUsage(filerClient, dir.Child(entry.Name), false)
+		usage.Bytes += subUsage.Bytes
+		usage.Objects += subUsage.Objects
+		return err
+	})
+	return
+}
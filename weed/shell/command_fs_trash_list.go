@@ -0,0 +1,103 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsTrashList{})
+}
+
+type commandFsTrashList struct {
+}
+
+func (c *commandFsTrashList) Name() string {
+	return "fs.trash.list"
+}
+
+func (c *commandFsTrashList) Help() string {
+	return `list the deleted entries kept in the trash
+
+	fs.trash.list                  # all the trash
+	fs.trash.list -bucket <name>   # the deleted objects of a bucket
+	fs.trash.list -user <uid>      # the deleted entries owned by the user, outside of the buckets
+
+	The filer keeps the deleted entries in the trash when "trash_retention_days" is set in filer.toml.
+	Each line shows the deletion time, the original path, and the path in the trash.
+`
+}
+
+func (c *commandFsTrashList) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	trashCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	bucket := trashCommand.String("bucket", "", "only the trash of the bucket")
+	user := trashCommand.Int("user", -1, "only the trash of the user id")
+	if err = trashCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	return eachTrashEntry(commandEnv, *bucket, *user, func(dir util.FullPath, entry *filer_pb.Entry, deletedAt time.Time) error {
+		size := "dir"
+		if !entry.IsDirectory {
+			size = fmt.Sprintf("%d", filer2.TotalSize(entry.Chunks))
+		}
+		fmt.Fprintf(writer, "%s\t%10s\t%s\t%s\n", deletedAt.Format(time.RFC3339), size,
+			entry.Extended[filer2.TrashPathKey], dir.Child(entry.Name))
+		return nil
+	})
+
+}
+
+// eachTrashEntry visits the deleted entries in the trash of the bucket, or of the user if not negative, or all of them
+func eachTrashEntry(filerClient filer_pb.FilerClient, bucket string, user int, fn func(dir util.FullPath, entry *filer_pb.Entry, deletedAt time.Time) error) error {
+
+	var trashDirs []util.FullPath
+	switch {
+	case bucket != "":
+		trashDirs = append(trashDirs, filer2.BucketTrashDir(bucket))
+	case user >= 0:
+		trashDirs = append(trashDirs, filer2.UserTrashDir(uint32(user)))
+	default:
+		for _, dir := range []util.FullPath{filer2.TrashBucketsDir, filer2.TrashUsersDir} {
+			err := filer_pb.ReadDirAllEntries(filerClient, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
+				if entry.IsDirectory {
+					trashDirs = append(trashDirs, dir.Child(entry.Name))
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("list %s: %v", dir, err)
+			}
+		}
+	}
+
+	for _, dir := range trashDirs {
+		var entries []*filer_pb.Entry
+		err := filer_pb.ReadDirAllEntries(filerClient, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
+			entries = append(entries, entry)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("list %s: %v", dir, err)
+		}
+		// the entries are listed first, since fn may remove them
+		for _, entry := range entries {
+			deletedAt, found := filer2.TrashTime(entry.Extended)
+			if !found {
+				continue
+			}
+			if err = fn(dir, entry, deletedAt); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/wdclient"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsTrashPurge{})
+}
+
+type commandFsTrashPurge struct {
+}
+
+func (c *commandFsTrashPurge) Name() string {
+	return "fs.trash.purge"
+}
+
+func (c *commandFsTrashPurge) Help() string {
+	return `delete the entries in the trash, together with their chunks
+
+	fs.trash.purge                          # empty all the trash
+	fs.trash.purge -bucket <name>           # empty the trash of a bucket
+	fs.trash.purge -user <uid>              # empty the trash of a user
+	fs.trash.purge -olderThan 72h           # only the entries deleted more than 72 hours ago
+
+	The filer also purges the entries older than "trash_retention_days" in filer.toml.
+	Only one filer or shell purges the trash at a time.
+`
+}
+
+func (c *commandFsTrashPurge) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	trashCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	bucket := trashCommand.String("bucket", "", "only the trash of the bucket")
+	user := trashCommand.Int("user", -1, "only the trash of the user id")
+	olderThan := trashCommand.Duration("olderThan", 0, "only the entries deleted longer ago than this")
+	if err = trashCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	// the filers purging the trash at the same time would release the chunk references twice
+	locker := wdclient.NewExclusiveLocker(commandEnv.MasterClient, filer2.TrashPurgeLockName)
+	if !locker.TryLock() {
+		return fmt.Errorf("the trash is being purged by others, try again later")
+	}
+	defer locker.ReleaseLock()
+
+	cutoff := time.Now().Add(-*olderThan)
+	purged := 0
+	err = eachTrashEntry(commandEnv, *bucket, *user, func(dir util.FullPath, entry *filer_pb.Entry, deletedAt time.Time) error {
+		if deletedAt.After(cutoff) {
+			return nil
+		}
+		if err := filer_pb.Remove(commandEnv, string(dir), entry.Name, true, true, false); err != nil {
+			return fmt.Errorf("purge %s: %v", dir.Child(entry.Name), err)
+		}
+		purged++
+		return nil
+	})
+
+	fmt.Fprintf(writer, "purged %d entries\n", purged)
+
+	return err
+}
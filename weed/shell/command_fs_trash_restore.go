@@ -0,0 +1,152 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsTrashRestore{})
+}
+
+type commandFsTrashRestore struct {
+}
+
+func (c *commandFsTrashRestore) Name() string {
+	return "fs.trash.restore"
+}
+
+func (c *commandFsTrashRestore) Help() string {
+	return `move a deleted entry in the trash back
+
+	fs.trash.restore /.trash/users/0/1590000000000000000-file_name              # to its original path
+	fs.trash.restore -to /dir/new_name /.trash/buckets/b1/1590000000000000000-dir  # to another path
+
+	The paths in the trash are shown by fs.trash.list. The target path must not exist,
+	and its missing parent folders are created.
+`
+}
+
+func (c *commandFsTrashRestore) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	trashCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	to := trashCommand.String("to", "", "restore to this path instead of the original path")
+	if err = trashCommand.Parse(args); err != nil {
+		return nil
+	}
+	if trashCommand.NArg() != 1 {
+		return fmt.Errorf("need one entry in the trash to restore")
+	}
+
+	trashPath, err := commandEnv.parseUrl(trashCommand.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	entry, err := filer_pb.GetEntry(commandEnv, util.FullPath(trashPath))
+	if err != nil {
+		return fmt.Errorf("read %s: %v", trashPath, err)
+	}
+	if entry == nil || len(entry.Extended[filer2.TrashPathKey]) == 0 {
+		return fmt.Errorf("%s is not a deleted entry in the trash", trashPath)
+	}
+
+	targetPath := string(entry.Extended[filer2.TrashPathKey])
+	if *to != "" {
+		if targetPath, err = commandEnv.parseUrl(*to); err != nil {
+			return err
+		}
+	}
+	if existing, err := filer_pb.GetEntry(commandEnv, util.FullPath(targetPath)); err != nil {
+		return fmt.Errorf("read %s: %v", targetPath, err)
+	} else if existing != nil {
+		return fmt.Errorf("%s already exists, restore to another path with -to", targetPath)
+	}
+
+	trashDir, trashName := util.FullPath(trashPath).DirAndName()
+	targetDir, targetName := util.FullPath(targetPath).DirAndName()
+
+	// the original folder may have been deleted too
+	if err = ensureDirectory(commandEnv, util.FullPath(targetDir), writer); err != nil {
+		return err
+	}
+
+	err = commandEnv.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		if _, err := client.AtomicRenameEntry(context.Background(), &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: trashDir,
+			OldName:      trashName,
+			NewDirectory: targetDir,
+			NewName:      targetName,
+		}); err != nil {
+			return fmt.Errorf("move %s => %s: %v", trashPath, targetPath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the restored entry is no longer in the trash, and is read again to keep the changes during the move
+	restored, err := filer_pb.GetEntry(commandEnv, util.FullPath(targetPath))
+	if err != nil {
+		return fmt.Errorf("read %s: %v", targetPath, err)
+	}
+	if restored == nil {
+		return fmt.Errorf("%s is moved away after restored", targetPath)
+	}
+	delete(restored.Extended, filer2.TrashPathKey)
+	delete(restored.Extended, filer2.TrashTimeKey)
+	err = commandEnv.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		if _, err := client.UpdateEntry(context.Background(), &filer_pb.UpdateEntryRequest{
+			Directory: targetDir,
+			Entry:     restored,
+		}); err != nil {
+			return fmt.Errorf("update %s: %v", targetPath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(writer, "restore: %s => %s\n", trashPath, targetPath)
+
+	return nil
+}
+
+// ensureDirectory creates the directory and its missing parents
+func ensureDirectory(commandEnv *CommandEnv, dir util.FullPath, writer io.Writer) error {
+
+	if dir == "/" {
+		return nil
+	}
+
+	entry, err := filer_pb.GetEntry(commandEnv, dir)
+	if err != nil {
+		return fmt.Errorf("read %s: %v", dir, err)
+	}
+	if entry != nil {
+		if !entry.IsDirectory {
+			return fmt.Errorf("%s is not a folder", dir)
+		}
+		return nil
+	}
+
+	parent, name := dir.DirAndName()
+	if err = ensureDirectory(commandEnv, util.FullPath(parent), writer); err != nil {
+		return err
+	}
+	if err = filer_pb.Mkdir(commandEnv, parent, name, nil); err != nil {
+		return err
+	}
+	fmt.Fprintf(writer, "create folder: %s\n", dir)
+
+	return nil
+}
@@ -28,7 +28,7 @@ type CommandEnv struct {
 	env          map[string]string
 	MasterClient *wdclient.MasterClient
 	option       ShellOptions
-	locker       *ExclusiveLocker
+	locker       *wdclient.ExclusiveLocker
 }
 
 type command interface {
@@ -47,7 +47,7 @@ func NewCommandEnv(options ShellOptions) *CommandEnv {
 		MasterClient: wdclient.NewMasterClient(options.GrpcDialOption, pb.AdminShellClient, "", 0, strings.Split(*options.Masters, ",")),
 		option:       options,
 	}
-	ce.locker = NewExclusiveLocker(ce.MasterClient)
+	ce.locker = wdclient.NewExclusiveLocker(ce.MasterClient, wdclient.AdminLockName)
 	return ce
 }
 
@@ -70,7 +70,7 @@ func (ce *CommandEnv) isDirectory(path string) bool {
 
 func (ce *CommandEnv) confirmIsLocked() error {
 
-	if ce.locker.isLocking {
+	if ce.locker.IsLocking() {
 		return nil
 	}
 
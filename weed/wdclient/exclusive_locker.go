@@ -1,4 +1,4 @@
-package shell
+package wdclient
 
 import (
 	"context"
@@ -7,25 +7,29 @@ import (
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
-	"github.com/chrislusf/seaweedfs/weed/wdclient"
 )
 
 const (
 	RenewInteval     = 4 * time.Second
 	SafeRenewInteval = 3 * time.Second
 	InitLockInteval  = 1 * time.Second
+	// AdminLockName is the lock taken by the shell, and by the lease requests without a lock name
+	AdminLockName = "admin"
 )
 
 type ExclusiveLocker struct {
-	masterClient *wdclient.MasterClient
+	masterClient *MasterClient
+	lockName     string
 	token        int64
 	lockTsNs     int64
 	isLocking    bool
 }
 
-func NewExclusiveLocker(masterClient *wdclient.MasterClient) *ExclusiveLocker {
+// NewExclusiveLocker leases the named lock of the master. The locks of different names do not block each other.
+func NewExclusiveLocker(masterClient *MasterClient, lockName string) *ExclusiveLocker {
 	return &ExclusiveLocker{
 		masterClient: masterClient,
+		lockName:     lockName,
 	}
 }
 
@@ -37,20 +41,14 @@ func (l *ExclusiveLocker) GetToken() (token int64, lockTsNs int64) {
 	return atomic.LoadInt64(&l.token), atomic.LoadInt64(&l.lockTsNs)
 }
 
+func (l *ExclusiveLocker) IsLocking() bool {
+	return l.isLocking
+}
+
 func (l *ExclusiveLocker) RequestLock() {
 	// retry to get the lease
 	for {
-		if err := l.masterClient.WithClient(func(client master_pb.SeaweedClient) error {
-			resp, err := client.LeaseAdminToken(context.Background(), &master_pb.LeaseAdminTokenRequest{
-				PreviousToken:    atomic.LoadInt64(&l.token),
-				PreviousLockTime: atomic.LoadInt64(&l.lockTsNs),
-			})
-			if err == nil {
-				atomic.StoreInt64(&l.token, resp.Token)
-				atomic.StoreInt64(&l.lockTsNs, resp.LockTsNs)
-			}
-			return err
-		}); err != nil {
+		if err := l.leaseAdminToken(); err != nil {
 			// println("leasing problem", err.Error())
 			time.Sleep(InitLockInteval)
 		} else {
@@ -58,23 +56,43 @@ func (l *ExclusiveLocker) RequestLock() {
 		}
 	}
 
+	l.startRenewing()
+}
+
+// TryLock leases the admin token once, and returns false if it is held by others
+func (l *ExclusiveLocker) TryLock() bool {
+	if err := l.leaseAdminToken(); err != nil {
+		glog.V(1).Infof("lease admin token: %v", err)
+		return false
+	}
+
+	l.startRenewing()
+	return true
+}
+
+func (l *ExclusiveLocker) leaseAdminToken() error {
+	return l.masterClient.WithClient(func(client master_pb.SeaweedClient) error {
+		resp, err := client.LeaseAdminToken(context.Background(), &master_pb.LeaseAdminTokenRequest{
+			PreviousToken:    atomic.LoadInt64(&l.token),
+			PreviousLockTime: atomic.LoadInt64(&l.lockTsNs),
+			LockName:         l.lockName,
+		})
+		if err == nil {
+			atomic.StoreInt64(&l.token, resp.Token)
+			atomic.StoreInt64(&l.lockTsNs, resp.LockTsNs)
+		}
+		return err
+	})
+}
+
+func (l *ExclusiveLocker) startRenewing() {
+
 	l.isLocking = true
 
 	// start a goroutine to renew the lease
 	go func() {
 		for l.isLocking {
-			if err := l.masterClient.WithClient(func(client master_pb.SeaweedClient) error {
-				resp, err := client.LeaseAdminToken(context.Background(), &master_pb.LeaseAdminTokenRequest{
-					PreviousToken:    atomic.LoadInt64(&l.token),
-					PreviousLockTime: atomic.LoadInt64(&l.lockTsNs),
-				})
-				if err == nil {
-					atomic.StoreInt64(&l.token, resp.Token)
-					atomic.StoreInt64(&l.lockTsNs, resp.LockTsNs)
-					// println("ts", l.lockTsNs, "token", l.token)
-				}
-				return err
-			}); err != nil {
+			if err := l.leaseAdminToken(); err != nil {
 				glog.Errorf("failed to renew lock: %v", err)
 				return
 			} else {
@@ -92,6 +110,7 @@ func (l *ExclusiveLocker) ReleaseLock() {
 		client.ReleaseAdminToken(context.Background(), &master_pb.ReleaseAdminTokenRequest{
 			PreviousToken:    atomic.LoadInt64(&l.token),
 			PreviousLockTime: atomic.LoadInt64(&l.lockTsNs),
+			LockName:         l.lockName,
 		})
 		return nil
 	})